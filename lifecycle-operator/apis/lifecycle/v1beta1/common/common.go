@@ -20,14 +20,24 @@ const VersionAnnotation = "keptn.sh/version"
 const AppAnnotation = "keptn.sh/app"
 const PreDeploymentTaskAnnotation = "keptn.sh/pre-deployment-tasks"
 const PostDeploymentTaskAnnotation = "keptn.sh/post-deployment-tasks"
+const PreDeploymentTaskDependencyAnnotation = "keptn.sh/pre-deployment-task-dependencies"
+const PostDeploymentTaskDependencyAnnotation = "keptn.sh/post-deployment-task-dependencies"
+const ExecutionConditionAnnotationPrefix = "keptn.sh/when."
 const K8sRecommendedWorkloadAnnotations = "app.kubernetes.io/name"
 const K8sRecommendedVersionAnnotations = "app.kubernetes.io/version"
 const K8sRecommendedAppAnnotations = "app.kubernetes.io/part-of"
 const K8sRecommendedManagedByAnnotations = "app.kubernetes.io/managed-by"
 const PreDeploymentEvaluationAnnotation = "keptn.sh/pre-deployment-evaluations"
 const PostDeploymentEvaluationAnnotation = "keptn.sh/post-deployment-evaluations"
+const PreDeploymentAnalysisAnnotation = "keptn.sh/pre-deployment-analyses"
+const PostDeploymentAnalysisAnnotation = "keptn.sh/post-deployment-analyses"
+const PromotionTaskAnnotation = "keptn.sh/promotion-tasks"
+const OnFailureTaskAnnotation = "keptn.sh/on-failure-tasks"
 const SchedulingGateRemoved = "keptn.sh/scheduling-gate-removed"
 const TaskNameAnnotation = "keptn.sh/task-name"
+const ParentUIDLabel = "keptn.sh/parent-uid"
+const TraceParentAnnotation = "keptn.sh/traceparent"
+const TraceStateAnnotation = "keptn.sh/tracestate"
 const NamespaceEnabledAnnotation = "keptn.sh/lifecycle-toolkit"
 const CreateAppTaskSpanName = "create_%s_app_task"
 const CreateWorkloadTaskSpanName = "create_%s_deployment_task"
@@ -37,6 +47,14 @@ const AppTypeAnnotation = "keptn.sh/app-type"
 const KeptnGate = "keptn-prechecks-gate"
 const ContainerNameAnnotation = "keptn.sh/container"
 const MetadataAnnotation = "keptn.sh/metadata"
+const ApprovalAnnotation = "keptn.sh/approval"
+const ApprovalUserAnnotation = "keptn.sh/approval-user"
+const ApprovalTimeAnnotation = "keptn.sh/approval-time"
+const ApprovalApproved = "approved"
+const ApprovalRejected = "rejected"
+const FreezeOverrideAnnotation = "keptn.sh/freeze-override"
+const PromotedFromAnnotation = "keptn.sh/promoted-from"
+const CommitTimestampMetadataKey = "commitTimestamp"
 
 const MinKeptnNameLen = 80
 const MaxK8sObjectLength = 253
@@ -48,7 +66,7 @@ const (
 	AppTypeMultiService  AppType = "multi-service"
 )
 
-// KeptnState  is a string containing current Phase state  (Progressing/Succeeded/Failed/Unknown/Pending/Deprecated/Warning)
+// KeptnState  is a string containing current Phase state  (Progressing/Succeeded/Failed/Unknown/Pending/Deprecated/Warning/Skipped)
 type KeptnState string
 
 const (
@@ -59,10 +77,12 @@ const (
 	StatePending     KeptnState = "Pending"
 	StateDeprecated  KeptnState = "Deprecated"
 	StateWarning     KeptnState = "Warning"
+	// StateSkipped represents a task or evaluation that has not been executed because its when expression did not hold.
+	StateSkipped KeptnState = "Skipped"
 )
 
 func (k KeptnState) IsCompleted() bool {
-	return k == StateSucceeded || k == StateFailed || k == StateDeprecated || k == StateWarning
+	return k == StateSucceeded || k == StateFailed || k == StateDeprecated || k == StateWarning || k == StateSkipped
 }
 
 func (k KeptnState) IsSucceeded() bool {
@@ -85,6 +105,10 @@ func (k KeptnState) IsWarning() bool {
 	return k == StateWarning
 }
 
+func (k KeptnState) IsSkipped() bool {
+	return k == StateSkipped
+}
+
 type StatusSummary struct {
 	Total       int
 	Progressing int
@@ -93,6 +117,7 @@ type StatusSummary struct {
 	Pending     int
 	Unknown     int
 	Deprecated  int
+	Skipped     int
 }
 
 func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary {
@@ -109,12 +134,37 @@ func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary
 		summary.Pending++
 	case StateUnknown:
 		summary.Unknown++
+	case StateSkipped:
+		summary.Skipped++
 	}
 	return summary
 }
 
+// UpdateCheckStatusSummary updates the StatusSummary of evaluations and analyses, which count a Warning as succeeded,
+// since a warning does not block the deployment
+func UpdateCheckStatusSummary(status KeptnState, summary StatusSummary) StatusSummary {
+	if status.IsWarning() {
+		status = StateSucceeded
+	}
+	return UpdateStatusSummary(status, summary)
+}
+
+// Add returns the sum of both StatusSummaries
+func (s StatusSummary) Add(other StatusSummary) StatusSummary {
+	return StatusSummary{
+		Total:       s.Total + other.Total,
+		Progressing: s.Progressing + other.Progressing,
+		Failed:      s.Failed + other.Failed,
+		Succeeded:   s.Succeeded + other.Succeeded,
+		Pending:     s.Pending + other.Pending,
+		Unknown:     s.Unknown + other.Unknown,
+		Deprecated:  s.Deprecated + other.Deprecated,
+		Skipped:     s.Skipped + other.Skipped,
+	}
+}
+
 func (s StatusSummary) GetTotalCount() int {
-	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated
+	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated + s.Skipped
 }
 
 func GetOverallState(s StatusSummary) KeptnState {
@@ -163,6 +213,8 @@ type CheckType string
 const PreDeploymentCheckType CheckType = "pre"
 const PostDeploymentCheckType CheckType = "post"
 const PromotionCheckType CheckType = "promotion"
+const RollbackCheckType CheckType = "rollback"
+const OnFailureCheckType CheckType = "on-failure"
 const PreDeploymentEvaluationCheckType CheckType = "pre-eval"
 const PostDeploymentEvaluationCheckType CheckType = "post-eval"
 
@@ -176,6 +228,10 @@ type KeptnMeters struct {
 	EvaluationCount    metric.Int64Counter
 	EvaluationDuration metric.Float64Histogram
 	PromotionCount     metric.Int64Counter
+	PrunedCount        metric.Int64Counter
+
+	CloudEventsDeliveredCount metric.Int64Counter
+	CloudEventsFailedCount    metric.Int64Counter
 }
 
 const (
@@ -195,6 +251,11 @@ const (
 	EvaluationStatus        attribute.Key = attribute.Key("keptn.deployment.evaluation.status")
 	EvaluationName          attribute.Key = attribute.Key("keptn.deployment.evaluation.name")
 	EvaluationType          attribute.Key = attribute.Key("keptn.deployment.evaluation.type")
+	PrunedKind              attribute.Key = attribute.Key("keptn.pruned.kind")
+	PrunedNamespace         attribute.Key = attribute.Key("keptn.pruned.namespace")
+	CloudEventSink          attribute.Key = attribute.Key("keptn.cloudevents.sink")
+	CloudEventType          attribute.Key = attribute.Key("keptn.cloudevents.type")
+	CloudEventReason        attribute.Key = attribute.Key("keptn.cloudevents.reason")
 )
 
 func GenerateTaskName(checkType CheckType, taskName string) string {
@@ -227,5 +288,5 @@ func MergeMaps(m1 map[string]string, m2 map[string]string) map[string]string {
 
 // IsOwnerSupported returns whether the owner of the given object is supported to be considered a KeptnWorkload
 func IsOwnerSupported(owner metav1.OwnerReference) bool {
-	return owner.Kind == "ReplicaSet" || owner.Kind == "Deployment" || owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet" || owner.Kind == "Rollout"
+	return owner.Kind == "ReplicaSet" || owner.Kind == "Deployment" || owner.Kind == "StatefulSet" || owner.Kind == "DaemonSet" || owner.Kind == "Rollout" || owner.Kind == "Job"
 }
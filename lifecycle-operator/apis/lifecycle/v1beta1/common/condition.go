@@ -0,0 +1,85 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// ConditionVariables contains the values the when expressions of tasks and evaluations are evaluated against
+type ConditionVariables struct {
+	// Metadata contains the metadata of the KeptnApp or KeptnWorkload
+	Metadata map[string]string
+	// Version is the version of the KeptnAppVersion or KeptnWorkloadVersion
+	Version string
+	// PreviousVersion is the version that has been deployed before, if any
+	PreviousVersion string
+	// TraceId contains the OpenTelemetry trace IDs of the KeptnAppVersion or KeptnWorkloadVersion
+	TraceId map[string]string
+	// CheckType is the type of the phase the task or evaluation belongs to
+	CheckType CheckType
+	// Now is the time at which the expression is evaluated
+	Now time.Time
+}
+
+var conditionEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("metadata", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("version", cel.StringType),
+		cel.Variable("previousVersion", cel.StringType),
+		cel.Variable("traceId", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("checkType", cel.StringType),
+		cel.Variable("now", cel.TimestampType),
+		ext.Strings(),
+	)
+})
+
+// CompileCondition compiles the given when expression and verifies that it evaluates to a boolean
+func CompileCondition(expression string) (cel.Program, error) {
+	env, err := conditionEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !cel.BoolType.IsAssignableType(ast.OutputType()) {
+		return nil, fmt.Errorf("expression must evaluate to bool, got %s", ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+// EvaluateCondition returns whether the given when expression holds for the given variables
+func EvaluateCondition(expression string, vars ConditionVariables) (bool, error) {
+	program, err := CompileCondition(expression)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := program.Eval(map[string]interface{}{
+		"metadata":        nonNilMap(vars.Metadata),
+		"version":         vars.Version,
+		"previousVersion": vars.PreviousVersion,
+		"traceId":         nonNilMap(vars.TraceId),
+		"checkType":       string(vars.CheckType),
+		"now":             vars.Now,
+	})
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to bool, got %T", out.Value())
+	}
+	return result, nil
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{
+			name:       "valid expression",
+			expression: "metadata.commitMessage.contains('[migrate]')",
+		},
+		{
+			name:       "syntax error",
+			expression: "metadata.commitMessage.contains(",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown variable",
+			expression: "workload == 'podtato-head'",
+			wantErr:    true,
+		},
+		{
+			name:       "not a boolean",
+			expression: "version",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileCondition(tt.expression)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	// 2024-01-01 was a Monday
+	monday := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	vars := ConditionVariables{
+		Metadata:        map[string]string{"commitMessage": "add column [migrate]"},
+		Version:         "2.1.0",
+		PreviousVersion: "1.4.2",
+		TraceId:         map[string]string{"traceparent": "00-trace-span-01"},
+		CheckType:       PreDeploymentCheckType,
+		Now:             monday,
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		vars       ConditionVariables
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:       "metadata",
+			expression: "metadata.commitMessage.contains('[migrate]')",
+			vars:       vars,
+			want:       true,
+		},
+		{
+			name:       "missing metadata with has",
+			expression: "has(metadata.ticket) && metadata.ticket != ''",
+			vars:       vars,
+			want:       false,
+		},
+		{
+			name:       "missing metadata",
+			expression: "metadata.ticket != ''",
+			vars:       vars,
+			wantErr:    true,
+		},
+		{
+			name:       "weekday",
+			expression: "now.getDayOfWeek() == 1",
+			vars:       vars,
+			want:       true,
+		},
+		{
+			name:       "major version changed",
+			expression: "version.split('.')[0] != previousVersion.split('.')[0]",
+			vars:       vars,
+			want:       true,
+		},
+		{
+			name:       "trace id and check type",
+			expression: "traceId.traceparent.startsWith('00-') && checkType == 'pre'",
+			vars:       vars,
+			want:       true,
+		},
+		{
+			name:       "nil maps",
+			expression: "size(metadata) == 0 && !('traceparent' in traceId)",
+			vars:       ConditionVariables{},
+			want:       true,
+		},
+		{
+			name:       "invalid expression",
+			expression: "version ==",
+			vars:       vars,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateCondition(tt.expression, tt.vars)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
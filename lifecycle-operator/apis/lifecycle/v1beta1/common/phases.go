@@ -19,11 +19,16 @@ var phases = []KeptnPhaseType{
 	PhaseWorkloadPreEvaluation,
 	PhaseWorkloadPostEvaluation,
 	PhaseWorkloadDeployment,
+	PhaseWorkloadPromotion,
 	PhaseAppPreDeployment,
 	PhaseAppPostDeployment,
 	PhaseAppPreEvaluation,
 	PhaseAppPostEvaluation,
 	PhasePromotion,
+	PhasePromoteApp,
+	PhaseAppRollback,
+	PhaseAppOnFailure,
+	PhaseWorkloadOnFailure,
 	PhaseAppDeployment,
 	PhaseReconcileEvaluation,
 	PhaseReconcileTask,
@@ -70,6 +75,14 @@ func (p KeptnPhaseType) IsPromotionTask() bool {
 	return strings.Contains(p.ShortName, "PromotionTasks")
 }
 
+func (p KeptnPhaseType) IsRollback() bool {
+	return strings.Contains(p.ShortName, "Rollback")
+}
+
+func (p KeptnPhaseType) IsOnFailureTask() bool {
+	return strings.Contains(p.ShortName, "OnFailureTasks")
+}
+
 func GetShortPhaseName(phase string) string {
 	for _, p := range phases {
 		if phase == p.ShortName {
@@ -92,11 +105,16 @@ var (
 	PhaseWorkloadPreEvaluation    = KeptnPhaseType{LongName: "Workload Pre-Deployment Evaluations", ShortName: "WorkloadPreDeployEvaluations"}
 	PhaseWorkloadPostEvaluation   = KeptnPhaseType{LongName: "Workload Post-Deployment Evaluations", ShortName: "WorkloadPostDeployEvaluations"}
 	PhaseWorkloadDeployment       = KeptnPhaseType{LongName: "Workload Deployment", ShortName: "WorkloadDeploy"}
+	PhaseWorkloadPromotion        = KeptnPhaseType{LongName: "Workload Promotion Tasks", ShortName: "WorkloadPromotionTasks"}
 	PhaseAppPreDeployment         = KeptnPhaseType{LongName: "App Pre-Deployment Tasks", ShortName: "AppPreDeployTasks"}
 	PhaseAppPostDeployment        = KeptnPhaseType{LongName: "App Post-Deployment Tasks", ShortName: "AppPostDeployTasks"}
 	PhaseAppPreEvaluation         = KeptnPhaseType{LongName: "App Pre-Deployment Evaluations", ShortName: "AppPreDeployEvaluations"}
 	PhaseAppPostEvaluation        = KeptnPhaseType{LongName: "App Post-Deployment Evaluations", ShortName: "AppPostDeployEvaluations"}
 	PhasePromotion                = KeptnPhaseType{LongName: "Promotion Tasks", ShortName: "PromotionTasks"}
+	PhasePromoteApp               = KeptnPhaseType{LongName: "Promote App", ShortName: "PromoteApp"}
+	PhaseAppRollback              = KeptnPhaseType{LongName: "App Rollback", ShortName: "AppRollback"}
+	PhaseAppOnFailure             = KeptnPhaseType{LongName: "App On-Failure Tasks", ShortName: "AppOnFailureTasks"}
+	PhaseWorkloadOnFailure        = KeptnPhaseType{LongName: "Workload On-Failure Tasks", ShortName: "WorkloadOnFailureTasks"}
 	PhaseAppDeployment            = KeptnPhaseType{LongName: "App Deployment", ShortName: "AppDeploy"}
 	PhaseReconcileEvaluation      = KeptnPhaseType{LongName: "Reconcile Evaluation", ShortName: "ReconcileEvaluation"}
 	PhaseReconcileTask            = KeptnPhaseType{LongName: "Reconcile Task", ShortName: "ReconcileTask"}
@@ -134,4 +152,6 @@ var (
 	PhaseStateReconcileError   = "ReconcileError"
 	PhaseStateReconcileTimeout = "ReconcileTimeout"
 	PhaseStateNotFound         = "NotFound"
+	PhaseStateBlocked          = "Blocked"
+	PhaseStateOverridden       = "Overridden"
 )
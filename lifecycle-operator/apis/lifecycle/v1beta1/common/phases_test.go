@@ -282,6 +282,10 @@ func TestKeptnPhaseType_IsPromotionTask(t *testing.T) {
 			State: PhasePromotion,
 			Want:  true,
 		},
+		{
+			State: PhaseWorkloadPromotion,
+			Want:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
@@ -290,6 +294,68 @@ func TestKeptnPhaseType_IsPromotionTask(t *testing.T) {
 	}
 }
 
+func TestKeptnPhaseType_IsRollback(t *testing.T) {
+	tests := []struct {
+		State KeptnPhaseType
+		Want  bool
+	}{
+		{
+			State: PhaseAppPostDeployment,
+			Want:  false,
+		},
+		{
+			State: PhaseAppPostEvaluation,
+			Want:  false,
+		},
+		{
+			State: PhasePromotion,
+			Want:  false,
+		},
+		{
+			State: PhaseAppRollback,
+			Want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			require.Equal(t, tt.State.IsRollback(), tt.Want)
+		})
+	}
+}
+
+func TestKeptnPhaseType_IsOnFailureTask(t *testing.T) {
+	tests := []struct {
+		State KeptnPhaseType
+		Want  bool
+	}{
+		{
+			State: PhaseAppPreDeployment,
+			Want:  false,
+		},
+		{
+			State: PhaseWorkloadPostDeployment,
+			Want:  false,
+		},
+		{
+			State: PhaseAppRollback,
+			Want:  false,
+		},
+		{
+			State: PhaseAppOnFailure,
+			Want:  true,
+		},
+		{
+			State: PhaseWorkloadOnFailure,
+			Want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			require.Equal(t, tt.State.IsOnFailureTask(), tt.Want)
+		})
+	}
+}
+
 func TestPhaseTraceID(t *testing.T) {
 	trace := PhaseTraceID{}
 
@@ -0,0 +1,46 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateExecutionConditions verifies that the conditions only refer to the given tasks and evaluations
+// and that their when expressions compile
+func validateExecutionConditions(items []string, conditions []ExecutionCondition, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := make(map[string]struct{}, len(conditions))
+
+	for i, condition := range conditions {
+		namePath := path.Index(i).Child("name")
+		if !slices.Contains(items, condition.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, condition.Name, "must be one of the tasks or evaluations"))
+		} else if _, ok := seen[condition.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(namePath, condition.Name))
+		}
+		seen[condition.Name] = struct{}{}
+
+		if _, err := common.CompileCondition(condition.When); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("when"), condition.When, err.Error()))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,68 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateExecutionConditions(t *testing.T) {
+	path := field.NewPath("spec").Child("executionConditions")
+	items := []string{"migrate", "notify", "slo-check"}
+
+	tests := []struct {
+		name       string
+		conditions []ExecutionCondition
+		want       field.ErrorList
+	}{
+		{
+			name: "no conditions",
+		},
+		{
+			name: "valid conditions",
+			conditions: []ExecutionCondition{
+				{Name: "migrate", When: "metadata.commitMessage.contains('[migrate]')"},
+				{Name: "slo-check", When: "now.getDayOfWeek() == 1"},
+			},
+		},
+		{
+			name: "unknown item",
+			conditions: []ExecutionCondition{
+				{Name: "cleanup", When: "version != previousVersion"},
+			},
+			want: field.ErrorList{
+				field.Invalid(path.Index(0).Child("name"), "cleanup", "must be one of the tasks or evaluations"),
+			},
+		},
+		{
+			name: "duplicate item",
+			conditions: []ExecutionCondition{
+				{Name: "notify", When: "version != previousVersion"},
+				{Name: "notify", When: "checkType == 'post'"},
+			},
+			want: field.ErrorList{
+				field.Duplicate(path.Index(1).Child("name"), "notify"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, validateExecutionConditions(items, tt.conditions, path))
+		})
+	}
+}
+
+func TestValidateExecutionConditions_InvalidExpression(t *testing.T) {
+	path := field.NewPath("spec").Child("executionConditions")
+
+	errs := validateExecutionConditions([]string{"migrate"}, []ExecutionCondition{
+		{Name: "migrate", When: "version"},
+		{Name: "migrate", When: "unknown == 'value'"},
+	}, path)
+
+	require.Len(t, errs, 3)
+	require.Equal(t, path.Index(0).Child("when").String(), errs[0].Field)
+	require.Equal(t, field.Duplicate(path.Index(1).Child("name"), "migrate"), errs[1])
+	require.Equal(t, path.Index(1).Child("when").String(), errs[2].Field)
+}
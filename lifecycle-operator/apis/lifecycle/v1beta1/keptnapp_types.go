@@ -48,6 +48,32 @@ type KeptnAppStatus struct {
 	// CurrentVersion indicates the version that is currently deployed or being reconciled.
 	// +optional
 	CurrentVersion string `json:"currentVersion,omitempty"`
+	// DORAMetrics contains the DORA metrics of the KeptnApp, computed from its KeptnAppVersions.
+	// +optional
+	DORAMetrics *DORAMetrics `json:"doraMetrics,omitempty"`
+}
+
+// DORAMetrics contains the DORA metrics of a KeptnApp, computed from the KeptnAppVersions completed within a time window
+type DORAMetrics struct {
+	// Window is the time frame the metrics are computed for.
+	Window metav1.Duration `json:"window"`
+	// DeploymentCount is the number of succeeded and failed deployments within the window.
+	DeploymentCount int `json:"deploymentCount"`
+	// FailedDeploymentCount is the number of failed deployments within the window.
+	FailedDeploymentCount int `json:"failedDeploymentCount"`
+	// DeploymentFrequency is the average number of deployments per day within the window.
+	DeploymentFrequency string `json:"deploymentFrequency"`
+	// ChangeFailureRate is the ratio of failed deployments to all deployments within the window.
+	ChangeFailureRate string `json:"changeFailureRate"`
+	// LeadTimeForChanges is the average time from the commit to the succeeded deployment within the window.
+	// The time of the commit is taken from the commitTimestamp metadata of the KeptnAppContext.
+	// +optional
+	LeadTimeForChanges *metav1.Duration `json:"leadTimeForChanges,omitempty"`
+	// TimeToRestore is the average time from a failed deployment to the next succeeded deployment within the window.
+	// +optional
+	TimeToRestore *metav1.Duration `json:"timeToRestore,omitempty"`
+	// LastUpdateTime is the time the metrics have last changed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
 }
 
 // KeptnWorkloadRef refers to a KeptnWorkload that is part of a KeptnApp
@@ -99,10 +125,15 @@ func (a KeptnApp) SetSpanAttributes(span trace.Span) {
 }
 
 func (a KeptnApp) GenerateAppVersion(previousVersion string) KeptnAppVersion {
+	var annotations map[string]string
+	if reason, ok := a.Annotations[common.FreezeOverrideAnnotation]; ok {
+		annotations = map[string]string{common.FreezeOverrideAnnotation: reason}
+	}
 	return KeptnAppVersion{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      a.GetAppVersionName(),
-			Namespace: a.Namespace,
+			Name:        a.GetAppVersionName(),
+			Namespace:   a.Namespace,
+			Annotations: annotations,
 		},
 		Spec: KeptnAppVersionSpec{
 			KeptnAppSpec:    a.Spec,
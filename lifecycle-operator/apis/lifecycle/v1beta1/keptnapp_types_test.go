@@ -39,6 +39,10 @@ func TestKeptnApp(t *testing.T) {
 		},
 	}, appVersion)
 
+	app.Annotations = map[string]string{common.FreezeOverrideAnnotation: "hotfix"}
+	appVersion = app.GenerateAppVersion("prev")
+	require.Equal(t, map[string]string{common.FreezeOverrideAnnotation: "hotfix"}, appVersion.Annotations)
+
 	require.Equal(t, []attribute.KeyValue{
 		common.AppName.String("app"),
 		common.AppVersion.String("version"),
@@ -29,6 +29,18 @@ type DeploymentTaskSpec struct {
 	// The items of this list refer to the names of KeptnTaskDefinitions
 	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
 	PostDeploymentTasks []string `json:"postDeploymentTasks,omitempty"`
+	// PreDeploymentTaskDependencies defines the order in which the PreDeploymentTasks are executed.
+	// Each item refers to one of the PreDeploymentTasks and lists the tasks it depends on.
+	// A task is only started once all of its dependencies have succeeded.
+	// Tasks that are not listed here are started right away.
+	// +optional
+	PreDeploymentTaskDependencies []TaskDependency `json:"preDeploymentTaskDependencies,omitempty"`
+	// PostDeploymentTaskDependencies defines the order in which the PostDeploymentTasks are executed.
+	// Each item refers to one of the PostDeploymentTasks and lists the tasks it depends on.
+	// A task is only started once all of its dependencies have succeeded.
+	// Tasks that are not listed here are started right away.
+	// +optional
+	PostDeploymentTaskDependencies []TaskDependency `json:"postDeploymentTaskDependencies,omitempty"`
 	// PreDeploymentEvaluations is a list of all evaluations to be performed
 	// during the pre-deployment phase of the KeptnApp.
 	// The items of this list refer to the names of KeptnEvaluationDefinitions
@@ -39,12 +51,75 @@ type DeploymentTaskSpec struct {
 	// The items of this list refer to the names of KeptnEvaluationDefinitions
 	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
 	PostDeploymentEvaluations []string `json:"postDeploymentEvaluations,omitempty"`
+	// PreDeploymentAnalyses is a list of all analyses to be performed
+	// during the pre-deployment evaluation phase of the KeptnApp.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	// +optional
+	PreDeploymentAnalyses []string `json:"preDeploymentAnalyses,omitempty"`
+	// PostDeploymentAnalyses is a list of all analyses to be performed
+	// during the post-deployment evaluation phase of the KeptnApp.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	// +optional
+	PostDeploymentAnalyses []string `json:"postDeploymentAnalyses,omitempty"`
+	// ExecutionConditions defines conditions under which the tasks and evaluations of the KeptnApp are executed.
+	// Each item refers to one of the tasks or evaluations by name.
+	// Tasks and evaluations whose condition does not hold are not executed and marked as Skipped.
+	// +optional
+	ExecutionConditions []ExecutionCondition `json:"executionConditions,omitempty"`
 	// PromotionTasks is a list of all tasks to be performed during the promotion phase of the KeptnApp.
 	// The items of this list refer to the names of KeptnTaskDefinitions
 	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
 	PromotionTasks []string `json:"promotionTasks,omitempty"`
+	// RollbackTasks is a list of all tasks to be performed during the rollback phase of the KeptnApp.
+	// The rollback phase is only executed if the KeptnAppVersion has failed.
+	// The items of this list refer to the names of KeptnTaskDefinitions
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	RollbackTasks []string `json:"rollbackTasks,omitempty"`
+	// OnFailureTasks is a list of all tasks to be performed during the on-failure phase of the KeptnApp.
+	// The on-failure phase is only executed if a phase of the KeptnAppVersion has failed.
+	// The tasks receive the name of the failed phase and the items that have failed in that phase
+	// as part of their context.
+	// The items of this list refer to the names of KeptnTaskDefinitions
+	// located in the same namespace as the KeptnApp, or in the Keptn namespace.
+	// +optional
+	OnFailureTasks []string `json:"onFailureTasks,omitempty"`
+}
+
+// TaskDependency defines the tasks a task of a deployment phase depends on.
+type TaskDependency struct {
+	// Name is the name of the task, which must be one of the tasks of the same phase.
+	Name string `json:"name"`
+	// DependsOn is a list of tasks of the same phase that must succeed before the task is started.
+	// If one of these tasks fails, the task is not executed and marked as Deprecated.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// ExecutionCondition defines when a task or evaluation is executed.
+type ExecutionCondition struct {
+	// Name is the name of the task or evaluation the condition applies to.
+	Name string `json:"name"`
+	// When is a CEL expression that must evaluate to true for the task or evaluation to be executed.
+	// The expression has access to the following variables:
+	// metadata (the metadata of the KeptnApp or KeptnWorkload), version, previousVersion,
+	// traceId, checkType (the type of the phase, e.g. pre or post-eval) and now (the current time).
+	When string `json:"when"`
 }
 
+// RollbackStrategy defines how the workloads of a failed KeptnAppVersion are rolled back.
+// +kubebuilder:validation:Enum=None;RestorePreviousVersion
+type RollbackStrategy string
+
+const (
+	// RollbackStrategyNone does not modify any workloads during the rollback phase.
+	RollbackStrategyNone RollbackStrategy = "None"
+	// RollbackStrategyRestorePreviousVersion restores the revision of each workload
+	// that has been deployed with the previous version of the KeptnApp.
+	RollbackStrategyRestorePreviousVersion RollbackStrategy = "RestorePreviousVersion"
+)
+
 // KeptnAppContextSpec defines the desired state of KeptnAppContext
 type KeptnAppContextSpec struct {
 	DeploymentTaskSpec `json:",inline"`
@@ -57,6 +132,13 @@ type KeptnAppContextSpec struct {
 	// SpanLinks are links to OpenTelemetry span IDs for tracking. These links establish relationships between spans across different services, enabling distributed tracing.
 	// For more information on OpenTelemetry span links, refer to the documentation: https://opentelemetry.io/docs/concepts/signals/traces/#span-links
 	SpanLinks []string `json:"spanLinks,omitempty"`
+
+	// +kubebuilder:default:=None
+	// +optional
+	// RollbackStrategy defines how the workloads are rolled back if the KeptnAppVersion fails.
+	// If set to RestorePreviousVersion, the revision of each workload that has been deployed with the
+	// previous version of the KeptnApp is restored during the rollback phase.
+	RollbackStrategy RollbackStrategy `json:"rollbackStrategy,omitempty"`
 }
 
 // KeptnAppContextStatus defines the observed state of KeptnAppContext
@@ -0,0 +1,88 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var keptnappcontextlog = logf.Log.WithName("keptnappcontext-resource")
+
+func (r *KeptnAppContext) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1beta1-keptnappcontext,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnappcontexts,verbs=create;update,versions=v1beta1,name=vkeptnappcontext.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnAppContext{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnAppContext) ValidateCreate() (admission.Warnings, error) {
+	keptnappcontextlog.Info("validate create", "name", r.Name)
+
+	return []string{}, r.validateKeptnAppContext()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnAppContext) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	keptnappcontextlog.Info("validate update", "name", r.Name)
+
+	return []string{}, r.validateKeptnAppContext()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnAppContext) ValidateDelete() (admission.Warnings, error) {
+	keptnappcontextlog.Info("validate delete", "name", r.Name)
+
+	return []string{}, nil
+}
+
+func (r *KeptnAppContext) validateKeptnAppContext() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateTaskDependencies(r.Spec.PreDeploymentTasks, r.Spec.PreDeploymentTaskDependencies, specPath.Child("preDeploymentTaskDependencies"))...)
+	allErrs = append(allErrs, validateTaskDependencies(r.Spec.PostDeploymentTasks, r.Spec.PostDeploymentTaskDependencies, specPath.Child("postDeploymentTaskDependencies"))...)
+	var items []string
+	items = append(items, r.Spec.PreDeploymentTasks...)
+	items = append(items, r.Spec.PostDeploymentTasks...)
+	items = append(items, r.Spec.PreDeploymentEvaluations...)
+	items = append(items, r.Spec.PostDeploymentEvaluations...)
+	items = append(items, r.Spec.PreDeploymentAnalyses...)
+	items = append(items, r.Spec.PostDeploymentAnalyses...)
+	items = append(items, r.Spec.PromotionTasks...)
+	items = append(items, r.Spec.RollbackTasks...)
+	items = append(items, r.Spec.OnFailureTasks...)
+	allErrs = append(allErrs, validateExecutionConditions(items, r.Spec.ExecutionConditions, specPath.Child("executionConditions"))...)
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+		r.Name,
+		allErrs)
+}
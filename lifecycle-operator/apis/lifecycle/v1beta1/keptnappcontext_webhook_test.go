@@ -0,0 +1,103 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnAppContext_Validate(t *testing.T) {
+	validSpec := KeptnAppContextSpec{
+		DeploymentTaskSpec: DeploymentTaskSpec{
+			PreDeploymentTasks: []string{"migrate", "warmup"},
+			PreDeploymentTaskDependencies: []TaskDependency{
+				{Name: "warmup", DependsOn: []string{"migrate"}},
+			},
+		},
+	}
+
+	cyclicSpec := KeptnAppContextSpec{
+		DeploymentTaskSpec: DeploymentTaskSpec{
+			PostDeploymentTasks: []string{"test", "notify"},
+			PostDeploymentTaskDependencies: []TaskDependency{
+				{Name: "test", DependsOn: []string{"notify"}},
+				{Name: "notify", DependsOn: []string{"test"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		spec KeptnAppContextSpec
+		verb string
+		want error
+	}{
+		{
+			name: "create-valid",
+			spec: validSpec,
+			verb: "create",
+		},
+		{
+			name: "create-with-cycle",
+			spec: cyclicSpec,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+				"create-with-cycle",
+				field.ErrorList{field.Invalid(
+					field.NewPath("spec").Child("postDeploymentTaskDependencies"),
+					"test -> notify -> test",
+					"task dependencies must not contain cycles",
+				)},
+			),
+		},
+		{
+			name: "update-with-cycle",
+			spec: cyclicSpec,
+			verb: "update",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnAppContext"},
+				"update-with-cycle",
+				field.ErrorList{field.Invalid(
+					field.NewPath("spec").Child("postDeploymentTaskDependencies"),
+					"test -> notify -> test",
+					"task dependencies must not contain cycles",
+				)},
+			),
+		},
+		{
+			name: "delete",
+			spec: cyclicSpec,
+			verb: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appContext := &KeptnAppContext{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       tt.spec,
+			}
+
+			var got error
+			switch tt.verb {
+			case "create":
+				_, got = appContext.ValidateCreate()
+			case "update":
+				_, got = appContext.ValidateUpdate(&KeptnAppContext{})
+			case "delete":
+				_, got = appContext.ValidateDelete()
+			}
+
+			if tt.want != nil {
+				require.EqualValues(t, tt.want, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
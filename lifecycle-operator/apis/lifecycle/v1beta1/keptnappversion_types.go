@@ -56,6 +56,14 @@ type KeptnAppVersionStatus struct {
 	// +kubebuilder:default:=Pending
 	// +optional
 	PromotionStatus common.KeptnState `json:"promotionStatus,omitempty"`
+	// RollbackStatus indicates the current status of the KeptnAppVersion's Rollback phase.
+	// +kubebuilder:default:=Pending
+	// +optional
+	RollbackStatus common.KeptnState `json:"rollbackStatus,omitempty"`
+	// OnFailureStatus indicates the current status of the KeptnAppVersion's OnFailure phase.
+	// +kubebuilder:default:=Pending
+	// +optional
+	OnFailureStatus common.KeptnState `json:"onFailureStatus,omitempty"`
 	// PreDeploymentEvaluationStatus indicates the current status of the KeptnAppVersion's PreDeploymentEvaluation phase.
 	// +kubebuilder:default:=Pending
 	// +optional
@@ -74,6 +82,9 @@ type KeptnAppVersionStatus struct {
 	// CurrentPhase indicates the current phase of the KeptnAppVersion.
 	// +optional
 	CurrentPhase string `json:"currentPhase,omitempty"`
+	// FailedPhase indicates the phase in which the KeptnAppVersion has failed.
+	// +optional
+	FailedPhase string `json:"failedPhase,omitempty"`
 	// PreDeploymentTaskStatus indicates the current state of each preDeploymentTask of the KeptnAppVersion.
 	// +optional
 	PreDeploymentTaskStatus []ItemStatus `json:"preDeploymentTaskStatus,omitempty"`
@@ -83,12 +94,33 @@ type KeptnAppVersionStatus struct {
 	// PromotionTaskStatus indicates the current state of each promotionTask of the KeptnAppVersion.
 	// +optional
 	PromotionTaskStatus []ItemStatus `json:"promotionTaskStatus,omitempty"`
+	// RollbackTaskStatus indicates the current state of each rollbackTask of the KeptnAppVersion.
+	// +optional
+	RollbackTaskStatus []ItemStatus `json:"rollbackTaskStatus,omitempty"`
+	// RollbackWorkloadStatus contains the status of each KeptnWorkload whose previous version
+	// has been restored during the rollback phase of the KeptnAppVersion.
+	// +optional
+	RollbackWorkloadStatus []WorkloadStatus `json:"rollbackWorkloadStatus,omitempty"`
+	// OnFailureTaskStatus indicates the current state of each onFailureTask of the KeptnAppVersion.
+	// +optional
+	OnFailureTaskStatus []ItemStatus `json:"onFailureTaskStatus,omitempty"`
 	// PreDeploymentEvaluationTaskStatus indicates the current state of each preDeploymentEvaluation of the KeptnAppVersion.
 	// +optional
 	PreDeploymentEvaluationTaskStatus []ItemStatus `json:"preDeploymentEvaluationTaskStatus,omitempty"`
 	// PostDeploymentEvaluationTaskStatus indicates the current state of each postDeploymentEvaluation of the KeptnAppVersion.
 	// +optional
 	PostDeploymentEvaluationTaskStatus []ItemStatus `json:"postDeploymentEvaluationTaskStatus,omitempty"`
+	// PreDeploymentAnalysisStatus indicates the current state of each preDeploymentAnalysis of the KeptnAppVersion.
+	// The name of each item refers to the Analysis created for the respective AnalysisDefinition.
+	// +optional
+	PreDeploymentAnalysisStatus []ItemStatus `json:"preDeploymentAnalysisStatus,omitempty"`
+	// PostDeploymentAnalysisStatus indicates the current state of each postDeploymentAnalysis of the KeptnAppVersion.
+	// The name of each item refers to the Analysis created for the respective AnalysisDefinition.
+	// +optional
+	PostDeploymentAnalysisStatus []ItemStatus `json:"postDeploymentAnalysisStatus,omitempty"`
+	// FreezeWindow contains information about the KeptnFreezeWindow that blocks the deployment of the KeptnAppVersion.
+	// +optional
+	FreezeWindow *FreezeWindowStatus `json:"freezeWindow,omitempty"`
 	// PhaseTraceIDs contains the trace IDs of the OpenTelemetry spans of each phase of the KeptnAppVersion.
 	// +optional
 	PhaseTraceIDs common.PhaseTraceID `json:"phaseTraceIDs,omitempty"`
@@ -128,6 +160,8 @@ type WorkloadStatus struct {
 // +kubebuilder:printcolumn:name="PostDeploymentStatus",priority=1,type=string,JSONPath=`.status.postDeploymentStatus`
 // +kubebuilder:printcolumn:name="PostDeploymentEvaluationStatus",priority=1,type=string,JSONPath=`.status.postDeploymentEvaluationStatus`
 // +kubebuilder:printcolumn:name="PromotionStatus",priority=1,type=string,JSONPath=`.status.promotionStatus`
+// +kubebuilder:printcolumn:name="RollbackStatus",priority=1,type=string,JSONPath=`.status.rollbackStatus`
+// +kubebuilder:printcolumn:name="OnFailureStatus",priority=1,type=string,JSONPath=`.status.onFailureStatus`
 
 // KeptnAppVersion is the Schema for the keptnappversions API
 type KeptnAppVersion struct {
@@ -247,6 +281,38 @@ func (a KeptnAppVersion) IsPromotionSucceeded() bool {
 	return a.Status.PromotionStatus.IsSucceeded()
 }
 
+func (a KeptnAppVersion) IsRollbackEnabled() bool {
+	return len(a.Spec.RollbackTasks) > 0 || a.Spec.RollbackStrategy == RollbackStrategyRestorePreviousVersion
+}
+
+func (a KeptnAppVersion) IsRollbackCompleted() bool {
+	return a.Status.RollbackStatus.IsCompleted()
+}
+
+func (a KeptnAppVersion) IsRollbackSucceeded() bool {
+	return a.Status.RollbackStatus.IsSucceeded()
+}
+
+// IsRollbackRequired returns true if the KeptnAppVersion has failed, has a rollback configured
+// and the rollback phase has not been completed yet
+func (a KeptnAppVersion) IsRollbackRequired() bool {
+	return a.Status.Status.IsFailed() && a.IsRollbackEnabled() && !a.IsRollbackCompleted()
+}
+
+func (a KeptnAppVersion) IsOnFailureEnabled() bool {
+	return len(a.Spec.OnFailureTasks) > 0
+}
+
+func (a KeptnAppVersion) IsOnFailureCompleted() bool {
+	return a.Status.OnFailureStatus.IsCompleted()
+}
+
+// IsOnFailureRequired returns true if the KeptnAppVersion has failed, has onFailureTasks configured
+// and the on-failure phase has not been completed yet
+func (a KeptnAppVersion) IsOnFailureRequired() bool {
+	return a.Status.Status.IsFailed() && a.IsOnFailureEnabled() && !a.IsOnFailureCompleted()
+}
+
 func (a KeptnAppVersion) AreWorkloadsCompleted() bool {
 	return a.Status.WorkloadOverallStatus.IsCompleted()
 }
@@ -328,10 +394,34 @@ func (a KeptnAppVersion) GetPostDeploymentTasks() []string {
 	return a.Spec.PostDeploymentTasks
 }
 
+func (a KeptnAppVersion) GetPreDeploymentTaskDependencies() []TaskDependency {
+	return a.Spec.PreDeploymentTaskDependencies
+}
+
+func (a KeptnAppVersion) GetPostDeploymentTaskDependencies() []TaskDependency {
+	return a.Spec.PostDeploymentTaskDependencies
+}
+
+func (a KeptnAppVersion) GetExecutionConditions() []ExecutionCondition {
+	return a.Spec.ExecutionConditions
+}
+
+func (a KeptnAppVersion) GetTraceId() map[string]string {
+	return a.Spec.TraceId
+}
+
 func (a KeptnAppVersion) GetPromotionTasks() []string {
 	return a.Spec.PromotionTasks
 }
 
+func (a KeptnAppVersion) GetRollbackTasks() []string {
+	return a.Spec.RollbackTasks
+}
+
+func (a KeptnAppVersion) GetOnFailureTasks() []string {
+	return a.Spec.OnFailureTasks
+}
+
 func (a KeptnAppVersion) GetPreDeploymentTaskStatus() []ItemStatus {
 	return a.Status.PreDeploymentTaskStatus
 }
@@ -356,10 +446,62 @@ func (a KeptnAppVersion) GetPostDeploymentEvaluationTaskStatus() []ItemStatus {
 	return a.Status.PostDeploymentEvaluationTaskStatus
 }
 
+func (a KeptnAppVersion) GetPreDeploymentAnalyses() []string {
+	return a.Spec.PreDeploymentAnalyses
+}
+
+func (a KeptnAppVersion) GetPostDeploymentAnalyses() []string {
+	return a.Spec.PostDeploymentAnalyses
+}
+
+func (a KeptnAppVersion) GetPreDeploymentAnalysisStatus() []ItemStatus {
+	return a.Status.PreDeploymentAnalysisStatus
+}
+
+func (a KeptnAppVersion) GetPostDeploymentAnalysisStatus() []ItemStatus {
+	return a.Status.PostDeploymentAnalysisStatus
+}
+
 func (a KeptnAppVersion) GetPromotionTaskStatus() []ItemStatus {
 	return a.Status.PromotionTaskStatus
 }
 
+func (a KeptnAppVersion) GetRollbackTaskStatus() []ItemStatus {
+	return a.Status.RollbackTaskStatus
+}
+
+func (a KeptnAppVersion) GetOnFailureTaskStatus() []ItemStatus {
+	return a.Status.OnFailureTaskStatus
+}
+
+func (a KeptnAppVersion) GetFailedPhase() string {
+	return a.Status.FailedPhase
+}
+
+// GetFailedItemStatus returns the status of the tasks, evaluations, analyses or workloads
+// that have failed in the phase that caused the KeptnAppVersion to fail
+func (a KeptnAppVersion) GetFailedItemStatus() []ItemStatus {
+	switch a.Status.FailedPhase {
+	case common.PhaseAppPreDeployment.ShortName:
+		return getFailedItems(a.Status.PreDeploymentTaskStatus)
+	case common.PhaseAppPreEvaluation.ShortName:
+		return getFailedItems(a.Status.PreDeploymentEvaluationTaskStatus, a.Status.PreDeploymentAnalysisStatus)
+	case common.PhaseAppDeployment.ShortName:
+		workloads := make([]ItemStatus, 0, len(a.Status.WorkloadStatus))
+		for _, w := range a.Status.WorkloadStatus {
+			workloads = append(workloads, ItemStatus{Name: a.GetWorkloadNameOfApp(w.Workload.Name), Status: w.Status})
+		}
+		return getFailedItems(workloads)
+	case common.PhaseAppPostDeployment.ShortName:
+		return getFailedItems(a.Status.PostDeploymentTaskStatus)
+	case common.PhaseAppPostEvaluation.ShortName:
+		return getFailedItems(a.Status.PostDeploymentEvaluationTaskStatus, a.Status.PostDeploymentAnalysisStatus)
+	case common.PhasePromotion.ShortName:
+		return getFailedItems(a.Status.PromotionTaskStatus)
+	}
+	return nil
+}
+
 func (a KeptnAppVersion) GetAppName() string {
 	return a.Spec.AppName
 }
@@ -402,10 +544,11 @@ func (a KeptnAppVersion) GenerateTask(taskDefinition KeptnTaskDefinition, checkT
 		},
 		Spec: KeptnTaskSpec{
 			Context: TaskContext{
-				AppName:    a.GetParentName(),
-				AppVersion: a.GetVersion(),
-				TaskType:   string(checkType),
-				ObjectType: "App",
+				AppName:         a.GetParentName(),
+				AppVersion:      a.GetVersion(),
+				TaskType:        string(checkType),
+				ObjectType:      "App",
+				PreviousVersion: a.GetPreviousVersion(),
 			},
 			TaskDefinition:   taskDefinition.Name,
 			Parameters:       TaskParameters{},
@@ -426,6 +569,7 @@ func (a KeptnAppVersion) GenerateEvaluation(evaluationDefinition KeptnEvaluation
 		Spec: KeptnEvaluationSpec{
 			AppVersion:           a.Spec.Version,
 			AppName:              a.Spec.AppName,
+			PreviousVersion:      a.Spec.PreviousVersion,
 			EvaluationDefinition: evaluationDefinition.Name,
 			Type:                 checkType,
 			FailureConditions: FailureConditions{
@@ -465,6 +609,10 @@ func (v KeptnAppVersion) GetWorkloadNameOfApp(workloadName string) string {
 
 //nolint:dupl
 func (a *KeptnAppVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType) {
+	// remember the failed phase for the on-failure phase
+	if phase != common.PhaseDeprecated {
+		a.Status.FailedPhase = phase.ShortName
+	}
 	// no need to deprecate anything when promotion tasks fail
 	if phase == common.PhasePromotion {
 		return
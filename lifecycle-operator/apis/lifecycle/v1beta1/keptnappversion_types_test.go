@@ -53,6 +53,20 @@ func TestKeptnAppVersion(t *testing.T) {
 					Name:           "taskname4",
 				},
 			},
+			PreDeploymentAnalysisStatus: []ItemStatus{
+				{
+					DefinitionName: "analysisdef1",
+					Status:         common.StateSucceeded,
+					Name:           "analysis1",
+				},
+			},
+			PostDeploymentAnalysisStatus: []ItemStatus{
+				{
+					DefinitionName: "analysisdef2",
+					Status:         common.StateWarning,
+					Name:           "analysis2",
+				},
+			},
 			PromotionTaskStatus: []ItemStatus{
 				{
 					DefinitionName: "defname5",
@@ -60,6 +74,13 @@ func TestKeptnAppVersion(t *testing.T) {
 					Name:           "taskname5",
 				},
 			},
+			RollbackTaskStatus: []ItemStatus{
+				{
+					DefinitionName: "defname6",
+					Status:         common.StateFailed,
+					Name:           "taskname6",
+				},
+			},
 			CurrentPhase: common.PhaseAppDeployment.ShortName,
 		},
 		Spec: KeptnAppVersionSpec{
@@ -72,7 +93,19 @@ func TestKeptnAppVersion(t *testing.T) {
 					PostDeploymentTasks:       []string{"task3", "task4"},
 					PreDeploymentEvaluations:  []string{"task5", "task6"},
 					PostDeploymentEvaluations: []string{"task7", "task8"},
+					PreDeploymentAnalyses:     []string{"analysisdef1"},
+					PostDeploymentAnalyses:    []string{"analysisdef2"},
 					PromotionTasks:            []string{"task9", "task10"},
+					RollbackTasks:             []string{"task11"},
+					PreDeploymentTaskDependencies: []TaskDependency{
+						{Name: "task2", DependsOn: []string{"task1"}},
+					},
+					PostDeploymentTaskDependencies: []TaskDependency{
+						{Name: "task4", DependsOn: []string{"task3"}},
+					},
+					ExecutionConditions: []ExecutionCondition{
+						{Name: "task1", When: "version != previousVersion"},
+					},
 				},
 			},
 			PreviousVersion: "prev",
@@ -159,9 +192,14 @@ func TestKeptnAppVersion(t *testing.T) {
 
 	require.Equal(t, []string{"task1", "task2"}, app.GetPreDeploymentTasks())
 	require.Equal(t, []string{"task3", "task4"}, app.GetPostDeploymentTasks())
+	require.Equal(t, []TaskDependency{{Name: "task2", DependsOn: []string{"task1"}}}, app.GetPreDeploymentTaskDependencies())
+	require.Equal(t, []TaskDependency{{Name: "task4", DependsOn: []string{"task3"}}}, app.GetPostDeploymentTaskDependencies())
+	require.Equal(t, []ExecutionCondition{{Name: "task1", When: "version != previousVersion"}}, app.GetExecutionConditions())
+	require.Equal(t, map[string]string{"traceparent": "trace1"}, app.GetTraceId())
 	require.Equal(t, []string{"task5", "task6"}, app.GetPreDeploymentEvaluations())
 	require.Equal(t, []string{"task7", "task8"}, app.GetPostDeploymentEvaluations())
 	require.Equal(t, []string{"task9", "task10"}, app.GetPromotionTasks())
+	require.Equal(t, []string{"task11"}, app.GetRollbackTasks())
 
 	require.Equal(t, []ItemStatus{
 		{
@@ -195,6 +233,23 @@ func TestKeptnAppVersion(t *testing.T) {
 		},
 	}, app.GetPostDeploymentEvaluationTaskStatus())
 
+	require.Equal(t, []string{"analysisdef1"}, app.GetPreDeploymentAnalyses())
+	require.Equal(t, []string{"analysisdef2"}, app.GetPostDeploymentAnalyses())
+	require.Equal(t, []ItemStatus{
+		{
+			DefinitionName: "analysisdef1",
+			Status:         common.StateSucceeded,
+			Name:           "analysis1",
+		},
+	}, app.GetPreDeploymentAnalysisStatus())
+	require.Equal(t, []ItemStatus{
+		{
+			DefinitionName: "analysisdef2",
+			Status:         common.StateWarning,
+			Name:           "analysis2",
+		},
+	}, app.GetPostDeploymentAnalysisStatus())
+
 	require.Equal(t, []ItemStatus{
 		{
 			DefinitionName: "defname5",
@@ -203,6 +258,14 @@ func TestKeptnAppVersion(t *testing.T) {
 		},
 	}, app.GetPromotionTaskStatus())
 
+	require.Equal(t, []ItemStatus{
+		{
+			DefinitionName: "defname6",
+			Status:         common.StateFailed,
+			Name:           "taskname6",
+		},
+	}, app.GetRollbackTaskStatus())
+
 	require.Equal(t, "appname", app.GetAppName())
 	require.Equal(t, "prev", app.GetPreviousVersion())
 	require.Equal(t, "appname", app.GetParentName())
@@ -245,10 +308,11 @@ func TestKeptnAppVersion(t *testing.T) {
 	}, common.PostDeploymentCheckType)
 	require.Equal(t, KeptnTaskSpec{
 		Context: TaskContext{
-			AppName:    app.GetParentName(),
-			AppVersion: app.GetVersion(),
-			TaskType:   string(common.PostDeploymentCheckType),
-			ObjectType: "App",
+			AppName:         app.GetParentName(),
+			AppVersion:      app.GetVersion(),
+			TaskType:        string(common.PostDeploymentCheckType),
+			ObjectType:      "App",
+			PreviousVersion: "prev",
 		},
 		TaskDefinition:   "task-def",
 		Parameters:       TaskParameters{},
@@ -284,6 +348,7 @@ func TestKeptnAppVersion(t *testing.T) {
 	require.Equal(t, KeptnEvaluationSpec{
 		AppVersion:           app.GetVersion(),
 		AppName:              app.GetParentName(),
+		PreviousVersion:      "prev",
 		EvaluationDefinition: "eval-def",
 		Type:                 common.PostDeploymentCheckType,
 		FailureConditions: FailureConditions{
@@ -346,6 +411,89 @@ func TestKeptnAppVersion_GetWorkloadNameOfApp(t *testing.T) {
 }
 
 //nolint:dupl
+func TestKeptnAppVersion_IsRollbackRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   KeptnAppContextSpec
+		status KeptnAppVersionStatus
+		want   bool
+	}{
+		{
+			name: "no rollback configured",
+			status: KeptnAppVersionStatus{
+				Status: common.StateFailed,
+			},
+			want: false,
+		},
+		{
+			name: "rollback tasks configured, app version failed",
+			spec: KeptnAppContextSpec{
+				DeploymentTaskSpec: DeploymentTaskSpec{
+					RollbackTasks: []string{"rollback"},
+				},
+			},
+			status: KeptnAppVersionStatus{
+				Status: common.StateFailed,
+			},
+			want: true,
+		},
+		{
+			name: "restore strategy configured, app version failed",
+			spec: KeptnAppContextSpec{
+				RollbackStrategy: RollbackStrategyRestorePreviousVersion,
+			},
+			status: KeptnAppVersionStatus{
+				Status:         common.StateFailed,
+				RollbackStatus: common.StateProgressing,
+			},
+			want: true,
+		},
+		{
+			name: "rollback configured, app version succeeded",
+			spec: KeptnAppContextSpec{
+				RollbackStrategy: RollbackStrategyRestorePreviousVersion,
+			},
+			status: KeptnAppVersionStatus{
+				Status: common.StateSucceeded,
+			},
+			want: false,
+		},
+		{
+			name: "rollback already completed",
+			spec: KeptnAppContextSpec{
+				RollbackStrategy: RollbackStrategyRestorePreviousVersion,
+			},
+			status: KeptnAppVersionStatus{
+				Status:         common.StateFailed,
+				RollbackStatus: common.StateSucceeded,
+			},
+			want: false,
+		},
+		{
+			name: "strategy None without tasks",
+			spec: KeptnAppContextSpec{
+				RollbackStrategy: RollbackStrategyNone,
+			},
+			status: KeptnAppVersionStatus{
+				Status: common.StateFailed,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := KeptnAppVersion{
+				Spec: KeptnAppVersionSpec{
+					KeptnAppContextSpec: tt.spec,
+				},
+				Status: tt.status,
+			}
+			require.Equal(t, tt.want, app.IsRollbackRequired())
+		})
+	}
+}
+
 func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 	app := KeptnAppVersion{
 		Status: KeptnAppVersionStatus{
@@ -375,6 +523,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StatePending,
 					PromotionStatus:                common.StatePending,
 					WorkloadOverallStatus:          common.StatePending,
+					FailedPhase:                    common.PhasePromotion.ShortName,
 					Status:                         common.StatePending,
 				},
 			},
@@ -390,6 +539,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StatePending,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
+					FailedPhase:                    common.PhaseAppPostEvaluation.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -405,6 +555,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
+					FailedPhase:                    common.PhaseAppPostDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -420,6 +571,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StatePending,
+					FailedPhase:                    common.PhaseAppDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -435,6 +587,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
+					FailedPhase:                    common.PhaseAppPreEvaluation.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -450,6 +603,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					PromotionStatus:                common.StateDeprecated,
 					WorkloadOverallStatus:          common.StateDeprecated,
+					FailedPhase:                    common.PhaseAppPreDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -480,6 +634,7 @@ func TestKeptnAppVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentEvaluationStatus: common.StatePending,
 					PromotionStatus:                common.StatePending,
 					WorkloadOverallStatus:          common.StatePending,
+					FailedPhase:                    common.PhaseWorkloadPreDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -40,6 +40,11 @@ type KeptnEvaluationSpec struct {
 	// AppVersion defines the version of the KeptnApp for which the KeptnEvaluation is done.
 	// +optional
 	AppVersion string `json:"appVersion,omitempty"`
+	// PreviousVersion is the version of the KeptnWorkload or KeptnApp that has been deployed prior to the version
+	// for which the KeptnEvaluation is done.
+	// The values recorded by the KeptnEvaluations of this version serve as baseline for the objectives.
+	// +optional
+	PreviousVersion string `json:"previousVersion,omitempty"`
 	// EvaluationDefinition refers to the name of the KeptnEvaluationDefinition
 	// which includes the objectives for the KeptnEvaluation.
 	// The KeptnEvaluationDefinition can be
@@ -77,6 +82,10 @@ type KeptnEvaluationStatus struct {
 type EvaluationStatusItem struct {
 	// Value represents the value of the KeptnMetric being evaluated.
 	Value string `json:"value"`
+	// BaselineValue is the value of the KeptnMetric recorded by the KeptnEvaluation of the previous version,
+	// which the Value has been compared to.
+	// +optional
+	BaselineValue string `json:"baselineValue,omitempty"`
 	// Status indicates the status of the objective being evaluated.
 	Status common.KeptnState `json:"status"`
 	// Message contains additional information about the evaluation of an objective.
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -47,12 +48,101 @@ type FailureConditions struct {
 	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.evaluationTarget) || has(self.target) || has(self.baseline)",message="either evaluationTarget, target or baseline must be set"
 type Objective struct {
 	// KeptnMetricRef references the KeptnMetric that should be evaluated.
 	KeptnMetricRef KeptnMetricReference `json:"keptnMetricRef"`
 	// EvaluationTarget specifies the target value for the references KeptnMetric.
 	// Needs to start with either '<' or '>', followed by the target value (e.g. '<10').
-	EvaluationTarget string `json:"evaluationTarget"`
+	// EvaluationTarget is ignored if Target is set.
+	// +optional
+	EvaluationTarget string `json:"evaluationTarget,omitempty"`
+	// Target defines the failure and warning criteria for the value of the referenced KeptnMetric.
+	// If the failure criteria are met, the objective fails.
+	// If the warning criteria are met, the objective finishes with a warning, which does not block the deployment.
+	// +optional
+	Target *Target `json:"target,omitempty"`
+	// Baseline defines the failure and warning criteria for the change of the value of the referenced KeptnMetric
+	// compared to the value recorded by the KeptnEvaluation of the previous version.
+	// If no value of the previous version is available, e.g. for the first version, the baseline criteria are not evaluated.
+	// +optional
+	Baseline *Baseline `json:"baseline,omitempty"`
+}
+
+// BaselineDeltaType defines how the change of a value compared to its baseline is computed
+// +kubebuilder:validation:Enum=Percent;Absolute
+type BaselineDeltaType string
+
+const (
+	// BaselineDeltaTypePercent computes the change in percent of the baseline value
+	BaselineDeltaTypePercent BaselineDeltaType = "Percent"
+	// BaselineDeltaTypeAbsolute computes the difference between the value and the baseline value
+	BaselineDeltaTypeAbsolute BaselineDeltaType = "Absolute"
+)
+
+// Baseline defines the failure and warning criteria for the change of a value compared to its baseline
+type Baseline struct {
+	// DeltaType defines how the change of the value compared to the baseline is computed.
+	// Percent computes the change in percent of the baseline value, e.g. 10 for an increase from 200 to 220.
+	// Absolute computes the difference between the value and the baseline value, e.g. 20 for an increase from 200 to 220.
+	// A decrease results in a negative change.
+	// +kubebuilder:default:=Percent
+	// +optional
+	DeltaType BaselineDeltaType `json:"deltaType,omitempty"`
+	// Target defines the failure and warning criteria for the change of the value.
+	Target `json:",inline"`
+}
+
+// Target defines the failure and warning criteria.
+// Target, Operator, OperatorValue and RangeValue mirror the types of the AnalysisDefinition
+// of the metrics operator (metrics.keptn.sh/v1beta1), so objectives can be moved between both.
+// The lifecycle operator does not depend on the metrics operator module, keep them in sync.
+type Target struct {
+	// Failure defines limits up to which an evaluation fails
+	// +optional
+	Failure *Operator `json:"failure,omitempty" yaml:"failure,omitempty"`
+	// Warning defines limits where the result does not pass or fail
+	// +optional
+	Warning *Operator `json:"warning,omitempty" yaml:"warning,omitempty"`
+}
+
+// OperatorValue represents the value to which the result is compared
+type OperatorValue struct {
+	// FixedValue defines the value for comparison
+	FixedValue resource.Quantity `json:"fixedValue" yaml:"fixedValue"`
+}
+
+// RangeValue represents a range which the value should fit
+type RangeValue struct {
+	// LowBound defines the lower bound of the range
+	LowBound resource.Quantity `json:"lowBound" yaml:"lowBound"`
+	// HighBound defines the higher bound of the range
+	HighBound resource.Quantity `json:"highBound" yaml:"highBound"`
+}
+
+// Operator specifies the supported operators for value comparisons
+type Operator struct {
+	// LessThanOrEqual represents '<=' operator
+	// +optional
+	LessThanOrEqual *OperatorValue `json:"lessThanOrEqual,omitempty" yaml:"lessThanOrEqual,omitempty"`
+	// LessThan represents '<' operator
+	// +optional
+	LessThan *OperatorValue `json:"lessThan,omitempty" yaml:"lessThan,omitempty"`
+	// GreaterThan represents '>' operator
+	// +optional
+	GreaterThan *OperatorValue `json:"greaterThan,omitempty" yaml:"greaterThan,omitempty"`
+	// GreaterThanOrEqual represents '>=' operator
+	// +optional
+	GreaterThanOrEqual *OperatorValue `json:"greaterThanOrEqual,omitempty" yaml:"greaterThanOrEqual,omitempty"`
+	// EqualTo represents '==' operator
+	// +optional
+	EqualTo *OperatorValue `json:"equalTo,omitempty" yaml:"equalTo,omitempty"`
+	// InRange represents operator checking the value is inclusively in the defined range, e.g. 2 <= x <= 5
+	// +optional
+	InRange *RangeValue `json:"inRange,omitempty" yaml:"inRange,omitempty"`
+	// NotInRange represents operator checking the value is exclusively out of the defined range, e.g. x < 2 AND x > 5
+	// +optional
+	NotInRange *RangeValue `json:"notInRange,omitempty" yaml:"notInRange,omitempty"`
 }
 
 type KeptnMetricReference struct {
@@ -95,3 +185,7 @@ type KeptnEvaluationDefinitionList struct {
 func init() {
 	SchemeBuilder.Register(&KeptnEvaluationDefinition{}, &KeptnEvaluationDefinitionList{})
 }
+
+func (o *OperatorValue) GetFloatValue() float64 {
+	return o.FixedValue.AsApproximateFloat64()
+}
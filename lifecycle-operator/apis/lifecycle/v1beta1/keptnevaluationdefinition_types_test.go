@@ -0,0 +1,52 @@
+package v1beta1
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// TestTargetMirrorsAnalysisDefinition makes sure the target types keep the schema
+// of the AnalysisDefinition objectives of the metrics operator
+func TestTargetMirrorsAnalysisDefinition(t *testing.T) {
+	evaluationTarget := getCRDTargetSchema(t, "../../../config/crd/bases/lifecycle.keptn.sh_keptnevaluationdefinitions.yaml")
+	analysisTarget := getCRDTargetSchema(t, "../../../../metrics-operator/config/crd/bases/metrics.keptn.sh_analysisdefinitions.yaml")
+
+	for _, criteria := range []string{"failure", "warning"} {
+		operator := evaluationTarget[criteria].(map[string]interface{})["properties"]
+		require.NotEmpty(t, operator)
+		require.Equal(t, analysisTarget[criteria].(map[string]interface{})["properties"], operator)
+	}
+}
+
+func getCRDTargetSchema(t *testing.T, path string) map[string]interface{} {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	crd := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(content, &crd))
+
+	for _, v := range crd["spec"].(map[string]interface{})["versions"].([]interface{}) {
+		version := v.(map[string]interface{})
+		if version["name"] != "v1beta1" {
+			continue
+		}
+		schema := getProperty(t, version["schema"].(map[string]interface{})["openAPIV3Schema"], "spec", "objectives")
+		target := getProperty(t, schema["items"], "target")
+		return target["properties"].(map[string]interface{})
+	}
+	require.Fail(t, "no v1beta1 version in "+path)
+	return nil
+}
+
+func getProperty(t *testing.T, schema interface{}, names ...string) map[string]interface{} {
+	current := schema.(map[string]interface{})
+	for _, name := range names {
+		property, ok := current["properties"].(map[string]interface{})[name]
+		require.True(t, ok, "missing property "+name)
+		current = property.(map[string]interface{})
+	}
+	return current
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	operatorcommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// KeptnFreezeWindowSpec defines the desired state of KeptnFreezeWindow
+type KeptnFreezeWindowSpec struct {
+	// Schedule is a cron expression with the fields minute, hour, day of month, month and day of week,
+	// defining the start of a recurring freeze window.
+	// Either Schedule or Start must be defined.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// Start is the start of a one-off freeze window.
+	// Either Schedule or Start must be defined.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+	// Duration is the length of the freeze window, starting at Start or at each time matching the Schedule.
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the name of the IANA time zone the Schedule is evaluated in, e.g. Europe/Vienna.
+	// +kubebuilder:default:=UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// NamespaceSelector selects the namespaces, based on their labels, in which deployments are blocked
+	// during the freeze window. If empty, all namespaces are selected.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// AppSelector selects the KeptnApps, based on their labels, whose deployments are blocked
+	// during the freeze window. If empty, all KeptnApps are selected.
+	// +optional
+	AppSelector *metav1.LabelSelector `json:"appSelector,omitempty"`
+	// Message is an explanation of the freeze window that is added to the status and the events
+	// of blocked KeptnAppVersions and KeptnWorkloadVersions.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FreezeWindowStatus describes the KeptnFreezeWindow that blocks or blocked the deployment of a
+// KeptnAppVersion or KeptnWorkloadVersion.
+type FreezeWindowStatus struct {
+	// Name is the name of the KeptnFreezeWindow.
+	Name string `json:"name"`
+	// Until is the time at which the freeze window ends.
+	// +optional
+	Until metav1.Time `json:"until,omitempty"`
+	// Message explains why the deployment is blocked.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Overridden indicates that the freeze window has been bypassed
+	// with the keptn.sh/freeze-override annotation.
+	// +optional
+	Overridden bool `json:"overridden,omitempty"`
+	// OverrideReason is the value of the keptn.sh/freeze-override annotation.
+	// +optional
+	OverrideReason string `json:"overrideReason,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Start",type=string,JSONPath=`.spec.start`
+// +kubebuilder:printcolumn:name="Duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="TimeZone",type=string,JSONPath=`.spec.timeZone`
+
+// KeptnFreezeWindow is the Schema for the keptnfreezewindows API
+type KeptnFreezeWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KeptnFreezeWindowSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeptnFreezeWindowList contains a list of KeptnFreezeWindow
+type KeptnFreezeWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeptnFreezeWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeptnFreezeWindow{}, &KeptnFreezeWindowList{})
+}
+
+// GetActiveUntil returns the end of the freeze window if it is active at the given time.
+// The returned bool is false if the freeze window is not active.
+func (w KeptnFreezeWindow) GetActiveUntil(now time.Time) (time.Time, bool, error) {
+	if w.Spec.Start != nil {
+		end := w.Spec.Start.Add(w.Spec.Duration.Duration)
+		return end, !now.Before(w.Spec.Start.Time) && now.Before(end), nil
+	}
+
+	schedule, err := operatorcommon.ParseCronSchedule(w.Spec.Schedule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	loc, err := w.GetLocation()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	// the window is active if it has been started by the schedule within the last duration
+	start := schedule.Next(now.In(loc).Add(-w.Spec.Duration.Duration))
+	if start.IsZero() || start.After(now) {
+		return time.Time{}, false, nil
+	}
+	return start.Add(w.Spec.Duration.Duration), true, nil
+}
+
+// GetLocation returns the time zone the schedule of the freeze window is evaluated in
+func (w KeptnFreezeWindow) GetLocation() (*time.Location, error) {
+	if w.Spec.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.Spec.TimeZone)
+}
+
+// Selects returns whether the freeze window applies to a KeptnApp with the given labels
+// in a namespace with the given labels
+func (w KeptnFreezeWindow) Selects(namespaceLabels map[string]string, appLabels map[string]string) (bool, error) {
+	selected, err := selectorMatches(w.Spec.NamespaceSelector, namespaceLabels)
+	if err != nil || !selected {
+		return false, err
+	}
+	return selectorMatches(w.Spec.AppSelector, appLabels)
+}
+
+func selectorMatches(selector *metav1.LabelSelector, objectLabels map[string]string) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(objectLabels)), nil
+}
+
+// IsBlocking returns whether the deployment is blocked by the freeze window
+func (s *FreezeWindowStatus) IsBlocking() bool {
+	return s != nil && !s.Overridden
+}
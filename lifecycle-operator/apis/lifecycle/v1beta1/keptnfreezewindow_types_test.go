@@ -0,0 +1,97 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeptnFreezeWindow_GetActiveUntil(t *testing.T) {
+	start := metav1.NewTime(time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		spec       KeptnFreezeWindowSpec
+		now        time.Time
+		wantActive bool
+		wantUntil  time.Time
+	}{
+		{
+			name:       "one-off window active",
+			spec:       KeptnFreezeWindowSpec{Start: &start, Duration: metav1.Duration{Duration: 24 * time.Hour}},
+			now:        time.Date(2024, 12, 20, 12, 0, 0, 0, time.UTC),
+			wantActive: true,
+			wantUntil:  time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "one-off window over",
+			spec: KeptnFreezeWindowSpec{Start: &start, Duration: metav1.Duration{Duration: 24 * time.Hour}},
+			now:  time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "recurring window active",
+			spec:       KeptnFreezeWindowSpec{Schedule: "0 18 * * 5", Duration: metav1.Duration{Duration: 62 * time.Hour}},
+			now:        time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+			wantActive: true,
+			wantUntil:  time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "recurring window not active",
+			spec: KeptnFreezeWindowSpec{Schedule: "0 18 * * 5", Duration: metav1.Duration{Duration: 62 * time.Hour}},
+			now:  time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "recurring window in time zone",
+			spec: KeptnFreezeWindowSpec{
+				Schedule: "0 18 * * 5",
+				Duration: metav1.Duration{Duration: time.Hour},
+				TimeZone: "Europe/Vienna",
+			},
+			now:        time.Date(2024, 3, 8, 17, 30, 0, 0, time.UTC),
+			wantActive: true,
+			wantUntil:  time.Date(2024, 3, 8, 18, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := KeptnFreezeWindow{Spec: tt.spec}
+			until, active, err := window.GetActiveUntil(tt.now)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantActive, active)
+			if tt.wantActive {
+				require.True(t, tt.wantUntil.Equal(until), "got %s", until)
+			}
+		})
+	}
+}
+
+func TestKeptnFreezeWindow_Selects(t *testing.T) {
+	window := KeptnFreezeWindow{
+		Spec: KeptnFreezeWindowSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+			AppSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"internal"}},
+				},
+			},
+		},
+	}
+
+	selected, err := window.Selects(map[string]string{"env": "production"}, map[string]string{"tier": "frontend"})
+	require.Nil(t, err)
+	require.True(t, selected)
+
+	selected, err = window.Selects(map[string]string{"env": "production"}, map[string]string{"tier": "internal"})
+	require.Nil(t, err)
+	require.False(t, selected)
+
+	selected, err = window.Selects(map[string]string{"env": "staging"}, nil)
+	require.Nil(t, err)
+	require.False(t, selected)
+
+	selected, err = KeptnFreezeWindow{}.Selects(nil, nil)
+	require.Nil(t, err)
+	require.True(t, selected)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	operatorcommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var keptnfreezewindowlog = logf.Log.WithName("keptnfreezewindow-resource")
+
+func (r *KeptnFreezeWindow) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1beta1-keptnfreezewindow,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnfreezewindows,verbs=create;update,versions=v1beta1,name=vkeptnfreezewindow.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnFreezeWindow{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnFreezeWindow) ValidateCreate() (admission.Warnings, error) {
+	keptnfreezewindowlog.Info("validate create", "name", r.Name)
+
+	return []string{}, r.validateKeptnFreezeWindow()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnFreezeWindow) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	keptnfreezewindowlog.Info("validate update", "name", r.Name)
+
+	return []string{}, r.validateKeptnFreezeWindow()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnFreezeWindow) ValidateDelete() (admission.Warnings, error) {
+	keptnfreezewindowlog.Info("validate delete", "name", r.Name)
+
+	return []string{}, nil
+}
+
+func (r *KeptnFreezeWindow) validateKeptnFreezeWindow() error {
+	var allErrs field.ErrorList //defined as a list to allow returning multiple validation errors
+	specPath := field.NewPath("spec")
+
+	if (r.Spec.Schedule == "") == (r.Spec.Start == nil) {
+		allErrs = append(allErrs, field.Invalid(specPath, r.Spec, "exactly one of schedule or start must be defined"))
+	}
+	if r.Spec.Schedule != "" {
+		if _, err := operatorcommon.ParseCronSchedule(r.Spec.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("schedule"), r.Spec.Schedule, err.Error()))
+		}
+	}
+	if r.Spec.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("duration"), r.Spec.Duration.Duration.String(), "duration must be greater than zero"))
+	}
+	if _, err := r.GetLocation(); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("timeZone"), r.Spec.TimeZone, err.Error()))
+	}
+	if r.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("namespaceSelector"), r.Spec.NamespaceSelector, err.Error()))
+		}
+	}
+	if r.Spec.AppSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.AppSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("appSelector"), r.Spec.AppSelector, err.Error()))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnFreezeWindow"},
+		r.Name,
+		allErrs)
+}
@@ -0,0 +1,128 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnFreezeWindow_Validate(t *testing.T) {
+	start := metav1.NewTime(time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC))
+	duration := metav1.Duration{Duration: 24 * time.Hour}
+
+	tests := []struct {
+		name string
+		spec KeptnFreezeWindowSpec
+		verb string
+		want error
+	}{
+		{
+			name: "create-schedule",
+			spec: KeptnFreezeWindowSpec{Schedule: "0 18 * * 5", Duration: duration, TimeZone: "Europe/Vienna"},
+			verb: "create",
+		},
+		{
+			name: "create-start",
+			spec: KeptnFreezeWindowSpec{Start: &start, Duration: duration},
+			verb: "create",
+		},
+		{
+			name: "create-schedule-and-start",
+			spec: KeptnFreezeWindowSpec{Schedule: "0 18 * * 5", Start: &start, Duration: duration},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnFreezeWindow"},
+				"create-schedule-and-start",
+				field.ErrorList{field.Invalid(
+					field.NewPath("spec"),
+					KeptnFreezeWindowSpec{Schedule: "0 18 * * 5", Start: &start, Duration: duration},
+					"exactly one of schedule or start must be defined",
+				)},
+			),
+		},
+		{
+			name: "update-invalid-schedule",
+			spec: KeptnFreezeWindowSpec{Schedule: "0 25 * * *", Duration: duration},
+			verb: "update",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnFreezeWindow"},
+				"update-invalid-schedule",
+				field.ErrorList{field.Invalid(
+					field.NewPath("spec").Child("schedule"),
+					"0 25 * * *",
+					`invalid value "25" in hour field, must be between 0 and 23`,
+				)},
+			),
+		},
+		{
+			name: "create-invalid-duration-and-timezone",
+			spec: KeptnFreezeWindowSpec{Start: &start, TimeZone: "Mars/Olympus_Mons"},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnFreezeWindow"},
+				"create-invalid-duration-and-timezone",
+				field.ErrorList{
+					field.Invalid(field.NewPath("spec").Child("duration"), "0s", "duration must be greater than zero"),
+					field.Invalid(field.NewPath("spec").Child("timeZone"), "Mars/Olympus_Mons", "unknown time zone Mars/Olympus_Mons"),
+				},
+			),
+		},
+		{
+			name: "create-invalid-selector",
+			spec: KeptnFreezeWindowSpec{
+				Start:    &start,
+				Duration: duration,
+				AppSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Equals"}},
+				},
+			},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnFreezeWindow"},
+				"create-invalid-selector",
+				field.ErrorList{field.Invalid(
+					field.NewPath("spec").Child("appSelector"),
+					&metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Equals"}},
+					},
+					`"Equals" is not a valid label selector operator`,
+				)},
+			),
+		},
+		{
+			name: "delete",
+			spec: KeptnFreezeWindowSpec{},
+			verb: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := &KeptnFreezeWindow{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       tt.spec,
+			}
+
+			var got error
+			switch tt.verb {
+			case "create":
+				_, got = window.ValidateCreate()
+			case "update":
+				_, got = window.ValidateUpdate(&KeptnFreezeWindow{})
+			case "delete":
+				_, got = window.ValidateDelete()
+			}
+
+			if tt.want != nil {
+				require.EqualValues(t, tt.want, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"text/template"
+	"time"
+
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NotificationFormatSlack = "slack"
+	NotificationFormatTeams = "teams"
+	NotificationFormatRaw   = "raw"
+
+	// DefaultNotificationTemplate is used to render the message of a notification if no template is defined
+	DefaultNotificationTemplate = `{{ .Phase }} {{ .State }}: {{ .Kind }} {{ .Namespace }}/{{ .Name }}{{ if .Version }} (version {{ .Version }}){{ end }} {{ .Message }}`
+
+	defaultNotificationRateLimit         = 5
+	defaultNotificationRateLimitInterval = time.Minute
+	defaultNotificationDeduplication     = 10 * time.Minute
+)
+
+// KeptnNotificationConfigSpec defines the desired state of KeptnNotificationConfig
+type KeptnNotificationConfigSpec struct {
+	// NamespaceSelector selects the namespaces, based on their labels, about which notifications are sent.
+	// It is only taken into account for KeptnNotificationConfigs in the namespace of Keptn,
+	// all other KeptnNotificationConfigs only send notifications about their own namespace.
+	// If empty, all namespaces are selected.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// AppSelector selects the KeptnApps, based on their labels, about which notifications are sent.
+	// If empty, all KeptnApps are selected.
+	// +optional
+	AppSelector *metav1.LabelSelector `json:"appSelector,omitempty"`
+	// Phases is a list of the phases about which notifications are sent, referenced by their short name,
+	// e.g. AppDeploy, or their long name, e.g. App Deployment.
+	// If empty, all phases are selected.
+	// +optional
+	Phases []string `json:"phases,omitempty"`
+	// States is a list of the states of a phase, e.g. Started, Finished or Failed, on which notifications are sent.
+	// +kubebuilder:default:={"Started","Finished","Failed"}
+	// +optional
+	States []string `json:"states,omitempty"`
+	// Format is the shape of the payload that is sent to the webhook.
+	// slack and teams wrap the rendered template into the payload of an incoming webhook of Slack or Microsoft Teams,
+	// raw sends the rendered template as it is, which must then be a valid JSON document.
+	// +kubebuilder:validation:Enum=slack;teams;raw
+	// +kubebuilder:default:=slack
+	// +optional
+	Format string `json:"format,omitempty"`
+	// Template is a Go template that is rendered to the message of the notification.
+	// If empty, a message containing the phase, the state and the affected resource is sent.
+	// The template is required if the Format is raw.
+	// +optional
+	Template string `json:"template,omitempty"`
+	// WebhookSecretRef refers to the key of a Secret in the namespace of the KeptnNotificationConfig
+	// containing the URL of the webhook.
+	WebhookSecretRef corev1.SecretKeySelector `json:"webhookSecretRef"`
+	// RateLimit limits the number of notifications that are sent about the same resource and phase.
+	// +optional
+	RateLimit *NotificationRateLimit `json:"rateLimit,omitempty"`
+	// DeduplicationWindow is the time during which a notification about the same state of a phase
+	// of the same resource is not sent again.
+	// +kubebuilder:default:="10m"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	DeduplicationWindow *metav1.Duration `json:"deduplicationWindow,omitempty"`
+}
+
+type NotificationRateLimit struct {
+	// Notifications is the maximum number of notifications that are sent about the same resource and phase
+	// within the Interval. Further notifications are dropped.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=5
+	// +optional
+	Notifications int32 `json:"notifications,omitempty"`
+	// Interval is the sliding time window the Notifications are counted in.
+	// +kubebuilder:default:="1m"
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Format",type=string,JSONPath=`.spec.format`
+// +kubebuilder:printcolumn:name="Phases",type=string,JSONPath=`.spec.phases`
+// +kubebuilder:printcolumn:name="States",type=string,JSONPath=`.spec.states`
+
+// KeptnNotificationConfig is the Schema for the keptnnotificationconfigs API
+type KeptnNotificationConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KeptnNotificationConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeptnNotificationConfigList contains a list of KeptnNotificationConfig
+type KeptnNotificationConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeptnNotificationConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeptnNotificationConfig{}, &KeptnNotificationConfigList{})
+}
+
+// SelectsPhase returns whether notifications are sent for the given phase and state
+func (c KeptnNotificationConfig) SelectsPhase(phase common.KeptnPhaseType, state string) bool {
+	if !slices.Contains(c.GetStates(), state) {
+		return false
+	}
+	if len(c.Spec.Phases) == 0 {
+		return true
+	}
+	for _, p := range c.Spec.Phases {
+		if p == phase.ShortName || p == phase.LongName {
+			return true
+		}
+	}
+	return false
+}
+
+// Selects returns whether notifications are sent about a KeptnApp with the given labels
+// in a namespace with the given labels
+func (c KeptnNotificationConfig) Selects(namespaceLabels map[string]string, appLabels map[string]string) (bool, error) {
+	selected, err := selectorMatches(c.Spec.NamespaceSelector, namespaceLabels)
+	if err != nil || !selected {
+		return false, err
+	}
+	return selectorMatches(c.Spec.AppSelector, appLabels)
+}
+
+// ParseTemplate parses the template of the notification message.
+// The function json can be used in the template to encode a value as JSON, e.g. in the raw format.
+func (c KeptnNotificationConfig) ParseTemplate() (*template.Template, error) {
+	text := c.Spec.Template
+	if text == "" {
+		text = DefaultNotificationTemplate
+	}
+	return template.New(c.Name).Option("missingkey=zero").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			out, err := json.Marshal(v)
+			return string(out), err
+		},
+	}).Parse(text)
+}
+
+func (c KeptnNotificationConfig) GetStates() []string {
+	if len(c.Spec.States) == 0 {
+		return []string{common.PhaseStateStarted, common.PhaseStateFinished, common.PhaseStateFailed}
+	}
+	return c.Spec.States
+}
+
+func (c KeptnNotificationConfig) GetFormat() string {
+	if c.Spec.Format == "" {
+		return NotificationFormatSlack
+	}
+	return c.Spec.Format
+}
+
+// GetRateLimit returns the maximum number of notifications about the same resource and phase
+// and the interval they are counted in
+func (c KeptnNotificationConfig) GetRateLimit() (int, time.Duration) {
+	notifications, interval := defaultNotificationRateLimit, defaultNotificationRateLimitInterval
+	if c.Spec.RateLimit != nil {
+		if c.Spec.RateLimit.Notifications > 0 {
+			notifications = int(c.Spec.RateLimit.Notifications)
+		}
+		if c.Spec.RateLimit.Interval.Duration > 0 {
+			interval = c.Spec.RateLimit.Interval.Duration
+		}
+	}
+	return notifications, interval
+}
+
+func (c KeptnNotificationConfig) GetDeduplicationWindow() time.Duration {
+	if c.Spec.DeduplicationWindow == nil {
+		return defaultNotificationDeduplication
+	}
+	return c.Spec.DeduplicationWindow.Duration
+}
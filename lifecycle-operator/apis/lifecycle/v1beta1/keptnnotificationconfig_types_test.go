@@ -0,0 +1,80 @@
+package v1beta1
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeptnNotificationConfig_SelectsPhase(t *testing.T) {
+	defaults := KeptnNotificationConfig{}
+	require.True(t, defaults.SelectsPhase(common.PhaseAppDeployment, common.PhaseStateStarted))
+	require.True(t, defaults.SelectsPhase(common.PhaseReconcileTask, common.PhaseStateFailed))
+	require.False(t, defaults.SelectsPhase(common.PhaseAppDeployment, common.PhaseStateStatusChanged))
+
+	filtered := KeptnNotificationConfig{
+		Spec: KeptnNotificationConfigSpec{
+			Phases: []string{"AppDeploy", "Workload Deployment"},
+			States: []string{common.PhaseStateFailed},
+		},
+	}
+	require.True(t, filtered.SelectsPhase(common.PhaseAppDeployment, common.PhaseStateFailed))
+	require.True(t, filtered.SelectsPhase(common.PhaseWorkloadDeployment, common.PhaseStateFailed))
+	require.False(t, filtered.SelectsPhase(common.PhaseAppDeployment, common.PhaseStateFinished))
+	require.False(t, filtered.SelectsPhase(common.PhaseAppPreDeployment, common.PhaseStateFailed))
+}
+
+func TestKeptnNotificationConfig_Selects(t *testing.T) {
+	notificationConfig := KeptnNotificationConfig{
+		Spec: KeptnNotificationConfigSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "production"}},
+			AppSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"team": "checkout"}},
+		},
+	}
+
+	selected, err := notificationConfig.Selects(map[string]string{"environment": "production"}, map[string]string{"team": "checkout"})
+	require.Nil(t, err)
+	require.True(t, selected)
+
+	selected, err = notificationConfig.Selects(map[string]string{"environment": "staging"}, map[string]string{"team": "checkout"})
+	require.Nil(t, err)
+	require.False(t, selected)
+
+	selected, err = notificationConfig.Selects(map[string]string{"environment": "production"}, nil)
+	require.Nil(t, err)
+	require.False(t, selected)
+}
+
+func TestKeptnNotificationConfig_ParseTemplate(t *testing.T) {
+	notificationConfig := KeptnNotificationConfig{
+		Spec: KeptnNotificationConfigSpec{
+			Template: `{"message": {{ json .Message }}}`,
+		},
+	}
+	tmpl, err := notificationConfig.ParseTemplate()
+	require.Nil(t, err)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, tmpl.Execute(buf, map[string]string{"Message": `has "failed"`}))
+	require.Equal(t, `{"message": "has \"failed\""}`, buf.String())
+}
+
+func TestKeptnNotificationConfig_Defaults(t *testing.T) {
+	notificationConfig := KeptnNotificationConfig{}
+	require.Equal(t, NotificationFormatSlack, notificationConfig.GetFormat())
+	notifications, interval := notificationConfig.GetRateLimit()
+	require.Equal(t, 5, notifications)
+	require.Equal(t, time.Minute, interval)
+	require.Equal(t, 10*time.Minute, notificationConfig.GetDeduplicationWindow())
+
+	notificationConfig.Spec.RateLimit = &NotificationRateLimit{Notifications: 1, Interval: metav1.Duration{Duration: time.Hour}}
+	notificationConfig.Spec.DeduplicationWindow = &metav1.Duration{}
+	notifications, interval = notificationConfig.GetRateLimit()
+	require.Equal(t, 1, notifications)
+	require.Equal(t, time.Hour, interval)
+	require.Zero(t, notificationConfig.GetDeduplicationWindow())
+}
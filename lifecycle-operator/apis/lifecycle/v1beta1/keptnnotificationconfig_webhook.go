@@ -0,0 +1,102 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var keptnnotificationconfiglog = logf.Log.WithName("keptnnotificationconfig-resource")
+
+func (r *KeptnNotificationConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1beta1-keptnnotificationconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnnotificationconfigs,verbs=create;update,versions=v1beta1,name=vkeptnnotificationconfig.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnNotificationConfig{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnNotificationConfig) ValidateCreate() (admission.Warnings, error) {
+	keptnnotificationconfiglog.Info("validate create", "name", r.Name)
+
+	return []string{}, r.validateKeptnNotificationConfig()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnNotificationConfig) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	keptnnotificationconfiglog.Info("validate update", "name", r.Name)
+
+	return []string{}, r.validateKeptnNotificationConfig()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnNotificationConfig) ValidateDelete() (admission.Warnings, error) {
+	keptnnotificationconfiglog.Info("validate delete", "name", r.Name)
+
+	return []string{}, nil
+}
+
+func (r *KeptnNotificationConfig) validateKeptnNotificationConfig() error {
+	var allErrs field.ErrorList //defined as a list to allow returning multiple validation errors
+	specPath := field.NewPath("spec")
+
+	if r.GetFormat() == NotificationFormatRaw && r.Spec.Template == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("template"), "template must be defined for the raw format"))
+	}
+	if _, err := r.ParseTemplate(); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("template"), r.Spec.Template, err.Error()))
+	}
+	for i, phase := range r.Spec.Phases {
+		if common.GetShortPhaseName(phase) == "" {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("phases").Index(i), phase, "unknown phase"))
+		}
+	}
+	if r.Spec.WebhookSecretRef.Name == "" || r.Spec.WebhookSecretRef.Key == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("webhookSecretRef"), "name and key of the Secret must be defined"))
+	}
+	if r.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("namespaceSelector"), r.Spec.NamespaceSelector, err.Error()))
+		}
+	}
+	if r.Spec.AppSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.AppSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("appSelector"), r.Spec.AppSelector, err.Error()))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnNotificationConfig"},
+		r.Name,
+		allErrs)
+}
@@ -0,0 +1,133 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnNotificationConfig_Validate(t *testing.T) {
+	secretRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "slack-webhook"},
+		Key:                  "url",
+	}
+
+	tests := []struct {
+		name string
+		spec KeptnNotificationConfigSpec
+		verb string
+		want error
+	}{
+		{
+			name: "create-default",
+			spec: KeptnNotificationConfigSpec{WebhookSecretRef: secretRef},
+			verb: "create",
+		},
+		{
+			name: "create-raw",
+			spec: KeptnNotificationConfigSpec{
+				Format:           NotificationFormatRaw,
+				Template:         `{"phase": {{ json .Phase }}, "state": {{ json .State }}}`,
+				Phases:           []string{"AppDeploy", "Workload Deployment"},
+				WebhookSecretRef: secretRef,
+			},
+			verb: "create",
+		},
+		{
+			name: "create-raw-without-template",
+			spec: KeptnNotificationConfigSpec{Format: NotificationFormatRaw, WebhookSecretRef: secretRef},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnNotificationConfig"},
+				"create-raw-without-template",
+				field.ErrorList{field.Required(field.NewPath("spec").Child("template"), "template must be defined for the raw format")},
+			),
+		},
+		{
+			name: "update-unknown-phase-and-missing-secret",
+			spec: KeptnNotificationConfigSpec{Phases: []string{"AppDeploy", "Deploy"}},
+			verb: "update",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnNotificationConfig"},
+				"update-unknown-phase-and-missing-secret",
+				field.ErrorList{
+					field.Invalid(field.NewPath("spec").Child("phases").Index(1), "Deploy", "unknown phase"),
+					field.Required(field.NewPath("spec").Child("webhookSecretRef"), "name and key of the Secret must be defined"),
+				},
+			),
+		},
+		{
+			name: "create-invalid-selector",
+			spec: KeptnNotificationConfigSpec{
+				WebhookSecretRef: secretRef,
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Equals"}},
+				},
+			},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnNotificationConfig"},
+				"create-invalid-selector",
+				field.ErrorList{field.Invalid(
+					field.NewPath("spec").Child("namespaceSelector"),
+					&metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Equals"}},
+					},
+					`"Equals" is not a valid label selector operator`,
+				)},
+			),
+		},
+		{
+			name: "delete",
+			spec: KeptnNotificationConfigSpec{},
+			verb: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationConfig := &KeptnNotificationConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       tt.spec,
+			}
+
+			var got error
+			switch tt.verb {
+			case "create":
+				_, got = notificationConfig.ValidateCreate()
+			case "update":
+				_, got = notificationConfig.ValidateUpdate(&KeptnNotificationConfig{})
+			case "delete":
+				_, got = notificationConfig.ValidateDelete()
+			}
+
+			if tt.want != nil {
+				require.EqualValues(t, tt.want, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
+
+func TestKeptnNotificationConfig_ValidateTemplate(t *testing.T) {
+	notificationConfig := &KeptnNotificationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid-template"},
+		Spec: KeptnNotificationConfigSpec{
+			Template: "{{ .Phase ",
+			WebhookSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "slack-webhook"},
+				Key:                  "url",
+			},
+		},
+	}
+
+	_, err := notificationConfig.ValidateCreate()
+	require.True(t, apierrors.IsInvalid(err))
+	require.Contains(t, err.Error(), "spec.template")
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KeptnPromotionPipelineSpec defines the desired state of KeptnPromotionPipeline
+type KeptnPromotionPipelineSpec struct {
+	// AppName is the name of the KeptnApp that is promoted through the stages.
+	// The KeptnApp must have the same name in the namespace of each stage.
+	// +kubebuilder:validation:MinLength=1
+	AppName string `json:"appName"`
+	// Stages is the ordered list of stages the KeptnApp is promoted through.
+	// Once a KeptnAppVersion has succeeded in a stage, the KeptnApp and KeptnAppContext are promoted
+	// into the namespace of the next stage.
+	// +kubebuilder:validation:MinItems=2
+	Stages []PromotionStage `json:"stages"`
+}
+
+// PromotionStage describes a single stage of a KeptnPromotionPipeline.
+type PromotionStage struct {
+	// Name is the name of the stage, e.g. staging.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace is the namespace the KeptnApp is deployed to in this stage.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// ApprovalTask is the name of a KeptnTaskDefinition of the approval type,
+	// located in the namespace of the stage or in the Keptn namespace.
+	// It is added to the pre-deployment tasks of the KeptnApp promoted into this stage,
+	// so the deployment only starts once it has been approved.
+	// Not supported for the first stage.
+	// +optional
+	ApprovalTask string `json:"approvalTask,omitempty"`
+	// Evaluations is a list of KeptnEvaluationDefinitions which must succeed in the post-deployment
+	// evaluations of this stage before the KeptnApp is promoted into the next stage.
+	// The evaluations are added to the KeptnAppContext promoted into this stage.
+	// For the first stage, they must be part of the KeptnAppContext of the KeptnApp.
+	// +optional
+	Evaluations []string `json:"evaluations,omitempty"`
+}
+
+// KeptnPromotionPipelineStatus defines the observed state of KeptnPromotionPipeline
+type KeptnPromotionPipelineStatus struct {
+	// Stages contains the current status of each stage of the KeptnPromotionPipeline.
+	// +optional
+	Stages []PromotionStageStatus `json:"stages,omitempty"`
+}
+
+// PromotionStageStatus describes the current state of a stage of a KeptnPromotionPipeline.
+type PromotionStageStatus struct {
+	// Name is the name of the stage.
+	Name string `json:"name"`
+	// Namespace is the namespace of the stage.
+	Namespace string `json:"namespace"`
+	// Version is the version of the KeptnApp deployed in this stage.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// AppVersionName is the name of the KeptnAppVersion deployed in this stage.
+	// +optional
+	AppVersionName string `json:"appVersionName,omitempty"`
+	// Status is the status of the KeptnAppVersion deployed in this stage.
+	// +kubebuilder:default:=Pending
+	// +optional
+	Status common.KeptnState `json:"status,omitempty"`
+	// TraceParent is the W3C trace context of the KeptnAppVersion deployed in this stage.
+	// +optional
+	TraceParent string `json:"traceParent,omitempty"`
+	// PromotionTime is the time at which the version has been promoted into this stage.
+	// +optional
+	PromotionTime metav1.Time `json:"promotionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="AppName",type=string,JSONPath=`.spec.appName`
+
+// KeptnPromotionPipeline is the Schema for the keptnpromotionpipelines API
+type KeptnPromotionPipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeptnPromotionPipelineSpec   `json:"spec,omitempty"`
+	Status KeptnPromotionPipelineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeptnPromotionPipelineList contains a list of KeptnPromotionPipeline
+type KeptnPromotionPipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeptnPromotionPipeline `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeptnPromotionPipeline{}, &KeptnPromotionPipelineList{})
+}
+
+// GetStageIndex returns the index of the stage with the given namespace, or -1 if the namespace is not part of the pipeline
+func (p KeptnPromotionPipeline) GetStageIndex(namespace string) int {
+	for i, stage := range p.Spec.Stages {
+		if stage.Namespace == namespace {
+			return i
+		}
+	}
+	return -1
+}
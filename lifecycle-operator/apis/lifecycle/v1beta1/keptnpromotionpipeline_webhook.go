@@ -0,0 +1,91 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var keptnpromotionpipelinelog = logf.Log.WithName("keptnpromotionpipeline-resource")
+
+func (r *KeptnPromotionPipeline) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1beta1-keptnpromotionpipeline,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnpromotionpipelines,verbs=create;update,versions=v1beta1,name=vkeptnpromotionpipeline.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnPromotionPipeline{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnPromotionPipeline) ValidateCreate() (admission.Warnings, error) {
+	keptnpromotionpipelinelog.Info("validate create", "name", r.Name)
+
+	return []string{}, r.validateKeptnPromotionPipeline()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnPromotionPipeline) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	keptnpromotionpipelinelog.Info("validate update", "name", r.Name)
+
+	return []string{}, r.validateKeptnPromotionPipeline()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnPromotionPipeline) ValidateDelete() (admission.Warnings, error) {
+	keptnpromotionpipelinelog.Info("validate delete", "name", r.Name)
+
+	return []string{}, nil
+}
+
+func (r *KeptnPromotionPipeline) validateKeptnPromotionPipeline() error {
+	var allErrs field.ErrorList //defined as a list to allow returning multiple validation errors
+	stagesPath := field.NewPath("spec").Child("stages")
+
+	names := map[string]bool{}
+	namespaces := map[string]bool{}
+	for i, stage := range r.Spec.Stages {
+		if names[stage.Name] {
+			allErrs = append(allErrs, field.Duplicate(stagesPath.Index(i).Child("name"), stage.Name))
+		}
+		if namespaces[stage.Namespace] {
+			allErrs = append(allErrs, field.Duplicate(stagesPath.Index(i).Child("namespace"), stage.Namespace))
+		}
+		names[stage.Name] = true
+		namespaces[stage.Namespace] = true
+	}
+	if len(r.Spec.Stages) > 0 && r.Spec.Stages[0].ApprovalTask != "" {
+		allErrs = append(allErrs, field.Forbidden(stagesPath.Index(0).Child("approvalTask"), "the first stage cannot require an approval"))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnPromotionPipeline"},
+		r.Name,
+		allErrs)
+}
@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnPromotionPipeline_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		spec KeptnPromotionPipelineSpec
+		verb string
+		want error
+	}{
+		{
+			name: "create-valid",
+			spec: KeptnPromotionPipelineSpec{
+				AppName: "podtato-head",
+				Stages: []PromotionStage{
+					{Name: "dev", Namespace: "podtato-dev", Evaluations: []string{"smoke-tests"}},
+					{Name: "prod", Namespace: "podtato-prod", ApprovalTask: "release-approval"},
+				},
+			},
+			verb: "create",
+		},
+		{
+			name: "update-duplicate-stages",
+			spec: KeptnPromotionPipelineSpec{
+				AppName: "podtato-head",
+				Stages: []PromotionStage{
+					{Name: "dev", Namespace: "podtato-dev"},
+					{Name: "dev", Namespace: "podtato-dev"},
+				},
+			},
+			verb: "update",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnPromotionPipeline"},
+				"update-duplicate-stages",
+				field.ErrorList{
+					field.Duplicate(field.NewPath("spec").Child("stages").Index(1).Child("name"), "dev"),
+					field.Duplicate(field.NewPath("spec").Child("stages").Index(1).Child("namespace"), "podtato-dev"),
+				},
+			),
+		},
+		{
+			name: "create-approval-in-first-stage",
+			spec: KeptnPromotionPipelineSpec{
+				AppName: "podtato-head",
+				Stages: []PromotionStage{
+					{Name: "dev", Namespace: "podtato-dev", ApprovalTask: "release-approval"},
+					{Name: "prod", Namespace: "podtato-prod"},
+				},
+			},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnPromotionPipeline"},
+				"create-approval-in-first-stage",
+				field.ErrorList{field.Forbidden(
+					field.NewPath("spec").Child("stages").Index(0).Child("approvalTask"),
+					"the first stage cannot require an approval",
+				)},
+			),
+		},
+		{
+			name: "delete",
+			verb: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := &KeptnPromotionPipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       tt.spec,
+			}
+
+			var got error
+			switch tt.verb {
+			case "create":
+				_, got = pipeline.ValidateCreate()
+			case "update":
+				_, got = pipeline.ValidateUpdate(&KeptnPromotionPipeline{})
+			case "delete":
+				_, got = pipeline.ValidateDelete()
+			}
+
+			if tt.want != nil {
+				require.EqualValues(t, tt.want, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
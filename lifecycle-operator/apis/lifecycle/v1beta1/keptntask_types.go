@@ -81,9 +81,42 @@ type TaskContext struct {
 	// ObjectType indicates whether the KeptnTask is being executed for a KeptnApp or KeptnWorkload.
 	// +optional
 	ObjectType string `json:"objectType"`
+	// PreviousVersion the version of the KeptnApp or KeptnWorkload that has been deployed prior to the version
+	// the KeptnTask is being executed for.
+	// +optional
+	PreviousVersion string `json:"previousVersion,omitempty"`
 	// +optional
 	// Metadata contains additional key-value pairs for contextual information.
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// +optional
+	// Outputs contains the outputs of the KeptnTasks that have already been executed
+	// for the same KeptnAppVersion or KeptnWorkloadVersion, grouped by the name of their KeptnTaskDefinition.
+	Outputs map[string]map[string]string `json:"outputs,omitempty"`
+	// FailedPhase is the name of the phase of the KeptnAppVersion or KeptnWorkloadVersion that has failed.
+	// This is only set for KeptnTasks that are executed during the on-failure phase.
+	// +optional
+	FailedPhase string `json:"failedPhase,omitempty"`
+	// FailedItems contains the tasks, evaluations, analyses or workloads that have failed in the FailedPhase.
+	// This is only set for KeptnTasks that are executed during the on-failure phase.
+	// +optional
+	FailedItems []FailedItem `json:"failedItems,omitempty"`
+}
+
+// FailedItem describes a task, evaluation, analysis or workload that has failed during a phase.
+type FailedItem struct {
+	// Name is the name of the KeptnTask, KeptnEvaluation, Analysis or KeptnWorkload that has failed.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// DefinitionName is the name of the KeptnTaskDefinition, KeptnEvaluationDefinition or AnalysisDefinition
+	// of the failed item.
+	// +optional
+	DefinitionName string `json:"definitionName,omitempty"`
+	// Reason contains the reason for the last transition of the Job executing the failed KeptnTask.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message contains information about why the item has failed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type TaskParameters struct {
@@ -109,6 +142,9 @@ type KeptnTaskStatus struct {
 	// JobName is the name of the Job executing the Task.
 	// +optional
 	JobName string `json:"jobName,omitempty"`
+	// PipelineRunName is the name of the Tekton PipelineRun executing the Task.
+	// +optional
+	PipelineRunName string `json:"pipelineRunName,omitempty"`
 	// Status represents the overall state of the KeptnTask.
 	// +kubebuilder:default:=Pending
 	// +optional
@@ -125,6 +161,43 @@ type KeptnTaskStatus struct {
 	// Reason contains more information about the reason for the last transition of the Job executing the KeptnTask.
 	// +optional
 	Reason string `json:"reason,omitempty"`
+	// Outputs contains the key-value pairs the Job executing the KeptnTask has written
+	// to the termination message of its container.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+	// Http contains information about the HTTP requests sent for KeptnTasks based on a KeptnTaskDefinition
+	// with an HTTP spec.
+	// +optional
+	Http *HttpTaskStatus `json:"http,omitempty"`
+	// Approval contains information about the approval of KeptnTasks based on a KeptnTaskDefinition
+	// with an approval spec.
+	// +optional
+	Approval *ApprovalTaskStatus `json:"approval,omitempty"`
+}
+
+type HttpTaskStatus struct {
+	// Attempts is the number of requests that have been sent.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// StatusCode is the status code of the last response.
+	// +optional
+	StatusCode int32 `json:"statusCode,omitempty"`
+	// Response contains the beginning of the body of the last response.
+	// +optional
+	Response string `json:"response,omitempty"`
+}
+
+type ApprovalTaskStatus struct {
+	// Decision is the decision of the approver, either approved or rejected.
+	// It is empty as long as the KeptnTask is waiting for approval.
+	// +optional
+	Decision string `json:"decision,omitempty"`
+	// User is the name of the user who approved or rejected the KeptnTask.
+	// +optional
+	User string `json:"user,omitempty"`
+	// Time represents the time at which the KeptnTask was approved or rejected.
+	// +optional
+	Time metav1.Time `json:"time,omitempty"`
 }
 
 // +kubebuilder:object:root=true
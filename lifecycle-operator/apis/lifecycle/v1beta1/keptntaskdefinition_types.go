@@ -33,9 +33,34 @@ type KeptnTaskDefinitionSpec struct {
 	// Deno contains the definition for the Deno function that is to be executed in KeptnTasks.
 	// +optional
 	Deno *RuntimeSpec `json:"deno,omitempty"`
+	// Wasm contains the definition for the WebAssembly module that is to be executed in KeptnTasks.
+	// The module is executed with a WASI runtime. An inline module must be provided as base64 encoded string.
+	// +optional
+	Wasm *RuntimeSpec `json:"wasm,omitempty"`
 	// Container contains the definition for the container that is to be used in Job.
 	// +optional
 	Container *ContainerSpec `json:"container,omitempty"`
+	// Http contains the definition of an HTTP request that is sent directly by the KeptnTask controller,
+	// without creating a Job.
+	// +optional
+	Http *HttpSpec `json:"http,omitempty"`
+	// Approval contains the definition of a manual approval that is required for the KeptnTask to succeed.
+	// No Job is created for KeptnTasks based on a KeptnTaskDefinition with an approval.
+	// +optional
+	Approval *ApprovalSpec `json:"approval,omitempty"`
+	// Tekton contains the reference to a Tekton Pipeline or Task that is executed in a Tekton PipelineRun
+	// instead of a Job.
+	// +optional
+	Tekton *TektonSpec `json:"tekton,omitempty"`
+	// External marks KeptnTasks as executed by an external system, such as a CI pipeline,
+	// which reports the result with a CloudEvent. No Job is created for these KeptnTasks.
+	// +optional
+	External *ExternalSpec `json:"external,omitempty"`
+	// TemplateParameters enables rendering the parameters and command line parameters of KeptnTasks
+	// as Go templates with access to the context of the KeptnTask.
+	// If disabled, the parameters are passed to the KeptnTask unchanged.
+	// +optional
+	TemplateParameters bool `json:"templateParameters,omitempty"`
 	// Retries specifies how many times a job executing the KeptnTaskDefinition should be restarted in the case
 	// of an unsuccessful attempt.
 	// +kubebuilder:default:=10
@@ -124,6 +149,90 @@ type ContainerSpec struct {
 	*v1.Container `json:",inline"`
 }
 
+type HttpSpec struct {
+	// Method is the HTTP method of the request.
+	// +kubebuilder:default:=GET
+	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Url is the URL the request is sent to.
+	// It is rendered as a Go template with access to the context and the parameters of the KeptnTask,
+	// e.g. https://example.com/{{ .Context.AppName }}/{{ .Parameters.stage }}.
+	Url string `json:"url"`
+	// Body is the body of the request.
+	// It is rendered as a Go template in the same way as the Url.
+	// +optional
+	Body string `json:"body,omitempty"`
+	// Headers is a list of headers that are added to the request.
+	// +optional
+	Headers []HttpHeader `json:"headers,omitempty"`
+	// ExpectedStatus is a list of status codes that are considered successful.
+	// If empty, any 2xx status code is considered successful.
+	// +optional
+	ExpectedStatus []int32 `json:"expectedStatus,omitempty"`
+	// Assertions is a list of JSONPath assertions that must hold for the JSON body of the response.
+	// +optional
+	Assertions []HttpAssertion `json:"assertions,omitempty"`
+	// Timeout specifies the maximum time to wait for the response of a single request.
+	// +kubebuilder:default:="30s"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+type HttpHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+	// Value is the value of the header.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// SecretKeyRef refers to a key of a Secret in the namespace of the KeptnTask containing the value of the header.
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+type HttpAssertion struct {
+	// JSONPath is a JSONPath expression in the format used by kubectl, e.g. {.status},
+	// which is evaluated on the JSON body of the response.
+	JSONPath string `json:"jsonPath"`
+	// Value is the expected result of the JSONPath expression.
+	// If empty, the assertion only checks that the expression matches a value.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+type ApprovalSpec struct {
+	// Users is a list of names of users who are allowed to approve or reject the KeptnTask.
+	// +optional
+	Users []string `json:"users,omitempty"`
+	// Groups is a list of groups whose members are allowed to approve or reject the KeptnTask.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+type TektonSpec struct {
+	// PipelineRef is the name of the Tekton Pipeline that is executed for the KeptnTask.
+	// +optional
+	PipelineRef string `json:"pipelineRef,omitempty"`
+	// TaskRef is the name of the Tekton Task that is executed for the KeptnTask.
+	// The Task is executed as the only task of a PipelineRun.
+	// +optional
+	TaskRef string `json:"taskRef,omitempty"`
+	// Params contains parameters that are passed to the PipelineRun, in addition to the parameters
+	// and the context of the KeptnTask.
+	// +optional
+	Params map[string]string `json:"params,omitempty"`
+}
+
+type ExternalSpec struct {
+	// Source is the source of the CloudEvents that are allowed to report the result of the KeptnTask,
+	// e.g. the URI of a CI pipeline.
+	// If empty, events of any source are accepted.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
 type AutomountServiceAccountTokenSpec struct {
 	Type *bool `json:"type"`
 }
@@ -189,3 +298,20 @@ func (d *KeptnTaskDefinition) GetAutomountServiceAccountToken() *bool {
 	}
 	return d.Spec.AutomountServiceAccountToken.Type
 }
+
+// IsApprover returns true if the given user or one of the given groups is allowed to approve or reject the KeptnTask
+func (a ApprovalSpec) IsApprover(user string, groups []string) bool {
+	for _, u := range a.Users {
+		if u == user {
+			return true
+		}
+	}
+	for _, g := range a.Groups {
+		for _, group := range groups {
+			if g == group {
+				return true
+			}
+		}
+	}
+	return false
+}
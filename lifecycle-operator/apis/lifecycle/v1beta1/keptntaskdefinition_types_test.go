@@ -38,3 +38,13 @@ func TestTaskDefinition_GetAutomountServiceAccountToken(t *testing.T) {
 	}
 	require.True(t, *d.GetAutomountServiceAccountToken())
 }
+
+func TestApprovalSpec_IsApprover(t *testing.T) {
+	approval := ApprovalSpec{
+		Users:  []string{"jane"},
+		Groups: []string{"release-managers"},
+	}
+	require.True(t, approval.IsApprover("jane", nil))
+	require.True(t, approval.IsApprover("john", []string{"developers", "release-managers"}))
+	require.False(t, approval.IsApprover("john", []string{"developers"}))
+}
@@ -17,11 +17,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/base64"
+	"text/template"
+
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/jsonpath"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -68,6 +72,20 @@ func (r *KeptnTaskDefinition) validateKeptnTaskDefinition() error {
 	if err = r.validateFields(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if r.Spec.Http != nil {
+		allErrs = append(allErrs, validateHttpSpec(r.Spec.Http, field.NewPath("spec").Child("http"))...)
+	}
+	if r.Spec.Wasm != nil && r.Spec.Wasm.Inline.Code != "" {
+		if _, err := base64.StdEncoding.DecodeString(r.Spec.Wasm.Inline.Code); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("wasm").Child("inline").Child("code"), "", "inline WebAssembly module must be base64 encoded"))
+		}
+	}
+	if r.Spec.Approval != nil && len(r.Spec.Approval.Users) == 0 && len(r.Spec.Approval.Groups) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("approval"), "at least one user or group must be allowed to approve"))
+	}
+	if r.Spec.Tekton != nil && (r.Spec.Tekton.PipelineRef == "") == (r.Spec.Tekton.TaskRef == "") {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("tekton"), r.Spec.Tekton, "exactly one of pipelineRef or taskRef must be defined"))
+	}
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -83,7 +101,7 @@ func (r *KeptnTaskDefinition) validateFields() *field.Error {
 		return field.Invalid(
 			field.NewPath("spec"),
 			r.Spec,
-			errors.New("Forbidden! Either Function, Container, Python, or Deno field must be defined").Error(),
+			errors.New("Forbidden! Either Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field must be defined").Error(),
 		)
 	}
 
@@ -91,7 +109,7 @@ func (r *KeptnTaskDefinition) validateFields() *field.Error {
 		return field.Invalid(
 			field.NewPath("spec"),
 			r.Spec,
-			errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+			errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 		)
 	}
 
@@ -112,5 +130,41 @@ func countSpec(r *KeptnTaskDefinition) int {
 	if r.Spec.Deno != nil {
 		count++
 	}
+	if r.Spec.Wasm != nil {
+		count++
+	}
+	if r.Spec.Http != nil {
+		count++
+	}
+	if r.Spec.Approval != nil {
+		count++
+	}
+	if r.Spec.Tekton != nil {
+		count++
+	}
+	if r.Spec.External != nil {
+		count++
+	}
 	return count
 }
+
+func validateHttpSpec(spec *HttpSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if _, err := template.New("url").Parse(spec.Url); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("url"), spec.Url, err.Error()))
+	}
+	if _, err := template.New("body").Parse(spec.Body); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("body"), spec.Body, err.Error()))
+	}
+	for i, header := range spec.Headers {
+		if (header.Value == "") == (header.SecretKeyRef == nil) {
+			allErrs = append(allErrs, field.Invalid(path.Child("headers").Index(i), header.Name, "exactly one of value or secretKeyRef must be defined"))
+		}
+	}
+	for i, assertion := range spec.Assertions {
+		if err := jsonpath.New("assertion").Parse(assertion.JSONPath); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("assertions").Index(i).Child("jsonPath"), assertion.JSONPath, err.Error()))
+		}
+	}
+	return allErrs
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,6 +25,13 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 		Python:   &RuntimeSpec{},
 	}
 
+	specWithTektonPipelineAndTask := KeptnTaskDefinitionSpec{
+		Tekton: &TektonSpec{
+			PipelineRef: "smoke-tests",
+			TaskRef:     "smoke-test",
+		},
+	}
+
 	specWithFunctionAndDeno := KeptnTaskDefinitionSpec{
 		Function: &RuntimeSpec{},
 		Deno:     &RuntimeSpec{},
@@ -44,6 +52,28 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 		Deno:   &RuntimeSpec{},
 	}
 
+	specWithDenoAndWasm := KeptnTaskDefinitionSpec{
+		Deno: &RuntimeSpec{},
+		Wasm: &RuntimeSpec{},
+	}
+
+	specWithContainerAndHttp := KeptnTaskDefinitionSpec{
+		Container: &ContainerSpec{},
+		Http:      &HttpSpec{Url: "http://localhost"},
+	}
+
+	specWithInvalidHttp := KeptnTaskDefinitionSpec{
+		Http: &HttpSpec{
+			Url: "http://localhost/{{ .Context.AppName",
+			Headers: []HttpHeader{
+				{Name: "Authorization"},
+			},
+			Assertions: []HttpAssertion{
+				{JSONPath: "{.status", Value: "ok"},
+			},
+		},
+	}
+
 	emptySpec := KeptnTaskDefinitionSpec{}
 
 	tests := []struct {
@@ -62,7 +92,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					emptySpec,
-					errors.New("Forbidden! Either Function, Container, Python, or Deno field must be defined").Error(),
+					errors.New("Forbidden! Either Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field must be defined").Error(),
 				)},
 			),
 			verb: "create",
@@ -77,7 +107,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithFunctionAndContainer,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 		},
@@ -109,6 +139,159 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 			},
 			verb: "create",
 		},
+		{
+			name: "with-http-only",
+			spec: KeptnTaskDefinitionSpec{
+				Http: &HttpSpec{
+					Url: "http://localhost/{{ .Context.AppName }}",
+					Headers: []HttpHeader{
+						{Name: "Content-Type", Value: "application/json"},
+						{Name: "Authorization", SecretKeyRef: &corev1.SecretKeySelector{Key: "token"}},
+					},
+					Assertions: []HttpAssertion{
+						{JSONPath: "{.status}", Value: "ok"},
+					},
+				},
+			},
+			verb: "create",
+		},
+		{
+			name: "with-both-container-and-http",
+			spec: specWithContainerAndHttp,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-both-container-and-http",
+				[]*field.Error{field.Invalid(
+					field.NewPath("spec"),
+					specWithContainerAndHttp,
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
+				)},
+			),
+		},
+		{
+			name: "with-invalid-http",
+			spec: specWithInvalidHttp,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-invalid-http",
+				[]*field.Error{
+					field.Invalid(
+						field.NewPath("spec").Child("http").Child("url"),
+						"http://localhost/{{ .Context.AppName",
+						"template: url:1: unclosed action",
+					),
+					field.Invalid(
+						field.NewPath("spec").Child("http").Child("headers").Index(0),
+						"Authorization",
+						"exactly one of value or secretKeyRef must be defined",
+					),
+					field.Invalid(
+						field.NewPath("spec").Child("http").Child("assertions").Index(0).Child("jsonPath"),
+						"{.status",
+						"unclosed action",
+					),
+				},
+			),
+		},
+		{
+			name: "with-wasm-only",
+			spec: KeptnTaskDefinitionSpec{
+				Wasm: &RuntimeSpec{
+					Inline: Inline{Code: "AGFzbQEAAAA="},
+				},
+			},
+			verb: "create",
+		},
+		{
+			name: "with-both-deno-and-wasm",
+			spec: specWithDenoAndWasm,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-both-deno-and-wasm",
+				[]*field.Error{field.Invalid(
+					field.NewPath("spec"),
+					specWithDenoAndWasm,
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
+				)},
+			),
+		},
+		{
+			name: "with-wasm-not-base64-encoded",
+			spec: KeptnTaskDefinitionSpec{
+				Wasm: &RuntimeSpec{
+					Inline: Inline{Code: "(module)"},
+				},
+			},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-wasm-not-base64-encoded",
+				[]*field.Error{field.Invalid(
+					field.NewPath("spec").Child("wasm").Child("inline").Child("code"),
+					"",
+					"inline WebAssembly module must be base64 encoded",
+				)},
+			),
+		},
+		{
+			name: "with-approval-only",
+			spec: KeptnTaskDefinitionSpec{
+				Approval: &ApprovalSpec{
+					Users:  []string{"jane"},
+					Groups: []string{"release-managers"},
+				},
+			},
+			verb: "create",
+		},
+		{
+			name: "with-approval-without-approvers",
+			spec: KeptnTaskDefinitionSpec{
+				Approval: &ApprovalSpec{},
+			},
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-approval-without-approvers",
+				[]*field.Error{field.Required(
+					field.NewPath("spec").Child("approval"),
+					"at least one user or group must be allowed to approve",
+				)},
+			),
+		},
+		{
+			name: "with-tekton-only",
+			spec: KeptnTaskDefinitionSpec{
+				Tekton: &TektonSpec{
+					PipelineRef: "smoke-tests",
+					Params:      map[string]string{"target": "staging"},
+				},
+			},
+			verb: "create",
+		},
+		{
+			name: "with-tekton-pipeline-and-task",
+			spec: specWithTektonPipelineAndTask,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+				"with-tekton-pipeline-and-task",
+				[]*field.Error{field.Invalid(
+					field.NewPath("spec").Child("tekton"),
+					specWithTektonPipelineAndTask.Tekton,
+					"exactly one of pipelineRef or taskRef must be defined",
+				)},
+			),
+		},
+		{
+			name: "with-external-only",
+			spec: KeptnTaskDefinitionSpec{
+				External: &ExternalSpec{Source: "https://ci.example.com/pipelines/integration-tests"},
+			},
+			verb: "create",
+		},
 
 		{
 			name: "update-with-both-function-and-container",
@@ -119,7 +302,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithFunctionAndContainer,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -138,7 +321,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithFunctionAndPython,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 		},
@@ -151,7 +334,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithFunctionAndPython,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -170,7 +353,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithFunctionAndDeno,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 		},
@@ -183,7 +366,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithFunctionAndDeno,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -202,7 +385,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndPython,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 		},
@@ -215,7 +398,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndPython,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -234,7 +417,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndDeno,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 		},
@@ -247,7 +430,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithContainerAndDeno,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -265,7 +448,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithPythonAndDeno,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 		},
@@ -278,7 +461,7 @@ func TestKeptnTaskDefinition_ValidateFields(t *testing.T) {
 				[]*field.Error{field.Invalid(
 					field.NewPath("spec"),
 					specWithPythonAndDeno,
-					errors.New("Forbidden! Only one of Function, Container, Python, or Deno field can be defined").Error(),
+					errors.New("Forbidden! Only one of Function, Container, Python, Deno, Wasm, Http, Approval, Tekton, or External field can be defined").Error(),
 				)},
 			),
 			oldSpec: &KeptnTaskDefinition{
@@ -43,6 +43,18 @@ type KeptnWorkloadSpec struct {
 	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
 	// +optional
 	PostDeploymentTasks []string `json:"postDeploymentTasks,omitempty"`
+	// PreDeploymentTaskDependencies defines the order in which the PreDeploymentTasks are executed.
+	// Each item refers to one of the PreDeploymentTasks and lists the tasks it depends on.
+	// A task is only started once all of its dependencies have succeeded.
+	// Tasks that are not listed here are started right away.
+	// +optional
+	PreDeploymentTaskDependencies []TaskDependency `json:"preDeploymentTaskDependencies,omitempty"`
+	// PostDeploymentTaskDependencies defines the order in which the PostDeploymentTasks are executed.
+	// Each item refers to one of the PostDeploymentTasks and lists the tasks it depends on.
+	// A task is only started once all of its dependencies have succeeded.
+	// Tasks that are not listed here are started right away.
+	// +optional
+	PostDeploymentTaskDependencies []TaskDependency `json:"postDeploymentTaskDependencies,omitempty"`
 	// PreDeploymentEvaluations is a list of all evaluations to be performed
 	// during the pre-deployment phase of the KeptnWorkload.
 	// The items of this list refer to the names of KeptnEvaluationDefinitions
@@ -55,8 +67,38 @@ type KeptnWorkloadSpec struct {
 	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
 	// +optional
 	PostDeploymentEvaluations []string `json:"postDeploymentEvaluations,omitempty"`
+	// PreDeploymentAnalyses is a list of all analyses to be performed
+	// during the pre-deployment evaluation phase of the KeptnWorkload.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
+	// +optional
+	PreDeploymentAnalyses []string `json:"preDeploymentAnalyses,omitempty"`
+	// PostDeploymentAnalyses is a list of all analyses to be performed
+	// during the post-deployment evaluation phase of the KeptnWorkload.
+	// The items of this list refer to the names of AnalysisDefinitions
+	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
+	// +optional
+	PostDeploymentAnalyses []string `json:"postDeploymentAnalyses,omitempty"`
+	// ExecutionConditions defines conditions under which the tasks and evaluations of the KeptnWorkload are executed.
+	// Each item refers to one of the tasks or evaluations by name.
+	// Tasks and evaluations whose condition does not hold are not executed and marked as Skipped.
+	// +optional
+	ExecutionConditions []ExecutionCondition `json:"executionConditions,omitempty"`
+	// PromotionTasks is a list of all tasks to be performed during the promotion phase of the KeptnWorkload.
+	// The items of this list refer to the names of KeptnTaskDefinitions
+	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
+	// +optional
+	PromotionTasks []string `json:"promotionTasks,omitempty"`
+	// OnFailureTasks is a list of all tasks to be performed during the on-failure phase of the KeptnWorkload.
+	// The on-failure phase is only executed if a phase of the KeptnWorkloadVersion has failed.
+	// The tasks receive the name of the failed phase and the items that have failed in that phase
+	// as part of their context.
+	// The items of this list refer to the names of KeptnTaskDefinitions
+	// located in the same namespace as the KeptnWorkload, or in the Keptn namespace.
+	// +optional
+	OnFailureTasks []string `json:"onFailureTasks,omitempty"`
 	// ResourceReference is a reference to the Kubernetes resource
-	// (Deployment, DaemonSet, StatefulSet or ReplicaSet) the KeptnWorkload is representing.
+	// (Deployment, DaemonSet, StatefulSet, ReplicaSet, Job or Pod) the KeptnWorkload is representing.
 	ResourceReference ResourceReference `json:"resourceReference"`
 	// +optional
 	// Metadata contains additional key-value pairs for contextual information.
@@ -0,0 +1,113 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestKeptnWorkload_Validate(t *testing.T) {
+	validSpec := KeptnWorkloadSpec{
+		PreDeploymentTasks: []string{"migrate", "warmup"},
+		PreDeploymentTaskDependencies: []TaskDependency{
+			{Name: "warmup", DependsOn: []string{"migrate"}},
+		},
+		PostDeploymentAnalyses: []string{"error-rate"},
+		ExecutionConditions: []ExecutionCondition{
+			{Name: "migrate", When: "metadata.commitMessage.contains('[migrate]')"},
+			{Name: "error-rate", When: "previousVersion != ''"},
+		},
+	}
+
+	invalidSpec := KeptnWorkloadSpec{
+		PreDeploymentTasks: []string{"migrate", "warmup"},
+		PreDeploymentTaskDependencies: []TaskDependency{
+			{Name: "warmup", DependsOn: []string{"warmup"}},
+		},
+		PostDeploymentTasks: []string{"test"},
+		PostDeploymentTaskDependencies: []TaskDependency{
+			{Name: "test", DependsOn: []string{"migrate"}},
+		},
+		ExecutionConditions: []ExecutionCondition{
+			{Name: "cleanup", When: "checkType == 'post'"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		spec KeptnWorkloadSpec
+		verb string
+		want error
+	}{
+		{
+			name: "create-valid",
+			spec: validSpec,
+			verb: "create",
+		},
+		{
+			name: "update-valid",
+			spec: validSpec,
+			verb: "update",
+		},
+		{
+			name: "create-invalid",
+			spec: invalidSpec,
+			verb: "create",
+			want: apierrors.NewInvalid(
+				schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnWorkload"},
+				"create-invalid",
+				field.ErrorList{
+					field.Invalid(
+						field.NewPath("spec").Child("preDeploymentTaskDependencies"),
+						"warmup -> warmup",
+						"task dependencies must not contain cycles",
+					),
+					field.Invalid(
+						field.NewPath("spec").Child("postDeploymentTaskDependencies").Index(0).Child("dependsOn").Index(0),
+						"migrate",
+						"must be one of the tasks of the phase",
+					),
+					field.Invalid(
+						field.NewPath("spec").Child("executionConditions").Index(0).Child("name"),
+						"cleanup",
+						"must be one of the tasks or evaluations",
+					),
+				},
+			),
+		},
+		{
+			name: "delete",
+			spec: invalidSpec,
+			verb: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &KeptnWorkload{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       tt.spec,
+			}
+
+			var got error
+			switch tt.verb {
+			case "create":
+				_, got = workload.ValidateCreate()
+			case "update":
+				_, got = workload.ValidateUpdate(&KeptnWorkload{})
+			case "delete":
+				_, got = workload.ValidateDelete()
+			}
+
+			if tt.want != nil {
+				require.EqualValues(t, tt.want, got)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
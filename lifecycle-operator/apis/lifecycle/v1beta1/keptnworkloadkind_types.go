@@ -0,0 +1,111 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KeptnWorkloadKindSpec defines the desired state of KeptnWorkloadKind
+type KeptnWorkloadKindSpec struct {
+	// APIVersion is the API version of the workload resource, e.g. serving.knative.dev/v1.
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the workload resource, e.g. VirtualMachineInstance.
+	// Pods owned by a resource of this kind are considered a KeptnWorkload.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="!(self in ['ReplicaSet', 'Deployment', 'StatefulSet', 'DaemonSet', 'Rollout', 'Job', 'CronJob', 'Pod'])",message="built-in workload kinds cannot be overridden"
+	Kind string `json:"kind"`
+	// Running is a CEL expression returning a bool, which is evaluated against the workload resource.
+	// The resource is available as `self`. The deployment of the workload succeeds once the expression returns true.
+	// +kubebuilder:validation:MinLength=1
+	Running string `json:"running"`
+	// Failed is a CEL expression returning a bool, which is evaluated against the workload resource.
+	// The resource is available as `self`. The deployment of the workload fails once the expression returns true.
+	// +optional
+	Failed string `json:"failed,omitempty"`
+	// Version is a CEL expression returning a string, which is evaluated against the workload resource.
+	// The resource is available as `self`. The result is used as version of the KeptnWorkload
+	// if the workload resource and its owners are not annotated with a version.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// OwnerChain is the list of owners of the workload resource, starting with its direct owner.
+	// If the workload resource does not carry any Keptn annotations,
+	// the pod mutator follows this chain to find the resource the annotations are copied from.
+	// +optional
+	OwnerChain []WorkloadKindOwner `json:"ownerChain,omitempty"`
+}
+
+// WorkloadKindOwner references a kind of owner in the owner chain of a workload resource.
+type WorkloadKindOwner struct {
+	// APIVersion is the API version of the owner, e.g. kubevirt.io/v1.
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the owner, e.g. VirtualMachine.
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+}
+
+// KeptnWorkloadKindStatus defines the observed state of KeptnWorkloadKind
+type KeptnWorkloadKindStatus struct {
+	// Ready indicates whether the expressions of the KeptnWorkloadKind are valid
+	// and the kind is considered by the lifecycle operator.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+	// Message contains the reason why the KeptnWorkloadKind is not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="APIVersion",type=string,JSONPath=`.spec.apiVersion`
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.kind`
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == self.spec.kind.lowerAscii()",message="the name of a KeptnWorkloadKind must be the lowercase kind of the workload resource"
+
+// KeptnWorkloadKind is the Schema for the keptnworkloadkinds API
+type KeptnWorkloadKind struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeptnWorkloadKindSpec   `json:"spec,omitempty"`
+	Status KeptnWorkloadKindStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeptnWorkloadKindList contains a list of KeptnWorkloadKind
+type KeptnWorkloadKindList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeptnWorkloadKind `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeptnWorkloadKind{}, &KeptnWorkloadKindList{})
+}
+
+func (w KeptnWorkloadKind) GetGroupKind() schema.GroupKind {
+	return schema.FromAPIVersionAndKind(w.Spec.APIVersion, w.Spec.Kind).GroupKind()
+}
+
+func (o WorkloadKindOwner) Matches(ref metav1.OwnerReference) bool {
+	return schema.FromAPIVersionAndKind(o.APIVersion, o.Kind).GroupKind() == schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).GroupKind()
+}
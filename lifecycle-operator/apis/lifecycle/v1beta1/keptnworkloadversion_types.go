@@ -81,6 +81,14 @@ type KeptnWorkloadVersionStatus struct {
 	// +kubebuilder:default:=Pending
 	// +optional
 	PostDeploymentStatus common.KeptnState `json:"postDeploymentStatus,omitempty"`
+	// PromotionStatus indicates the current status of the KeptnWorkloadVersion's Promotion phase.
+	// +kubebuilder:default:=Pending
+	// +optional
+	PromotionStatus common.KeptnState `json:"promotionStatus,omitempty"`
+	// OnFailureStatus indicates the current status of the KeptnWorkloadVersion's OnFailure phase.
+	// +kubebuilder:default:=Pending
+	// +optional
+	OnFailureStatus common.KeptnState `json:"onFailureStatus,omitempty"`
 	// PreDeploymentTaskStatus indicates the current state of each preDeploymentTask of the KeptnWorkloadVersion.
 	// +optional
 	PreDeploymentTaskStatus []ItemStatus `json:"preDeploymentTaskStatus,omitempty"`
@@ -93,6 +101,20 @@ type KeptnWorkloadVersionStatus struct {
 	// PostDeploymentEvaluationTaskStatus indicates the current state of each postDeploymentEvaluation of the KeptnWorkloadVersion.
 	// +optional
 	PostDeploymentEvaluationTaskStatus []ItemStatus `json:"postDeploymentEvaluationTaskStatus,omitempty"`
+	// PreDeploymentAnalysisStatus indicates the current state of each preDeploymentAnalysis of the KeptnWorkloadVersion.
+	// The name of each item refers to the Analysis created for the respective AnalysisDefinition.
+	// +optional
+	PreDeploymentAnalysisStatus []ItemStatus `json:"preDeploymentAnalysisStatus,omitempty"`
+	// PostDeploymentAnalysisStatus indicates the current state of each postDeploymentAnalysis of the KeptnWorkloadVersion.
+	// The name of each item refers to the Analysis created for the respective AnalysisDefinition.
+	// +optional
+	PostDeploymentAnalysisStatus []ItemStatus `json:"postDeploymentAnalysisStatus,omitempty"`
+	// PromotionTaskStatus indicates the current state of each promotionTask of the KeptnWorkloadVersion.
+	// +optional
+	PromotionTaskStatus []ItemStatus `json:"promotionTaskStatus,omitempty"`
+	// OnFailureTaskStatus indicates the current state of each onFailureTask of the KeptnWorkloadVersion.
+	// +optional
+	OnFailureTaskStatus []ItemStatus `json:"onFailureTaskStatus,omitempty"`
 	// StartTime represents the time at which the deployment of the KeptnWorkloadVersion started.
 	// +optional
 	StartTime metav1.Time `json:"startTime,omitempty"`
@@ -105,8 +127,15 @@ type KeptnWorkloadVersionStatus struct {
 	// - Deployment
 	// - PostDeploymentTasks
 	// - PostDeploymentEvaluations
+	// - PromotionTasks
 	// +optional
 	CurrentPhase string `json:"currentPhase,omitempty"`
+	// FailedPhase indicates the phase in which the KeptnWorkloadVersion has failed.
+	// +optional
+	FailedPhase string `json:"failedPhase,omitempty"`
+	// FreezeWindow contains information about the KeptnFreezeWindow that blocks the deployment of the KeptnWorkloadVersion.
+	// +optional
+	FreezeWindow *FreezeWindowStatus `json:"freezeWindow,omitempty"`
 	// PhaseTraceIDs contains the trace IDs of the OpenTelemetry spans of each phase of the KeptnWorkloadVersion
 	// +optional
 	PhaseTraceIDs common.PhaseTraceID `json:"phaseTraceIDs,omitempty"`
@@ -135,6 +164,8 @@ type KeptnWorkloadVersionStatus struct {
 // +kubebuilder:printcolumn:name="DeploymentStatus",type=string,priority=1,JSONPath=`.status.deploymentStatus`
 // +kubebuilder:printcolumn:name="PostDeploymentStatus",type=string,priority=1,JSONPath=`.status.postDeploymentStatus`
 // +kubebuilder:printcolumn:name="PostDeploymentEvaluationStatus",priority=1,type=string,JSONPath=`.status.postDeploymentEvaluationStatus`
+// +kubebuilder:printcolumn:name="PromotionStatus",priority=1,type=string,JSONPath=`.status.promotionStatus`
+// +kubebuilder:printcolumn:name="OnFailureStatus",priority=1,type=string,JSONPath=`.status.onFailureStatus`
 
 // KeptnWorkloadVersion is the Schema for the keptnworkloadversions API
 type KeptnWorkloadVersion struct {
@@ -232,6 +263,18 @@ func (w KeptnWorkloadVersion) IsPostDeploymentEvaluationFailed() bool {
 	return w.Status.PostDeploymentEvaluationStatus.IsFailed()
 }
 
+func (w KeptnWorkloadVersion) IsPromotionCompleted() bool {
+	return w.Status.PromotionStatus.IsCompleted()
+}
+
+func (w KeptnWorkloadVersion) IsPromotionSucceeded() bool {
+	return w.Status.PromotionStatus.IsSucceeded()
+}
+
+func (w KeptnWorkloadVersion) IsPromotionFailed() bool {
+	return w.Status.PromotionStatus.IsFailed()
+}
+
 func (w KeptnWorkloadVersion) IsDeploymentCompleted() bool {
 	return w.Status.DeploymentStatus.IsCompleted()
 }
@@ -268,6 +311,33 @@ func (e *ItemStatus) SetEndTime() {
 	}
 }
 
+// getFailedItems returns the items of the given lists that have failed
+func getFailedItems(statuses ...[]ItemStatus) []ItemStatus {
+	var result []ItemStatus
+	for _, items := range statuses {
+		for _, item := range items {
+			if item.Status.IsFailed() {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+func (w KeptnWorkloadVersion) IsOnFailureEnabled() bool {
+	return len(w.Spec.OnFailureTasks) > 0
+}
+
+func (w KeptnWorkloadVersion) IsOnFailureCompleted() bool {
+	return w.Status.OnFailureStatus.IsCompleted()
+}
+
+// IsOnFailureRequired returns true if the KeptnWorkloadVersion has failed, has onFailureTasks configured
+// and the on-failure phase has not been completed yet
+func (w KeptnWorkloadVersion) IsOnFailureRequired() bool {
+	return w.Status.Status.IsFailed() && w.IsOnFailureEnabled() && !w.IsOnFailureCompleted()
+}
+
 func (w *KeptnWorkloadVersion) IsStartTimeSet() bool {
 	return !w.Status.StartTime.IsZero()
 }
@@ -328,6 +398,22 @@ func (w KeptnWorkloadVersion) GetPostDeploymentTasks() []string {
 	return w.Spec.PostDeploymentTasks
 }
 
+func (w KeptnWorkloadVersion) GetPreDeploymentTaskDependencies() []TaskDependency {
+	return w.Spec.PreDeploymentTaskDependencies
+}
+
+func (w KeptnWorkloadVersion) GetPostDeploymentTaskDependencies() []TaskDependency {
+	return w.Spec.PostDeploymentTaskDependencies
+}
+
+func (w KeptnWorkloadVersion) GetExecutionConditions() []ExecutionCondition {
+	return w.Spec.ExecutionConditions
+}
+
+func (w KeptnWorkloadVersion) GetTraceId() map[string]string {
+	return w.Spec.TraceId
+}
+
 func (w KeptnWorkloadVersion) GetPreDeploymentTaskStatus() []ItemStatus {
 	return w.Status.PreDeploymentTaskStatus
 }
@@ -352,16 +438,70 @@ func (w KeptnWorkloadVersion) GetPostDeploymentEvaluationTaskStatus() []ItemStat
 	return w.Status.PostDeploymentEvaluationTaskStatus
 }
 
+func (w KeptnWorkloadVersion) GetPreDeploymentAnalyses() []string {
+	return w.Spec.PreDeploymentAnalyses
+}
+
+func (w KeptnWorkloadVersion) GetPostDeploymentAnalyses() []string {
+	return w.Spec.PostDeploymentAnalyses
+}
+
+func (w KeptnWorkloadVersion) GetPreDeploymentAnalysisStatus() []ItemStatus {
+	return w.Status.PreDeploymentAnalysisStatus
+}
+
+func (w KeptnWorkloadVersion) GetPostDeploymentAnalysisStatus() []ItemStatus {
+	return w.Status.PostDeploymentAnalysisStatus
+}
+
 func (w KeptnWorkloadVersion) GetPromotionTasks() []string {
-	// promotion tasks are not included in Workloads, but we need the implementation of this method to fulfil the PhaseItem interface
-	return []string{}
+	return w.Spec.PromotionTasks
 }
 
 func (w KeptnWorkloadVersion) GetPromotionTaskStatus() []ItemStatus {
-	// promotion tasks are not included in Workloads, but we need the implementation of this method to fulfil the PhaseItem interface
+	return w.Status.PromotionTaskStatus
+}
+
+func (w KeptnWorkloadVersion) GetRollbackTasks() []string {
+	// rollback tasks are not included in Workloads, but we need the implementation of this method to fulfil the PhaseItem interface
+	return []string{}
+}
+
+func (w KeptnWorkloadVersion) GetRollbackTaskStatus() []ItemStatus {
+	// rollback tasks are not included in Workloads, but we need the implementation of this method to fulfil the PhaseItem interface
 	return []ItemStatus{}
 }
 
+func (w KeptnWorkloadVersion) GetOnFailureTasks() []string {
+	return w.Spec.OnFailureTasks
+}
+
+func (w KeptnWorkloadVersion) GetOnFailureTaskStatus() []ItemStatus {
+	return w.Status.OnFailureTaskStatus
+}
+
+func (w KeptnWorkloadVersion) GetFailedPhase() string {
+	return w.Status.FailedPhase
+}
+
+// GetFailedItemStatus returns the status of the tasks, evaluations or analyses
+// that have failed in the phase that caused the KeptnWorkloadVersion to fail
+func (w KeptnWorkloadVersion) GetFailedItemStatus() []ItemStatus {
+	switch w.Status.FailedPhase {
+	case common.PhaseWorkloadPreDeployment.ShortName:
+		return getFailedItems(w.Status.PreDeploymentTaskStatus)
+	case common.PhaseWorkloadPreEvaluation.ShortName:
+		return getFailedItems(w.Status.PreDeploymentEvaluationTaskStatus, w.Status.PreDeploymentAnalysisStatus)
+	case common.PhaseWorkloadPostDeployment.ShortName:
+		return getFailedItems(w.Status.PostDeploymentTaskStatus)
+	case common.PhaseWorkloadPostEvaluation.ShortName:
+		return getFailedItems(w.Status.PostDeploymentEvaluationTaskStatus, w.Status.PostDeploymentAnalysisStatus)
+	case common.PhaseWorkloadPromotion.ShortName:
+		return getFailedItems(w.Status.PromotionTaskStatus)
+	}
+	return nil
+}
+
 func (w KeptnWorkloadVersion) GetAppName() string {
 	return w.Spec.AppName
 }
@@ -409,6 +549,7 @@ func (w KeptnWorkloadVersion) GenerateTask(taskDefinition KeptnTaskDefinition, c
 				WorkloadVersion: w.GetVersion(),
 				TaskType:        string(checkType),
 				ObjectType:      "Workload",
+				PreviousVersion: w.GetPreviousVersion(),
 			},
 			TaskDefinition:   taskDefinition.Name,
 			Parameters:       TaskParameters{},
@@ -430,6 +571,7 @@ func (w KeptnWorkloadVersion) GenerateEvaluation(evaluationDefinition KeptnEvalu
 			AppName:              w.GetAppName(),
 			WorkloadVersion:      w.GetVersion(),
 			Workload:             w.GetParentName(),
+			PreviousVersion:      w.GetPreviousVersion(),
 			EvaluationDefinition: evaluationDefinition.Name,
 			Type:                 checkType,
 			FailureConditions: FailureConditions{
@@ -466,24 +608,36 @@ func (w KeptnWorkloadVersion) SetSpanAttributes(span trace.Span) {
 
 //nolint:dupl
 func (w *KeptnWorkloadVersion) DeprecateRemainingPhases(phase common.KeptnPhaseType) {
-	// no need to deprecate anything when post-eval tasks fail
+	// remember the failed phase for the on-failure phase
+	if phase != common.PhaseDeprecated {
+		w.Status.FailedPhase = phase.ShortName
+	}
+	// no need to deprecate anything when promotion tasks fail
+	if phase == common.PhaseWorkloadPromotion {
+		return
+	}
+	// deprecate promotion tasks when post evaluation failed
 	if phase == common.PhaseWorkloadPostEvaluation {
+		w.Status.PromotionStatus = common.StateDeprecated
 		return
 	}
 	// deprecate post evaluation when post tasks failed
 	if phase == common.PhaseWorkloadPostDeployment {
 		w.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
+		w.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate post evaluation and tasks when app deployment failed
 	if phase == common.PhaseWorkloadDeployment {
 		w.Status.PostDeploymentStatus = common.StateDeprecated
 		w.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
+		w.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate app deployment, post tasks and evaluations if app pre-eval failed
 	if phase == common.PhaseWorkloadPreEvaluation {
 		w.Status.PostDeploymentStatus = common.StateDeprecated
 		w.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
 		w.Status.DeploymentStatus = common.StateDeprecated
+		w.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate pre evaluations, app deployment and post tasks and evaluations when pre-tasks failed
 	if phase == common.PhaseWorkloadPreDeployment {
@@ -491,6 +645,7 @@ func (w *KeptnWorkloadVersion) DeprecateRemainingPhases(phase common.KeptnPhaseT
 		w.Status.PostDeploymentEvaluationStatus = common.StateDeprecated
 		w.Status.DeploymentStatus = common.StateDeprecated
 		w.Status.PreDeploymentEvaluationStatus = common.StateDeprecated
+		w.Status.PromotionStatus = common.StateDeprecated
 	}
 	// deprecate completely everything
 	if phase == common.PhaseDeprecated {
@@ -499,6 +654,7 @@ func (w *KeptnWorkloadVersion) DeprecateRemainingPhases(phase common.KeptnPhaseT
 		w.Status.DeploymentStatus = common.StateDeprecated
 		w.Status.PreDeploymentEvaluationStatus = common.StateDeprecated
 		w.Status.PreDeploymentStatus = common.StateDeprecated
+		w.Status.PromotionStatus = common.StateDeprecated
 		w.Status.Status = common.StateDeprecated
 		return
 	}
@@ -513,6 +669,18 @@ func (w *KeptnWorkloadVersion) SetPhaseTraceID(phase string, carrier propagation
 	w.Status.PhaseTraceIDs[common.GetShortPhaseName(phase)] = carrier
 }
 
+// GetPhaseTraceContext returns the trace context of the span of the workload deployment phase.
+// If the deployment phase has not been started yet, the trace context of the current phase is returned,
+// and the trace context of the KeptnWorkloadVersion if no phase has been started at all.
+func (w KeptnWorkloadVersion) GetPhaseTraceContext() propagation.MapCarrier {
+	for _, phase := range []string{common.PhaseWorkloadDeployment.ShortName, w.Status.CurrentPhase} {
+		if carrier := w.Status.PhaseTraceIDs[phase]; carrier.Get("traceparent") != "" {
+			return carrier
+		}
+	}
+	return w.Spec.TraceId
+}
+
 func (w KeptnWorkloadVersion) GetEventAnnotations() map[string]string {
 	return map[string]string{
 		"appName":             w.Spec.AppName,
@@ -23,6 +23,7 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 			PostDeploymentStatus:           common.StateFailed,
 			PostDeploymentEvaluationStatus: common.StateFailed,
 			DeploymentStatus:               common.StateFailed,
+			PromotionStatus:                common.StateFailed,
 			Status:                         common.StateFailed,
 			PreDeploymentTaskStatus: []ItemStatus{
 				{
@@ -52,6 +53,27 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 					Name:           "taskname4",
 				},
 			},
+			PreDeploymentAnalysisStatus: []ItemStatus{
+				{
+					DefinitionName: "analysisdef1",
+					Status:         common.StateSucceeded,
+					Name:           "analysis1",
+				},
+			},
+			PostDeploymentAnalysisStatus: []ItemStatus{
+				{
+					DefinitionName: "analysisdef2",
+					Status:         common.StateWarning,
+					Name:           "analysis2",
+				},
+			},
+			PromotionTaskStatus: []ItemStatus{
+				{
+					DefinitionName: "defname5",
+					Status:         common.StateFailed,
+					Name:           "taskname5",
+				},
+			},
 			CurrentPhase: common.PhaseAppDeployment.ShortName,
 		},
 		Spec: KeptnWorkloadVersionSpec{
@@ -60,8 +82,20 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 				PostDeploymentTasks:       []string{"task3", "task4"},
 				PreDeploymentEvaluations:  []string{"task5", "task6"},
 				PostDeploymentEvaluations: []string{"task7", "task8"},
+				PreDeploymentAnalyses:     []string{"analysisdef1"},
+				PostDeploymentAnalyses:    []string{"analysisdef2"},
 				Version:                   "version",
 				AppName:                   "appname",
+				PreDeploymentTaskDependencies: []TaskDependency{
+					{Name: "task2", DependsOn: []string{"task1"}},
+				},
+				PostDeploymentTaskDependencies: []TaskDependency{
+					{Name: "task4", DependsOn: []string{"task3"}},
+				},
+				ExecutionConditions: []ExecutionCondition{
+					{Name: "task1", When: "version != previousVersion"},
+				},
+				PromotionTasks: []string{"task9"},
 			},
 			PreviousVersion: "prev",
 			WorkloadName:    "workloadname",
@@ -89,6 +123,10 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 	require.False(t, workload.IsDeploymentSucceeded())
 	require.True(t, workload.IsDeploymentFailed())
 
+	require.True(t, workload.IsPromotionCompleted())
+	require.False(t, workload.IsPromotionSucceeded())
+	require.True(t, workload.IsPromotionFailed())
+
 	workload.Status.PreDeploymentStatus = common.StateWarning
 	workload.Status.PreDeploymentEvaluationStatus = common.StateWarning
 	workload.Status.PostDeploymentStatus = common.StateWarning
@@ -146,6 +184,10 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 
 	require.Equal(t, []string{"task1", "task2"}, workload.GetPreDeploymentTasks())
 	require.Equal(t, []string{"task3", "task4"}, workload.GetPostDeploymentTasks())
+	require.Equal(t, []TaskDependency{{Name: "task2", DependsOn: []string{"task1"}}}, workload.GetPreDeploymentTaskDependencies())
+	require.Equal(t, []TaskDependency{{Name: "task4", DependsOn: []string{"task3"}}}, workload.GetPostDeploymentTaskDependencies())
+	require.Equal(t, []ExecutionCondition{{Name: "task1", When: "version != previousVersion"}}, workload.GetExecutionConditions())
+	require.Equal(t, map[string]string{"traceparent": "trace1"}, workload.GetTraceId())
 	require.Equal(t, []string{"task5", "task6"}, workload.GetPreDeploymentEvaluations())
 	require.Equal(t, []string{"task7", "task8"}, workload.GetPostDeploymentEvaluations())
 
@@ -181,6 +223,23 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 		},
 	}, workload.GetPostDeploymentEvaluationTaskStatus())
 
+	require.Equal(t, []string{"analysisdef1"}, workload.GetPreDeploymentAnalyses())
+	require.Equal(t, []string{"analysisdef2"}, workload.GetPostDeploymentAnalyses())
+	require.Equal(t, []ItemStatus{
+		{
+			DefinitionName: "analysisdef1",
+			Status:         common.StateSucceeded,
+			Name:           "analysis1",
+		},
+	}, workload.GetPreDeploymentAnalysisStatus())
+	require.Equal(t, []ItemStatus{
+		{
+			DefinitionName: "analysisdef2",
+			Status:         common.StateWarning,
+			Name:           "analysis2",
+		},
+	}, workload.GetPostDeploymentAnalysisStatus())
+
 	require.Equal(t, "appname", workload.GetAppName())
 	require.Equal(t, "prev", workload.GetPreviousVersion())
 	require.Equal(t, "workloadname", workload.GetParentName())
@@ -228,6 +287,7 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 			WorkloadName:    workload.GetParentName(),
 			TaskType:        string(common.PostDeploymentCheckType),
 			ObjectType:      "Workload",
+			PreviousVersion: "prev",
 		},
 		TaskDefinition:   "task-def",
 		Parameters:       TaskParameters{},
@@ -263,6 +323,7 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 	require.Equal(t, KeptnEvaluationSpec{
 		AppName:              workload.GetAppName(),
 		WorkloadVersion:      workload.GetVersion(),
+		PreviousVersion:      "prev",
 		Workload:             workload.GetParentName(),
 		EvaluationDefinition: "eval-def",
 		Type:                 common.PostDeploymentCheckType,
@@ -293,14 +354,30 @@ func TestKeptnWorkloadVersion(t *testing.T) {
 	}, workload.GetEventAnnotations())
 
 	require.Equal(t,
-		[]string{},
+		[]string{"task9"},
 		workload.GetPromotionTasks(),
 	)
 
 	require.Equal(t,
-		[]ItemStatus{},
+		[]ItemStatus{
+			{
+				DefinitionName: "defname5",
+				Status:         common.StateFailed,
+				Name:           "taskname5",
+			},
+		},
 		workload.GetPromotionTaskStatus(),
 	)
+
+	require.Equal(t,
+		[]string{},
+		workload.GetRollbackTasks(),
+	)
+
+	require.Equal(t,
+		[]ItemStatus{},
+		workload.GetRollbackTaskStatus(),
+	)
 }
 
 //nolint:dupl
@@ -312,6 +389,7 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 			PostDeploymentStatus:           common.StatePending,
 			PostDeploymentEvaluationStatus: common.StatePending,
 			DeploymentStatus:               common.StatePending,
+			PromotionStatus:                common.StatePending,
 			Status:                         common.StatePending,
 		},
 	}
@@ -321,6 +399,22 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 		phase           common.KeptnPhaseType
 		want            KeptnWorkloadVersion
 	}{
+		{
+			workloadVersion: workloadVersion,
+			phase:           common.PhaseWorkloadPromotion,
+			want: KeptnWorkloadVersion{
+				Status: KeptnWorkloadVersionStatus{
+					PreDeploymentStatus:            common.StatePending,
+					PreDeploymentEvaluationStatus:  common.StatePending,
+					PostDeploymentStatus:           common.StatePending,
+					PostDeploymentEvaluationStatus: common.StatePending,
+					DeploymentStatus:               common.StatePending,
+					PromotionStatus:                common.StatePending,
+					FailedPhase:                    common.PhaseWorkloadPromotion.ShortName,
+					Status:                         common.StatePending,
+				},
+			},
+		},
 		{
 			workloadVersion: workloadVersion,
 			phase:           common.PhaseWorkloadPostEvaluation,
@@ -331,6 +425,8 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentStatus:           common.StatePending,
 					PostDeploymentEvaluationStatus: common.StatePending,
 					DeploymentStatus:               common.StatePending,
+					PromotionStatus:                common.StateDeprecated,
+					FailedPhase:                    common.PhaseWorkloadPostEvaluation.ShortName,
 					Status:                         common.StatePending,
 				},
 			},
@@ -345,6 +441,8 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentStatus:           common.StatePending,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					DeploymentStatus:               common.StatePending,
+					PromotionStatus:                common.StateDeprecated,
+					FailedPhase:                    common.PhaseWorkloadPostDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -359,6 +457,8 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					DeploymentStatus:               common.StatePending,
+					PromotionStatus:                common.StateDeprecated,
+					FailedPhase:                    common.PhaseWorkloadDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -373,6 +473,8 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					DeploymentStatus:               common.StateDeprecated,
+					PromotionStatus:                common.StateDeprecated,
+					FailedPhase:                    common.PhaseWorkloadPreEvaluation.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -387,6 +489,8 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					DeploymentStatus:               common.StateDeprecated,
+					PromotionStatus:                common.StateDeprecated,
+					FailedPhase:                    common.PhaseWorkloadPreDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -401,6 +505,7 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentStatus:           common.StateDeprecated,
 					PostDeploymentEvaluationStatus: common.StateDeprecated,
 					DeploymentStatus:               common.StateDeprecated,
+					PromotionStatus:                common.StateDeprecated,
 					Status:                         common.StateDeprecated,
 				},
 			},
@@ -415,6 +520,8 @@ func TestKeptnWorkloadVersion_DeprecateRemainingPhases(t *testing.T) {
 					PostDeploymentStatus:           common.StatePending,
 					PostDeploymentEvaluationStatus: common.StatePending,
 					DeploymentStatus:               common.StatePending,
+					PromotionStatus:                common.StatePending,
+					FailedPhase:                    common.PhaseAppPreDeployment.ShortName,
 					Status:                         common.StateFailed,
 				},
 			},
@@ -466,6 +573,23 @@ func TestKeptnWorkloadVersion_SetPhaseTraceID(t *testing.T) {
 	}, app)
 }
 
+func TestKeptnWorkloadVersion_GetPhaseTraceContext(t *testing.T) {
+	workloadVersion := KeptnWorkloadVersion{
+		Spec: KeptnWorkloadVersionSpec{
+			TraceId: map[string]string{"traceparent": "workload-version"},
+		},
+	}
+	require.Equal(t, propagation.MapCarrier{"traceparent": "workload-version"}, workloadVersion.GetPhaseTraceContext())
+
+	workloadVersion.SetCurrentPhase(common.PhaseWorkloadPreDeployment.ShortName)
+	workloadVersion.SetPhaseTraceID(common.PhaseWorkloadPreDeployment.ShortName, propagation.MapCarrier{"traceparent": "pre-deployment"})
+	require.Equal(t, propagation.MapCarrier{"traceparent": "pre-deployment"}, workloadVersion.GetPhaseTraceContext())
+
+	workloadVersion.SetPhaseTraceID(common.PhaseWorkloadDeployment.ShortName, propagation.MapCarrier{"traceparent": "deployment"})
+	workloadVersion.SetCurrentPhase(common.PhaseWorkloadPostDeployment.ShortName)
+	require.Equal(t, propagation.MapCarrier{"traceparent": "deployment"}, workloadVersion.GetPhaseTraceContext())
+}
+
 func TestKeptnWorkloadVersionList(t *testing.T) {
 	list := KeptnWorkloadVersionList{
 		Items: []KeptnWorkloadVersion{
@@ -0,0 +1,98 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateTaskDependencies verifies that the dependencies only refer to the given tasks
+// and that they form a directed acyclic graph
+func validateTaskDependencies(tasks []string, dependencies []TaskDependency, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	graph := make(map[string][]string, len(dependencies))
+
+	for i, dependency := range dependencies {
+		namePath := path.Index(i).Child("name")
+		if !slices.Contains(tasks, dependency.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, dependency.Name, "must be one of the tasks of the phase"))
+			continue
+		}
+		if _, ok := graph[dependency.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(namePath, dependency.Name))
+			continue
+		}
+		for j, dependsOn := range dependency.DependsOn {
+			if !slices.Contains(tasks, dependsOn) {
+				allErrs = append(allErrs, field.Invalid(path.Index(i).Child("dependsOn").Index(j), dependsOn, "must be one of the tasks of the phase"))
+			}
+		}
+		graph[dependency.Name] = dependency.DependsOn
+	}
+
+	if cycle := findCycle(graph, dependencies); len(cycle) > 0 {
+		allErrs = append(allErrs, field.Invalid(path, strings.Join(cycle, " -> "), "task dependencies must not contain cycles"))
+	}
+
+	return allErrs
+}
+
+// findCycle returns the tasks forming the first cycle found in the graph,
+// or nil if the graph is acyclic
+func findCycle(graph map[string][]string, dependencies []TaskDependency) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(graph))
+	var stack []string
+
+	var visit func(task string) []string
+	visit = func(task string) []string {
+		switch state[task] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(stack, task)
+			return append(slices.Clone(stack[start:]), task)
+		}
+		state[task] = visiting
+		stack = append(stack, task)
+		for _, dependsOn := range graph[task] {
+			if cycle := visit(dependsOn); cycle != nil {
+				return cycle
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[task] = visited
+		return nil
+	}
+
+	// iterate over the dependencies rather than the map to report cycles deterministically
+	for _, dependency := range dependencies {
+		if state[dependency.Name] == unvisited {
+			if cycle := visit(dependency.Name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateTaskDependencies(t *testing.T) {
+	path := field.NewPath("spec").Child("preDeploymentTaskDependencies")
+	tasks := []string{"migrate", "warmup", "notify"}
+
+	tests := []struct {
+		name         string
+		dependencies []TaskDependency
+		want         field.ErrorList
+	}{
+		{
+			name: "no dependencies",
+		},
+		{
+			name: "valid graph",
+			dependencies: []TaskDependency{
+				{Name: "warmup", DependsOn: []string{"migrate"}},
+				{Name: "notify", DependsOn: []string{"migrate", "warmup"}},
+			},
+		},
+		{
+			name: "unknown task",
+			dependencies: []TaskDependency{
+				{Name: "cleanup", DependsOn: []string{"migrate"}},
+			},
+			want: field.ErrorList{
+				field.Invalid(path.Index(0).Child("name"), "cleanup", "must be one of the tasks of the phase"),
+			},
+		},
+		{
+			name: "unknown dependency",
+			dependencies: []TaskDependency{
+				{Name: "warmup", DependsOn: []string{"migrate", "cleanup"}},
+			},
+			want: field.ErrorList{
+				field.Invalid(path.Index(0).Child("dependsOn").Index(1), "cleanup", "must be one of the tasks of the phase"),
+			},
+		},
+		{
+			name: "duplicate task",
+			dependencies: []TaskDependency{
+				{Name: "warmup", DependsOn: []string{"migrate"}},
+				{Name: "warmup", DependsOn: []string{"notify"}},
+			},
+			want: field.ErrorList{
+				field.Duplicate(path.Index(1).Child("name"), "warmup"),
+			},
+		},
+		{
+			name: "task depends on itself",
+			dependencies: []TaskDependency{
+				{Name: "warmup", DependsOn: []string{"warmup"}},
+			},
+			want: field.ErrorList{
+				field.Invalid(path, "warmup -> warmup", "task dependencies must not contain cycles"),
+			},
+		},
+		{
+			name: "cycle",
+			dependencies: []TaskDependency{
+				{Name: "migrate", DependsOn: []string{"notify"}},
+				{Name: "warmup", DependsOn: []string{"migrate"}},
+				{Name: "notify", DependsOn: []string{"warmup"}},
+			},
+			want: field.ErrorList{
+				field.Invalid(path, "migrate -> notify -> warmup -> migrate", "task dependencies must not contain cycles"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, validateTaskDependencies(tasks, tt.dependencies, path))
+		})
+	}
+}
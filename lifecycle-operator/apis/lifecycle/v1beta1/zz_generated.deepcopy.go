@@ -24,9 +24,51 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"go.opentelemetry.io/otel/propagation"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalSpec) DeepCopyInto(out *ApprovalSpec) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalSpec.
+func (in *ApprovalSpec) DeepCopy() *ApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalTaskStatus) DeepCopyInto(out *ApprovalTaskStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalTaskStatus.
+func (in *ApprovalTaskStatus) DeepCopy() *ApprovalTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutomountServiceAccountTokenSpec) DeepCopyInto(out *AutomountServiceAccountTokenSpec) {
 	*out = *in
@@ -47,6 +89,22 @@ func (in *AutomountServiceAccountTokenSpec) DeepCopy() *AutomountServiceAccountT
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Baseline) DeepCopyInto(out *Baseline) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Baseline.
+func (in *Baseline) DeepCopy() *Baseline {
+	if in == nil {
+		return nil
+	}
+	out := new(Baseline)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
 	*out = *in
@@ -82,6 +140,33 @@ func (in *ContainerSpec) DeepCopy() *ContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DORAMetrics) DeepCopyInto(out *DORAMetrics) {
+	*out = *in
+	out.Window = in.Window
+	if in.LeadTimeForChanges != nil {
+		in, out := &in.LeadTimeForChanges, &out.LeadTimeForChanges
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TimeToRestore != nil {
+		in, out := &in.TimeToRestore, &out.TimeToRestore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DORAMetrics.
+func (in *DORAMetrics) DeepCopy() *DORAMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(DORAMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentTaskSpec) DeepCopyInto(out *DeploymentTaskSpec) {
 	*out = *in
@@ -95,6 +180,20 @@ func (in *DeploymentTaskSpec) DeepCopyInto(out *DeploymentTaskSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreDeploymentTaskDependencies != nil {
+		in, out := &in.PreDeploymentTaskDependencies, &out.PreDeploymentTaskDependencies
+		*out = make([]TaskDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentTaskDependencies != nil {
+		in, out := &in.PostDeploymentTaskDependencies, &out.PostDeploymentTaskDependencies
+		*out = make([]TaskDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PreDeploymentEvaluations != nil {
 		in, out := &in.PreDeploymentEvaluations, &out.PreDeploymentEvaluations
 		*out = make([]string, len(*in))
@@ -105,11 +204,36 @@ func (in *DeploymentTaskSpec) DeepCopyInto(out *DeploymentTaskSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreDeploymentAnalyses != nil {
+		in, out := &in.PreDeploymentAnalyses, &out.PreDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostDeploymentAnalyses != nil {
+		in, out := &in.PostDeploymentAnalyses, &out.PostDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecutionConditions != nil {
+		in, out := &in.ExecutionConditions, &out.ExecutionConditions
+		*out = make([]ExecutionCondition, len(*in))
+		copy(*out, *in)
+	}
 	if in.PromotionTasks != nil {
 		in, out := &in.PromotionTasks, &out.PromotionTasks
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RollbackTasks != nil {
+		in, out := &in.RollbackTasks, &out.RollbackTasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnFailureTasks != nil {
+		in, out := &in.OnFailureTasks, &out.OnFailureTasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTaskSpec.
@@ -137,6 +261,51 @@ func (in *EvaluationStatusItem) DeepCopy() *EvaluationStatusItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionCondition) DeepCopyInto(out *ExecutionCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionCondition.
+func (in *ExecutionCondition) DeepCopy() *ExecutionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSpec) DeepCopyInto(out *ExternalSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSpec.
+func (in *ExternalSpec) DeepCopy() *ExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedItem) DeepCopyInto(out *FailedItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedItem.
+func (in *FailedItem) DeepCopy() *FailedItem {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailureConditions) DeepCopyInto(out *FailureConditions) {
 	*out = *in
@@ -153,6 +322,22 @@ func (in *FailureConditions) DeepCopy() *FailureConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeWindowStatus) DeepCopyInto(out *FreezeWindowStatus) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeWindowStatus.
+func (in *FreezeWindowStatus) DeepCopy() *FreezeWindowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeWindowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionReference) DeepCopyInto(out *FunctionReference) {
 	*out = *in
@@ -183,6 +368,41 @@ func (in *FunctionStatus) DeepCopy() *FunctionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpAssertion) DeepCopyInto(out *HttpAssertion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpAssertion.
+func (in *HttpAssertion) DeepCopy() *HttpAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpHeader) DeepCopyInto(out *HttpHeader) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpHeader.
+func (in *HttpHeader) DeepCopy() *HttpHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpReference) DeepCopyInto(out *HttpReference) {
 	*out = *in
@@ -198,6 +418,54 @@ func (in *HttpReference) DeepCopy() *HttpReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpSpec) DeepCopyInto(out *HttpSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HttpHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpectedStatus != nil {
+		in, out := &in.ExpectedStatus, &out.ExpectedStatus
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]HttpAssertion, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpSpec.
+func (in *HttpSpec) DeepCopy() *HttpSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpTaskStatus) DeepCopyInto(out *HttpTaskStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpTaskStatus.
+func (in *HttpTaskStatus) DeepCopy() *HttpTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Inline) DeepCopyInto(out *Inline) {
 	*out = *in
@@ -236,7 +504,7 @@ func (in *KeptnApp) DeepCopyInto(out *KeptnApp) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnApp.
@@ -487,6 +755,11 @@ func (in *KeptnAppSpec) DeepCopy() *KeptnAppSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnAppStatus) DeepCopyInto(out *KeptnAppStatus) {
 	*out = *in
+	if in.DORAMetrics != nil {
+		in, out := &in.DORAMetrics, &out.DORAMetrics
+		*out = new(DORAMetrics)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnAppStatus.
@@ -611,6 +884,25 @@ func (in *KeptnAppVersionStatus) DeepCopyInto(out *KeptnAppVersionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RollbackTaskStatus != nil {
+		in, out := &in.RollbackTaskStatus, &out.RollbackTaskStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RollbackWorkloadStatus != nil {
+		in, out := &in.RollbackWorkloadStatus, &out.RollbackWorkloadStatus
+		*out = make([]WorkloadStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnFailureTaskStatus != nil {
+		in, out := &in.OnFailureTaskStatus, &out.OnFailureTaskStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PreDeploymentEvaluationTaskStatus != nil {
 		in, out := &in.PreDeploymentEvaluationTaskStatus, &out.PreDeploymentEvaluationTaskStatus
 		*out = make([]ItemStatus, len(*in))
@@ -625,6 +917,25 @@ func (in *KeptnAppVersionStatus) DeepCopyInto(out *KeptnAppVersionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreDeploymentAnalysisStatus != nil {
+		in, out := &in.PreDeploymentAnalysisStatus, &out.PreDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentAnalysisStatus != nil {
+		in, out := &in.PostDeploymentAnalysisStatus, &out.PostDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FreezeWindow != nil {
+		in, out := &in.FreezeWindow, &out.FreezeWindow
+		*out = new(FreezeWindowStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PhaseTraceIDs != nil {
 		in, out := &in.PhaseTraceIDs, &out.PhaseTraceIDs
 		*out = make(common.PhaseTraceID, len(*in))
@@ -748,7 +1059,9 @@ func (in *KeptnEvaluationDefinitionSpec) DeepCopyInto(out *KeptnEvaluationDefini
 	if in.Objectives != nil {
 		in, out := &in.Objectives, &out.Objectives
 		*out = make([]Objective, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	out.FailureConditions = in.FailureConditions
 }
@@ -836,41 +1149,25 @@ func (in *KeptnEvaluationStatus) DeepCopy() *KeptnEvaluationStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeptnMetricReference) DeepCopyInto(out *KeptnMetricReference) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricReference.
-func (in *KeptnMetricReference) DeepCopy() *KeptnMetricReference {
-	if in == nil {
-		return nil
-	}
-	out := new(KeptnMetricReference)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeptnTask) DeepCopyInto(out *KeptnTask) {
+func (in *KeptnFreezeWindow) DeepCopyInto(out *KeptnFreezeWindow) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTask.
-func (in *KeptnTask) DeepCopy() *KeptnTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnFreezeWindow.
+func (in *KeptnFreezeWindow) DeepCopy() *KeptnFreezeWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(KeptnTask)
+	out := new(KeptnFreezeWindow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeptnTask) DeepCopyObject() runtime.Object {
+func (in *KeptnFreezeWindow) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -878,26 +1175,31 @@ func (in *KeptnTask) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeptnTaskDefinition) DeepCopyInto(out *KeptnTaskDefinition) {
+func (in *KeptnFreezeWindowList) DeepCopyInto(out *KeptnFreezeWindowList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeptnFreezeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskDefinition.
-func (in *KeptnTaskDefinition) DeepCopy() *KeptnTaskDefinition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnFreezeWindowList.
+func (in *KeptnFreezeWindowList) DeepCopy() *KeptnFreezeWindowList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeptnTaskDefinition)
+	out := new(KeptnFreezeWindowList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeptnTaskDefinition) DeepCopyObject() runtime.Object {
+func (in *KeptnFreezeWindowList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -905,16 +1207,322 @@ func (in *KeptnTaskDefinition) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeptnTaskDefinitionList) DeepCopyInto(out *KeptnTaskDefinitionList) {
+func (in *KeptnFreezeWindowSpec) DeepCopyInto(out *KeptnFreezeWindowSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeptnTaskDefinition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	out.Duration = in.Duration
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppSelector != nil {
+		in, out := &in.AppSelector, &out.AppSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnFreezeWindowSpec.
+func (in *KeptnFreezeWindowSpec) DeepCopy() *KeptnFreezeWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnFreezeWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnMetricReference) DeepCopyInto(out *KeptnMetricReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnMetricReference.
+func (in *KeptnMetricReference) DeepCopy() *KeptnMetricReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnMetricReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnNotificationConfig) DeepCopyInto(out *KeptnNotificationConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnNotificationConfig.
+func (in *KeptnNotificationConfig) DeepCopy() *KeptnNotificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnNotificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnNotificationConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnNotificationConfigList) DeepCopyInto(out *KeptnNotificationConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeptnNotificationConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnNotificationConfigList.
+func (in *KeptnNotificationConfigList) DeepCopy() *KeptnNotificationConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnNotificationConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnNotificationConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnNotificationConfigSpec) DeepCopyInto(out *KeptnNotificationConfigSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppSelector != nil {
+		in, out := &in.AppSelector, &out.AppSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.WebhookSecretRef.DeepCopyInto(&out.WebhookSecretRef)
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(NotificationRateLimit)
+		**out = **in
+	}
+	if in.DeduplicationWindow != nil {
+		in, out := &in.DeduplicationWindow, &out.DeduplicationWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnNotificationConfigSpec.
+func (in *KeptnNotificationConfigSpec) DeepCopy() *KeptnNotificationConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnNotificationConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnPromotionPipeline) DeepCopyInto(out *KeptnPromotionPipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnPromotionPipeline.
+func (in *KeptnPromotionPipeline) DeepCopy() *KeptnPromotionPipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnPromotionPipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnPromotionPipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnPromotionPipelineList) DeepCopyInto(out *KeptnPromotionPipelineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeptnPromotionPipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnPromotionPipelineList.
+func (in *KeptnPromotionPipelineList) DeepCopy() *KeptnPromotionPipelineList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnPromotionPipelineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnPromotionPipelineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnPromotionPipelineSpec) DeepCopyInto(out *KeptnPromotionPipelineSpec) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]PromotionStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnPromotionPipelineSpec.
+func (in *KeptnPromotionPipelineSpec) DeepCopy() *KeptnPromotionPipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnPromotionPipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnPromotionPipelineStatus) DeepCopyInto(out *KeptnPromotionPipelineStatus) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]PromotionStageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnPromotionPipelineStatus.
+func (in *KeptnPromotionPipelineStatus) DeepCopy() *KeptnPromotionPipelineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnPromotionPipelineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnTask) DeepCopyInto(out *KeptnTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTask.
+func (in *KeptnTask) DeepCopy() *KeptnTask {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnTaskDefinition) DeepCopyInto(out *KeptnTaskDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskDefinition.
+func (in *KeptnTaskDefinition) DeepCopy() *KeptnTaskDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnTaskDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnTaskDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnTaskDefinitionList) DeepCopyInto(out *KeptnTaskDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeptnTaskDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -954,11 +1562,36 @@ func (in *KeptnTaskDefinitionSpec) DeepCopyInto(out *KeptnTaskDefinitionSpec) {
 		*out = new(RuntimeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Wasm != nil {
+		in, out := &in.Wasm, &out.Wasm
+		*out = new(RuntimeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Container != nil {
 		in, out := &in.Container, &out.Container
 		*out = new(ContainerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Http != nil {
+		in, out := &in.Http, &out.Http
+		*out = new(HttpSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tekton != nil {
+		in, out := &in.Tekton, &out.Tekton
+		*out = new(TektonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalSpec)
+		**out = **in
+	}
 	if in.Retries != nil {
 		in, out := &in.Retries, &out.Retries
 		*out = new(int32)
@@ -1032,13 +1665,157 @@ func (in *KeptnTaskList) DeepCopy() *KeptnTaskList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeptnTaskList)
+	out := new(KeptnTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnTaskSpec) DeepCopyInto(out *KeptnTaskSpec) {
+	*out = *in
+	in.Context.DeepCopyInto(&out.Context)
+	in.Parameters.DeepCopyInto(&out.Parameters)
+	out.SecureParameters = in.SecureParameters
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskSpec.
+func (in *KeptnTaskSpec) DeepCopy() *KeptnTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnTaskStatus) DeepCopyInto(out *KeptnTaskStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Http != nil {
+		in, out := &in.Http, &out.Http
+		*out = new(HttpTaskStatus)
+		**out = **in
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalTaskStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskStatus.
+func (in *KeptnTaskStatus) DeepCopy() *KeptnTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnWorkload) DeepCopyInto(out *KeptnWorkload) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkload.
+func (in *KeptnWorkload) DeepCopy() *KeptnWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnWorkload) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnWorkloadKind) DeepCopyInto(out *KeptnWorkloadKind) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadKind.
+func (in *KeptnWorkloadKind) DeepCopy() *KeptnWorkloadKind {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnWorkloadKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeptnWorkloadKind) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeptnWorkloadKindList) DeepCopyInto(out *KeptnWorkloadKindList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeptnWorkloadKind, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadKindList.
+func (in *KeptnWorkloadKindList) DeepCopy() *KeptnWorkloadKindList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeptnWorkloadKindList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeptnTaskList) DeepCopyObject() runtime.Object {
+func (in *KeptnWorkloadKindList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1046,73 +1823,40 @@ func (in *KeptnTaskList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeptnTaskSpec) DeepCopyInto(out *KeptnTaskSpec) {
+func (in *KeptnWorkloadKindSpec) DeepCopyInto(out *KeptnWorkloadKindSpec) {
 	*out = *in
-	in.Context.DeepCopyInto(&out.Context)
-	in.Parameters.DeepCopyInto(&out.Parameters)
-	out.SecureParameters = in.SecureParameters
-	if in.Retries != nil {
-		in, out := &in.Retries, &out.Retries
-		*out = new(int32)
-		**out = **in
-	}
-	out.Timeout = in.Timeout
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskSpec.
-func (in *KeptnTaskSpec) DeepCopy() *KeptnTaskSpec {
-	if in == nil {
-		return nil
+	if in.OwnerChain != nil {
+		in, out := &in.OwnerChain, &out.OwnerChain
+		*out = make([]WorkloadKindOwner, len(*in))
+		copy(*out, *in)
 	}
-	out := new(KeptnTaskSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeptnTaskStatus) DeepCopyInto(out *KeptnTaskStatus) {
-	*out = *in
-	in.StartTime.DeepCopyInto(&out.StartTime)
-	in.EndTime.DeepCopyInto(&out.EndTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskStatus.
-func (in *KeptnTaskStatus) DeepCopy() *KeptnTaskStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadKindSpec.
+func (in *KeptnWorkloadKindSpec) DeepCopy() *KeptnWorkloadKindSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeptnTaskStatus)
+	out := new(KeptnWorkloadKindSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeptnWorkload) DeepCopyInto(out *KeptnWorkload) {
+func (in *KeptnWorkloadKindStatus) DeepCopyInto(out *KeptnWorkloadKindStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkload.
-func (in *KeptnWorkload) DeepCopy() *KeptnWorkload {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadKindStatus.
+func (in *KeptnWorkloadKindStatus) DeepCopy() *KeptnWorkloadKindStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeptnWorkload)
+	out := new(KeptnWorkloadKindStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeptnWorkload) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnWorkloadList) DeepCopyInto(out *KeptnWorkloadList) {
 	*out = *in
@@ -1173,6 +1917,20 @@ func (in *KeptnWorkloadSpec) DeepCopyInto(out *KeptnWorkloadSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreDeploymentTaskDependencies != nil {
+		in, out := &in.PreDeploymentTaskDependencies, &out.PreDeploymentTaskDependencies
+		*out = make([]TaskDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentTaskDependencies != nil {
+		in, out := &in.PostDeploymentTaskDependencies, &out.PostDeploymentTaskDependencies
+		*out = make([]TaskDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PreDeploymentEvaluations != nil {
 		in, out := &in.PreDeploymentEvaluations, &out.PreDeploymentEvaluations
 		*out = make([]string, len(*in))
@@ -1183,6 +1941,31 @@ func (in *KeptnWorkloadSpec) DeepCopyInto(out *KeptnWorkloadSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreDeploymentAnalyses != nil {
+		in, out := &in.PreDeploymentAnalyses, &out.PreDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostDeploymentAnalyses != nil {
+		in, out := &in.PostDeploymentAnalyses, &out.PostDeploymentAnalyses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecutionConditions != nil {
+		in, out := &in.ExecutionConditions, &out.ExecutionConditions
+		*out = make([]ExecutionCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.PromotionTasks != nil {
+		in, out := &in.PromotionTasks, &out.PromotionTasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnFailureTasks != nil {
+		in, out := &in.OnFailureTasks, &out.OnFailureTasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.ResourceReference = in.ResourceReference
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
@@ -1331,8 +2114,41 @@ func (in *KeptnWorkloadVersionStatus) DeepCopyInto(out *KeptnWorkloadVersionStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PreDeploymentAnalysisStatus != nil {
+		in, out := &in.PreDeploymentAnalysisStatus, &out.PreDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostDeploymentAnalysisStatus != nil {
+		in, out := &in.PostDeploymentAnalysisStatus, &out.PostDeploymentAnalysisStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PromotionTaskStatus != nil {
+		in, out := &in.PromotionTaskStatus, &out.PromotionTaskStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OnFailureTaskStatus != nil {
+		in, out := &in.OnFailureTaskStatus, &out.OnFailureTaskStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.FreezeWindow != nil {
+		in, out := &in.FreezeWindow, &out.FreezeWindow
+		*out = new(FreezeWindowStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PhaseTraceIDs != nil {
 		in, out := &in.PhaseTraceIDs, &out.PhaseTraceIDs
 		*out = make(common.PhaseTraceID, len(*in))
@@ -1371,10 +2187,36 @@ func (in *KeptnWorkloadVersionStatus) DeepCopy() *KeptnWorkloadVersionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationRateLimit) DeepCopyInto(out *NotificationRateLimit) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRateLimit.
+func (in *NotificationRateLimit) DeepCopy() *NotificationRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Objective) DeepCopyInto(out *Objective) {
 	*out = *in
 	out.KeptnMetricRef = in.KeptnMetricRef
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(Target)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Baseline != nil {
+		in, out := &in.Baseline, &out.Baseline
+		*out = new(Baseline)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Objective.
@@ -1387,6 +2229,125 @@ func (in *Objective) DeepCopy() *Objective {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Operator) DeepCopyInto(out *Operator) {
+	*out = *in
+	if in.LessThanOrEqual != nil {
+		in, out := &in.LessThanOrEqual, &out.LessThanOrEqual
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LessThan != nil {
+		in, out := &in.LessThan, &out.LessThan
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GreaterThan != nil {
+		in, out := &in.GreaterThan, &out.GreaterThan
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GreaterThanOrEqual != nil {
+		in, out := &in.GreaterThanOrEqual, &out.GreaterThanOrEqual
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EqualTo != nil {
+		in, out := &in.EqualTo, &out.EqualTo
+		*out = new(OperatorValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InRange != nil {
+		in, out := &in.InRange, &out.InRange
+		*out = new(RangeValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotInRange != nil {
+		in, out := &in.NotInRange, &out.NotInRange
+		*out = new(RangeValue)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operator.
+func (in *Operator) DeepCopy() *Operator {
+	if in == nil {
+		return nil
+	}
+	out := new(Operator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorValue) DeepCopyInto(out *OperatorValue) {
+	*out = *in
+	out.FixedValue = in.FixedValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorValue.
+func (in *OperatorValue) DeepCopy() *OperatorValue {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionStage) DeepCopyInto(out *PromotionStage) {
+	*out = *in
+	if in.Evaluations != nil {
+		in, out := &in.Evaluations, &out.Evaluations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionStage.
+func (in *PromotionStage) DeepCopy() *PromotionStage {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionStageStatus) DeepCopyInto(out *PromotionStageStatus) {
+	*out = *in
+	in.PromotionTime.DeepCopyInto(&out.PromotionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionStageStatus.
+func (in *PromotionStageStatus) DeepCopy() *PromotionStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RangeValue) DeepCopyInto(out *RangeValue) {
+	*out = *in
+	out.LowBound = in.LowBound.DeepCopy()
+	out.HighBound = in.HighBound.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RangeValue.
+func (in *RangeValue) DeepCopy() *RangeValue {
+	if in == nil {
+		return nil
+	}
+	out := new(RangeValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -1453,6 +2414,31 @@ func (in *ServiceAccountSpec) DeepCopy() *ServiceAccountSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Target) DeepCopyInto(out *Target) {
+	*out = *in
+	if in.Failure != nil {
+		in, out := &in.Failure, &out.Failure
+		*out = new(Operator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warning != nil {
+		in, out := &in.Warning, &out.Warning
+		*out = new(Operator)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Target.
+func (in *Target) DeepCopy() *Target {
+	if in == nil {
+		return nil
+	}
+	out := new(Target)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskContext) DeepCopyInto(out *TaskContext) {
 	*out = *in
@@ -1463,6 +2449,29 @@ func (in *TaskContext) DeepCopyInto(out *TaskContext) {
 			(*out)[key] = val
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.FailedItems != nil {
+		in, out := &in.FailedItems, &out.FailedItems
+		*out = make([]FailedItem, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskContext.
@@ -1475,6 +2484,26 @@ func (in *TaskContext) DeepCopy() *TaskContext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskDependency) DeepCopyInto(out *TaskDependency) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskDependency.
+func (in *TaskDependency) DeepCopy() *TaskDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskParameters) DeepCopyInto(out *TaskParameters) {
 	*out = *in
@@ -1497,6 +2526,43 @@ func (in *TaskParameters) DeepCopy() *TaskParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonSpec) DeepCopyInto(out *TektonSpec) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonSpec.
+func (in *TektonSpec) DeepCopy() *TektonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadKindOwner) DeepCopyInto(out *WorkloadKindOwner) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadKindOwner.
+func (in *WorkloadKindOwner) DeepCopy() *WorkloadKindOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadKindOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 	*out = *in
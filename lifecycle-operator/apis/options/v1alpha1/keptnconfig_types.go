@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strconv"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,6 +34,12 @@ type KeptnConfigSpec struct {
 	// +optional
 	OTelCollectorUrl string `json:"OTelCollectorUrl,omitempty"`
 
+	// Tracing configures the sampling and the resource attributes of the OpenTelemetry traces
+	// of the lifecycle operator.
+	// If not set, all traces are sampled.
+	// +optional
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+
 	// KeptnAppCreationRequestTimeoutSeconds is used to set the interval in which automatic app discovery
 	// searches for workload to put into the same auto-generated KeptnApp
 	// +kubebuilder:default:=30
@@ -42,6 +50,12 @@ type KeptnConfigSpec struct {
 	// +optional
 	CloudEventsEndpoint string `json:"cloudEventsEndpoint,omitempty"`
 
+	// CloudEventsSinks defines further endpoints where Cloud Events are delivered to by the lifecycle operator.
+	// In contrast to the CloudEventsEndpoint, the Cloud Events can be filtered by their type, and their delivery
+	// is retried and can be batched.
+	// +optional
+	CloudEventsSinks []CloudEventsSink `json:"cloudEventsSinks,omitempty"`
+
 	// BlockDeployment is used to block the deployment of the application until the pre-deployment
 	// tasks and evaluations succeed
 	// +kubebuilder:default:=true
@@ -56,6 +70,125 @@ type KeptnConfigSpec struct {
 	// +kubebuilder:validation:Type:=string
 	// +optional
 	ObservabilityTimeout metav1.Duration `json:"observabilityTimeout,omitempty"`
+
+	// Retention defines how long the deployment history, i.e. completed KeptnAppVersions and KeptnWorkloadVersions
+	// including their KeptnTasks and KeptnEvaluations, is kept.
+	// If not set, the deployment history is never deleted.
+	// +optional
+	Retention *RetentionSpec `json:"retention,omitempty"`
+}
+
+// CloudEventsSink defines an endpoint where Cloud Events are delivered to
+type CloudEventsSink struct {
+	// Name is the unique name of the sink.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Endpoint is the HTTP endpoint the Cloud Events are posted to.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+	// Types filters the Cloud Events that are delivered to the sink by their type, e.g. 'App Deployment.Finished'.
+	// The filters support the wildcards '*' and '?', e.g. '*.Failed'.
+	// If empty, all Cloud Events are delivered.
+	// +optional
+	Types []string `json:"types,omitempty"`
+	// QueueSize is the maximum number of Cloud Events that are buffered for the sink.
+	// Cloud Events are dropped if the queue is full.
+	// +kubebuilder:default:=100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QueueSize int32 `json:"queueSize,omitempty"`
+	// MaxRetries is the number of times the delivery of Cloud Events is retried before they are dropped.
+	// +kubebuilder:default:=5
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// RetryInterval is the interval before the first retry of a failed delivery.
+	// The interval is doubled after every further failed attempt, up to one minute.
+	// +kubebuilder:default:="1s"
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+	// BatchSize is the maximum number of buffered Cloud Events that are delivered together in batched mode.
+	// If set to 1, every Cloud Event is delivered on its own in binary mode.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
+}
+
+// TracingSpec configures the OpenTelemetry traces of the lifecycle operator
+type TracingSpec struct {
+	// SamplingRatio is the ratio of deployment traces that are sampled, between 0 and 1.
+	// Spans with a parent follow the sampling decision of the parent, so that a trace is either sampled completely or not at all.
+	// +kubebuilder:default:="1"
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	SamplingRatio string `json:"samplingRatio,omitempty"`
+	// Namespaces overrides the sampling ratio for the deployment traces of single namespaces.
+	// +optional
+	Namespaces []NamespaceSamplingPolicy `json:"namespaces,omitempty"`
+	// ResourceAttributes are added to the OpenTelemetry resource of the traces of the lifecycle operator,
+	// e.g. 'k8s.cluster.name' or 'deployment.environment'.
+	// The attributes 'service.name' and 'service.version' are set by the lifecycle operator and cannot be overridden.
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+}
+
+// NamespaceSamplingPolicy overrides the sampling ratio for a namespace
+type NamespaceSamplingPolicy struct {
+	// Namespace is the name of the namespace the sampling ratio applies to.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// SamplingRatio is the ratio of deployment traces in the namespace that are sampled, between 0 and 1.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	SamplingRatio string `json:"samplingRatio"`
+}
+
+// RetentionPolicy defines which parts of the deployment history are kept
+type RetentionPolicy struct {
+	// SucceededHistoryLimit is the number of completed KeptnAppVersions per KeptnApp and
+	// KeptnWorkloadVersions per KeptnWorkload that did not fail and are kept.
+	// If not set, all of them are kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SucceededHistoryLimit *int32 `json:"succeededHistoryLimit,omitempty"`
+	// FailedHistoryLimit is the number of failed KeptnAppVersions per KeptnApp and
+	// KeptnWorkloadVersions per KeptnWorkload that are kept.
+	// If not set, all of them are kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedHistoryLimit *int32 `json:"failedHistoryLimit,omitempty"`
+	// MaxAge is the time after which completed KeptnAppVersions, KeptnWorkloadVersions, KeptnTasks and
+	// KeptnEvaluations are deleted, regardless of the history limits.
+	// If not set, they are kept until the history limits are exceeded.
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// RetentionSpec defines the retention of the deployment history
+type RetentionSpec struct {
+	RetentionPolicy `json:",inline"`
+	// Interval is the interval in which the deployment history is pruned.
+	// +kubebuilder:default:="1h"
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Namespaces overrides the retention policy for single namespaces.
+	// Fields that are not set in an override are taken from the cluster-wide retention policy.
+	// +optional
+	Namespaces []NamespaceRetentionPolicy `json:"namespaces,omitempty"`
+}
+
+// NamespaceRetentionPolicy overrides the retention policy for a namespace
+type NamespaceRetentionPolicy struct {
+	// Namespace is the name of the namespace the retention policy applies to.
+	// +kubebuilder:validation:MinLength=1
+	Namespace       string `json:"namespace"`
+	RetentionPolicy `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -87,3 +220,46 @@ type KeptnConfigList struct {
 func init() {
 	SchemeBuilder.Register(&KeptnConfig{}, &KeptnConfigList{})
 }
+
+// GetPolicy returns the retention policy for the given namespace
+func (r RetentionSpec) GetPolicy(namespace string) RetentionPolicy {
+	policy := r.RetentionPolicy
+	for _, override := range r.Namespaces {
+		if override.Namespace != namespace {
+			continue
+		}
+		if override.SucceededHistoryLimit != nil {
+			policy.SucceededHistoryLimit = override.SucceededHistoryLimit
+		}
+		if override.FailedHistoryLimit != nil {
+			policy.FailedHistoryLimit = override.FailedHistoryLimit
+		}
+		if override.MaxAge != nil {
+			policy.MaxAge = override.MaxAge
+		}
+	}
+	return policy
+}
+
+// GetSamplingRatio returns the sampling ratio of the deployment traces.
+// All traces are sampled if the ratio is not set or invalid.
+func (t TracingSpec) GetSamplingRatio() float64 {
+	return parseSamplingRatio(t.SamplingRatio)
+}
+
+// GetNamespaceSamplingRatios returns the sampling ratios that have been overridden for single namespaces
+func (t TracingSpec) GetNamespaceSamplingRatios() map[string]float64 {
+	ratios := make(map[string]float64, len(t.Namespaces))
+	for _, override := range t.Namespaces {
+		ratios[override.Namespace] = parseSamplingRatio(override.SamplingRatio)
+	}
+	return ratios
+}
+
+func parseSamplingRatio(ratio string) float64 {
+	value, err := strconv.ParseFloat(ratio, 64)
+	if err != nil || value < 0 || value > 1 {
+		return 1
+	}
+	return value
+}
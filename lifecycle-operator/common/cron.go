@@ -0,0 +1,159 @@
+package operatorcommon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression with the five standard fields
+// minute, hour, day of month, month and day of week.
+type CronSchedule struct {
+	minutes     [60]bool
+	hours       [24]bool
+	daysOfMonth [32]bool
+	months      [13]bool
+	daysOfWeek  [7]bool
+	// anyDay is true if either the day of month or the day of week field is a wildcard.
+	// Otherwise, a day matches if it matches one of the two fields.
+	anyDay bool
+}
+
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// ParseCronSchedule parses a cron expression consisting of five space separated fields.
+// Each field supports wildcards (*), values, ranges (1-5), lists (1,3,5) and steps (*/15, 0-30/10).
+// Sunday can be specified as either 0 or 7 in the day of week field.
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected %d fields in cron expression %q, found %d", len(cronFields), expression, len(fields))
+	}
+
+	schedule := &CronSchedule{}
+	values := make([][]int, len(cronFields))
+	for i, field := range fields {
+		parsed, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = parsed
+	}
+
+	for _, v := range values[0] {
+		schedule.minutes[v] = true
+	}
+	for _, v := range values[1] {
+		schedule.hours[v] = true
+	}
+	for _, v := range values[2] {
+		schedule.daysOfMonth[v] = true
+	}
+	for _, v := range values[3] {
+		schedule.months[v] = true
+	}
+	for _, v := range values[4] {
+		schedule.daysOfWeek[v%7] = true
+	}
+	schedule.anyDay = strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+func parseCronField(field string, spec cronField) ([]int, error) {
+	var result []int
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q in %s field", stepPart, spec.name)
+			}
+		}
+
+		start, end := spec.min, spec.max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			start, err = parseCronValue(from, spec)
+			if err != nil {
+				return nil, err
+			}
+			end = start
+			if isRange {
+				end, err = parseCronValue(to, spec)
+				if err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				end = spec.max
+			}
+			if start > end {
+				return nil, fmt.Errorf("invalid range %q in %s field", rangePart, spec.name)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func parseCronValue(value string, spec cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < spec.min || v > spec.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, must be between %d and %d", value, spec.name, spec.min, spec.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that matches the schedule, in the location of t.
+// The zero time is returned if no such time exists within the next five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !s.months[t.Month()] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	if s.anyDay {
+		return s.daysOfMonth[t.Day()] && s.daysOfWeek[t.Weekday()]
+	}
+	return s.daysOfMonth[t.Day()] || s.daysOfWeek[t.Weekday()]
+}
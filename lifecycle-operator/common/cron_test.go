@@ -0,0 +1,98 @@
+package operatorcommon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{name: "every minute", expression: "* * * * *"},
+		{name: "weekends", expression: "0 18 * * 5"},
+		{name: "lists ranges and steps", expression: "*/15 8-18/2 1,15 1-12 1-5"},
+		{name: "sunday as seven", expression: "0 0 * * 7"},
+		{name: "too few fields", expression: "0 18 * *", wantErr: true},
+		{name: "value out of range", expression: "60 * * * *", wantErr: true},
+		{name: "invalid step", expression: "*/0 * * * *", wantErr: true},
+		{name: "inverted range", expression: "* 18-8 * * *", wantErr: true},
+		{name: "not a number", expression: "* * * dec *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCronSchedule(tt.expression)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.Nil(t, err)
+
+	tests := []struct {
+		name       string
+		expression string
+		from       time.Time
+		want       time.Time
+	}{
+		{
+			name:       "every minute",
+			expression: "* * * * *",
+			from:       time.Date(2024, 3, 1, 12, 0, 30, 0, time.UTC),
+			want:       time.Date(2024, 3, 1, 12, 1, 0, 0, time.UTC),
+		},
+		{
+			name:       "friday evening",
+			expression: "0 18 * * 5",
+			from:       time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, 3, 8, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "holiday season",
+			expression: "0 0 20 12 *",
+			from:       time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "day of month or day of week",
+			expression: "0 0 15 * 1",
+			from:       time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "steps",
+			expression: "*/20 * * * *",
+			from:       time.Date(2024, 3, 1, 12, 41, 0, 0, time.UTC),
+			want:       time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "time zone",
+			expression: "0 18 * * 5",
+			from:       time.Date(2024, 3, 8, 12, 0, 0, 0, berlin),
+			want:       time.Date(2024, 3, 8, 18, 0, 0, 0, berlin),
+		},
+		{
+			name:       "no match",
+			expression: "0 0 31 2 *",
+			from:       time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+			want:       time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expression)
+			require.Nil(t, err)
+			require.True(t, tt.want.Equal(schedule.Next(tt.from)), "got %s", schedule.Next(tt.from))
+		})
+	}
+}
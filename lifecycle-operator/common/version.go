@@ -0,0 +1,54 @@
+package operatorcommon
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var ErrInvalidImageVersion = errors.New("Invalid image version")
+var ErrContainerNotFound = errors.New("container not found")
+
+// GetImageVersion returns the tag of the given container image.
+// Images without a tag or with the 'latest' tag do not have a version.
+func GetImageVersion(image string) (string, error) {
+	splitImage := strings.Split(image, ":")
+	lenImg := len(splitImage) - 1
+	if lenImg >= 1 && splitImage[lenImg] != "" && splitImage[lenImg] != "latest" {
+		return splitImage[lenImg], nil
+	}
+	return "", ErrInvalidImageVersion
+}
+
+// CalculateVersion derives the version of a workload from the given pod spec.
+// If a container name is given, the image tag of that container is used as the version.
+// Otherwise, or if the image does not have a version, the version is a hash of the names, images and
+// environment variables of all containers.
+func CalculateVersion(spec corev1.PodSpec, containerName string) (string, error) {
+	name := ""
+	containerFound := false
+	for _, item := range spec.Containers {
+		if item.Name == containerName {
+			containerFound = true
+			version, err := GetImageVersion(item.Image)
+			if err == nil {
+				return version, nil
+			}
+		}
+		name = name + item.Name + item.Image
+		for _, e := range item.Env {
+			name = name + e.Name + e.Value
+		}
+	}
+
+	if containerName != "" && !containerFound {
+		return "", fmt.Errorf("%w: %s", ErrContainerNotFound, containerName)
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprint(h.Sum32()), nil
+}
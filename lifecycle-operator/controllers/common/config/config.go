@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,20 +16,26 @@ type IConfig interface {
 	GetCreationRequestTimeout() time.Duration
 	SetCloudEventsEndpoint(endpoint string)
 	GetCloudEventsEndpoint() string
+	SetCloudEventsSinks(sinks []optionsv1alpha1.CloudEventsSink)
+	GetCloudEventsSinks() []optionsv1alpha1.CloudEventsSink
 	SetDefaultNamespace(namespace string)
 	GetDefaultNamespace() string
 	SetBlockDeployment(value bool)
 	GetBlockDeployment() bool
 	SetObservabilityTimeout(timeout metav1.Duration)
 	GetObservabilityTimeout() metav1.Duration
+	SetRetention(retention *optionsv1alpha1.RetentionSpec)
+	GetRetention() *optionsv1alpha1.RetentionSpec
 }
 
 type ControllerConfig struct {
 	keptnAppCreationRequestTimeout time.Duration
 	cloudEventsEndpoint            string
+	cloudEventsSinks               []optionsv1alpha1.CloudEventsSink
 	defaultNamespace               string
 	blockDeployment                bool
 	observabilityTimeout           metav1.Duration
+	retention                      *optionsv1alpha1.RetentionSpec
 }
 
 var instance *ControllerConfig
@@ -63,6 +70,14 @@ func (o *ControllerConfig) GetCloudEventsEndpoint() string {
 	return o.cloudEventsEndpoint
 }
 
+func (o *ControllerConfig) SetCloudEventsSinks(sinks []optionsv1alpha1.CloudEventsSink) {
+	o.cloudEventsSinks = sinks
+}
+
+func (o *ControllerConfig) GetCloudEventsSinks() []optionsv1alpha1.CloudEventsSink {
+	return o.cloudEventsSinks
+}
+
 func (o *ControllerConfig) SetDefaultNamespace(ns string) {
 	o.defaultNamespace = ns
 }
@@ -86,3 +101,11 @@ func (o *ControllerConfig) SetObservabilityTimeout(timeout metav1.Duration) {
 func (o *ControllerConfig) GetObservabilityTimeout() metav1.Duration {
 	return o.observabilityTimeout
 }
+
+func (o *ControllerConfig) SetRetention(retention *optionsv1alpha1.RetentionSpec) {
+	o.retention = retention
+}
+
+func (o *ControllerConfig) GetRetention() *optionsv1alpha1.RetentionSpec {
+	return o.retention
+}
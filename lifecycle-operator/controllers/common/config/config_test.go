@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -57,6 +58,19 @@ func TestConfig_SetAndGetCloudEventEndpoint(t *testing.T) {
 	require.Equal(t, "mytestendpoint", i.GetCloudEventsEndpoint())
 }
 
+func TestConfig_SetAndGetCloudEventsSinks(t *testing.T) {
+	i := Instance()
+
+	require.Empty(t, i.GetCloudEventsSinks())
+
+	sinks := []optionsv1alpha1.CloudEventsSink{
+		{Name: "my-sink", Endpoint: "http://my-sink"},
+	}
+	i.SetCloudEventsSinks(sinks)
+
+	require.Equal(t, sinks, i.GetCloudEventsSinks())
+}
+
 func TestConfig_SetAndGetBlockDeployment(t *testing.T) {
 	i := Instance()
 
@@ -81,3 +95,16 @@ func TestConfig_SetAndGetObservabilityTimeout(t *testing.T) {
 		Duration: time.Duration(10 * time.Minute),
 	}, i.GetObservabilityTimeout())
 }
+
+func TestConfig_SetAndGetRetention(t *testing.T) {
+	i := Instance()
+
+	require.Nil(t, i.GetRetention())
+
+	retention := &optionsv1alpha1.RetentionSpec{
+		Interval: metav1.Duration{Duration: time.Hour},
+	}
+	i.SetRetention(retention)
+
+	require.Equal(t, retention, i.GetRetention())
+}
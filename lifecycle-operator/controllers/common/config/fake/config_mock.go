@@ -4,6 +4,7 @@
 package fake
 
 import (
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sync"
 	"time"
@@ -21,6 +22,9 @@ import (
 //			GetCloudEventsEndpointFunc: func() string {
 //				panic("mock out the GetCloudEventsEndpoint method")
 //			},
+//			GetCloudEventsSinksFunc: func() []v1alpha1.CloudEventsSink {
+//				panic("mock out the GetCloudEventsSinks method")
+//			},
 //			GetCreationRequestTimeoutFunc: func() time.Duration {
 //				panic("mock out the GetCreationRequestTimeout method")
 //			},
@@ -30,12 +34,18 @@ import (
 //			GetObservabilityTimeoutFunc: func() metav1.Duration {
 //				panic("mock out the GetObservabilityTimeout method")
 //			},
+//			GetRetentionFunc: func() *v1alpha1.RetentionSpec {
+//				panic("mock out the GetRetention method")
+//			},
 //			SetBlockDeploymentFunc: func(value bool)  {
 //				panic("mock out the SetBlockDeployment method")
 //			},
 //			SetCloudEventsEndpointFunc: func(endpoint string)  {
 //				panic("mock out the SetCloudEventsEndpoint method")
 //			},
+//			SetCloudEventsSinksFunc: func(sinks []v1alpha1.CloudEventsSink)  {
+//				panic("mock out the SetCloudEventsSinks method")
+//			},
 //			SetCreationRequestTimeoutFunc: func(value time.Duration)  {
 //				panic("mock out the SetCreationRequestTimeout method")
 //			},
@@ -45,6 +55,9 @@ import (
 //			SetObservabilityTimeoutFunc: func(timeout metav1.Duration)  {
 //				panic("mock out the SetObservabilityTimeout method")
 //			},
+//			SetRetentionFunc: func(retention *v1alpha1.RetentionSpec)  {
+//				panic("mock out the SetRetention method")
+//			},
 //		}
 //
 //		// use mockedIConfig in code that requires config.IConfig
@@ -58,6 +71,9 @@ type MockConfig struct {
 	// GetCloudEventsEndpointFunc mocks the GetCloudEventsEndpoint method.
 	GetCloudEventsEndpointFunc func() string
 
+	// GetCloudEventsSinksFunc mocks the GetCloudEventsSinks method.
+	GetCloudEventsSinksFunc func() []v1alpha1.CloudEventsSink
+
 	// GetCreationRequestTimeoutFunc mocks the GetCreationRequestTimeout method.
 	GetCreationRequestTimeoutFunc func() time.Duration
 
@@ -67,12 +83,18 @@ type MockConfig struct {
 	// GetObservabilityTimeoutFunc mocks the GetObservabilityTimeout method.
 	GetObservabilityTimeoutFunc func() metav1.Duration
 
+	// GetRetentionFunc mocks the GetRetention method.
+	GetRetentionFunc func() *v1alpha1.RetentionSpec
+
 	// SetBlockDeploymentFunc mocks the SetBlockDeployment method.
 	SetBlockDeploymentFunc func(value bool)
 
 	// SetCloudEventsEndpointFunc mocks the SetCloudEventsEndpoint method.
 	SetCloudEventsEndpointFunc func(endpoint string)
 
+	// SetCloudEventsSinksFunc mocks the SetCloudEventsSinks method.
+	SetCloudEventsSinksFunc func(sinks []v1alpha1.CloudEventsSink)
+
 	// SetCreationRequestTimeoutFunc mocks the SetCreationRequestTimeout method.
 	SetCreationRequestTimeoutFunc func(value time.Duration)
 
@@ -82,6 +104,9 @@ type MockConfig struct {
 	// SetObservabilityTimeoutFunc mocks the SetObservabilityTimeout method.
 	SetObservabilityTimeoutFunc func(timeout metav1.Duration)
 
+	// SetRetentionFunc mocks the SetRetention method.
+	SetRetentionFunc func(retention *v1alpha1.RetentionSpec)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetBlockDeployment holds details about calls to the GetBlockDeployment method.
@@ -90,6 +115,9 @@ type MockConfig struct {
 		// GetCloudEventsEndpoint holds details about calls to the GetCloudEventsEndpoint method.
 		GetCloudEventsEndpoint []struct {
 		}
+		// GetCloudEventsSinks holds details about calls to the GetCloudEventsSinks method.
+		GetCloudEventsSinks []struct {
+		}
 		// GetCreationRequestTimeout holds details about calls to the GetCreationRequestTimeout method.
 		GetCreationRequestTimeout []struct {
 		}
@@ -99,6 +127,9 @@ type MockConfig struct {
 		// GetObservabilityTimeout holds details about calls to the GetObservabilityTimeout method.
 		GetObservabilityTimeout []struct {
 		}
+		// GetRetention holds details about calls to the GetRetention method.
+		GetRetention []struct {
+		}
 		// SetBlockDeployment holds details about calls to the SetBlockDeployment method.
 		SetBlockDeployment []struct {
 			// Value is the value argument value.
@@ -109,6 +140,11 @@ type MockConfig struct {
 			// Endpoint is the endpoint argument value.
 			Endpoint string
 		}
+		// SetCloudEventsSinks holds details about calls to the SetCloudEventsSinks method.
+		SetCloudEventsSinks []struct {
+			// Sinks is the sinks argument value.
+			Sinks []v1alpha1.CloudEventsSink
+		}
 		// SetCreationRequestTimeout holds details about calls to the SetCreationRequestTimeout method.
 		SetCreationRequestTimeout []struct {
 			// Value is the value argument value.
@@ -124,17 +160,26 @@ type MockConfig struct {
 			// Timeout is the timeout argument value.
 			Timeout metav1.Duration
 		}
+		// SetRetention holds details about calls to the SetRetention method.
+		SetRetention []struct {
+			// Retention is the retention argument value.
+			Retention *v1alpha1.RetentionSpec
+		}
 	}
 	lockGetBlockDeployment        sync.RWMutex
 	lockGetCloudEventsEndpoint    sync.RWMutex
+	lockGetCloudEventsSinks       sync.RWMutex
 	lockGetCreationRequestTimeout sync.RWMutex
 	lockGetDefaultNamespace       sync.RWMutex
 	lockGetObservabilityTimeout   sync.RWMutex
+	lockGetRetention              sync.RWMutex
 	lockSetBlockDeployment        sync.RWMutex
 	lockSetCloudEventsEndpoint    sync.RWMutex
+	lockSetCloudEventsSinks       sync.RWMutex
 	lockSetCreationRequestTimeout sync.RWMutex
 	lockSetDefaultNamespace       sync.RWMutex
 	lockSetObservabilityTimeout   sync.RWMutex
+	lockSetRetention              sync.RWMutex
 }
 
 // GetBlockDeployment calls GetBlockDeploymentFunc.
@@ -191,6 +236,33 @@ func (mock *MockConfig) GetCloudEventsEndpointCalls() []struct {
 	return calls
 }
 
+// GetCloudEventsSinks calls GetCloudEventsSinksFunc.
+func (mock *MockConfig) GetCloudEventsSinks() []v1alpha1.CloudEventsSink {
+	if mock.GetCloudEventsSinksFunc == nil {
+		panic("MockConfig.GetCloudEventsSinksFunc: method is nil but IConfig.GetCloudEventsSinks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCloudEventsSinks.Lock()
+	mock.calls.GetCloudEventsSinks = append(mock.calls.GetCloudEventsSinks, callInfo)
+	mock.lockGetCloudEventsSinks.Unlock()
+	return mock.GetCloudEventsSinksFunc()
+}
+
+// GetCloudEventsSinksCalls gets all the calls that were made to GetCloudEventsSinks.
+// Check the length with:
+//
+//	len(mockedIConfig.GetCloudEventsSinksCalls())
+func (mock *MockConfig) GetCloudEventsSinksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCloudEventsSinks.RLock()
+	calls = mock.calls.GetCloudEventsSinks
+	mock.lockGetCloudEventsSinks.RUnlock()
+	return calls
+}
+
 // GetCreationRequestTimeout calls GetCreationRequestTimeoutFunc.
 func (mock *MockConfig) GetCreationRequestTimeout() time.Duration {
 	if mock.GetCreationRequestTimeoutFunc == nil {
@@ -272,6 +344,33 @@ func (mock *MockConfig) GetObservabilityTimeoutCalls() []struct {
 	return calls
 }
 
+// GetRetention calls GetRetentionFunc.
+func (mock *MockConfig) GetRetention() *v1alpha1.RetentionSpec {
+	if mock.GetRetentionFunc == nil {
+		panic("MockConfig.GetRetentionFunc: method is nil but IConfig.GetRetention was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetRetention.Lock()
+	mock.calls.GetRetention = append(mock.calls.GetRetention, callInfo)
+	mock.lockGetRetention.Unlock()
+	return mock.GetRetentionFunc()
+}
+
+// GetRetentionCalls gets all the calls that were made to GetRetention.
+// Check the length with:
+//
+//	len(mockedIConfig.GetRetentionCalls())
+func (mock *MockConfig) GetRetentionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRetention.RLock()
+	calls = mock.calls.GetRetention
+	mock.lockGetRetention.RUnlock()
+	return calls
+}
+
 // SetBlockDeployment calls SetBlockDeploymentFunc.
 func (mock *MockConfig) SetBlockDeployment(value bool) {
 	if mock.SetBlockDeploymentFunc == nil {
@@ -336,6 +435,38 @@ func (mock *MockConfig) SetCloudEventsEndpointCalls() []struct {
 	return calls
 }
 
+// SetCloudEventsSinks calls SetCloudEventsSinksFunc.
+func (mock *MockConfig) SetCloudEventsSinks(sinks []v1alpha1.CloudEventsSink) {
+	if mock.SetCloudEventsSinksFunc == nil {
+		panic("MockConfig.SetCloudEventsSinksFunc: method is nil but IConfig.SetCloudEventsSinks was just called")
+	}
+	callInfo := struct {
+		Sinks []v1alpha1.CloudEventsSink
+	}{
+		Sinks: sinks,
+	}
+	mock.lockSetCloudEventsSinks.Lock()
+	mock.calls.SetCloudEventsSinks = append(mock.calls.SetCloudEventsSinks, callInfo)
+	mock.lockSetCloudEventsSinks.Unlock()
+	mock.SetCloudEventsSinksFunc(sinks)
+}
+
+// SetCloudEventsSinksCalls gets all the calls that were made to SetCloudEventsSinks.
+// Check the length with:
+//
+//	len(mockedIConfig.SetCloudEventsSinksCalls())
+func (mock *MockConfig) SetCloudEventsSinksCalls() []struct {
+	Sinks []v1alpha1.CloudEventsSink
+} {
+	var calls []struct {
+		Sinks []v1alpha1.CloudEventsSink
+	}
+	mock.lockSetCloudEventsSinks.RLock()
+	calls = mock.calls.SetCloudEventsSinks
+	mock.lockSetCloudEventsSinks.RUnlock()
+	return calls
+}
+
 // SetCreationRequestTimeout calls SetCreationRequestTimeoutFunc.
 func (mock *MockConfig) SetCreationRequestTimeout(value time.Duration) {
 	if mock.SetCreationRequestTimeoutFunc == nil {
@@ -431,3 +562,35 @@ func (mock *MockConfig) SetObservabilityTimeoutCalls() []struct {
 	mock.lockSetObservabilityTimeout.RUnlock()
 	return calls
 }
+
+// SetRetention calls SetRetentionFunc.
+func (mock *MockConfig) SetRetention(retention *v1alpha1.RetentionSpec) {
+	if mock.SetRetentionFunc == nil {
+		panic("MockConfig.SetRetentionFunc: method is nil but IConfig.SetRetention was just called")
+	}
+	callInfo := struct {
+		Retention *v1alpha1.RetentionSpec
+	}{
+		Retention: retention,
+	}
+	mock.lockSetRetention.Lock()
+	mock.calls.SetRetention = append(mock.calls.SetRetention, callInfo)
+	mock.lockSetRetention.Unlock()
+	mock.SetRetentionFunc(retention)
+}
+
+// SetRetentionCalls gets all the calls that were made to SetRetention.
+// Check the length with:
+//
+//	len(mockedIConfig.SetRetentionCalls())
+func (mock *MockConfig) SetRetentionCalls() []struct {
+	Retention *v1alpha1.RetentionSpec
+} {
+	var calls []struct {
+		Retention *v1alpha1.RetentionSpec
+	}
+	mock.lockSetRetention.RLock()
+	calls = mock.calls.SetRetention
+	mock.lockSetRetention.RUnlock()
+	return calls
+}
@@ -0,0 +1,227 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	keptncontext "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/context"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	analysisAPIVersion = "metrics.keptn.sh/v1beta1"
+	analysisKind       = "Analysis"
+	// analysisStateCompleted is the state of an Analysis whose objectives have been evaluated
+	analysisStateCompleted = "Completed"
+)
+
+// ReconcileAnalyses creates an Analysis for each AnalysisDefinition referenced by the pre- or post-deployment analyses
+// of the reconciled object and maps the result of each Analysis onto the status of the respective item.
+// An Analysis that passes is Succeeded, an Analysis with a warning is marked as Warning and does not block
+// the deployment, and every other completed Analysis is Failed.
+//
+//nolint:gocognit
+func (r Handler) ReconcileAnalyses(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(reconcileObject)
+	if err != nil {
+		return nil, apicommon.StatusSummary{}, err
+	}
+
+	analyses, statuses := r.setupAnalyses(checkType, piWrapper)
+
+	var summary apicommon.StatusSummary
+	summary.Total = len(analyses)
+	var newStatus []klcv1beta1.ItemStatus
+	for _, definitionName := range analyses {
+		analysisStatus := common.GetItemStatus(definitionName, statuses)
+
+		// Check if the analysis has already succeeded, failed or has been skipped
+		if analysisStatus.Status.IsCompleted() {
+			newStatus = append(newStatus, analysisStatus)
+			continue
+		}
+
+		// Skip the analysis if its execution condition does not hold
+		if analysisStatus.Name == "" && !r.shouldExecute(phaseCtx, piWrapper, reconcileObject, definitionName, checkType, &analysisStatus) {
+			newStatus = append(newStatus, analysisStatus)
+			continue
+		}
+
+		analysis := newAnalysis()
+		if analysisStatus.Name != "" {
+			err := r.Client.Get(ctx, types.NamespacedName{Name: analysisStatus.Name, Namespace: piWrapper.GetNamespace()}, analysis)
+			if errors.IsNotFound(err) {
+				analysisStatus.Name = ""
+			} else if err != nil {
+				return nil, summary, err
+			}
+		}
+
+		if analysisStatus.Name == "" {
+			if err := r.createAnalysis(ctx, phaseCtx, reconcileObject, piWrapper, definitionName, checkType, &analysisStatus); err != nil {
+				if errors.IsNotFound(err) {
+					r.Log.Info("AnalysisDefinition for Analysis not found",
+						"analysisDefinition", definitionName,
+						"namespace", piWrapper.GetNamespace(),
+					)
+				} else {
+					// log the error, but continue to proceed with other analyses that may be created
+					r.Log.Error(err, "Could not create Analysis",
+						"analysisDefinition", definitionName,
+						"namespace", piWrapper.GetNamespace(),
+					)
+				}
+				continue
+			}
+		} else {
+			r.handleAnalysisExists(reconcileObject, piWrapper, analysis, &analysisStatus)
+		}
+		newStatus = append(newStatus, analysisStatus)
+	}
+
+	for _, ns := range newStatus {
+		summary = apicommon.UpdateCheckStatusSummary(ns.Status, summary)
+	}
+
+	return newStatus, summary, nil
+}
+
+func (r Handler) setupAnalyses(checkType apicommon.CheckType, piWrapper *interfaces.PhaseItemWrapper) ([]string, []klcv1beta1.ItemStatus) {
+	switch checkType {
+	case apicommon.PreDeploymentEvaluationCheckType:
+		return piWrapper.GetPreDeploymentAnalyses(), piWrapper.GetPreDeploymentAnalysisStatus()
+	case apicommon.PostDeploymentEvaluationCheckType:
+		return piWrapper.GetPostDeploymentAnalyses(), piWrapper.GetPostDeploymentAnalysisStatus()
+	}
+	return nil, nil
+}
+
+func (r Handler) createAnalysis(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, piWrapper *interfaces.PhaseItemWrapper, definitionName string, checkType apicommon.CheckType, analysisStatus *klcv1beta1.ItemStatus) error {
+	definition, err := common.GetAnalysisDefinition(r.Client, r.Log, ctx, definitionName, piWrapper.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	analysis, err := generateAnalysis(phaseCtx, piWrapper, definition, checkType, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(reconcileObject, analysis, r.Scheme); err != nil {
+		r.Log.Error(err, "could not set controller reference:")
+	}
+	if err := r.Client.Create(ctx, analysis); err != nil {
+		r.EventSender.Emit(apicommon.PhaseCreateEvaluation, "Warning", reconcileObject, apicommon.PhaseStateFailed, "could not create Analysis", piWrapper.GetVersion())
+		return err
+	}
+
+	analysisStatus.Name = analysis.GetName()
+	analysisStatus.SetStartTime()
+	return nil
+}
+
+func (r Handler) handleAnalysisExists(reconcileObject client.Object, piWrapper *interfaces.PhaseItemWrapper, analysis *unstructured.Unstructured, analysisStatus *klcv1beta1.ItemStatus) {
+	analysisStatus.Status = getAnalysisState(analysis)
+	if !analysisStatus.Status.IsCompleted() {
+		return
+	}
+	if analysisStatus.Status.IsFailed() {
+		r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", reconcileObject, apicommon.PhaseStateFailed, fmt.Sprintf("analysis %s of AnalysisDefinition %s failed", analysis.GetName(), analysisStatus.DefinitionName), piWrapper.GetVersion())
+	} else if analysisStatus.Status.IsWarning() {
+		r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", reconcileObject, apicommon.PhaseStateFinished, fmt.Sprintf("analysis %s of AnalysisDefinition %s finished with a warning", analysis.GetName(), analysisStatus.DefinitionName), piWrapper.GetVersion())
+	}
+	analysisStatus.SetEndTime()
+}
+
+// generateAnalysis creates an Analysis for the given AnalysisDefinition. The timeframe of the Analysis starts when the
+// pre- or post-deployment phase of the reconciled object has started and ends now. The args of the Analysis contain
+// the metadata of the KeptnApp or KeptnWorkload, as well as the name, version and namespace of the reconciled object.
+func generateAnalysis(phaseCtx context.Context, piWrapper *interfaces.PhaseItemWrapper, definition *unstructured.Unstructured, checkType apicommon.CheckType, now time.Time) (*unstructured.Unstructured, error) {
+	from := getPhaseStartTime(piWrapper, checkType)
+	if from.IsZero() || !from.Before(now) {
+		from = now.Add(-time.Minute)
+	}
+
+	analysis := newAnalysis()
+	analysis.SetName(apicommon.GenerateEvaluationName(checkType, definition.GetName()))
+	analysis.SetNamespace(piWrapper.GetNamespace())
+	if err := unstructured.SetNestedStringMap(analysis.Object, map[string]string{
+		"name":      definition.GetName(),
+		"namespace": definition.GetNamespace(),
+	}, "spec", "analysisDefinition"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedStringMap(analysis.Object, map[string]string{
+		"from": from.UTC().Format(time.RFC3339),
+		"to":   now.UTC().Format(time.RFC3339),
+	}, "spec", "timeframe"); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedStringMap(analysis.Object, getAnalysisArgs(phaseCtx, piWrapper), "spec", "args"); err != nil {
+		return nil, err
+	}
+	return analysis, nil
+}
+
+// getPhaseStartTime returns the time the pre- or post-deployment phase of the reconciled object has started.
+// The pre-deployment phase starts with the lifecycle of the reconciled object. The post-deployment phase starts
+// with its first task, or with its first evaluation or analysis if it has no tasks.
+func getPhaseStartTime(piWrapper *interfaces.PhaseItemWrapper, checkType apicommon.CheckType) time.Time {
+	if checkType == apicommon.PreDeploymentEvaluationCheckType {
+		return piWrapper.GetStartTime()
+	}
+	if start := getEarliestStartTime(piWrapper.GetPostDeploymentTaskStatus()); !start.IsZero() {
+		return start
+	}
+	return getEarliestStartTime(append(piWrapper.GetPostDeploymentEvaluationTaskStatus(), piWrapper.GetPostDeploymentAnalysisStatus()...))
+}
+
+func getEarliestStartTime(statuses []klcv1beta1.ItemStatus) time.Time {
+	var start time.Time
+	for _, status := range statuses {
+		if !status.StartTime.IsZero() && (start.IsZero() || status.StartTime.Time.Before(start)) {
+			start = status.StartTime.Time
+		}
+	}
+	return start
+}
+
+func getAnalysisArgs(phaseCtx context.Context, piWrapper *interfaces.PhaseItemWrapper) map[string]string {
+	metadata, _ := keptncontext.GetAppMetadataFromContext(phaseCtx)
+	return common.MergeMaps(metadata, map[string]string{
+		"appName":   piWrapper.GetAppName(),
+		"name":      piWrapper.GetParentName(),
+		"version":   piWrapper.GetVersion(),
+		"namespace": piWrapper.GetNamespace(),
+	})
+}
+
+// getAnalysisState maps the status of an Analysis onto a KeptnState
+func getAnalysisState(analysis *unstructured.Unstructured) apicommon.KeptnState {
+	state, _, _ := unstructured.NestedString(analysis.Object, "status", "state")
+	if state != analysisStateCompleted {
+		return apicommon.StateProgressing
+	}
+	if pass, _, _ := unstructured.NestedBool(analysis.Object, "status", "pass"); pass {
+		return apicommon.StateSucceeded
+	}
+	if warning, _, _ := unstructured.NestedBool(analysis.Object, "status", "warning"); warning {
+		return apicommon.StateWarning
+	}
+	return apicommon.StateFailed
+}
+
+func newAnalysis() *unstructured.Unstructured {
+	analysis := &unstructured.Unstructured{}
+	analysis.SetAPIVersion(analysisAPIVersion)
+	analysis.SetKind(analysisKind)
+	return analysis
+}
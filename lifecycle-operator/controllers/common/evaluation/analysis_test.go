@@ -0,0 +1,288 @@
+package evaluation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	keptncontext "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/context"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	telemetryfake "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry/fake"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestAnalysisDefinition(name string, namespace string) *unstructured.Unstructured {
+	definition := &unstructured.Unstructured{}
+	definition.SetAPIVersion("metrics.keptn.sh/v1beta1")
+	definition.SetKind("AnalysisDefinition")
+	definition.SetName(name)
+	definition.SetNamespace(namespace)
+	return definition
+}
+
+func newTestAnalysis(name string, state string, pass bool, warning bool) *unstructured.Unstructured {
+	analysis := newAnalysis()
+	analysis.SetName(name)
+	analysis.SetNamespace("namespace")
+	analysis.Object["status"] = map[string]interface{}{
+		"state":   state,
+		"pass":    pass,
+		"warning": warning,
+	}
+	return analysis
+}
+
+func newTestAnalysisAppVersion(status []v1beta1.ItemStatus) *v1beta1.KeptnAppVersion {
+	return &v1beta1.KeptnAppVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-app-1.0.0",
+			Namespace: "namespace",
+		},
+		Spec: v1beta1.KeptnAppVersionSpec{
+			KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+				DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+					PostDeploymentAnalyses: []string{"my-analysis-def"},
+				},
+			},
+			KeptnAppSpec: v1beta1.KeptnAppSpec{
+				Version: "1.0.0",
+			},
+			AppName: "my-app",
+		},
+		Status: v1beta1.KeptnAppVersionStatus{
+			PostDeploymentAnalysisStatus: status,
+			StartTime:                    v1.NewTime(time.Now().UTC().Add(-5 * time.Minute)),
+		},
+	}
+}
+
+func TestEvaluationHandler_ReconcileAnalyses(t *testing.T) {
+	tests := []struct {
+		name        string
+		object      client.Object
+		objects     []client.Object
+		wantStatus  []v1beta1.ItemStatus
+		wantSummary apicommon.StatusSummary
+		wantErr     error
+		events      []string
+	}{
+		{
+			name:    "cannot unwrap object",
+			object:  &v1beta1.KeptnEvaluation{},
+			wantErr: controllererrors.ErrCannotWrapToPhaseItem,
+		},
+		{
+			name:        "no analyses",
+			object:      &v1beta1.KeptnAppVersion{},
+			wantSummary: apicommon.StatusSummary{},
+		},
+		{
+			name:        "analysis not started - could not find AnalysisDefinition",
+			object:      newTestAnalysisAppVersion(nil),
+			wantStatus:  nil,
+			wantSummary: apicommon.StatusSummary{Total: 1},
+		},
+		{
+			name:        "analysis already finished",
+			object:      newTestAnalysisAppVersion([]v1beta1.ItemStatus{{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateSucceeded}}),
+			wantStatus:  []v1beta1.ItemStatus{{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateSucceeded}},
+			wantSummary: apicommon.StatusSummary{Total: 1, Succeeded: 1},
+		},
+		{
+			name:    "analysis in progress",
+			object:  newTestAnalysisAppVersion([]v1beta1.ItemStatus{{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StatePending}}),
+			objects: []client.Object{newTestAnalysis("my-analysis", "Progressing", false, false)},
+			wantStatus: []v1beta1.ItemStatus{
+				{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateProgressing},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Progressing: 1},
+		},
+		{
+			name:    "analysis passed",
+			object:  newTestAnalysisAppVersion([]v1beta1.ItemStatus{{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateProgressing}}),
+			objects: []client.Object{newTestAnalysis("my-analysis", "Completed", true, false)},
+			wantStatus: []v1beta1.ItemStatus{
+				{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateSucceeded},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Succeeded: 1},
+		},
+		{
+			name:    "analysis finished with warning",
+			object:  newTestAnalysisAppVersion([]v1beta1.ItemStatus{{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateProgressing}}),
+			objects: []client.Object{newTestAnalysis("my-analysis", "Completed", false, true)},
+			wantStatus: []v1beta1.ItemStatus{
+				{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateWarning},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Succeeded: 1},
+			events:      []string{"finished with a warning"},
+		},
+		{
+			name:    "analysis failed",
+			object:  newTestAnalysisAppVersion([]v1beta1.ItemStatus{{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateProgressing}}),
+			objects: []client.Object{newTestAnalysis("my-analysis", "Completed", false, false)},
+			wantStatus: []v1beta1.ItemStatus{
+				{DefinitionName: "my-analysis-def", Name: "my-analysis", Status: apicommon.StateFailed},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Failed: 1},
+			events:      []string{"analysis my-analysis of AnalysisDefinition my-analysis-def failed"},
+		},
+		{
+			name: "execution condition does not hold",
+			object: func() client.Object {
+				appVersion := newTestAnalysisAppVersion(nil)
+				appVersion.Spec.ExecutionConditions = []v1beta1.ExecutionCondition{{Name: "my-analysis-def", When: "previousVersion != ''"}}
+				return appVersion
+			}(),
+			wantStatus: []v1beta1.ItemStatus{
+				{DefinitionName: "my-analysis-def", Status: apicommon.StateSkipped},
+			},
+			wantSummary: apicommon.StatusSummary{Total: 1, Skipped: 1},
+		},
+	}
+
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeRecorder := record.NewFakeRecorder(100)
+			handler := NewHandler(
+				testcommon.NewTestClient(append(tt.objects, tt.object)...),
+				eventsender.NewK8sSender(fakeRecorder),
+				ctrl.Log.WithName("controller"),
+				noop.NewTracerProvider().Tracer("tracer"),
+				scheme.Scheme,
+				&telemetryfake.ISpanHandlerMock{})
+
+			status, summary, err := handler.ReconcileAnalyses(context.TODO(), context.TODO(), tt.object, apicommon.PostDeploymentEvaluationCheckType)
+			require.Len(t, status, len(tt.wantStatus))
+			for i := range status {
+				require.Equal(t, tt.wantStatus[i].DefinitionName, status[i].DefinitionName)
+				require.Equal(t, tt.wantStatus[i].Name, status[i].Name)
+				require.Equal(t, tt.wantStatus[i].Status, status[i].Status)
+			}
+			require.Equal(t, tt.wantSummary, summary)
+			require.Equal(t, tt.wantErr, err)
+
+			if tt.events != nil {
+				for _, e := range tt.events {
+					event := <-fakeRecorder.Events
+					require.Contains(t, event, e)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluationHandler_ReconcileAnalyses_CreateAnalysis(t *testing.T) {
+	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
+	appVersion := newTestAnalysisAppVersion(nil)
+	fakeClient := testcommon.NewTestClient(appVersion, newTestAnalysisDefinition("my-analysis-def", testcommon.KeptnNamespace))
+	handler := NewHandler(
+		fakeClient,
+		eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		ctrl.Log.WithName("controller"),
+		noop.NewTracerProvider().Tracer("tracer"),
+		scheme.Scheme,
+		&telemetryfake.ISpanHandlerMock{})
+
+	phaseCtx := keptncontext.WithAppMetadata(context.TODO(), map[string]string{"stage": "prod"})
+	status, summary, err := handler.ReconcileAnalyses(context.TODO(), phaseCtx, appVersion, apicommon.PostDeploymentEvaluationCheckType)
+
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StatusSummary{Total: 1, Pending: 1}, summary)
+	require.Len(t, status, 1)
+	require.Equal(t, "my-analysis-def", status[0].DefinitionName)
+	require.True(t, strings.HasPrefix(status[0].Name, "post-eval-my-analysis-def-"))
+	require.False(t, status[0].StartTime.IsZero())
+
+	analysis := newAnalysis()
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: status[0].Name, Namespace: "namespace"}, analysis)
+	require.Nil(t, err)
+
+	definitionRef, _, _ := unstructured.NestedStringMap(analysis.Object, "spec", "analysisDefinition")
+	require.Equal(t, map[string]string{"name": "my-analysis-def", "namespace": testcommon.KeptnNamespace}, definitionRef)
+	args, _, _ := unstructured.NestedStringMap(analysis.Object, "spec", "args")
+	require.Equal(t, map[string]string{
+		"stage":     "prod",
+		"appName":   "my-app",
+		"name":      "my-app",
+		"version":   "1.0.0",
+		"namespace": "namespace",
+	}, args)
+	require.Len(t, analysis.GetOwnerReferences(), 1)
+	require.Equal(t, "my-app-1.0.0", analysis.GetOwnerReferences()[0].Name)
+}
+
+func Test_generateAnalysis(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	appVersion := newTestAnalysisAppVersion(nil)
+	appVersion.Status.StartTime = v1.NewTime(now.Add(-10 * time.Minute))
+
+	analysis, err := generateAnalysis(context.TODO(), &interfaces.PhaseItemWrapper{Obj: appVersion}, newTestAnalysisDefinition("my-def", "namespace"), apicommon.PreDeploymentEvaluationCheckType, now)
+
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(analysis.GetName(), "pre-eval-my-def-"))
+	require.Equal(t, "namespace", analysis.GetNamespace())
+	timeframe, _, _ := unstructured.NestedStringMap(analysis.Object, "spec", "timeframe")
+	require.Equal(t, map[string]string{"from": "2024-01-01T11:50:00Z", "to": "2024-01-01T12:00:00Z"}, timeframe)
+
+	// without a start time, the timeframe covers the last minute
+	appVersion.Status.StartTime = v1.Time{}
+	analysis, err = generateAnalysis(context.TODO(), &interfaces.PhaseItemWrapper{Obj: appVersion}, newTestAnalysisDefinition("my-def", "namespace"), apicommon.PreDeploymentEvaluationCheckType, now)
+
+	require.Nil(t, err)
+	timeframe, _, _ = unstructured.NestedStringMap(analysis.Object, "spec", "timeframe")
+	require.Equal(t, map[string]string{"from": "2024-01-01T11:59:00Z", "to": "2024-01-01T12:00:00Z"}, timeframe)
+}
+
+func Test_getPhaseStartTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	appVersion := newTestAnalysisAppVersion(nil)
+	appVersion.Status.StartTime = v1.NewTime(now.Add(-time.Hour))
+	piWrapper := &interfaces.PhaseItemWrapper{Obj: appVersion}
+
+	// the pre-deployment phase starts with the lifecycle of the KeptnAppVersion
+	require.Equal(t, now.Add(-time.Hour), getPhaseStartTime(piWrapper, apicommon.PreDeploymentEvaluationCheckType))
+
+	// the post-deployment phase has not started yet
+	require.True(t, getPhaseStartTime(piWrapper, apicommon.PostDeploymentEvaluationCheckType).IsZero())
+
+	// the post-deployment phase starts with its first evaluation if it has no tasks
+	appVersion.Status.PostDeploymentEvaluationTaskStatus = []v1beta1.ItemStatus{{StartTime: v1.NewTime(now.Add(-5 * time.Minute))}}
+	require.Equal(t, now.Add(-5*time.Minute), getPhaseStartTime(piWrapper, apicommon.PostDeploymentEvaluationCheckType))
+
+	// the post-deployment phase starts with its first task
+	appVersion.Status.PostDeploymentTaskStatus = []v1beta1.ItemStatus{
+		{StartTime: v1.NewTime(now.Add(-10 * time.Minute))},
+		{StartTime: v1.NewTime(now.Add(-20 * time.Minute))},
+	}
+	require.Equal(t, now.Add(-20*time.Minute), getPhaseStartTime(piWrapper, apicommon.PostDeploymentEvaluationCheckType))
+
+	analysis, err := generateAnalysis(context.TODO(), piWrapper, newTestAnalysisDefinition("my-def", "namespace"), apicommon.PostDeploymentEvaluationCheckType, now)
+	require.Nil(t, err)
+	timeframe, _, _ := unstructured.NestedStringMap(analysis.Object, "spec", "timeframe")
+	require.Equal(t, map[string]string{"from": "2024-01-01T11:40:00Z", "to": "2024-01-01T12:00:00Z"}, timeframe)
+}
+
+func Test_getAnalysisState(t *testing.T) {
+	require.Equal(t, apicommon.StateProgressing, getAnalysisState(newAnalysis()))
+	require.Equal(t, apicommon.StateProgressing, getAnalysisState(newTestAnalysis("a", "Progressing", false, false)))
+	require.Equal(t, apicommon.StateSucceeded, getAnalysisState(newTestAnalysis("a", "Completed", true, false)))
+	require.Equal(t, apicommon.StateWarning, getAnalysisState(newTestAnalysis("a", "Completed", false, true)))
+	require.Equal(t, apicommon.StateFailed, getAnalysisState(newTestAnalysis("a", "Completed", false, false)))
+}
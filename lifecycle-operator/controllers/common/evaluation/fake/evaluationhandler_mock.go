@@ -18,6 +18,9 @@ import (
 //
 //		// make and configure a mocked evaluation.IEvaluationHandler
 //		mockedIEvaluationHandler := &MockEvaluationHandler{
+//			ReconcileAnalysesFunc: func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
+//				panic("mock out the ReconcileAnalyses method")
+//			},
 //			ReconcileEvaluationsFunc: func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, evaluationCreateAttributes evaluation.CreateEvaluationAttributes) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
 //				panic("mock out the ReconcileEvaluations method")
 //			},
@@ -28,11 +31,25 @@ import (
 //
 //	}
 type MockEvaluationHandler struct {
+	// ReconcileAnalysesFunc mocks the ReconcileAnalyses method.
+	ReconcileAnalysesFunc func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error)
+
 	// ReconcileEvaluationsFunc mocks the ReconcileEvaluations method.
 	ReconcileEvaluationsFunc func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, evaluationCreateAttributes evaluation.CreateEvaluationAttributes) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ReconcileAnalyses holds details about calls to the ReconcileAnalyses method.
+		ReconcileAnalyses []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PhaseCtx is the phaseCtx argument value.
+			PhaseCtx context.Context
+			// ReconcileObject is the reconcileObject argument value.
+			ReconcileObject client.Object
+			// CheckType is the checkType argument value.
+			CheckType apicommon.CheckType
+		}
 		// ReconcileEvaluations holds details about calls to the ReconcileEvaluations method.
 		ReconcileEvaluations []struct {
 			// Ctx is the ctx argument value.
@@ -45,9 +62,54 @@ type MockEvaluationHandler struct {
 			EvaluationCreateAttributes evaluation.CreateEvaluationAttributes
 		}
 	}
+	lockReconcileAnalyses    sync.RWMutex
 	lockReconcileEvaluations sync.RWMutex
 }
 
+// ReconcileAnalyses calls ReconcileAnalysesFunc.
+func (mock *MockEvaluationHandler) ReconcileAnalyses(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
+	if mock.ReconcileAnalysesFunc == nil {
+		panic("MockEvaluationHandler.ReconcileAnalysesFunc: method is nil but IEvaluationHandler.ReconcileAnalyses was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		PhaseCtx        context.Context
+		ReconcileObject client.Object
+		CheckType       apicommon.CheckType
+	}{
+		Ctx:             ctx,
+		PhaseCtx:        phaseCtx,
+		ReconcileObject: reconcileObject,
+		CheckType:       checkType,
+	}
+	mock.lockReconcileAnalyses.Lock()
+	mock.calls.ReconcileAnalyses = append(mock.calls.ReconcileAnalyses, callInfo)
+	mock.lockReconcileAnalyses.Unlock()
+	return mock.ReconcileAnalysesFunc(ctx, phaseCtx, reconcileObject, checkType)
+}
+
+// ReconcileAnalysesCalls gets all the calls that were made to ReconcileAnalyses.
+// Check the length with:
+//
+//	len(mockedIEvaluationHandler.ReconcileAnalysesCalls())
+func (mock *MockEvaluationHandler) ReconcileAnalysesCalls() []struct {
+	Ctx             context.Context
+	PhaseCtx        context.Context
+	ReconcileObject client.Object
+	CheckType       apicommon.CheckType
+} {
+	var calls []struct {
+		Ctx             context.Context
+		PhaseCtx        context.Context
+		ReconcileObject client.Object
+		CheckType       apicommon.CheckType
+	}
+	mock.lockReconcileAnalyses.RLock()
+	calls = mock.calls.ReconcileAnalyses
+	mock.lockReconcileAnalyses.RUnlock()
+	return calls
+}
+
 // ReconcileEvaluations calls ReconcileEvaluationsFunc.
 func (mock *MockEvaluationHandler) ReconcileEvaluations(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, evaluationCreateAttributes evaluation.CreateEvaluationAttributes) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
 	if mock.ReconcileEvaluationsFunc == nil {
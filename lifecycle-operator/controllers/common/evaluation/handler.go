@@ -25,6 +25,7 @@ import (
 //go:generate moq -pkg fake -skip-ensure -out ./fake/evaluationhandler_mock.go . IEvaluationHandler:MockEvaluationHandler
 type IEvaluationHandler interface {
 	ReconcileEvaluations(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, evaluationCreateAttributes CreateEvaluationAttributes) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error)
+	ReconcileAnalyses(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error)
 }
 
 type Handler struct {
@@ -78,12 +79,18 @@ func (r Handler) ReconcileEvaluations(ctx context.Context, phaseCtx context.Cont
 			r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("evaluation status changed from %s to %s", oldstatus, evaluationStatus.Status), piWrapper.GetVersion())
 		}
 
-		// Check if evaluation has already succeeded or failed
+		// Check if evaluation has already succeeded, failed or has been skipped
 		if evaluationStatus.Status.IsCompleted() {
 			newStatus = append(newStatus, evaluationStatus)
 			continue
 		}
 
+		// Skip the evaluation if its execution condition does not hold
+		if evaluationStatus.Name == "" && !r.shouldExecute(phaseCtx, piWrapper, reconcileObject, evaluationName, evaluationCreateAttributes.CheckType, &evaluationStatus) {
+			newStatus = append(newStatus, evaluationStatus)
+			continue
+		}
+
 		// Check if Evaluation is already created
 		if evaluationStatus.Name != "" {
 			err := r.Client.Get(ctx, types.NamespacedName{Name: evaluationStatus.Name, Namespace: piWrapper.GetNamespace()}, evaluation)
@@ -137,7 +144,7 @@ func (r Handler) ReconcileEvaluations(ctx context.Context, phaseCtx context.Cont
 	}
 
 	for _, ns := range newStatus {
-		summary = apicommon.UpdateStatusSummary(ns.Status, summary)
+		summary = apicommon.UpdateCheckStatusSummary(ns.Status, summary)
 	}
 
 	return newStatus, summary, nil
@@ -179,6 +186,18 @@ func (r Handler) emitEvaluationFailureEvents(evaluation *klcv1beta1.KeptnEvaluat
 	r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", evaluation, apicommon.PhaseStateFailed, k8sEventMessage, piWrapper.GetVersion())
 }
 
+func (r Handler) emitEvaluationWarningEvents(evaluation *klcv1beta1.KeptnEvaluation, spanTrace trace.Span, piWrapper *interfaces.PhaseItemWrapper) {
+	k8sEventMessage := "evaluation finished with a warning"
+	for k, v := range evaluation.Status.EvaluationStatus {
+		if v.Status == apicommon.StateWarning {
+			msg := fmt.Sprintf("evaluation of '%s' finished with a warning with value: '%s' and reason: '%s'", k, v.Value, v.Message)
+			spanTrace.AddEvent(msg, trace.WithTimestamp(time.Now().UTC()))
+			k8sEventMessage = fmt.Sprintf("%s\n%s", k8sEventMessage, msg)
+		}
+	}
+	r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", evaluation, apicommon.PhaseStateFinished, k8sEventMessage, piWrapper.GetVersion())
+}
+
 func (r Handler) setupEvaluations(evaluationCreateAttributes CreateEvaluationAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]string, []klcv1beta1.ItemStatus) {
 	var evaluations []string
 	var statuses []klcv1beta1.ItemStatus
@@ -194,6 +213,29 @@ func (r Handler) setupEvaluations(evaluationCreateAttributes CreateEvaluationAtt
 	return evaluations, statuses
 }
 
+// shouldExecute evaluates the execution condition of the evaluation and marks the evaluation as Skipped if it does not hold,
+// or as Failed if the condition could not be evaluated
+func (r Handler) shouldExecute(phaseCtx context.Context, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, evaluationName string, checkType apicommon.CheckType, evaluationStatus *klcv1beta1.ItemStatus) bool {
+	execute, err := common.ShouldExecute(phaseCtx, piWrapper, evaluationName, checkType)
+	if err != nil {
+		r.Log.Error(err, "Could not evaluate execution condition of evaluation",
+			"evaluationDefinition", evaluationName,
+			"namespace", piWrapper.GetNamespace(),
+		)
+		r.EventSender.Emit(apicommon.PhaseReconcileEvaluation, "Warning", reconcileObject, apicommon.PhaseStateFailed, fmt.Sprintf("could not evaluate execution condition of evaluation %s: %s", evaluationName, err.Error()), piWrapper.GetVersion())
+		evaluationStatus.Status = apicommon.StateFailed
+		return false
+	}
+	if !execute {
+		r.Log.Info("Execution condition of evaluation does not hold, skipping evaluation",
+			"evaluationDefinition", evaluationName,
+			"namespace", piWrapper.GetNamespace(),
+		)
+		evaluationStatus.Status = apicommon.StateSkipped
+	}
+	return execute
+}
+
 func (r Handler) handleEvaluationNotExists(ctx context.Context, phaseCtx context.Context, evaluationCreateAttributes CreateEvaluationAttributes, evaluationName string, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, evaluation *klcv1beta1.KeptnEvaluation, evaluationStatus *klcv1beta1.ItemStatus) error {
 	definition, err := common.GetEvaluationDefinition(r.Client, r.Log, ctx, evaluationName, piWrapper.GetNamespace())
 	if err != nil {
@@ -225,6 +267,10 @@ func (r Handler) handleEvaluationExists(phaseCtx context.Context, piWrapper *int
 		if evaluationStatus.Status.IsSucceeded() {
 			spanEvaluationTrace.AddEvent(evaluation.Name + " has finished")
 			spanEvaluationTrace.SetStatus(codes.Ok, "Finished")
+		} else if evaluationStatus.Status.IsWarning() {
+			spanEvaluationTrace.AddEvent(evaluation.Name + " has finished with a warning")
+			r.emitEvaluationWarningEvents(evaluation, spanEvaluationTrace, piWrapper)
+			spanEvaluationTrace.SetStatus(codes.Ok, "Finished")
 		} else {
 			spanEvaluationTrace.AddEvent(evaluation.Name + " has failed")
 			r.emitEvaluationFailureEvents(evaluation, spanEvaluationTrace, piWrapper)
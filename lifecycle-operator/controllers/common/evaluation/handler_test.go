@@ -387,6 +387,103 @@ func TestEvaluationHandler(t *testing.T) {
 			getSpanCalls:    1,
 			unbindSpanCalls: 1,
 		},
+		{
+			name: "warning evaluation",
+			object: &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1beta1.KeptnAppVersionSpec{
+					KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+						DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+							PreDeploymentEvaluations: []string{"eval-def"},
+						},
+					},
+				},
+				Status: v1beta1.KeptnAppVersionStatus{
+					PreDeploymentEvaluationStatus: apicommon.StateSucceeded,
+					PreDeploymentEvaluationTaskStatus: []v1beta1.ItemStatus{
+						{
+							DefinitionName: "eval-def",
+							Status:         apicommon.StateProgressing,
+							Name:           "pre-eval-eval-def-",
+						},
+					},
+				},
+			},
+			evalObj: v1beta1.KeptnEvaluation{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "pre-eval-eval-def-",
+				},
+				Status: v1beta1.KeptnEvaluationStatus{
+					OverallStatus: apicommon.StateWarning,
+					EvaluationStatus: map[string]v1beta1.EvaluationStatusItem{
+						"my-target": {
+							Value:   "1",
+							Status:  apicommon.StateWarning,
+							Message: "warning",
+						},
+					},
+				},
+			},
+			createAttr: CreateEvaluationAttributes{
+				SpanName: "",
+				Definition: v1beta1.KeptnEvaluationDefinition{
+					ObjectMeta: v1.ObjectMeta{
+						Name: "eval-def",
+					},
+				},
+				CheckType: apicommon.PreDeploymentEvaluationCheckType,
+			},
+			wantStatus: []v1beta1.ItemStatus{
+				{
+					DefinitionName: "eval-def",
+					Status:         apicommon.StateWarning,
+					Name:           "pre-eval-eval-def-",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 1, Succeeded: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 1,
+			events: []string{
+				"evaluation of 'my-target' finished with a warning with value: '1' and reason: 'warning'",
+			},
+		},
+		{
+			name: "skipped evaluation",
+			object: &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1beta1.KeptnAppVersionSpec{
+					KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+						DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+							PreDeploymentEvaluations: []string{"eval-def"},
+							ExecutionConditions: []v1beta1.ExecutionCondition{
+								{Name: "eval-def", When: "checkType == 'post-eval'"},
+							},
+						},
+					},
+				},
+			},
+			evalObj: v1beta1.KeptnEvaluation{},
+			createAttr: CreateEvaluationAttributes{
+				SpanName:  "",
+				CheckType: apicommon.PreDeploymentEvaluationCheckType,
+			},
+			wantStatus: []v1beta1.ItemStatus{
+				{
+					DefinitionName: "eval-def",
+					Status:         apicommon.StateSkipped,
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 1, Skipped: 1},
+			wantErr:         nil,
+			getSpanCalls:    0,
+			unbindSpanCalls: 0,
+		},
 	}
 
 	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
@@ -0,0 +1,522 @@
+package eventreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ApprovalGrantedEventType  = "sh.keptn.approval.granted"
+	ApprovalRejectedEventType = "sh.keptn.approval.rejected"
+	TaskFinishedEventType     = "sh.keptn.task.finished"
+	AppVersionRetryEventType  = "sh.keptn.appversion.retry"
+
+	// SignatureHeader contains the HMAC-SHA256 signature of the type, source, id and time of the CloudEvent
+	// and of the request body, created with the token
+	SignatureHeader = "X-Keptn-Signature"
+	signaturePrefix = "sha256="
+
+	// ScopeAnnotationPrefix is the prefix of the annotations of the Secret that define the resources each identity
+	// may act on, e.g. scope.cloudevents.keptn.sh/ci: "podtato-kubectl,staging/my-app"
+	ScopeAnnotationPrefix = "scope.cloudevents.keptn.sh/"
+	// ReceivedEventsAnnotation contains the source and id of the CloudEvents that have been applied to a KeptnTask
+	// or KeptnApp within the maximum event age
+	ReceivedEventsAnnotation = "keptn.sh/received-cloudevents"
+
+	maxBodySize = 1 << 20
+	// maxEventAge is the maximum difference between the time of a CloudEvent and the time it is received
+	maxEventAge = 5 * time.Minute
+)
+
+var (
+	errUnauthorized        = errors.New("the CloudEvent is not authenticated")
+	errUnsupportedType     = errors.New("the type of the CloudEvent is not supported")
+	errInvalidData         = errors.New("the data of the CloudEvent is invalid")
+	errForbiddenSource     = errors.New("the source of the CloudEvent is not allowed")
+	errForbiddenApprover   = errors.New("the sender of the CloudEvent is not allowed to approve or reject the KeptnTask")
+	errUnexpectedState     = errors.New("the resource is not in the expected state")
+	errReceiverUnavailable = errors.New("the token for authenticating CloudEvents could not be retrieved")
+)
+
+// eventData is the data of the CloudEvents accepted by the Receiver
+type eventData struct {
+	// Namespace is the namespace of the KeptnTask or KeptnAppVersion.
+	Namespace string `json:"namespace"`
+	// Task is the name of the KeptnTask that is approved, rejected or finished.
+	Task string `json:"task,omitempty"`
+	// AppVersion is the name of the KeptnAppVersion that is retried.
+	AppVersion string `json:"appVersion,omitempty"`
+	// Status is the result of an externally executed KeptnTask, either Succeeded or Failed.
+	Status string `json:"status,omitempty"`
+	// Message contains details about the result of an externally executed KeptnTask.
+	Message string `json:"message,omitempty"`
+	// Outputs contains the outputs of an externally executed KeptnTask.
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// Receiver is an HTTP server accepting CloudEvents of external systems, such as CI pipelines,
+// that drive the lifecycle of KeptnTasks and KeptnAppVersions without credentials for the Kubernetes API
+type Receiver struct {
+	client.Client
+	Log         logr.Logger
+	EventSender eventsender.IEvent
+	// Address is the address the HTTP server listens on, e.g. ":8090".
+	Address string
+	// Secret is the Secret containing the tokens that authenticate CloudEvents.
+	// Each key of the Secret is the name of an identity, and its value is the token of the identity.
+	// The resources an identity may act on are defined by the scope annotation of the identity.
+	Secret types.NamespacedName
+}
+
+// identity is an authenticated identity of the Secret of the Receiver
+type identity struct {
+	name string
+	// scope contains the namespaces the identity may act on, the KeptnTaskDefinitions and KeptnApps
+	// it may act on in the form <namespace>/<name>, or * for all namespaces
+	scope []string
+}
+
+func newIdentity(secret *corev1.Secret, name string) identity {
+	id := identity{name: name}
+	for _, entry := range strings.Split(secret.Annotations[ScopeAnnotationPrefix+name], ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			id.scope = append(id.scope, entry)
+		}
+	}
+	return id
+}
+
+// isAllowedNamespace returns whether the identity may act on any resource in the given namespace
+func (i identity) isAllowedNamespace(namespace string) bool {
+	for _, entry := range i.scope {
+		if entry == "*" || entry == namespace || strings.HasPrefix(entry, namespace+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowed returns whether the identity may act on the KeptnTasks of the KeptnTaskDefinition,
+// or on the KeptnAppVersions of the KeptnApp, with the given namespace and name
+func (i identity) isAllowed(namespace string, name string) bool {
+	for _, entry := range i.scope {
+		if entry == "*" || entry == namespace || entry == namespace+"/"+name {
+			return true
+		}
+	}
+	return false
+}
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;update
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntaskdefinitions,verbs=get
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions,verbs=get
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapps,verbs=get;update
+
+// Start runs the HTTP server until the context is cancelled
+func (r *Receiver) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              r.Address,
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			r.Log.Error(err, "could not shut down CloudEvents receiver")
+		}
+	}()
+
+	r.Log.Info("Starting CloudEvents receiver", "address", r.Address)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, since every replica of the lifecycle operator receives CloudEvents.
+// CloudEvents that have been applied are recorded in the resource they have been applied to,
+// so that they are rejected by all replicas if they are sent again.
+func (r *Receiver) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	event, err := cehttp.NewEventFromHTTPRequest(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("could not parse CloudEvent: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	id, err := r.authenticate(req.Context(), req.Header, *event, body)
+	if err != nil {
+		r.writeError(w, err)
+		return
+	}
+	if err := checkEventTime(*event, time.Now()); err != nil {
+		r.writeError(w, err)
+		return
+	}
+
+	if err := r.handleEvent(req.Context(), *event, id); err != nil {
+		r.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authenticate returns the identity whose token is sent as bearer token, or whose token created
+// the HMAC-SHA256 signature of the CloudEvent
+func (r *Receiver) authenticate(ctx context.Context, header http.Header, event ce.Event, body []byte) (identity, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, r.Secret, secret); err != nil {
+		r.Log.Error(err, "could not retrieve Secret of CloudEvents receiver", "secret", r.Secret)
+		return identity{}, errReceiverUnavailable
+	}
+	if len(secret.Data) == 0 {
+		r.Log.Info("Secret of CloudEvents receiver does not contain a token", "secret", r.Secret)
+		return identity{}, errReceiverUnavailable
+	}
+
+	if bearer, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok {
+		for name, token := range secret.Data {
+			if len(token) > 0 && subtle.ConstantTimeCompare([]byte(bearer), token) == 1 {
+				return newIdentity(secret, name), nil
+			}
+		}
+		return identity{}, errUnauthorized
+	}
+
+	if signature, ok := strings.CutPrefix(header.Get(SignatureHeader), signaturePrefix); ok {
+		expected, err := hex.DecodeString(signature)
+		if err != nil {
+			return identity{}, errUnauthorized
+		}
+		for name, token := range secret.Data {
+			if len(token) > 0 && hmac.Equal(Sign(token, event, body), expected) {
+				return newIdentity(secret, name), nil
+			}
+		}
+	}
+	return identity{}, errUnauthorized
+}
+
+// Sign returns the HMAC-SHA256 signature of a CloudEvent, which covers the type, source, id and time of the CloudEvent,
+// separated by newlines, followed by a newline and the request body.
+// The time is formatted in RFC 3339 in UTC, e.g. 2024-01-02T15:04:05.5Z.
+func Sign(token []byte, event ce.Event, body []byte) []byte {
+	mac := hmac.New(sha256.New, token)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n", event.Type(), event.Source(), event.ID(), event.Time().UTC().Format(time.RFC3339Nano))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// checkEventTime rejects CloudEvents whose time differs from now by more than the maximum event age
+func checkEventTime(event ce.Event, now time.Time) error {
+	if event.Time().IsZero() {
+		return fmt.Errorf("%w: time must be set", errUnauthorized)
+	}
+	if event.Time().Before(now.Add(-maxEventAge)) || event.Time().After(now.Add(maxEventAge)) {
+		return fmt.Errorf("%w: time %s is not within %s of the current time", errUnauthorized, event.Time().Format(time.RFC3339), maxEventAge)
+	}
+	return nil
+}
+
+// checkNotReceived rejects CloudEvents that have already been applied to the given resource
+func checkNotReceived(obj client.Object, event ce.Event) error {
+	if _, ok := getReceivedEvents(obj)[getEventKey(event)]; ok {
+		return fmt.Errorf("%w: CloudEvent %s has already been received", errUnauthorized, event.ID())
+	}
+	return nil
+}
+
+// recordReceived records the CloudEvent in the annotations of the given resource. CloudEvents older than
+// the maximum event age are removed from the annotation, since they are rejected because of their time.
+func recordReceived(obj client.Object, event ce.Event, now time.Time) {
+	received := getReceivedEvents(obj)
+	for key, eventTime := range received {
+		if eventTime.Before(now.Add(-maxEventAge)) {
+			delete(received, key)
+		}
+	}
+	received[getEventKey(event)] = event.Time()
+
+	value, _ := json.Marshal(received)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ReceivedEventsAnnotation] = string(value)
+	obj.SetAnnotations(annotations)
+}
+
+func getReceivedEvents(obj client.Object) map[string]time.Time {
+	received := map[string]time.Time{}
+	if value, ok := obj.GetAnnotations()[ReceivedEventsAnnotation]; ok {
+		// an invalid annotation is overwritten by the next CloudEvent
+		_ = json.Unmarshal([]byte(value), &received)
+	}
+	return received
+}
+
+func getEventKey(event ce.Event) string {
+	return event.Source() + "/" + event.ID()
+}
+
+func (r *Receiver) handleEvent(ctx context.Context, event ce.Event, id identity) error {
+	data := eventData{}
+	if err := event.DataAs(&data); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidData, err)
+	}
+	if data.Namespace == "" {
+		return fmt.Errorf("%w: namespace must be set", errInvalidData)
+	}
+	r.Log.Info("Received CloudEvent", "type", event.Type(), "source", event.Source(), "id", event.ID(), "identity", id.name)
+	if !id.isAllowedNamespace(data.Namespace) {
+		return fmt.Errorf("%w: %s may not act on namespace %s", errForbiddenSource, id.name, data.Namespace)
+	}
+
+	switch event.Type() {
+	case ApprovalGrantedEventType:
+		return r.handleApproval(ctx, event, data, id, apicommon.ApprovalApproved)
+	case ApprovalRejectedEventType:
+		return r.handleApproval(ctx, event, data, id, apicommon.ApprovalRejected)
+	case TaskFinishedEventType:
+		return r.handleTaskFinished(ctx, event, data, id)
+	case AppVersionRetryEventType:
+		return r.handleAppVersionRetry(ctx, event, data, id)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, event.Type())
+	}
+}
+
+// handleApproval records the decision of an approver in the status of a KeptnTask that is waiting for approval.
+// The authenticated identity must be one of the users of the approval of the KeptnTaskDefinition.
+func (r *Receiver) handleApproval(ctx context.Context, event ce.Event, data eventData, id identity, decision string) error {
+	if data.Task == "" {
+		return fmt.Errorf("%w: task must be set", errInvalidData)
+	}
+
+	user := id.name
+	task := &klcv1beta1.KeptnTask{}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.getTask(ctx, event, types.NamespacedName{Namespace: data.Namespace, Name: data.Task}, id, task); err != nil {
+			return err
+		}
+		definition, err := controllercommon.GetTaskDefinition(r.Client, r.Log, ctx, task.Spec.TaskDefinition, task.Namespace)
+		if err != nil {
+			return err
+		}
+		if definition.Spec.Approval == nil {
+			return fmt.Errorf("%w: KeptnTask %s is not an approval task", errUnexpectedState, task.Name)
+		}
+		if !definition.Spec.Approval.IsApprover(user, nil) {
+			return fmt.Errorf("%w: %s", errForbiddenApprover, user)
+		}
+		if task.Status.Approval == nil || task.Status.Approval.Decision != "" || task.Status.Status.IsCompleted() {
+			return fmt.Errorf("%w: KeptnTask %s is not waiting for approval", errUnexpectedState, task.Name)
+		}
+
+		task.Status.Approval = &klcv1beta1.ApprovalTaskStatus{
+			Decision: decision,
+			User:     user,
+			Time:     metav1.Now(),
+		}
+		if decision == apicommon.ApprovalApproved {
+			task.Status.Status = apicommon.StateSucceeded
+		} else {
+			task.Status.Status = apicommon.StateFailed
+			task.Status.Reason = "Rejected"
+			task.Status.Message = fmt.Sprintf("KeptnTask has been rejected by %s", user)
+		}
+		return r.Status().Update(ctx, task)
+	})
+	if err != nil {
+		return err
+	}
+	r.recordReceivedOnTask(ctx, task, event)
+
+	r.Log.Info("KeptnTask has been "+decision, "user", user, "task", task.Name, "namespace", task.Namespace)
+	if decision == apicommon.ApprovalApproved {
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Normal", task, apicommon.PhaseStateFinished, fmt.Sprintf("has been approved by %s", user), "")
+	} else {
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateFailed, fmt.Sprintf("has been rejected by %s", user), "")
+	}
+	return nil
+}
+
+// handleTaskFinished records the result of a KeptnTask that is executed by an external system
+func (r *Receiver) handleTaskFinished(ctx context.Context, event ce.Event, data eventData, id identity) error {
+	if data.Task == "" {
+		return fmt.Errorf("%w: task must be set", errInvalidData)
+	}
+	var status apicommon.KeptnState
+	switch {
+	case strings.EqualFold(data.Status, string(apicommon.StateSucceeded)):
+		status = apicommon.StateSucceeded
+	case strings.EqualFold(data.Status, string(apicommon.StateFailed)):
+		status = apicommon.StateFailed
+	default:
+		return fmt.Errorf("%w: status must be either %s or %s", errInvalidData, apicommon.StateSucceeded, apicommon.StateFailed)
+	}
+
+	task := &klcv1beta1.KeptnTask{}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.getTask(ctx, event, types.NamespacedName{Namespace: data.Namespace, Name: data.Task}, id, task); err != nil {
+			return err
+		}
+		definition, err := controllercommon.GetTaskDefinition(r.Client, r.Log, ctx, task.Spec.TaskDefinition, task.Namespace)
+		if err != nil {
+			return err
+		}
+		if definition.Spec.External == nil {
+			return fmt.Errorf("%w: KeptnTask %s is not executed by an external system", errUnexpectedState, task.Name)
+		}
+		if definition.Spec.External.Source != "" && definition.Spec.External.Source != event.Source() {
+			return fmt.Errorf("%w: %s", errForbiddenSource, event.Source())
+		}
+		if task.Status.Status.IsCompleted() {
+			return fmt.Errorf("%w: KeptnTask %s has already been completed", errUnexpectedState, task.Name)
+		}
+
+		task.Status.Status = status
+		task.Status.Message = data.Message
+		if status.IsFailed() {
+			task.Status.Reason = "ExternalTaskFailed"
+		}
+		task.Status.Outputs = data.Outputs
+		r.Log.Info("KeptnTask has been completed by an external system", "status", status, "source", event.Source(), "task", task.Name, "namespace", task.Namespace)
+		return r.Status().Update(ctx, task)
+	})
+	if err != nil {
+		return err
+	}
+	r.recordReceivedOnTask(ctx, task, event)
+	return nil
+}
+
+// getTask retrieves the KeptnTask a CloudEvent is sent for, and rejects the CloudEvent if the identity may not act
+// on the KeptnTasks of its KeptnTaskDefinition, or if it has already been applied to the KeptnTask
+func (r *Receiver) getTask(ctx context.Context, event ce.Event, name types.NamespacedName, id identity, task *klcv1beta1.KeptnTask) error {
+	if err := r.Get(ctx, name, task); err != nil {
+		return err
+	}
+	if !id.isAllowed(task.Namespace, task.Spec.TaskDefinition) {
+		return fmt.Errorf("%w: %s may not act on KeptnTaskDefinition %s", errForbiddenSource, id.name, task.Spec.TaskDefinition)
+	}
+	return checkNotReceived(task, event)
+}
+
+// recordReceivedOnTask records the CloudEvent in the annotations of the KeptnTask it has been applied to.
+// Since the status and the annotations cannot be updated at once, the CloudEvent is recorded after the KeptnTask
+// has been completed, which also rejects the CloudEvent if it is sent again in between.
+func (r *Receiver) recordReceivedOnTask(ctx context.Context, task *klcv1beta1.KeptnTask, event ce.Event) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, types.NamespacedName{Namespace: task.Namespace, Name: task.Name}, task); err != nil {
+			return err
+		}
+		recordReceived(task, event, time.Now())
+		return r.Update(ctx, task)
+	})
+	if err != nil {
+		r.Log.Error(err, "could not record CloudEvent", "id", event.ID(), "task", task.Name, "namespace", task.Namespace)
+	}
+}
+
+// handleAppVersionRetry retries a failed KeptnAppVersion by incrementing the revision of its KeptnApp.
+// The CloudEvent is recorded in the annotations of the KeptnApp with the same update.
+func (r *Receiver) handleAppVersionRetry(ctx context.Context, event ce.Event, data eventData, id identity) error {
+	if data.AppVersion == "" {
+		return fmt.Errorf("%w: appVersion must be set", errInvalidData)
+	}
+
+	appVersion := &klcv1beta1.KeptnAppVersion{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: data.Namespace, Name: data.AppVersion}, appVersion); err != nil {
+		return err
+	}
+	if !id.isAllowed(appVersion.Namespace, appVersion.Spec.AppName) {
+		return fmt.Errorf("%w: %s may not act on KeptnApp %s", errForbiddenSource, id.name, appVersion.Spec.AppName)
+	}
+	if !appVersion.Status.Status.IsFailed() {
+		return fmt.Errorf("%w: KeptnAppVersion %s has not failed", errUnexpectedState, appVersion.Name)
+	}
+
+	app := &klcv1beta1.KeptnApp{}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, types.NamespacedName{Namespace: data.Namespace, Name: appVersion.Spec.AppName}, app); err != nil {
+			return err
+		}
+		if err := checkNotReceived(app, event); err != nil {
+			return err
+		}
+		if app.GetAppVersionName() != appVersion.Name {
+			return fmt.Errorf("%w: KeptnAppVersion %s is not the current version of KeptnApp %s", errUnexpectedState, appVersion.Name, app.Name)
+		}
+
+		recordReceived(app, event, time.Now())
+		app.Spec.Revision++
+		r.Log.Info("Retrying KeptnAppVersion", "appVersion", appVersion.Name, "revision", app.Spec.Revision, "namespace", app.Namespace)
+		return r.Update(ctx, app)
+	})
+}
+
+func (r *Receiver) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, errForbiddenSource), errors.Is(err, errForbiddenApprover):
+		status = http.StatusForbidden
+	case errors.Is(err, errUnsupportedType), errors.Is(err, errInvalidData):
+		status = http.StatusBadRequest
+	case errors.Is(err, errUnexpectedState):
+		status = http.StatusConflict
+	case errors.Is(err, errReceiverUnavailable):
+		status = http.StatusServiceUnavailable
+	case k8serrors.IsNotFound(err):
+		status = http.StatusNotFound
+	default:
+		r.Log.Error(err, "could not handle CloudEvent")
+	}
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,395 @@
+package eventreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testToken     = "my-token"
+	approverToken = "approver-token"
+)
+
+var eventID = 0
+
+func newReceiver(t *testing.T, objs ...client.Object) (*Receiver, client.Client) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keptn-cloudevents-receiver",
+			Namespace: testcommon.KeptnNamespace,
+			Annotations: map[string]string{
+				ScopeAnnotationPrefix + "ci":    "default",
+				ScopeAnnotationPrefix + "alice": "default/approval",
+			},
+		},
+		Data: map[string][]byte{
+			"ci":    []byte(testToken),
+			"alice": []byte(approverToken),
+		},
+	}
+	fakeClient := testcommon.NewTestClient(append(objs, secret)...)
+	return newReplica(t, fakeClient), fakeClient
+}
+
+// newReplica returns the Receiver of another replica of the lifecycle operator
+func newReplica(t *testing.T, fakeClient client.Client) *Receiver {
+	return &Receiver{
+		Client:      fakeClient,
+		Log:         testr.New(t),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Secret:      types.NamespacedName{Name: "keptn-cloudevents-receiver", Namespace: testcommon.KeptnNamespace},
+	}
+}
+
+func newRequest(t *testing.T, eventType string, source string, data eventData) *http.Request {
+	eventID++
+	return newRequestAt(t, eventType, source, strconv.Itoa(eventID), time.Now(), data)
+}
+
+func newRequestAt(t *testing.T, eventType string, source string, id string, eventTime time.Time, data eventData) *http.Request {
+	event := map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              id,
+		"type":            eventType,
+		"source":          source,
+		"time":            eventTime.UTC().Format(time.RFC3339Nano),
+		"datacontenttype": "application/json",
+		"data":            data,
+	}
+	body, err := json.Marshal(event)
+	require.Nil(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	return req
+}
+
+func serve(r *Receiver, req *http.Request) int {
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func getTask(name string, definition string) *klcv1beta1.KeptnTask {
+	return &klcv1beta1.KeptnTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnTaskSpec{
+			TaskDefinition: definition,
+		},
+	}
+}
+
+func getApprovalTaskDefinition(users ...string) *klcv1beta1.KeptnTaskDefinition {
+	return &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "approval",
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Approval: &klcv1beta1.ApprovalSpec{Users: users},
+		},
+	}
+}
+
+func asApprover(req *http.Request) *http.Request {
+	req.Header.Set("Authorization", "Bearer "+approverToken)
+	return req
+}
+
+func sign(t *testing.T, req *http.Request, token string, event ce.Event) {
+	req.Header.Del("Authorization")
+	body, err := io.ReadAll(req.Body)
+	require.Nil(t, err)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(Sign([]byte(token), event, body)))
+}
+
+func getEvent(eventType string, source string, id string, eventTime time.Time) ce.Event {
+	event := ce.NewEvent()
+	event.SetType(eventType)
+	event.SetSource(source)
+	event.SetID(id)
+	event.SetTime(eventTime)
+	return event
+}
+
+func getExternalTaskDefinition(source string) *klcv1beta1.KeptnTaskDefinition {
+	return &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "external",
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			External: &klcv1beta1.ExternalSpec{Source: source},
+		},
+	}
+}
+
+func TestReceiver_Authentication(t *testing.T) {
+	task := getTask("my-task", "approval")
+	task.Status.Approval = &klcv1beta1.ApprovalTaskStatus{}
+	r, _ := newReceiver(t, task, getApprovalTaskDefinition("ci"))
+	data := eventData{Namespace: "default", Task: "my-task"}
+	eventTime := time.Now()
+
+	// missing credentials
+	req := newRequest(t, ApprovalGrantedEventType, "ci", data)
+	req.Header.Del("Authorization")
+	require.Equal(t, http.StatusUnauthorized, serve(r, req))
+
+	// wrong token
+	req = newRequest(t, ApprovalGrantedEventType, "ci", data)
+	req.Header.Set("Authorization", "Bearer wrong")
+	require.Equal(t, http.StatusUnauthorized, serve(r, req))
+
+	// wrong signature
+	req = newRequest(t, ApprovalGrantedEventType, "ci", data)
+	req.Header.Del("Authorization")
+	req.Header.Set(SignatureHeader, "sha256=0000")
+	require.Equal(t, http.StatusUnauthorized, serve(r, req))
+
+	// a signature of the body only does not cover the attributes of the CloudEvent
+	req = newRequestAt(t, ApprovalGrantedEventType, "ci", "signed", eventTime, data)
+	req.Header.Del("Authorization")
+	body, err := io.ReadAll(req.Body)
+	require.Nil(t, err)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	mac := hmac.New(sha256.New, []byte(testToken))
+	mac.Write(body)
+	req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	require.Equal(t, http.StatusUnauthorized, serve(r, req))
+
+	// a signature of another CloudEvent
+	req = newRequestAt(t, ApprovalGrantedEventType, "ci", "signed", eventTime, data)
+	sign(t, req, testToken, getEvent(ApprovalRejectedEventType, "ci", "signed", eventTime))
+	require.Equal(t, http.StatusUnauthorized, serve(r, req))
+
+	// stale CloudEvent
+	staleTime := time.Now().Add(-2 * maxEventAge)
+	req = newRequestAt(t, ApprovalGrantedEventType, "ci", "stale", staleTime, data)
+	sign(t, req, testToken, getEvent(ApprovalGrantedEventType, "ci", "stale", staleTime))
+	require.Equal(t, http.StatusUnauthorized, serve(r, req))
+
+	// valid signature
+	req = newRequestAt(t, ApprovalGrantedEventType, "ci", "signed", eventTime, data)
+	sign(t, req, testToken, getEvent(ApprovalGrantedEventType, "ci", "signed", eventTime))
+	require.Equal(t, http.StatusAccepted, serve(r, req))
+
+	// a CloudEvent cannot be replayed, neither against the same nor against another replica
+	req = newRequestAt(t, ApprovalGrantedEventType, "ci", "signed", eventTime, data)
+	sign(t, req, testToken, getEvent(ApprovalGrantedEventType, "ci", "signed", eventTime))
+	require.Equal(t, http.StatusUnauthorized, serve(r, req))
+
+	req = newRequestAt(t, ApprovalGrantedEventType, "ci", "signed", eventTime, data)
+	sign(t, req, testToken, getEvent(ApprovalGrantedEventType, "ci", "signed", eventTime))
+	require.Equal(t, http.StatusUnauthorized, serve(newReplica(t, r.Client), req))
+
+	// only POST requests are accepted
+	require.Equal(t, http.StatusMethodNotAllowed, serve(r, httptest.NewRequest(http.MethodGet, "/", nil)))
+}
+
+func TestReceiver_Approval(t *testing.T) {
+	approved := getTask("approved", "approval")
+	approved.Status.Approval = &klcv1beta1.ApprovalTaskStatus{}
+	rejected := getTask("rejected", "approval")
+	rejected.Status.Approval = &klcv1beta1.ApprovalTaskStatus{}
+	noApproval := getTask("no-approval", "external")
+	r, fakeClient := newReceiver(t, approved, rejected, noApproval, getApprovalTaskDefinition("alice"), getExternalTaskDefinition(""))
+
+	// only the users of the approval may decide
+	code := serve(r, newRequest(t, ApprovalGrantedEventType, "ci", eventData{Namespace: "default", Task: "approved"}))
+	require.Equal(t, http.StatusForbidden, code)
+
+	code = serve(r, asApprover(newRequest(t, ApprovalGrantedEventType, "ci", eventData{Namespace: "default", Task: "approved"})))
+	require.Equal(t, http.StatusAccepted, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "approved"}, approved))
+	require.Equal(t, apicommon.StateSucceeded, approved.Status.Status)
+	require.Equal(t, apicommon.ApprovalApproved, approved.Status.Approval.Decision)
+	require.Equal(t, "alice", approved.Status.Approval.User)
+
+	// a decision cannot be changed
+	code = serve(r, asApprover(newRequest(t, ApprovalRejectedEventType, "ci", eventData{Namespace: "default", Task: "approved"})))
+	require.Equal(t, http.StatusConflict, code)
+
+	code = serve(r, asApprover(newRequest(t, ApprovalRejectedEventType, "ci", eventData{Namespace: "default", Task: "rejected"})))
+	require.Equal(t, http.StatusAccepted, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "rejected"}, rejected))
+	require.Equal(t, apicommon.StateFailed, rejected.Status.Status)
+	require.Equal(t, "Rejected", rejected.Status.Reason)
+	require.Equal(t, "alice", rejected.Status.Approval.User)
+
+	code = serve(r, newRequest(t, ApprovalGrantedEventType, "ci", eventData{Namespace: "default", Task: "no-approval"}))
+	require.Equal(t, http.StatusConflict, code)
+
+	code = serve(r, asApprover(newRequest(t, ApprovalGrantedEventType, "ci", eventData{Namespace: "default", Task: "missing"})))
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestReceiver_TaskFinished(t *testing.T) {
+	task := getTask("my-task", "external")
+	task.Status.Status = apicommon.StateProgressing
+	r, fakeClient := newReceiver(t, task, getExternalTaskDefinition("https://ci.example.com"))
+
+	data := eventData{Namespace: "default", Task: "my-task", Status: "succeeded", Message: "pipeline finished", Outputs: map[string]string{"build": "42"}}
+
+	// only the configured source may report the result
+	code := serve(r, newRequest(t, TaskFinishedEventType, "https://other.example.com", data))
+	require.Equal(t, http.StatusForbidden, code)
+
+	code = serve(r, newRequest(t, TaskFinishedEventType, "https://ci.example.com", data))
+	require.Equal(t, http.StatusAccepted, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-task"}, task))
+	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+	require.Equal(t, "pipeline finished", task.Status.Message)
+	require.Equal(t, map[string]string{"build": "42"}, task.Status.Outputs)
+
+	// the result cannot be reported twice
+	code = serve(r, newRequest(t, TaskFinishedEventType, "https://ci.example.com", data))
+	require.Equal(t, http.StatusConflict, code)
+
+	data.Status = "unknown"
+	code = serve(r, newRequest(t, TaskFinishedEventType, "https://ci.example.com", data))
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestReceiver_TaskFinishedFailed(t *testing.T) {
+	task := getTask("my-task", "external")
+	task.Status.Status = apicommon.StateProgressing
+	r, fakeClient := newReceiver(t, task, getExternalTaskDefinition(""))
+
+	code := serve(r, newRequest(t, TaskFinishedEventType, "ci", eventData{Namespace: "default", Task: "my-task", Status: "Failed", Message: "tests failed"}))
+	require.Equal(t, http.StatusAccepted, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-task"}, task))
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, "ExternalTaskFailed", task.Status.Reason)
+	require.Equal(t, "tests failed", task.Status.Message)
+}
+
+func TestReceiver_AppVersionRetry(t *testing.T) {
+	app := testcommon.GetApp("my-app")
+	failed := testcommon.ReturnAppVersion("default", "my-app", "1.0.0", nil, klcv1beta1.KeptnAppVersionStatus{Status: apicommon.StateFailed})
+	failed.Name = app.GetAppVersionName()
+	succeeded := testcommon.ReturnAppVersion("default", "my-app", "0.9.0", nil, klcv1beta1.KeptnAppVersionStatus{Status: apicommon.StateSucceeded})
+	r, fakeClient := newReceiver(t, app, failed, succeeded)
+
+	code := serve(r, newRequest(t, AppVersionRetryEventType, "ci", eventData{Namespace: "default", AppVersion: failed.Name}))
+	require.Equal(t, http.StatusAccepted, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-app"}, app))
+	require.Equal(t, uint(1), app.Spec.Revision)
+
+	// only failed KeptnAppVersions can be retried
+	code = serve(r, newRequest(t, AppVersionRetryEventType, "ci", eventData{Namespace: "default", AppVersion: succeeded.Name}))
+	require.Equal(t, http.StatusConflict, code)
+}
+
+func TestReceiver_AppVersionRetryReplay(t *testing.T) {
+	app := testcommon.GetApp("my-app")
+	failed := testcommon.ReturnAppVersion("default", "my-app", "1.0.0", nil, klcv1beta1.KeptnAppVersionStatus{Status: apicommon.StateFailed})
+	failed.Name = app.GetAppVersionName()
+	r, fakeClient := newReceiver(t, app, failed)
+	data := eventData{Namespace: "default", AppVersion: failed.Name}
+	eventTime := time.Now()
+
+	code := serve(r, newRequestAt(t, AppVersionRetryEventType, "ci", "retry", eventTime, data))
+	require.Equal(t, http.StatusAccepted, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-app"}, app))
+	require.Contains(t, app.Annotations[ReceivedEventsAnnotation], "ci/retry")
+
+	// the CloudEvent is recorded in the KeptnApp, which rejects it on every replica
+	code = serve(newReplica(t, fakeClient), newRequestAt(t, AppVersionRetryEventType, "ci", "retry", eventTime, data))
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "my-app"}, app))
+	require.Equal(t, uint(1), app.Spec.Revision)
+}
+
+func TestReceiver_Scope(t *testing.T) {
+	approval := getTask("approval-task", "approval")
+	approval.Status.Approval = &klcv1beta1.ApprovalTaskStatus{}
+	external := getTask("external-task", "external")
+	external.Status.Status = apicommon.StateProgressing
+	otherTask := getTask("my-task", "external")
+	otherTask.Namespace = "other"
+	otherTask.Status.Status = apicommon.StateProgressing
+	otherDefinition := getExternalTaskDefinition("")
+	otherDefinition.Namespace = "other"
+	app := testcommon.GetApp("my-app")
+	failed := testcommon.ReturnAppVersion("default", "my-app", "1.0.0", nil, klcv1beta1.KeptnAppVersionStatus{Status: apicommon.StateFailed})
+	failed.Name = app.GetAppVersionName()
+	r, fakeClient := newReceiver(t, approval, external, otherTask, otherDefinition, app, failed, getApprovalTaskDefinition("alice"), getExternalTaskDefinition(""))
+
+	// an identity cannot act on resources in other namespaces
+	code := serve(r, newRequest(t, TaskFinishedEventType, "ci", eventData{Namespace: "other", Task: "my-task", Status: "Succeeded"}))
+	require.Equal(t, http.StatusForbidden, code)
+	require.Nil(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "other", Name: "my-task"}, otherTask))
+	require.Equal(t, apicommon.StateProgressing, otherTask.Status.Status)
+
+	code = serve(r, asApprover(newRequest(t, ApprovalGrantedEventType, "ci", eventData{Namespace: "other", Task: "my-task"})))
+	require.Equal(t, http.StatusForbidden, code)
+
+	// an identity scoped to a KeptnTaskDefinition cannot act on the KeptnTasks of other KeptnTaskDefinitions or on KeptnApps
+	code = serve(r, asApprover(newRequest(t, TaskFinishedEventType, "ci", eventData{Namespace: "default", Task: "external-task", Status: "Succeeded"})))
+	require.Equal(t, http.StatusForbidden, code)
+
+	code = serve(r, asApprover(newRequest(t, AppVersionRetryEventType, "ci", eventData{Namespace: "default", AppVersion: failed.Name})))
+	require.Equal(t, http.StatusForbidden, code)
+
+	code = serve(r, asApprover(newRequest(t, ApprovalGrantedEventType, "ci", eventData{Namespace: "default", Task: "approval-task"})))
+	require.Equal(t, http.StatusAccepted, code)
+
+	// an identity without scope cannot act on any resource
+	secret := &corev1.Secret{}
+	require.Nil(t, fakeClient.Get(context.TODO(), r.Secret, secret))
+	delete(secret.Annotations, ScopeAnnotationPrefix+"ci")
+	require.Nil(t, fakeClient.Update(context.TODO(), secret))
+
+	code = serve(r, newRequest(t, TaskFinishedEventType, "ci", eventData{Namespace: "default", Task: "external-task", Status: "Succeeded"}))
+	require.Equal(t, http.StatusForbidden, code)
+
+	// an identity scoped to all namespaces can act on any resource
+	secret.Annotations[ScopeAnnotationPrefix+"ci"] = "*"
+	require.Nil(t, fakeClient.Update(context.TODO(), secret))
+
+	code = serve(r, newRequest(t, TaskFinishedEventType, "ci", eventData{Namespace: "other", Task: "my-task", Status: "Succeeded"}))
+	require.Equal(t, http.StatusAccepted, code)
+}
+
+func TestReceiver_InvalidEvents(t *testing.T) {
+	r, _ := newReceiver(t)
+
+	code := serve(r, newRequest(t, "sh.keptn.unknown", "ci", eventData{Namespace: "default"}))
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code = serve(r, newRequest(t, ApprovalGrantedEventType, "ci", eventData{Task: "my-task"}))
+	require.Equal(t, http.StatusBadRequest, code)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("not a CloudEvent")))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	require.Equal(t, http.StatusBadRequest, serve(r, req))
+}
@@ -0,0 +1,269 @@
+package eventsender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/go-logr/logr"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// endpointSinkName is the name of the sink delivering to the CloudEventsEndpoint of the KeptnConfig
+	endpointSinkName = "cloudEventsEndpoint"
+
+	defaultQueueSize     = 100
+	defaultMaxRetries    = 5
+	defaultRetryInterval = time.Second
+	maxRetryInterval     = time.Minute
+	deliveryTimeout      = 10 * time.Second
+
+	batchContentType = "application/cloudevents-batch+json"
+)
+
+// Dispatcher delivers Cloud Events to the sinks configured in the KeptnConfig.
+// Every sink has its own bounded queue and worker, so that an unavailable sink does not delay
+// the delivery to the other sinks.
+type Dispatcher struct {
+	client     ce.Client
+	httpClient *http.Client
+	logger     logr.Logger
+	meters     apicommon.KeptnMeters
+	config     config.IConfig
+
+	mtx   sync.Mutex
+	sinks map[string]*sink
+}
+
+type sink struct {
+	spec  optionsv1alpha1.CloudEventsSink
+	queue chan ce.Event
+	done  chan struct{}
+}
+
+func NewDispatcher(logger logr.Logger, client ce.Client, meters apicommon.KeptnMeters) *Dispatcher {
+	return &Dispatcher{
+		client:     client,
+		httpClient: &http.Client{Timeout: deliveryTimeout},
+		logger:     logger,
+		meters:     meters,
+		config:     config.Instance(),
+		sinks:      map[string]*sink{},
+	}
+}
+
+// Dispatch queues the Cloud Event for every sink that accepts its type.
+// If the queue of a sink is full, the Cloud Event is dropped for this sink.
+func (d *Dispatcher) Dispatch(event ce.Event) {
+	for _, s := range d.getSinks() {
+		if !s.accepts(event.Type()) {
+			continue
+		}
+		select {
+		case s.queue <- event:
+		default:
+			d.logger.Info("Dropping Cloud Event, since the queue of the sink is full", "sink", s.spec.Name, "type", event.Type())
+			d.recordFailure(s, []ce.Event{event}, "QueueFull")
+		}
+	}
+}
+
+// getSinks returns the sinks of the current configuration. Workers are started for new or changed sinks,
+// and stopped for changed or removed sinks. Cloud Events that are still queued for a stopped worker are discarded.
+func (d *Dispatcher) getSinks() []*sink {
+	specs := d.getSinkSpecs()
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	active := make(map[string]*sink, len(specs))
+	sinks := make([]*sink, 0, len(specs))
+	for _, spec := range specs {
+		if _, ok := active[spec.Name]; ok {
+			d.logger.Info("Ignoring Cloud Events sink with duplicate name", "sink", spec.Name)
+			continue
+		}
+		s, ok := d.sinks[spec.Name]
+		if !ok || !reflect.DeepEqual(s.spec, spec) {
+			if ok {
+				close(s.done)
+			}
+			s = d.startSink(spec)
+		}
+		active[spec.Name] = s
+		sinks = append(sinks, s)
+	}
+	for name, s := range d.sinks {
+		if _, ok := active[name]; !ok {
+			close(s.done)
+		}
+	}
+	d.sinks = active
+	return sinks
+}
+
+func (d *Dispatcher) getSinkSpecs() []optionsv1alpha1.CloudEventsSink {
+	specs := []optionsv1alpha1.CloudEventsSink{}
+	endpoint := d.config.GetCloudEventsEndpoint()
+	if strings.HasPrefix(endpoint, "http") {
+		specs = append(specs, optionsv1alpha1.CloudEventsSink{Name: endpointSinkName, Endpoint: endpoint})
+	} else if endpoint != "" {
+		d.logger.V(5).Info(fmt.Sprintf("CloudEvent endpoint configured but it does not start with http: %s", endpoint))
+	}
+	return append(specs, d.config.GetCloudEventsSinks()...)
+}
+
+func (d *Dispatcher) startSink(spec optionsv1alpha1.CloudEventsSink) *sink {
+	queueSize := int(spec.QueueSize)
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	s := &sink{
+		spec:  spec,
+		queue: make(chan ce.Event, queueSize),
+		done:  make(chan struct{}),
+	}
+	go d.run(s)
+	return s
+}
+
+// run delivers the queued Cloud Events of a sink until the sink is stopped
+func (d *Dispatcher) run(s *sink) {
+	for {
+		select {
+		case <-s.done:
+			return
+		case event := <-s.queue:
+			d.deliverWithRetries(s, s.collectBatch(event))
+		}
+	}
+}
+
+// deliverWithRetries delivers the Cloud Events to the sink, and retries the delivery with exponential backoff
+func (d *Dispatcher) deliverWithRetries(s *sink, events []ce.Event) {
+	interval := s.spec.RetryInterval.Duration
+	if interval <= 0 {
+		interval = defaultRetryInterval
+	}
+	for attempt := 0; ; attempt++ {
+		err := d.deliver(s, events)
+		if err == nil {
+			d.recordDelivery(s, events)
+			return
+		}
+		if attempt >= s.maxRetries() {
+			d.logger.Error(err, "Could not deliver Cloud Events", "sink", s.spec.Name, "events", len(events), "attempts", attempt+1)
+			d.recordFailure(s, events, "Undelivered")
+			return
+		}
+		d.logger.V(5).Info("Retrying delivery of Cloud Events", "sink", s.spec.Name, "error", err.Error(), "interval", interval.String())
+		select {
+		case <-s.done:
+			d.recordFailure(s, events, "SinkRemoved")
+			return
+		case <-time.After(interval):
+		}
+		interval *= 2
+		if interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+}
+
+// deliver sends a single Cloud Event in binary mode, and several Cloud Events in batched mode
+func (d *Dispatcher) deliver(s *sink, events []ce.Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	if len(events) == 1 {
+		result := d.client.Send(ce.ContextWithTarget(ctx, s.spec.Endpoint), events[0])
+		if !ce.IsACK(result) {
+			return result
+		}
+		return nil
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.spec.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", batchContentType)
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *Dispatcher) recordDelivery(s *sink, events []ce.Event) {
+	d.record(d.meters.CloudEventsDeliveredCount, s, events)
+}
+
+func (d *Dispatcher) recordFailure(s *sink, events []ce.Event, reason string) {
+	d.record(d.meters.CloudEventsFailedCount, s, events, apicommon.CloudEventReason.String(reason))
+}
+
+func (d *Dispatcher) record(counter metric.Int64Counter, s *sink, events []ce.Event, attrs ...attribute.KeyValue) {
+	for _, event := range events {
+		eventAttrs := append([]attribute.KeyValue{
+			apicommon.CloudEventSink.String(s.spec.Name),
+			apicommon.CloudEventType.String(event.Type()),
+		}, attrs...)
+		counter.Add(context.Background(), 1, metric.WithAttributes(eventAttrs...))
+	}
+}
+
+// accepts returns true if the type of a Cloud Event matches one of the type filters of the sink
+func (s *sink) accepts(eventType string) bool {
+	if len(s.spec.Types) == 0 {
+		return true
+	}
+	for _, pattern := range s.spec.Types {
+		if matched, _ := path.Match(pattern, eventType); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// collectBatch adds the Cloud Events that are already queued to the batch, up to the batch size of the sink
+func (s *sink) collectBatch(event ce.Event) []ce.Event {
+	batch := []ce.Event{event}
+	for len(batch) < int(s.spec.BatchSize) {
+		select {
+		case next := <-s.queue:
+			batch = append(batch, next)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (s *sink) maxRetries() int {
+	if s.spec.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+	return int(*s.spec.MaxRetries)
+}
@@ -0,0 +1,313 @@
+package eventsender
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	fakeconfig "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func newTestMeters(t *testing.T, reader sdkmetric.Reader) apicommon.KeptnMeters {
+	options := []sdkmetric.Option{}
+	if reader != nil {
+		options = append(options, sdkmetric.WithReader(reader))
+	}
+	meter := sdkmetric.NewMeterProvider(options...).Meter("test")
+	delivered, err := meter.Int64Counter("keptn.cloudevents.delivered.count")
+	require.Nil(t, err)
+	failed, err := meter.Int64Counter("keptn.cloudevents.failed.count")
+	require.Nil(t, err)
+	return apicommon.KeptnMeters{
+		CloudEventsDeliveredCount: delivered,
+		CloudEventsFailedCount:    failed,
+	}
+}
+
+func newTestDispatcher(t *testing.T, reader sdkmetric.Reader, sinks ...optionsv1alpha1.CloudEventsSink) *Dispatcher {
+	client, err := ce.NewClientHTTP()
+	require.Nil(t, err)
+	d := NewDispatcher(testr.New(t), client, newTestMeters(t, reader))
+	d.config = &fakeconfig.MockConfig{
+		GetCloudEventsEndpointFunc: func() string {
+			return ""
+		},
+		GetCloudEventsSinksFunc: func() []optionsv1alpha1.CloudEventsSink {
+			return sinks
+		},
+	}
+	return d
+}
+
+func newTestEvent(eventType string) ce.Event {
+	event := ce.NewEvent()
+	event.SetID(eventType)
+	event.SetSource("keptn.sh")
+	event.SetType(eventType)
+	return event
+}
+
+// getCount returns the sum of the data points of a counter
+func getCount(t *testing.T, reader sdkmetric.Reader, name string) int64 {
+	rm := metricdata.ResourceMetrics{}
+	require.Nil(t, reader.Collect(context.TODO(), &rm))
+	var count int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				count += dp.Value
+			}
+		}
+	}
+	return count
+}
+
+func TestDispatcher_RetriesDelivery(t *testing.T) {
+	requests := atomic.Int32{}
+	received := make(chan string, 1)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first two attempts fail
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- r.Header.Get("Ce-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	reader := sdkmetric.NewManualReader()
+	maxRetries := int32(3)
+	d := newTestDispatcher(t, reader, optionsv1alpha1.CloudEventsSink{
+		Name:          "my-sink",
+		Endpoint:      svr.URL,
+		MaxRetries:    &maxRetries,
+		RetryInterval: metav1.Duration{Duration: 10 * time.Millisecond},
+	})
+
+	d.Dispatch(newTestEvent("App Deployment.Finished"))
+
+	select {
+	case eventType := <-received:
+		require.Equal(t, "App Deployment.Finished", eventType)
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not receive the Cloud Event")
+	}
+	require.Eventually(t, func() bool {
+		return getCount(t, reader, "keptn.cloudevents.delivered.count") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(3), requests.Load())
+}
+
+func TestDispatcher_GivesUpAfterMaxRetries(t *testing.T) {
+	requests := atomic.Int32{}
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	reader := sdkmetric.NewManualReader()
+	maxRetries := int32(1)
+	d := newTestDispatcher(t, reader, optionsv1alpha1.CloudEventsSink{
+		Name:          "my-sink",
+		Endpoint:      svr.URL,
+		MaxRetries:    &maxRetries,
+		RetryInterval: metav1.Duration{Duration: 10 * time.Millisecond},
+	})
+
+	d.Dispatch(newTestEvent("App Deployment.Failed"))
+
+	require.Eventually(t, func() bool {
+		return getCount(t, reader, "keptn.cloudevents.failed.count") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(2), requests.Load())
+	require.Zero(t, getCount(t, reader, "keptn.cloudevents.delivered.count"))
+}
+
+func TestDispatcher_FiltersByType(t *testing.T) {
+	received := make(chan string, 10)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Ce-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	d := newTestDispatcher(t, nil, optionsv1alpha1.CloudEventsSink{
+		Name:     "failures",
+		Endpoint: svr.URL,
+		Types:    []string{"*.Failed", "Reconcile Task.Finished"},
+	})
+
+	d.Dispatch(newTestEvent("App Deployment.Started"))
+	d.Dispatch(newTestEvent("App Deployment.Failed"))
+	d.Dispatch(newTestEvent("Reconcile Task.Finished"))
+
+	for _, expected := range []string{"App Deployment.Failed", "Reconcile Task.Finished"} {
+		select {
+		case eventType := <-received:
+			require.Equal(t, expected, eventType)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("did not receive the Cloud Event %s", expected)
+		}
+	}
+	require.Empty(t, received)
+}
+
+func TestDispatcher_DropsEventsIfQueueIsFull(t *testing.T) {
+	received := make(chan struct{}, 3)
+	release := make(chan struct{})
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	defer close(release)
+
+	reader := sdkmetric.NewManualReader()
+	d := newTestDispatcher(t, reader, optionsv1alpha1.CloudEventsSink{
+		Name:      "my-sink",
+		Endpoint:  svr.URL,
+		QueueSize: 1,
+	})
+
+	// the first Cloud Event is being delivered, the second one is queued and the third one is dropped
+	d.Dispatch(newTestEvent("first"))
+	<-received
+	d.Dispatch(newTestEvent("second"))
+	d.Dispatch(newTestEvent("third"))
+
+	require.Equal(t, int64(1), getCount(t, reader, "keptn.cloudevents.failed.count"))
+}
+
+func TestDispatcher_DeliversBatches(t *testing.T) {
+	received := make(chan []map[string]interface{}, 1)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, batchContentType, r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		events := []map[string]interface{}{}
+		require.Nil(t, json.Unmarshal(body, &events))
+		received <- events
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer svr.Close()
+
+	d := newTestDispatcher(t, nil)
+	s := &sink{
+		spec:  optionsv1alpha1.CloudEventsSink{Name: "my-sink", Endpoint: svr.URL, BatchSize: 2},
+		queue: make(chan ce.Event, 10),
+	}
+	s.queue <- newTestEvent("second")
+	s.queue <- newTestEvent("third")
+
+	batch := s.collectBatch(newTestEvent("first"))
+	require.Len(t, batch, 2)
+	require.Len(t, s.queue, 1)
+
+	require.Nil(t, d.deliver(s, batch))
+	events := <-received
+	require.Len(t, events, 2)
+	require.Equal(t, "first", events[0]["type"])
+	require.Equal(t, "second", events[1]["type"])
+}
+
+func TestDispatcher_UpdatesSinks(t *testing.T) {
+	sinks := []optionsv1alpha1.CloudEventsSink{
+		{Name: "first", Endpoint: "http://first"},
+		{Name: "second", Endpoint: "http://second"},
+	}
+	d := newTestDispatcher(t, nil)
+	d.config = &fakeconfig.MockConfig{
+		GetCloudEventsEndpointFunc: func() string {
+			return "http://endpoint"
+		},
+		GetCloudEventsSinksFunc: func() []optionsv1alpha1.CloudEventsSink {
+			return sinks
+		},
+	}
+
+	got := d.getSinks()
+	require.Len(t, got, 3)
+	require.Equal(t, endpointSinkName, got[0].spec.Name)
+	first, second := got[1], got[2]
+
+	// unchanged sinks are kept, changed and removed sinks are stopped
+	sinks = []optionsv1alpha1.CloudEventsSink{
+		{Name: "first", Endpoint: "http://first"},
+		{Name: "second", Endpoint: "http://other"},
+	}
+	got = d.getSinks()
+	require.Len(t, got, 3)
+	require.Same(t, first, got[1])
+	require.NotSame(t, second, got[2])
+	require.Equal(t, "http://other", got[2].spec.Endpoint)
+	require.Eventually(t, func() bool {
+		select {
+		case <-second.done:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	sinks = nil
+	got = d.getSinks()
+	require.Len(t, got, 1)
+	require.Len(t, d.sinks, 1)
+}
+
+func TestSetTraceContext(t *testing.T) {
+	appVersion := &klcv1beta1.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app-version",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"traceparent": "00-00000000000000000000000000000001-0000000000000001-01",
+			},
+		},
+		Status: klcv1beta1.KeptnAppVersionStatus{
+			PhaseTraceIDs: apicommon.PhaseTraceID{
+				apicommon.PhaseAppDeployment.ShortName: propagation.MapCarrier{
+					"traceparent": testTraceParent,
+				},
+			},
+		},
+	}
+
+	// the span of the phase is used
+	event := newTestEvent("App Deployment.Started")
+	setTraceContext(&event, apicommon.PhaseAppDeployment, appVersion)
+	require.Equal(t, testTraceParent, event.Extensions()["traceparent"])
+
+	// phases without span fall back to the trace context of the object
+	event = newTestEvent("App Pre-Deployment Tasks.Started")
+	setTraceContext(&event, apicommon.PhaseAppPreDeployment, appVersion)
+	require.Equal(t, "00-00000000000000000000000000000001-0000000000000001-01", event.Extensions()["traceparent"])
+
+	// no trace context is set if there is none
+	event = newTestEvent("Reconcile Task.Started")
+	setTraceContext(&event, apicommon.PhaseReconcileTask, &klcv1beta1.KeptnTask{})
+	require.NotContains(t, event.Extensions(), "traceparent")
+}
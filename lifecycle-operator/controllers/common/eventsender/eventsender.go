@@ -3,13 +3,15 @@ package eventsender
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	ce "github.com/cloudevents/sdk-go/v2"
+	ceclient "github.com/cloudevents/sdk-go/v2/client"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
-	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	"go.opentelemetry.io/otel/propagation"
 	"golang.org/x/exp/maps"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,12 +29,15 @@ type EventMultiplexer struct {
 	emitters []IEvent
 }
 
-func NewEventMultiplexer(logger logr.Logger, recorder record.EventRecorder, client ce.Client) *EventMultiplexer {
+func NewEventMultiplexer(logger logr.Logger, recorder record.EventRecorder, dispatcher *Dispatcher, notifier *Notifier) *EventMultiplexer {
 	multiplexer := &EventMultiplexer{
 		logger: logger,
 	}
-	multiplexer.register(newCloudEventSender(logger, client))
+	multiplexer.register(newCloudEventSender(logger, dispatcher))
 	multiplexer.register(NewK8sSender(recorder))
+	if notifier != nil {
+		multiplexer.register(notifier)
+	}
 	return multiplexer
 }
 
@@ -52,25 +57,20 @@ func (e *EventMultiplexer) Emit(phase apicommon.KeptnPhaseType, eventType string
 // ===== Cloud Event Sender =====
 
 type cloudEvent struct {
-	client ce.Client
-	logger logr.Logger
+	dispatcher *Dispatcher
+	logger     logr.Logger
 }
 
-func newCloudEventSender(logger logr.Logger, client ce.Client) *cloudEvent {
+func newCloudEventSender(logger logr.Logger, dispatcher *Dispatcher) *cloudEvent {
 	return &cloudEvent{
-		client: client,
-		logger: logger,
+		dispatcher: dispatcher,
+		logger:     logger,
 	}
 }
 
-// Emit creates a Cloud Event and send it to the endpoint
+// Emit creates a Cloud Event and hands it over to the dispatcher, which delivers it to the configured sinks
 func (e *cloudEvent) Emit(phase apicommon.KeptnPhaseType, eventType string, reconcileObject client.Object, status string, message string, version string) {
-	endpoint := config.Instance().GetCloudEventsEndpoint()
-	if endpoint == "" {
-		// if no endpoint is configured we don't emit any event
-		if !strings.HasPrefix(endpoint, "http") {
-			e.logger.V(5).Info(fmt.Sprintf("CloudEvent endpoint configured but it does not start with http: %s", endpoint))
-		}
+	if e.dispatcher == nil {
 		return
 	}
 	event := ce.NewEvent()
@@ -94,11 +94,34 @@ func (e *cloudEvent) Emit(phase apicommon.KeptnPhaseType, eventType string, reco
 		e.logger.V(5).Info(fmt.Sprintf("Failed to set data for CloudEvent: %v", err))
 		return
 	}
+	// the same ID is delivered to all sinks, so that they can detect duplicates
+	event = ceclient.DefaultIDToUUIDIfNotSet(context.TODO(), event)
+	event = ceclient.DefaultTimeToNowIfNotSet(context.TODO(), event)
+	setTraceContext(&event, phase, reconcileObject)
+
+	e.dispatcher.Dispatch(event)
+}
 
-	ctx := ce.ContextWithTarget(context.TODO(), endpoint)
-	if result := e.client.Send(ctx, event); ce.IsUndelivered(result) {
-		e.logger.V(5).Info(fmt.Sprintf("Failed to send CloudEvent: %v", event))
+// setTraceContext adds the W3C trace context of the span of the phase as distributed tracing extension
+// to the Cloud Event. If there is no span for the phase, the trace context of the reconciled object is used.
+func setTraceContext(event *ce.Event, phase apicommon.KeptnPhaseType, reconcileObject client.Object) {
+	var carrier propagation.MapCarrier
+	switch obj := reconcileObject.(type) {
+	case *klcv1beta1.KeptnAppVersion:
+		carrier = obj.Status.PhaseTraceIDs.GetPhaseTraceID(phase.ShortName)
+	case *klcv1beta1.KeptnWorkloadVersion:
+		carrier = obj.Status.PhaseTraceIDs.GetPhaseTraceID(phase.ShortName)
+	}
+	if carrier.Get("traceparent") == "" {
+		carrier = reconcileObject.GetAnnotations()
+	}
+	if carrier.Get("traceparent") == "" {
+		return
 	}
+	extensions.DistributedTracingExtension{
+		TraceParent: carrier.Get("traceparent"),
+		TraceState:  carrier.Get("tracestate"),
+	}.AddTracingAttributes(event)
 }
 
 // ===== K8s Event Sender =====
@@ -76,7 +76,7 @@ func TestEventSender_SendCloudEvent(t *testing.T) {
 	if err != nil {
 		log.Fatalf("failed to create client, %v", err)
 	}
-	ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), c)
+	ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), NewDispatcher(ctrl.Log.WithName("testytest"), c, newTestMeters(t, nil)))
 	ceSender.Emit(phase, eventType, &v1beta1.KeptnAppVersion{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      name,
@@ -124,7 +124,7 @@ func TestEventSender_CloudEventNoFailure(t *testing.T) {
 		if err != nil {
 			log.Fatalf("failed to create client, %v", err)
 		}
-		ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), c)
+		ceSender := newCloudEventSender(ctrl.Log.WithName("testytest"), NewDispatcher(ctrl.Log.WithName("testytest"), c, newTestMeters(t, nil)))
 		ceSender.Emit(common.PhaseAppCompleted, "type", &v1beta1.KeptnAppVersion{
 			ObjectMeta: v1.ObjectMeta{
 				Name:      "app",
@@ -159,10 +159,14 @@ func TestEventSender_Multiplexer_register(t *testing.T) {
 func TestEventSender_Multiplexer_new(t *testing.T) {
 	// when
 	// init the object
-	em := NewEventMultiplexer(zap.New(), nil, nil)
+	em := NewEventMultiplexer(zap.New(), nil, nil, nil)
 	// then assert
 	// k8s and ce are registered
 	require.Equal(t, 2, len(em.emitters))
+
+	// the notifier is registered if it is given
+	em = NewEventMultiplexer(zap.New(), nil, nil, NewNotifier(zap.New(), nil))
+	require.Equal(t, 3, len(em.emitters))
 }
 
 func TestEventSender_Multiplexer_emit(t *testing.T) {
@@ -0,0 +1,329 @@
+package eventsender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const notificationTimeout = 10 * time.Second
+
+// Notifier sends human-readable notifications about the phases of Keptn resources to the webhooks
+// configured in KeptnNotificationConfigs. Notifications about the same resource and phase are
+// deduplicated and rate limited per KeptnNotificationConfig.
+type Notifier struct {
+	client     client.Reader
+	httpClient *http.Client
+	logger     logr.Logger
+	config     config.IConfig
+	now        func() time.Time
+
+	mtx     sync.Mutex
+	dedup   map[string]time.Time
+	windows map[string]*rateLimitWindow
+}
+
+// rateLimitWindow contains the times of the notifications that have been sent about a resource and phase
+// within the interval of the rate limit
+type rateLimitWindow struct {
+	sent     []time.Time
+	interval time.Duration
+}
+
+// notificationData contains the values that can be referenced in the template of a KeptnNotificationConfig
+type notificationData struct {
+	Phase          string
+	PhaseShortName string
+	State          string
+	EventType      string
+	Message        string
+	Version        string
+	Kind           string
+	Name           string
+	Namespace      string
+	AppName        string
+	WorkloadName   string
+	Annotations    map[string]string
+	Time           time.Time
+}
+
+func NewNotifier(logger logr.Logger, client client.Reader) *Notifier {
+	return &Notifier{
+		client:     client,
+		httpClient: &http.Client{Timeout: notificationTimeout},
+		logger:     logger,
+		config:     config.Instance(),
+		now:        time.Now,
+		dedup:      map[string]time.Time{},
+		windows:    map[string]*rateLimitWindow{},
+	}
+}
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnnotificationconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapps,verbs=get
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// Emit sends a notification to the webhook of every KeptnNotificationConfig that selects the phase, state and resource
+func (n *Notifier) Emit(phase apicommon.KeptnPhaseType, eventType string, reconcileObject client.Object, status string, message string, version string) {
+	if reconcileObject == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+	defer cancel()
+
+	notificationConfigs := &klcv1beta1.KeptnNotificationConfigList{}
+	if err := n.client.List(ctx, notificationConfigs); err != nil {
+		n.logger.Error(err, "Could not list KeptnNotificationConfigs")
+		return
+	}
+	if len(notificationConfigs.Items) == 0 {
+		return
+	}
+
+	data := newNotificationData(phase, eventType, reconcileObject, status, message, version)
+	data.Time = n.now()
+	labels := &labelCache{}
+	for _, notificationConfig := range notificationConfigs.Items {
+		selected, err := n.selects(ctx, notificationConfig, phase, data, labels)
+		if err != nil {
+			n.logger.Error(err, "Could not evaluate selectors of KeptnNotificationConfig", "notificationConfig", notificationConfig.Name, "namespace", notificationConfig.Namespace)
+			continue
+		}
+		if !selected {
+			continue
+		}
+		if !n.allow(notificationConfig, data) {
+			n.logger.V(5).Info("Suppressing notification", "notificationConfig", notificationConfig.Name, "namespace", notificationConfig.Namespace, "object", data.Name, "phase", data.PhaseShortName, "state", data.State)
+			continue
+		}
+		if err := n.notify(ctx, notificationConfig, data); err != nil {
+			n.logger.Error(err, "Could not send notification", "notificationConfig", notificationConfig.Name, "namespace", notificationConfig.Namespace)
+		}
+	}
+}
+
+func newNotificationData(phase apicommon.KeptnPhaseType, eventType string, reconcileObject client.Object, status string, message string, version string) notificationData {
+	data := notificationData{
+		Phase:          phase.LongName,
+		PhaseShortName: phase.ShortName,
+		State:          status,
+		EventType:      eventType,
+		Message:        message,
+		Version:        version,
+		Kind:           reconcileObject.GetObjectKind().GroupVersionKind().Kind,
+		Name:           reconcileObject.GetName(),
+		Namespace:      reconcileObject.GetNamespace(),
+		Annotations:    map[string]string{},
+	}
+	if data.Kind == "" {
+		data.Kind = reflect.Indirect(reflect.ValueOf(reconcileObject)).Type().Name()
+	}
+	if piWrapper, err := interfaces.NewEventObjectWrapperFromClientObject(reconcileObject); err == nil {
+		data.Annotations = piWrapper.GetEventAnnotations()
+		data.AppName = data.Annotations["appName"]
+		data.WorkloadName = data.Annotations["workloadName"]
+	}
+	return data
+}
+
+// labelCache holds the labels of the namespace and the KeptnApp of a resource,
+// so that they are fetched at most once per notification
+type labelCache struct {
+	namespace  map[string]string
+	app        map[string]string
+	fetchedNs  bool
+	fetchedApp bool
+}
+
+// selects returns whether the KeptnNotificationConfig selects the phase, state, namespace and KeptnApp of the notification.
+// KeptnNotificationConfigs outside the namespace of Keptn only select resources in their own namespace.
+func (n *Notifier) selects(ctx context.Context, notificationConfig klcv1beta1.KeptnNotificationConfig, phase apicommon.KeptnPhaseType, data notificationData, labels *labelCache) (bool, error) {
+	if notificationConfig.Namespace != n.config.GetDefaultNamespace() {
+		if notificationConfig.Namespace != data.Namespace {
+			return false, nil
+		}
+		notificationConfig.Spec.NamespaceSelector = nil
+	}
+	if !notificationConfig.SelectsPhase(phase, data.State) {
+		return false, nil
+	}
+
+	if notificationConfig.Spec.NamespaceSelector != nil && !labels.fetchedNs {
+		ns := &corev1.Namespace{}
+		if err := n.client.Get(ctx, types.NamespacedName{Name: data.Namespace}, ns); err != nil {
+			return false, err
+		}
+		labels.namespace, labels.fetchedNs = ns.Labels, true
+	}
+	if notificationConfig.Spec.AppSelector != nil && !labels.fetchedApp {
+		if data.AppName != "" {
+			app := &klcv1beta1.KeptnApp{}
+			err := n.client.Get(ctx, types.NamespacedName{Namespace: data.Namespace, Name: data.AppName}, app)
+			if err != nil && !errors.IsNotFound(err) {
+				return false, err
+			}
+			labels.app = app.Labels
+		}
+		labels.fetchedApp = true
+	}
+	return notificationConfig.Selects(labels.namespace, labels.app)
+}
+
+// allow returns whether a notification may be sent, and records it if so.
+// A notification is suppressed if the same state of the phase of the resource has already been notified
+// within the deduplication window, or if the rate limit for the phase of the resource is exceeded.
+func (n *Notifier) allow(notificationConfig klcv1beta1.KeptnNotificationConfig, data notificationData) bool {
+	now := data.Time
+	rateLimitKey := strings.Join([]string{notificationConfig.Namespace, notificationConfig.Name, data.Kind, data.Namespace, data.Name, data.PhaseShortName}, "/")
+	dedupKey := rateLimitKey + "/" + data.State
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.prune(now)
+
+	if until, ok := n.dedup[dedupKey]; ok && now.Before(until) {
+		return false
+	}
+
+	limit, interval := notificationConfig.GetRateLimit()
+	window, ok := n.windows[rateLimitKey]
+	if !ok {
+		window = &rateLimitWindow{}
+		n.windows[rateLimitKey] = window
+	}
+	window.interval = interval
+	window.prune(now)
+	if len(window.sent) >= limit {
+		return false
+	}
+	window.sent = append(window.sent, now)
+
+	if dedupWindow := notificationConfig.GetDeduplicationWindow(); dedupWindow > 0 {
+		n.dedup[dedupKey] = now.Add(dedupWindow)
+	}
+	return true
+}
+
+// prune removes the records of notifications that are outside the deduplication and rate limit windows
+func (n *Notifier) prune(now time.Time) {
+	for key, until := range n.dedup {
+		if !now.Before(until) {
+			delete(n.dedup, key)
+		}
+	}
+	for key, window := range n.windows {
+		window.prune(now)
+		if len(window.sent) == 0 {
+			delete(n.windows, key)
+		}
+	}
+}
+
+func (w *rateLimitWindow) prune(now time.Time) {
+	i := 0
+	for i < len(w.sent) && now.Sub(w.sent[i]) >= w.interval {
+		i++
+	}
+	w.sent = w.sent[i:]
+}
+
+// notify renders the message of the notification and sends it to the webhook of the KeptnNotificationConfig
+func (n *Notifier) notify(ctx context.Context, notificationConfig klcv1beta1.KeptnNotificationConfig, data notificationData) error {
+	tmpl, err := notificationConfig.ParseTemplate()
+	if err != nil {
+		return err
+	}
+	message := &bytes.Buffer{}
+	if err := tmpl.Execute(message, data); err != nil {
+		return err
+	}
+	payload, err := buildPayload(notificationConfig.GetFormat(), message.String(), data)
+	if err != nil {
+		return err
+	}
+
+	url, err := n.getWebhookURL(ctx, notificationConfig)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (n *Notifier) getWebhookURL(ctx context.Context, notificationConfig klcv1beta1.KeptnNotificationConfig) (string, error) {
+	ref := notificationConfig.Spec.WebhookSecretRef
+	secret := &corev1.Secret{}
+	if err := n.client.Get(ctx, types.NamespacedName{Namespace: notificationConfig.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", err
+	}
+	url := strings.TrimSpace(string(secret.Data[ref.Key]))
+	if url == "" {
+		return "", fmt.Errorf("secret %s does not contain the key %s", ref.Name, ref.Key)
+	}
+	return url, nil
+}
+
+// buildPayload wraps the message into the payload of the given format
+func buildPayload(format string, message string, data notificationData) ([]byte, error) {
+	switch format {
+	case klcv1beta1.NotificationFormatRaw:
+		if !json.Valid([]byte(message)) {
+			return nil, fmt.Errorf("rendered template is not a valid JSON document")
+		}
+		return []byte(message), nil
+	case klcv1beta1.NotificationFormatTeams:
+		title := fmt.Sprintf("%s %s", data.Phase, data.State)
+		return json.Marshal(map[string]string{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    title,
+			"title":      title,
+			"themeColor": getThemeColor(data.State),
+			"text":       message,
+		})
+	default:
+		return json.Marshal(map[string]string{
+			"text": message,
+		})
+	}
+}
+
+func getThemeColor(state string) string {
+	switch state {
+	case apicommon.PhaseStateFailed:
+		return "D70000"
+	case apicommon.PhaseStateFinished:
+		return "2EB886"
+	default:
+		return "0076D7"
+	}
+}
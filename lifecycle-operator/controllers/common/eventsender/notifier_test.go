@@ -0,0 +1,189 @@
+package eventsender
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	fakeconfig "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newTestWebhook returns a webhook that forwards the bodies of the received requests to the returned channel
+func newTestWebhook(t *testing.T) (*httptest.Server, chan string) {
+	received := make(chan string, 10)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		received <- string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(svr.Close)
+	return svr, received
+}
+
+func newTestNotifier(t *testing.T, url string, objs ...client.Object) *Notifier {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: testcommon.KeptnNamespace},
+		Data:       map[string][]byte{"url": []byte(url)},
+	}
+	teamSecret := secret.DeepCopy()
+	teamSecret.Namespace = "default"
+	n := NewNotifier(testr.New(t), testcommon.NewTestClient(append(objs, secret, teamSecret)...))
+	n.config = &fakeconfig.MockConfig{
+		GetDefaultNamespaceFunc: func() string {
+			return testcommon.KeptnNamespace
+		},
+	}
+	return n
+}
+
+func newTestNotificationConfig(name string, namespace string, spec klcv1beta1.KeptnNotificationConfigSpec) *klcv1beta1.KeptnNotificationConfig {
+	spec.WebhookSecretRef = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+		Key:                  "url",
+	}
+	return &klcv1beta1.KeptnNotificationConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       spec,
+	}
+}
+
+func newTestAppVersion(namespace string) *klcv1beta1.KeptnAppVersion {
+	return &klcv1beta1.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app-1.0.0", Namespace: namespace},
+		Spec: klcv1beta1.KeptnAppVersionSpec{
+			AppName:      "my-app",
+			KeptnAppSpec: klcv1beta1.KeptnAppSpec{Version: "1.0.0"},
+		},
+	}
+}
+
+func TestNotifier_SendsNotifications(t *testing.T) {
+	svr, received := newTestWebhook(t)
+	n := newTestNotifier(t, svr.URL,
+		newTestNotificationConfig("slack", testcommon.KeptnNamespace, klcv1beta1.KeptnNotificationConfigSpec{}),
+		newTestNotificationConfig("raw", testcommon.KeptnNamespace, klcv1beta1.KeptnNotificationConfigSpec{
+			Format:   klcv1beta1.NotificationFormatRaw,
+			Template: `{"app": {{ json .AppName }}, "phase": {{ json .PhaseShortName }}, "state": {{ json .State }}}`,
+		}),
+	)
+
+	n.Emit(apicommon.PhaseAppDeployment, "Warning", newTestAppVersion("default"), apicommon.PhaseStateFailed, "has failed", "1.0.0")
+
+	// the KeptnNotificationConfigs are listed in alphabetical order
+	require.Len(t, received, 2)
+	require.JSONEq(t, `{"app": "my-app", "phase": "AppDeploy", "state": "Failed"}`, <-received)
+	slack := map[string]string{}
+	require.Nil(t, json.Unmarshal([]byte(<-received), &slack))
+	require.Equal(t, "App Deployment Failed: KeptnAppVersion default/my-app-1.0.0 (version 1.0.0) has failed", slack["text"])
+}
+
+func TestNotifier_Selection(t *testing.T) {
+	svr, received := newTestWebhook(t)
+	production := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "production", Labels: map[string]string{"environment": "production"}},
+	}
+	app := &klcv1beta1.KeptnApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "production", Labels: map[string]string{"team": "checkout"}},
+	}
+	n := newTestNotifier(t, svr.URL, production, app,
+		newTestNotificationConfig("production-failures", testcommon.KeptnNamespace, klcv1beta1.KeptnNotificationConfigSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "production"}},
+			AppSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"team": "checkout"}},
+			Phases:            []string{"AppDeploy"},
+			States:            []string{apicommon.PhaseStateFailed},
+		}),
+		// configs outside the namespace of Keptn only notify about their own namespace
+		newTestNotificationConfig("team", "default", klcv1beta1.KeptnNotificationConfigSpec{
+			Template: "team",
+		}),
+	)
+
+	n.Emit(apicommon.PhaseAppDeployment, "Warning", newTestAppVersion("production"), apicommon.PhaseStateFailed, "has failed", "1.0.0")
+	require.Len(t, received, 1)
+	require.NotContains(t, <-received, "team")
+
+	n.Emit(apicommon.PhaseAppDeployment, "Normal", newTestAppVersion("production"), apicommon.PhaseStateFinished, "has finished", "1.0.0")
+	n.Emit(apicommon.PhaseAppPreDeployment, "Warning", newTestAppVersion("production"), apicommon.PhaseStateFailed, "has failed", "1.0.0")
+	require.Empty(t, received)
+
+	n.Emit(apicommon.PhaseAppDeployment, "Normal", newTestAppVersion("default"), apicommon.PhaseStateStarted, "has started", "1.0.0")
+	require.Len(t, received, 1)
+	require.JSONEq(t, `{"text": "team"}`, <-received)
+}
+
+func TestNotifier_DeduplicatesAndRateLimits(t *testing.T) {
+	svr, received := newTestWebhook(t)
+	n := newTestNotifier(t, svr.URL,
+		newTestNotificationConfig("slack", testcommon.KeptnNamespace, klcv1beta1.KeptnNotificationConfigSpec{
+			States:              []string{apicommon.PhaseStateStarted, apicommon.PhaseStateFailed, apicommon.PhaseStateFinished},
+			RateLimit:           &klcv1beta1.NotificationRateLimit{Notifications: 2, Interval: metav1.Duration{Duration: time.Minute}},
+			DeduplicationWindow: &metav1.Duration{Duration: 10 * time.Minute},
+		}),
+	)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	n.now = func() time.Time {
+		return now
+	}
+	appVersion := newTestAppVersion("default")
+
+	// the same state of the phase is only notified once
+	n.Emit(apicommon.PhaseAppDeployment, "Normal", appVersion, apicommon.PhaseStateStarted, "has started", "1.0.0")
+	n.Emit(apicommon.PhaseAppDeployment, "Normal", appVersion, apicommon.PhaseStateStarted, "has started", "1.0.0")
+	require.Len(t, received, 1)
+
+	// other phases are not affected
+	n.Emit(apicommon.PhaseAppPreDeployment, "Normal", appVersion, apicommon.PhaseStateStarted, "has started", "1.0.0")
+	require.Len(t, received, 2)
+
+	// the rate limit of the phase is exceeded
+	n.Emit(apicommon.PhaseAppDeployment, "Warning", appVersion, apicommon.PhaseStateFailed, "has failed", "1.0.0")
+	n.Emit(apicommon.PhaseAppDeployment, "Normal", appVersion, apicommon.PhaseStateFinished, "has finished", "1.0.0")
+	require.Len(t, received, 3)
+
+	// the rate limit is replenished after the interval, but the deduplication window is still active
+	now = now.Add(2 * time.Minute)
+	n.Emit(apicommon.PhaseAppDeployment, "Normal", appVersion, apicommon.PhaseStateStarted, "has started", "1.0.0")
+	n.Emit(apicommon.PhaseAppDeployment, "Normal", appVersion, apicommon.PhaseStateFinished, "has finished", "1.0.0")
+	require.Len(t, received, 4)
+
+	// the records of old notifications are removed
+	now = now.Add(time.Hour)
+	n.mtx.Lock()
+	n.prune(now)
+	require.Empty(t, n.dedup)
+	require.Empty(t, n.windows)
+	n.mtx.Unlock()
+}
+
+func TestBuildPayload(t *testing.T) {
+	data := notificationData{Phase: "App Deployment", State: apicommon.PhaseStateFailed}
+
+	payload, err := buildPayload(klcv1beta1.NotificationFormatTeams, "my-app has failed", data)
+	require.Nil(t, err)
+	teams := map[string]string{}
+	require.Nil(t, json.Unmarshal(payload, &teams))
+	require.Equal(t, "MessageCard", teams["@type"])
+	require.Equal(t, "App Deployment Failed", teams["title"])
+	require.Equal(t, "D70000", teams["themeColor"])
+	require.Equal(t, "my-app has failed", teams["text"])
+
+	payload, err = buildPayload(klcv1beta1.NotificationFormatSlack, `my-app "has failed"`, data)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"text": "my-app \"has failed\""}`, string(payload))
+
+	_, err = buildPayload(klcv1beta1.NotificationFormatRaw, "not json", data)
+	require.NotNil(t, err)
+}
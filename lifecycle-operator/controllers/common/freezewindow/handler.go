@@ -0,0 +1,112 @@
+package freezewindow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Handler struct {
+	client.Client
+	EventSender eventsender.IEvent
+	Log         logr.Logger
+}
+
+// ReconcileFreezeWindows checks whether the deployment of the given KeptnAppVersion or KeptnWorkloadVersion
+// is blocked by an active KeptnFreezeWindow that selects its namespace and KeptnApp.
+// The returned status is nil if no freeze window is active. If the reconcileObject carries the
+// keptn.sh/freeze-override annotation, the freeze window is reported as overridden.
+// An event is emitted whenever the returned status differs from the currentStatus.
+func (r Handler) ReconcileFreezeWindows(ctx context.Context, phase apicommon.KeptnPhaseType, reconcileObject client.Object, appName string, currentStatus *klcv1beta1.FreezeWindowStatus) (*klcv1beta1.FreezeWindowStatus, error) {
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(reconcileObject)
+	if err != nil {
+		return nil, err
+	}
+
+	window, until, err := r.getActiveFreezeWindow(ctx, reconcileObject.GetNamespace(), appName, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if window == nil {
+		if currentStatus.IsBlocking() {
+			r.EventSender.Emit(phase, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("is no longer blocked by KeptnFreezeWindow %s", currentStatus.Name), piWrapper.GetVersion())
+		}
+		return nil, nil
+	}
+
+	newStatus := &klcv1beta1.FreezeWindowStatus{
+		Name:    window.Name,
+		Until:   v1.NewTime(until),
+		Message: window.Spec.Message,
+	}
+	if reason, ok := reconcileObject.GetAnnotations()[apicommon.FreezeOverrideAnnotation]; ok {
+		newStatus.Overridden = true
+		newStatus.OverrideReason = reason
+	}
+
+	if currentStatus != nil && currentStatus.Name == newStatus.Name && currentStatus.Overridden == newStatus.Overridden {
+		return newStatus, nil
+	}
+	if newStatus.Overridden {
+		r.Log.Info("KeptnFreezeWindow has been overridden", "freezeWindow", window.Name, "object", reconcileObject.GetName(), "namespace", reconcileObject.GetNamespace(), "reason", newStatus.OverrideReason)
+		r.EventSender.Emit(phase, "Warning", reconcileObject, apicommon.PhaseStateOverridden, fmt.Sprintf("overrides KeptnFreezeWindow %s: %s", window.Name, newStatus.OverrideReason), piWrapper.GetVersion())
+	} else {
+		r.EventSender.Emit(phase, "Warning", reconcileObject, apicommon.PhaseStateBlocked, fmt.Sprintf("is blocked by KeptnFreezeWindow %s until %s: %s", window.Name, until.Format(time.RFC3339), window.Spec.Message), piWrapper.GetVersion())
+	}
+	return newStatus, nil
+}
+
+// getActiveFreezeWindow returns the active freeze window selecting the given namespace and KeptnApp that ends last
+func (r Handler) getActiveFreezeWindow(ctx context.Context, namespace string, appName string, now time.Time) (*klcv1beta1.KeptnFreezeWindow, time.Time, error) {
+	windows := &klcv1beta1.KeptnFreezeWindowList{}
+	if err := r.Client.List(ctx, windows); err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(windows.Items) == 0 {
+		return nil, time.Time{}, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil && !errors.IsNotFound(err) {
+		return nil, time.Time{}, err
+	}
+	app := &klcv1beta1.KeptnApp{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: appName, Namespace: namespace}, app); err != nil && !errors.IsNotFound(err) {
+		return nil, time.Time{}, err
+	}
+
+	var activeWindow *klcv1beta1.KeptnFreezeWindow
+	var activeUntil time.Time
+	for i := range windows.Items {
+		window := &windows.Items[i]
+		selected, err := window.Selects(ns.Labels, app.Labels)
+		if err != nil {
+			r.Log.Error(err, "could not evaluate selectors of KeptnFreezeWindow", "freezeWindow", window.Name)
+			continue
+		}
+		if !selected {
+			continue
+		}
+		until, active, err := window.GetActiveUntil(now)
+		if err != nil {
+			r.Log.Error(err, "could not evaluate schedule of KeptnFreezeWindow", "freezeWindow", window.Name)
+			continue
+		}
+		if active && until.After(activeUntil) {
+			activeWindow = window
+			activeUntil = until
+		}
+	}
+	return activeWindow, activeUntil, nil
+}
@@ -0,0 +1,144 @@
+package freezewindow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestHandler_ReconcileFreezeWindows(t *testing.T) {
+	start := v1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	duration := v1.Duration{Duration: 2 * time.Hour}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: v1.ObjectMeta{Name: "production", Labels: map[string]string{"env": "production"}},
+	}
+	app := &klcv1beta1.KeptnApp{
+		ObjectMeta: v1.ObjectMeta{Name: "my-app", Namespace: "production", Labels: map[string]string{"tier": "frontend"}},
+	}
+	activeWindow := &klcv1beta1.KeptnFreezeWindow{
+		ObjectMeta: v1.ObjectMeta{Name: "release-freeze"},
+		Spec: klcv1beta1.KeptnFreezeWindowSpec{
+			Start:             &start,
+			Duration:          duration,
+			NamespaceSelector: &v1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+			Message:           "release freeze",
+		},
+	}
+	otherAppWindow := &klcv1beta1.KeptnFreezeWindow{
+		ObjectMeta: v1.ObjectMeta{Name: "backend-freeze"},
+		Spec: klcv1beta1.KeptnFreezeWindowSpec{
+			Start:       &start,
+			Duration:    duration,
+			AppSelector: &v1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}},
+		},
+	}
+	pastWindow := &klcv1beta1.KeptnFreezeWindow{
+		ObjectMeta: v1.ObjectMeta{Name: "past-freeze"},
+		Spec: klcv1beta1.KeptnFreezeWindowSpec{
+			Start:    &start,
+			Duration: v1.Duration{Duration: time.Minute},
+		},
+	}
+
+	appVersion := func(annotations map[string]string) *klcv1beta1.KeptnAppVersion {
+		return &klcv1beta1.KeptnAppVersion{
+			ObjectMeta: v1.ObjectMeta{Name: "my-app-1.0.0", Namespace: "production", Annotations: annotations},
+			Spec:       klcv1beta1.KeptnAppVersionSpec{AppName: "my-app"},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		objects       []client.Object
+		object        *klcv1beta1.KeptnAppVersion
+		currentStatus *klcv1beta1.FreezeWindowStatus
+		wantStatus    *klcv1beta1.FreezeWindowStatus
+		wantEvent     string
+	}{
+		{
+			name:    "no freeze windows",
+			objects: []client.Object{namespace, app},
+			object:  appVersion(nil),
+		},
+		{
+			name:    "freeze windows not active or not selecting the app",
+			objects: []client.Object{namespace, app, otherAppWindow, pastWindow},
+			object:  appVersion(nil),
+		},
+		{
+			name:       "blocked by active freeze window",
+			objects:    []client.Object{namespace, app, activeWindow, otherAppWindow},
+			object:     appVersion(nil),
+			wantStatus: &klcv1beta1.FreezeWindowStatus{Name: "release-freeze", Message: "release freeze"},
+			wantEvent:  "Warning AppPreDeployTasksBlocked",
+		},
+		{
+			name:          "still blocked by active freeze window",
+			objects:       []client.Object{namespace, app, activeWindow},
+			object:        appVersion(nil),
+			currentStatus: &klcv1beta1.FreezeWindowStatus{Name: "release-freeze"},
+			wantStatus:    &klcv1beta1.FreezeWindowStatus{Name: "release-freeze", Message: "release freeze"},
+		},
+		{
+			name:          "freeze window overridden",
+			objects:       []client.Object{namespace, app, activeWindow},
+			object:        appVersion(map[string]string{apicommon.FreezeOverrideAnnotation: "hotfix for incident 42"}),
+			currentStatus: &klcv1beta1.FreezeWindowStatus{Name: "release-freeze"},
+			wantStatus: &klcv1beta1.FreezeWindowStatus{
+				Name:           "release-freeze",
+				Message:        "release freeze",
+				Overridden:     true,
+				OverrideReason: "hotfix for incident 42",
+			},
+			wantEvent: "Warning AppPreDeployTasksOverridden",
+		},
+		{
+			name:          "freeze window ended",
+			objects:       []client.Object{namespace, app, pastWindow},
+			object:        appVersion(nil),
+			currentStatus: &klcv1beta1.FreezeWindowStatus{Name: "past-freeze"},
+			wantEvent:     "Normal AppPreDeployTasksStatusChanged",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(100)
+			handler := Handler{
+				Client:      testcommon.NewTestClient(tt.objects...),
+				EventSender: eventsender.NewK8sSender(recorder),
+				Log:         ctrl.Log.WithName("freezewindow-handler"),
+			}
+
+			status, err := handler.ReconcileFreezeWindows(context.TODO(), apicommon.PhaseAppPreDeployment, tt.object, tt.object.GetAppName(), tt.currentStatus)
+			require.Nil(t, err)
+
+			if tt.wantStatus == nil {
+				require.Nil(t, status)
+			} else {
+				require.NotNil(t, status)
+				require.True(t, start.Add(duration.Duration).Equal(status.Until.Time))
+				status.Until = v1.Time{}
+				require.Equal(t, tt.wantStatus, status)
+			}
+
+			if tt.wantEvent == "" {
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			require.Contains(t, <-recorder.Events, tt.wantEvent)
+		})
+	}
+}
@@ -3,13 +3,17 @@ package common
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	keptncontext "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/context"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"golang.org/x/exp/maps"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,6 +54,26 @@ func MergeMaps[M1 ~map[K]V, K comparable, V any](map1 M1, map2 M1) M1 {
 	return merged
 }
 
+// ShouldExecute evaluates the when expression of the ExecutionCondition referring to the given task or evaluation.
+// It returns true if there is no such condition.
+func ShouldExecute(phaseCtx context.Context, piWrapper *interfaces.PhaseItemWrapper, name string, checkType apicommon.CheckType) (bool, error) {
+	for _, condition := range piWrapper.GetExecutionConditions() {
+		if condition.Name != name {
+			continue
+		}
+		metadata, _ := keptncontext.GetAppMetadataFromContext(phaseCtx)
+		return apicommon.EvaluateCondition(condition.When, apicommon.ConditionVariables{
+			Metadata:        metadata,
+			Version:         piWrapper.GetVersion(),
+			PreviousVersion: piWrapper.GetPreviousVersion(),
+			TraceId:         piWrapper.GetTraceId(),
+			CheckType:       checkType,
+			Now:             time.Now().UTC(),
+		})
+	}
+	return true, nil
+}
+
 func GetTaskDefinition(k8sclient client.Client, log logr.Logger, ctx context.Context, definitionName string, namespace string) (*klcv1beta1.KeptnTaskDefinition, error) {
 	definition := &klcv1beta1.KeptnTaskDefinition{}
 	if err := getObject(k8sclient, log, ctx, definitionName, namespace, definition); err != nil {
@@ -66,6 +90,18 @@ func GetEvaluationDefinition(k8sclient client.Client, log logr.Logger, ctx conte
 	return definition, nil
 }
 
+// GetAnalysisDefinition retrieves the AnalysisDefinition of the metrics operator from the given namespace,
+// or from the Keptn namespace if it does not exist in the given namespace
+func GetAnalysisDefinition(k8sclient client.Client, log logr.Logger, ctx context.Context, definitionName string, namespace string) (*unstructured.Unstructured, error) {
+	definition := &unstructured.Unstructured{}
+	definition.SetAPIVersion("metrics.keptn.sh/v1beta1")
+	definition.SetKind("AnalysisDefinition")
+	if err := getObject(k8sclient, log, ctx, definitionName, namespace, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
 func getObject(k8sclient client.Client, log logr.Logger, ctx context.Context, definitionName string, namespace string, definition client.Object) error {
 	err := k8sclient.Get(ctx, types.NamespacedName{Name: definitionName, Namespace: namespace}, definition)
 	if err != nil {
@@ -101,3 +137,16 @@ func KeptnWorkloadVersionResourceRefUIDIndexFunc(rawObj client.Object) []string
 	}
 	return []string{string(workloadVersion.Spec.ResourceReference.UID)}
 }
+
+func KeptnWorkloadVersionResourceRefPodIndexFunc(rawObj client.Object) []string {
+	// Extract the name of the bare Pod referenced by the KeptnWorkloadVersion Spec, if one is provided.
+	// The UID of a bare Pod is not known at admission time, hence the name is used to look up its KeptnWorkloadVersions
+	workloadVersion, ok := rawObj.(*klcv1beta1.KeptnWorkloadVersion)
+	if !ok {
+		return nil
+	}
+	if workloadVersion.Spec.ResourceReference.Kind != "Pod" || workloadVersion.Spec.ResourceReference.Name == "" {
+		return nil
+	}
+	return []string{workloadVersion.Spec.ResourceReference.Name}
+}
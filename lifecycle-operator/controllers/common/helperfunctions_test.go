@@ -440,3 +440,50 @@ func Test_resourceRefUIDIndexFunc(t *testing.T) {
 		})
 	}
 }
+
+func Test_resourceRefPodIndexFunc(t *testing.T) {
+	tests := []struct {
+		name   string
+		rawObj client.Object
+		want   []string
+	}{
+		{
+			name: "get name of referenced pod",
+			rawObj: &klcv1beta1.KeptnWorkloadVersion{
+				Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+					KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+						ResourceReference: klcv1beta1.ResourceReference{
+							Kind: "Pod",
+							Name: "my-pod",
+						},
+					},
+				},
+			},
+			want: []string{"my-pod"},
+		},
+		{
+			name: "resource reference is not a pod",
+			rawObj: &klcv1beta1.KeptnWorkloadVersion{
+				Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+					KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+						ResourceReference: klcv1beta1.ResourceReference{
+							Kind: "ReplicaSet",
+							Name: "my-replicaset",
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name:   "not a KeptnWorkloadVersion",
+			rawObj: &v1.Pod{},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, KeptnWorkloadVersionResourceRefPodIndexFunc(tt.rawObj))
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	operatorcommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultInterval = time.Hour
+
+// Pruner periodically deletes the parts of the deployment history that exceed the retention policy of the KeptnConfig
+type Pruner struct {
+	client.Client
+	Log    logr.Logger
+	Meters apicommon.KeptnMeters
+	config config.IConfig
+}
+
+func NewPruner(client client.Client, log logr.Logger, meters apicommon.KeptnMeters) *Pruner {
+	return &Pruner{
+		Client: client,
+		Log:    log,
+		Meters: meters,
+		config: config.Instance(),
+	}
+}
+
+// historyItem is a completed or running part of the deployment history
+type historyItem struct {
+	object          client.Object
+	kind            string
+	group           string
+	state           apicommon.KeptnState
+	endTime         metav1.Time
+	version         string
+	previousVersion string
+	// workloadVersions contains the names of the KeptnWorkloadVersions that are part of a KeptnAppVersion
+	workloadVersions []string
+}
+
+// age returns the time since the item has been completed, or created if the end time is not known
+func (h historyItem) age(now time.Time) time.Duration {
+	completion := h.endTime.Time
+	if completion.IsZero() {
+		completion = h.object.GetCreationTimestamp().Time
+	}
+	return now.Sub(completion)
+}
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnworkloadversions,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnevaluations,verbs=get;list;watch;delete
+
+// Start prunes the deployment history once at startup and then in the interval of the retention policy
+// until the context is cancelled.
+// Since the Pruner does not implement LeaderElectionRunnable, the manager only starts it on the leader,
+// after the caches have been synced.
+func (p *Pruner) Start(ctx context.Context) error {
+	if err := p.Prune(ctx); err != nil {
+		p.Log.Error(err, "could not prune deployment history")
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(p.getInterval()):
+			if err := p.Prune(ctx); err != nil {
+				p.Log.Error(err, "could not prune deployment history")
+			}
+		}
+	}
+}
+
+func (p *Pruner) getInterval() time.Duration {
+	retention := p.config.GetRetention()
+	if retention == nil || retention.Interval.Duration <= 0 {
+		return defaultInterval
+	}
+	return retention.Interval.Duration
+}
+
+// Prune deletes the completed KeptnAppVersions and KeptnWorkloadVersions exceeding the retention policy
+// together with their KeptnTasks and KeptnEvaluations. KeptnTasks and KeptnEvaluations without an owner
+// are deleted once they exceed the maximum age.
+// The versions that are needed to roll back the remaining KeptnAppVersions, or to compare their evaluations
+// against a baseline, are kept.
+func (p *Pruner) Prune(ctx context.Context) error {
+	retention := p.config.GetRetention()
+	if retention == nil {
+		return nil
+	}
+	now := time.Now()
+
+	children, standalone, err := p.getTasksAndEvaluations(ctx)
+	if err != nil {
+		return err
+	}
+	versions, err := p.getVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	appGroups := map[string][]historyItem{}
+	workloadGroups := map[string][]historyItem{}
+	for _, item := range versions {
+		if len(item.workloadVersions) > 0 || item.kind == "KeptnAppVersion" {
+			appGroups[item.group] = append(appGroups[item.group], item)
+		} else {
+			workloadGroups[item.group] = append(workloadGroups[item.group], item)
+		}
+	}
+
+	var errs []error
+	deleteWithChildren := func(item historyItem) {
+		// children are deleted explicitly, so they are counted as well
+		for _, child := range children[item.object.GetUID()] {
+			errs = append(errs, p.delete(ctx, child))
+		}
+		errs = append(errs, p.delete(ctx, item))
+	}
+
+	// the KeptnWorkloadVersions of the remaining KeptnAppVersions are restored by a rollback, hence they are kept
+	referencedWorkloadVersions := map[types.NamespacedName]bool{}
+	for _, items := range appGroups {
+		policy := retention.GetPolicy(items[0].object.GetNamespace())
+		expired := selectExpired(items, policy, now)
+		for _, item := range items {
+			if isSelected(item, expired) {
+				continue
+			}
+			for _, name := range item.workloadVersions {
+				referencedWorkloadVersions[types.NamespacedName{Namespace: item.object.GetNamespace(), Name: name}] = true
+			}
+		}
+		for _, item := range expired {
+			deleteWithChildren(item)
+		}
+	}
+
+	for _, items := range workloadGroups {
+		policy := retention.GetPolicy(items[0].object.GetNamespace())
+		for _, item := range selectExpired(items, policy, now) {
+			if referencedWorkloadVersions[types.NamespacedName{Namespace: item.object.GetNamespace(), Name: item.object.GetName()}] {
+				continue
+			}
+			deleteWithChildren(item)
+		}
+	}
+
+	for _, item := range standalone {
+		policy := retention.GetPolicy(item.object.GetNamespace())
+		if isExpiredByAge(item, policy, now) {
+			errs = append(errs, p.delete(ctx, item))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getVersions returns all KeptnAppVersions grouped by KeptnApp and all KeptnWorkloadVersions grouped by KeptnWorkload
+func (p *Pruner) getVersions(ctx context.Context) ([]historyItem, error) {
+	appVersions := &klcv1beta1.KeptnAppVersionList{}
+	if err := p.List(ctx, appVersions); err != nil {
+		return nil, fmt.Errorf(controllererrors.ErrCannotRetrieveInstancesMsg, err)
+	}
+	workloadVersions := &klcv1beta1.KeptnWorkloadVersionList{}
+	if err := p.List(ctx, workloadVersions); err != nil {
+		return nil, fmt.Errorf(controllererrors.ErrCannotRetrieveInstancesMsg, err)
+	}
+
+	items := make([]historyItem, 0, len(appVersions.Items)+len(workloadVersions.Items))
+	for i := range appVersions.Items {
+		appVersion := &appVersions.Items[i]
+		workloadVersionNames := make([]string, 0, len(appVersion.Spec.Workloads))
+		for _, workload := range appVersion.Spec.Workloads {
+			workloadVersionNames = append(workloadVersionNames, operatorcommon.CreateResourceName(apicommon.MaxK8sObjectLength, apicommon.MinKeptnNameLen, appVersion.Spec.AppName, workload.Name, workload.Version))
+		}
+		items = append(items, historyItem{
+			object:           appVersion,
+			kind:             "KeptnAppVersion",
+			group:            fmt.Sprintf("KeptnAppVersion/%s/%s", appVersion.Namespace, appVersion.Spec.AppName),
+			state:            appVersion.Status.Status,
+			endTime:          appVersion.Status.EndTime,
+			version:          appVersion.Spec.Version,
+			previousVersion:  appVersion.Spec.PreviousVersion,
+			workloadVersions: workloadVersionNames,
+		})
+	}
+	for i := range workloadVersions.Items {
+		workloadVersion := &workloadVersions.Items[i]
+		items = append(items, historyItem{
+			object:          workloadVersion,
+			kind:            "KeptnWorkloadVersion",
+			group:           fmt.Sprintf("KeptnWorkloadVersion/%s/%s", workloadVersion.Namespace, workloadVersion.Spec.WorkloadName),
+			state:           workloadVersion.Status.Status,
+			endTime:         workloadVersion.Status.EndTime,
+			version:         workloadVersion.Spec.Version,
+			previousVersion: workloadVersion.Spec.PreviousVersion,
+		})
+	}
+	return items, nil
+}
+
+// getTasksAndEvaluations returns the KeptnTasks and KeptnEvaluations indexed by the UID of their owner,
+// as well as the ones without an owner
+func (p *Pruner) getTasksAndEvaluations(ctx context.Context) (map[types.UID][]historyItem, []historyItem, error) {
+	tasks := &klcv1beta1.KeptnTaskList{}
+	if err := p.List(ctx, tasks); err != nil {
+		return nil, nil, fmt.Errorf(controllererrors.ErrCannotRetrieveInstancesMsg, err)
+	}
+	evaluations := &klcv1beta1.KeptnEvaluationList{}
+	if err := p.List(ctx, evaluations); err != nil {
+		return nil, nil, fmt.Errorf(controllererrors.ErrCannotRetrieveInstancesMsg, err)
+	}
+
+	items := make([]historyItem, 0, len(tasks.Items)+len(evaluations.Items))
+	for i := range tasks.Items {
+		task := &tasks.Items[i]
+		items = append(items, historyItem{object: task, kind: "KeptnTask", state: task.Status.Status, endTime: task.Status.EndTime})
+	}
+	for i := range evaluations.Items {
+		evaluation := &evaluations.Items[i]
+		items = append(items, historyItem{object: evaluation, kind: "KeptnEvaluation", state: evaluation.Status.OverallStatus, endTime: evaluation.Status.EndTime})
+	}
+
+	children := map[types.UID][]historyItem{}
+	standalone := []historyItem{}
+	for _, item := range items {
+		if owner := metav1.GetControllerOf(item.object); owner != nil {
+			children[owner.UID] = append(children[owner.UID], item)
+		} else {
+			standalone = append(standalone, item)
+		}
+	}
+	return children, standalone, nil
+}
+
+func (p *Pruner) delete(ctx context.Context, item historyItem) error {
+	err := p.Delete(ctx, item.object, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("could not delete %s %s/%s: %w", item.kind, item.object.GetNamespace(), item.object.GetName(), err)
+	}
+	if err == nil {
+		p.Log.Info("Deleted deployment history exceeding the retention policy", "kind", item.kind, "namespace", item.object.GetNamespace(), "name", item.object.GetName())
+		p.Meters.PrunedCount.Add(ctx, 1, metric.WithAttributes(apicommon.PrunedKind.String(item.kind), apicommon.PrunedNamespace.String(item.object.GetNamespace())))
+	}
+	return nil
+}
+
+// selectExpired returns the completed items of a KeptnApp or KeptnWorkload exceeding the history limits or the maximum age.
+// Items that are not completed and the latest completed item are never selected. Neither are the items of the
+// previous version of a remaining item, since they are needed for a rollback and as baseline for evaluations.
+func selectExpired(items []historyItem, policy optionsv1alpha1.RetentionPolicy, now time.Time) []historyItem {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].object.GetCreationTimestamp().After(items[j].object.GetCreationTimestamp().Time)
+	})
+
+	succeeded, failed := 0, 0
+	candidates := []historyItem{}
+	for _, item := range items {
+		if !item.state.IsCompleted() {
+			continue
+		}
+		count, limit := &succeeded, policy.SucceededHistoryLimit
+		if item.state.IsFailed() {
+			count, limit = &failed, policy.FailedHistoryLimit
+		}
+		*count++
+		if succeeded+failed == 1 {
+			continue
+		}
+		if (limit != nil && *count > int(*limit)) || isExpiredByAge(item, policy, now) {
+			candidates = append(candidates, item)
+		}
+	}
+
+	previousVersions := map[string]bool{}
+	for _, item := range items {
+		if item.previousVersion != "" && !isSelected(item, candidates) {
+			previousVersions[item.previousVersion] = true
+		}
+	}
+	expired := []historyItem{}
+	for _, item := range candidates {
+		if !previousVersions[item.version] {
+			expired = append(expired, item)
+		}
+	}
+	return expired
+}
+
+func isSelected(item historyItem, selected []historyItem) bool {
+	for _, s := range selected {
+		if s.object.GetUID() == item.object.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+func isExpiredByAge(item historyItem, policy optionsv1alpha1.RetentionPolicy, now time.Time) bool {
+	return item.state.IsCompleted() && policy.MaxAge != nil && item.age(now) > policy.MaxAge.Duration
+}
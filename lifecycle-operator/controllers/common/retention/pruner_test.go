@@ -0,0 +1,294 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	fakeconfig "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config/fake"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var now = time.Now().Truncate(time.Second)
+
+func getAppVersion(name string, namespace string, state apicommon.KeptnState, age time.Duration) *klcv1beta1.KeptnAppVersion {
+	return &klcv1beta1.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		},
+		Spec: klcv1beta1.KeptnAppVersionSpec{
+			AppName: "my-app",
+		},
+		Status: klcv1beta1.KeptnAppVersionStatus{
+			Status:  state,
+			EndTime: metav1.NewTime(now.Add(-age)),
+		},
+	}
+}
+
+func getTask(name string, namespace string, owner client.Object, age time.Duration) *klcv1beta1.KeptnTask {
+	task := &klcv1beta1.KeptnTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		},
+		Status: klcv1beta1.KeptnTaskStatus{
+			Status:  apicommon.StateSucceeded,
+			EndTime: metav1.NewTime(now.Add(-age)),
+		},
+	}
+	if owner != nil {
+		isController := true
+		task.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: "lifecycle.keptn.sh/v1beta1",
+				Kind:       "KeptnAppVersion",
+				Name:       owner.GetName(),
+				UID:        owner.GetUID(),
+				Controller: &isController,
+			},
+		}
+	}
+	return task
+}
+
+func getWorkloadVersion(name string, version string, previousVersion string, age time.Duration) *klcv1beta1.KeptnWorkloadVersion {
+	return &klcv1beta1.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		},
+		Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+				Version: version,
+			},
+			WorkloadName:    "my-app-my-workload",
+			PreviousVersion: previousVersion,
+		},
+		Status: klcv1beta1.KeptnWorkloadVersionStatus{
+			Status:  apicommon.StateSucceeded,
+			EndTime: metav1.NewTime(now.Add(-age)),
+		},
+	}
+}
+
+func getEvaluation(name string, namespace string, owner client.Object, age time.Duration) *klcv1beta1.KeptnEvaluation {
+	isController := true
+	return &klcv1beta1.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "lifecycle.keptn.sh/v1beta1",
+					Kind:       "KeptnAppVersion",
+					Name:       owner.GetName(),
+					UID:        owner.GetUID(),
+					Controller: &isController,
+				},
+			},
+		},
+		Status: klcv1beta1.KeptnEvaluationStatus{
+			OverallStatus: apicommon.StateSucceeded,
+			EndTime:       metav1.NewTime(now.Add(-age)),
+		},
+	}
+}
+
+func newPruner(t *testing.T, retention *optionsv1alpha1.RetentionSpec, objs ...client.Object) (*Pruner, client.Client) {
+	fakeClient := testcommon.NewTestClient(objs...)
+	prunedCount, err := noop.NewMeterProvider().Meter("test").Int64Counter("keptn.pruned.count")
+	require.Nil(t, err)
+
+	p := NewPruner(fakeClient, testr.New(t), apicommon.KeptnMeters{PrunedCount: prunedCount})
+	p.config = &fakeconfig.MockConfig{
+		GetRetentionFunc: func() *optionsv1alpha1.RetentionSpec {
+			return retention
+		},
+	}
+	return p, fakeClient
+}
+
+func requireExists(t *testing.T, c client.Client, obj client.Object, exists bool) {
+	err := c.Get(context.TODO(), types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj)
+	if exists {
+		require.Nil(t, err, obj.GetName())
+	} else {
+		require.NotNil(t, err, obj.GetName())
+	}
+}
+
+func TestPruner_PruneHistoryLimits(t *testing.T) {
+	latest := getAppVersion("v5", "default", apicommon.StateFailed, time.Hour)
+	progressing := getAppVersion("v4", "default", apicommon.StateProgressing, 2*time.Hour)
+	succeeded := getAppVersion("v3", "default", apicommon.StateSucceeded, 3*time.Hour)
+	deprecated := getAppVersion("v2", "default", apicommon.StateDeprecated, 4*time.Hour)
+	failed := getAppVersion("v1", "default", apicommon.StateFailed, 5*time.Hour)
+	task := getTask("task", "default", deprecated, 4*time.Hour)
+
+	p, fakeClient := newPruner(t, &optionsv1alpha1.RetentionSpec{
+		RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+			SucceededHistoryLimit: int32Ptr(1),
+			FailedHistoryLimit:    int32Ptr(1),
+		},
+	}, latest, progressing, succeeded, deprecated, failed, task)
+
+	err := p.Prune(context.TODO())
+	require.Nil(t, err)
+
+	requireExists(t, fakeClient, latest, true)
+	requireExists(t, fakeClient, progressing, true)
+	requireExists(t, fakeClient, succeeded, true)
+	// the latest failed version counts towards the failed history limit
+	requireExists(t, fakeClient, failed, false)
+	requireExists(t, fakeClient, deprecated, false)
+	requireExists(t, fakeClient, task, false)
+}
+
+func TestPruner_PruneMaxAge(t *testing.T) {
+	// the latest version is kept even if it exceeds the maximum age
+	latest := getAppVersion("v3", "default", apicommon.StateSucceeded, 60*time.Hour)
+	old := getAppVersion("v2", "default", apicommon.StateSucceeded, 72*time.Hour)
+	recent := getAppVersion("v1", "other", apicommon.StateSucceeded, 96*time.Hour)
+	recentLatest := getAppVersion("v4", "other", apicommon.StateSucceeded, time.Hour)
+	standaloneTask := getTask("standalone", "default", nil, 72*time.Hour)
+	recentTask := getTask("recent", "default", nil, time.Hour)
+
+	p, fakeClient := newPruner(t, &optionsv1alpha1.RetentionSpec{
+		RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+			MaxAge: &metav1.Duration{Duration: 48 * time.Hour},
+		},
+		Namespaces: []optionsv1alpha1.NamespaceRetentionPolicy{
+			{
+				Namespace: "other",
+				RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+					MaxAge: &metav1.Duration{Duration: 168 * time.Hour},
+				},
+			},
+		},
+	}, latest, old, recent, recentLatest, standaloneTask, recentTask)
+
+	err := p.Prune(context.TODO())
+	require.Nil(t, err)
+
+	requireExists(t, fakeClient, latest, true)
+	requireExists(t, fakeClient, old, false)
+	requireExists(t, fakeClient, recent, true)
+	requireExists(t, fakeClient, recentLatest, true)
+	requireExists(t, fakeClient, standaloneTask, false)
+	requireExists(t, fakeClient, recentTask, true)
+}
+
+func TestPruner_PruneKeepsLatestCompletedVersion(t *testing.T) {
+	progressing := getAppVersion("v3", "default", apicommon.StateProgressing, time.Hour)
+	latest := getAppVersion("v2", "default", apicommon.StateSucceeded, 2*time.Hour)
+	old := getAppVersion("v1", "default", apicommon.StateSucceeded, 3*time.Hour)
+
+	p, fakeClient := newPruner(t, &optionsv1alpha1.RetentionSpec{
+		RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+			SucceededHistoryLimit: int32Ptr(0),
+		},
+	}, progressing, latest, old)
+
+	err := p.Prune(context.TODO())
+	require.Nil(t, err)
+
+	requireExists(t, fakeClient, progressing, true)
+	requireExists(t, fakeClient, latest, true)
+	requireExists(t, fakeClient, old, false)
+}
+
+func TestPruner_PruneKeepsPreviousVersions(t *testing.T) {
+	latest := getAppVersion("app-v3", "default", apicommon.StateSucceeded, time.Hour)
+	latest.Spec.Version = "v3"
+	latest.Spec.PreviousVersion = "v2"
+	latest.Spec.Workloads = []klcv1beta1.KeptnWorkloadRef{{Name: "my-workload", Version: "1.0.0"}}
+	previous := getAppVersion("app-v2", "default", apicommon.StateSucceeded, 2*time.Hour)
+	previous.Spec.Version = "v2"
+	previous.Spec.PreviousVersion = "v1"
+	previous.Spec.Workloads = []klcv1beta1.KeptnWorkloadRef{{Name: "my-workload", Version: "0.9.0"}}
+	old := getAppVersion("app-v1", "default", apicommon.StateSucceeded, 3*time.Hour)
+	old.Spec.Version = "v1"
+	old.Spec.Workloads = []klcv1beta1.KeptnWorkloadRef{{Name: "my-workload", Version: "0.8.0"}}
+	evaluation := getEvaluation("evaluation", "default", previous, 2*time.Hour)
+
+	latestWorkload := getWorkloadVersion("my-app-my-workload-1.0.0", "1.0.0", "0.9.0", time.Hour)
+	previousWorkload := getWorkloadVersion("my-app-my-workload-0.9.0", "0.9.0", "0.8.0", 2*time.Hour)
+	oldWorkload := getWorkloadVersion("my-app-my-workload-0.8.0", "0.8.0", "", 3*time.Hour)
+
+	p, fakeClient := newPruner(t, &optionsv1alpha1.RetentionSpec{
+		RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+			SucceededHistoryLimit: int32Ptr(1),
+		},
+	}, latest, previous, old, evaluation, latestWorkload, previousWorkload, oldWorkload)
+
+	err := p.Prune(context.TODO())
+	require.Nil(t, err)
+
+	requireExists(t, fakeClient, latest, true)
+	// the previous version is needed for a rollback and as baseline of evaluations
+	requireExists(t, fakeClient, previous, true)
+	requireExists(t, fakeClient, evaluation, true)
+	requireExists(t, fakeClient, old, false)
+	requireExists(t, fakeClient, latestWorkload, true)
+	requireExists(t, fakeClient, previousWorkload, true)
+	requireExists(t, fakeClient, oldWorkload, false)
+}
+
+func TestPruner_PruneWithoutRetention(t *testing.T) {
+	latest := getAppVersion("v2", "default", apicommon.StateSucceeded, time.Hour)
+	old := getAppVersion("v1", "default", apicommon.StateSucceeded, 1000*time.Hour)
+
+	p, fakeClient := newPruner(t, nil, latest, old)
+
+	err := p.Prune(context.TODO())
+	require.Nil(t, err)
+
+	requireExists(t, fakeClient, old, true)
+	require.Equal(t, defaultInterval, p.getInterval())
+}
+
+func TestRetentionSpec_GetPolicy(t *testing.T) {
+	retention := optionsv1alpha1.RetentionSpec{
+		RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+			SucceededHistoryLimit: int32Ptr(10),
+			FailedHistoryLimit:    int32Ptr(5),
+		},
+		Namespaces: []optionsv1alpha1.NamespaceRetentionPolicy{
+			{
+				Namespace: "production",
+				RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+					FailedHistoryLimit: int32Ptr(20),
+				},
+			},
+		},
+	}
+
+	policy := retention.GetPolicy("production")
+	require.Equal(t, int32(10), *policy.SucceededHistoryLimit)
+	require.Equal(t, int32(20), *policy.FailedHistoryLimit)
+	require.Nil(t, policy.MaxAge)
+
+	policy = retention.GetPolicy("default")
+	require.Equal(t, int32(5), *policy.FailedHistoryLimit)
+}
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
@@ -3,6 +3,8 @@ package task
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -19,7 +21,9 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,7 +54,7 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 
 	phase := apicommon.PhaseReconcileTask
 
-	tasks, statuses := r.setupTasks(taskCreateAttributes, piWrapper)
+	tasks, statuses, dependencies := r.setupTasks(taskCreateAttributes, piWrapper)
 
 	var summary apicommon.StatusSummary
 	summary.Total = len(tasks)
@@ -67,12 +71,34 @@ func (r Handler) ReconcileTasks(ctx context.Context, phaseCtx context.Context, r
 			r.EventSender.Emit(phase, "Normal", reconcileObject, apicommon.PhaseStateStatusChanged, fmt.Sprintf("task status changed from %s to %s", oldstatus, taskStatus.Status), piWrapper.GetVersion())
 		}
 
-		// Check if task has already succeeded or failed
-		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed {
+		// Check if task has already succeeded, failed, has been skipped or has been deprecated due to a failed dependency
+		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed || taskStatus.Status == apicommon.StateDeprecated || taskStatus.Status.IsSkipped() {
 			newStatus = append(newStatus, taskStatus)
 			continue
 		}
 
+		// Wait for the tasks this task depends on before creating it
+		if taskStatus.Name == "" {
+			dependencyState := getDependencyState(taskDefinitionName, dependencies, tasks, newStatus, statuses, map[string]bool{})
+			if dependencyState.IsFailed() {
+				r.Log.Info("Dependency of task failed, not executing task",
+					"taskDefinition", taskDefinitionName,
+					"namespace", piWrapper.GetNamespace(),
+				)
+				taskStatus.Status = apicommon.StateDeprecated
+				newStatus = append(newStatus, taskStatus)
+				continue
+			}
+			if !dependencyState.IsSucceeded() {
+				newStatus = append(newStatus, taskStatus)
+				continue
+			}
+			if !r.shouldExecute(phaseCtx, piWrapper, reconcileObject, taskDefinitionName, taskCreateAttributes.CheckType, &taskStatus) {
+				newStatus = append(newStatus, taskStatus)
+				continue
+			}
+		}
+
 		// Check if Task is already created
 		if taskStatus.Name != "" {
 			err := r.Client.Get(ctx, types.NamespacedName{Name: taskStatus.Name, Namespace: piWrapper.GetNamespace()}, task)
@@ -137,7 +163,23 @@ func (r Handler) CreateKeptnTask(ctx, phaseCtx context.Context, namespace string
 	phase := apicommon.PhaseCreateTask
 
 	newTask := piWrapper.GenerateTask(taskCreateAttributes.Definition, taskCreateAttributes.CheckType)
+	// the labels are copied from the KeptnTaskDefinition, hence they must not be modified in place
+	newTask.Labels = maps.Clone(newTask.Labels)
+	if newTask.Labels == nil {
+		newTask.Labels = map[string]string{}
+	}
+	newTask.Labels[apicommon.ParentUIDLabel] = string(reconcileObject.GetUID())
 	injectKeptnContext(phaseCtx, &newTask)
+	outputs, err := r.getTaskOutputs(ctx, namespace, reconcileObject)
+	if err != nil {
+		// the task can still be executed without the outputs of previous tasks
+		r.Log.Error(err, "could not retrieve outputs of previous KeptnTasks")
+	}
+	newTask.Spec.Context.Outputs = outputs
+	if taskCreateAttributes.CheckType == apicommon.OnFailureCheckType {
+		newTask.Spec.Context.FailedPhase = piWrapper.GetFailedPhase()
+		newTask.Spec.Context.FailedItems = r.getFailedItems(ctx, namespace, piWrapper)
+	}
 	err = controllerutil.SetControllerReference(reconcileObject, &newTask, r.Scheme)
 	if err != nil {
 		r.Log.Error(err, "could not set controller reference:")
@@ -152,10 +194,99 @@ func (r Handler) CreateKeptnTask(ctx, phaseCtx context.Context, namespace string
 	return newTask.Name, nil
 }
 
+// getTaskOutputs collects the outputs of all KeptnTasks that have been executed for the reconcileObject,
+// grouped by the name of their KeptnTaskDefinition.
+// If a KeptnTaskDefinition has been executed multiple times, the outputs of the latest execution are returned.
+func (r Handler) getTaskOutputs(ctx context.Context, namespace string, reconcileObject client.Object) (map[string]map[string]string, error) {
+	tasks := &klcv1beta1.KeptnTaskList{}
+	if err := r.Client.List(ctx, tasks, client.InNamespace(namespace), client.MatchingLabels{apicommon.ParentUIDLabel: string(reconcileObject.GetUID())}); err != nil {
+		return nil, err
+	}
+
+	var outputs map[string]map[string]string
+	endTimes := map[string]v1.Time{}
+	for _, task := range tasks.Items {
+		task := task
+		if len(task.Status.Outputs) == 0 || !v1.IsControlledBy(&task, reconcileObject) {
+			continue
+		}
+		definition := task.Spec.TaskDefinition
+		if endTime, ok := endTimes[definition]; ok && task.Status.EndTime.Before(&endTime) {
+			continue
+		}
+		if outputs == nil {
+			outputs = map[string]map[string]string{}
+		}
+		outputs[definition] = task.Status.Outputs
+		endTimes[definition] = task.Status.EndTime
+	}
+	return outputs, nil
+}
+
+// getFailedItems returns the items that have failed in the failed phase of the phase item,
+// together with the reason and message of the respective KeptnTasks and KeptnEvaluations.
+func (r Handler) getFailedItems(ctx context.Context, namespace string, piWrapper *interfaces.PhaseItemWrapper) []klcv1beta1.FailedItem {
+	failedPhase := apicommon.KeptnPhaseType{ShortName: piWrapper.GetFailedPhase()}
+
+	var result []klcv1beta1.FailedItem
+	for _, item := range piWrapper.GetFailedItemStatus() {
+		failedItem := klcv1beta1.FailedItem{
+			Name:           item.Name,
+			DefinitionName: item.DefinitionName,
+		}
+		if item.Name != "" {
+			var err error
+			switch {
+			case failedPhase.IsTask() || failedPhase.IsPromotionTask():
+				err = r.setTaskFailure(ctx, namespace, &failedItem)
+			case failedPhase.IsEvaluation():
+				err = r.setEvaluationFailure(ctx, namespace, &failedItem)
+			}
+			// failed analyses are not backed by a KeptnEvaluation, hence only their name is passed on
+			if err != nil && !errors.IsNotFound(err) {
+				r.Log.Error(err, "could not retrieve failed item", "name", item.Name, "namespace", namespace)
+			}
+		}
+		result = append(result, failedItem)
+	}
+	return result
+}
+
+func (r Handler) setTaskFailure(ctx context.Context, namespace string, failedItem *klcv1beta1.FailedItem) error {
+	task := &klcv1beta1.KeptnTask{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: failedItem.Name, Namespace: namespace}, task); err != nil {
+		return err
+	}
+	failedItem.Reason = task.Status.Reason
+	failedItem.Message = task.Status.Message
+	return nil
+}
+
+func (r Handler) setEvaluationFailure(ctx context.Context, namespace string, failedItem *klcv1beta1.FailedItem) error {
+	evaluation := &klcv1beta1.KeptnEvaluation{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: failedItem.Name, Namespace: namespace}, evaluation); err != nil {
+		return err
+	}
+	objectives := maps.Keys(evaluation.Status.EvaluationStatus)
+	sort.Strings(objectives)
+	messages := []string{}
+	for _, objective := range objectives {
+		status := evaluation.Status.EvaluationStatus[objective]
+		if status.Status.IsFailed() && status.Message != "" {
+			messages = append(messages, status.Message)
+		}
+	}
+	failedItem.Message = strings.Join(messages, "; ")
+	return nil
+}
+
 func injectKeptnContext(phaseCtx context.Context, newTask *klcv1beta1.KeptnTask) {
+	traceContextCarrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(phaseCtx, traceContextCarrier)
+	// the trace context of the phase is passed on to the pods executing the task
+	telemetry.SetTraceContextAnnotations(&newTask.ObjectMeta, traceContextCarrier)
+
 	if metadata, ok := keptncontext.GetAppMetadataFromContext(phaseCtx); ok {
-		traceContextCarrier := &propagation.MapCarrier{}
-		otel.GetTextMapPropagator().Inject(phaseCtx, traceContextCarrier)
 		newTask.Spec.Context.Metadata = map[string]string{}
 		maps.Copy(newTask.Spec.Context.Metadata, metadata)
 		for _, key := range traceContextCarrier.Keys() {
@@ -168,22 +299,89 @@ func (r Handler) setTaskFailureEvents(task *klcv1beta1.KeptnTask, spanTrace trac
 	spanTrace.AddEvent(fmt.Sprintf("task '%s' failed with reason: '%s'", task.Name, task.Status.Message), trace.WithTimestamp(time.Now().UTC()))
 }
 
-func (r Handler) setupTasks(taskCreateAttributes CreateTaskAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]string, []klcv1beta1.ItemStatus) {
+func (r Handler) setupTasks(taskCreateAttributes CreateTaskAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]string, []klcv1beta1.ItemStatus, map[string][]string) {
 	var tasks []string
 	var statuses []klcv1beta1.ItemStatus
+	dependencies := map[string][]string{}
 
 	switch taskCreateAttributes.CheckType {
 	case apicommon.PreDeploymentCheckType:
 		tasks = piWrapper.GetPreDeploymentTasks()
 		statuses = piWrapper.GetPreDeploymentTaskStatus()
+		for _, dependency := range piWrapper.GetPreDeploymentTaskDependencies() {
+			dependencies[dependency.Name] = dependency.DependsOn
+		}
 	case apicommon.PostDeploymentCheckType:
 		tasks = piWrapper.GetPostDeploymentTasks()
 		statuses = piWrapper.GetPostDeploymentTaskStatus()
+		for _, dependency := range piWrapper.GetPostDeploymentTaskDependencies() {
+			dependencies[dependency.Name] = dependency.DependsOn
+		}
 	case apicommon.PromotionCheckType:
 		tasks = piWrapper.GetPromotionTasks()
 		statuses = piWrapper.GetPromotionTaskStatus()
+	case apicommon.RollbackCheckType:
+		tasks = piWrapper.GetRollbackTasks()
+		statuses = piWrapper.GetRollbackTaskStatus()
+	case apicommon.OnFailureCheckType:
+		tasks = piWrapper.GetOnFailureTasks()
+		statuses = piWrapper.GetOnFailureTaskStatus()
+	}
+	return tasks, statuses, dependencies
+}
+
+// getDependencyState returns StateSucceeded if all dependencies of a task have succeeded or have been skipped,
+// StateFailed if any of its direct or transitive dependencies failed or has been deprecated,
+// and StatePending otherwise.
+// Dependencies that are not part of the tasks of the phase are ignored.
+func getDependencyState(taskName string, dependencies map[string][]string, tasks []string, newStatus []klcv1beta1.ItemStatus, oldStatus []klcv1beta1.ItemStatus, visited map[string]bool) apicommon.KeptnState {
+	state := apicommon.StateSucceeded
+	for _, dependency := range dependencies[taskName] {
+		if !slices.Contains(tasks, dependency) || visited[dependency] {
+			continue
+		}
+		visited[dependency] = true
+		// prefer the status of the current reconciliation, if the dependency has already been checked
+		status := common.GetItemStatus(dependency, newStatus)
+		if status.Status.IsPending() {
+			status = common.GetItemStatus(dependency, oldStatus)
+		}
+		switch {
+		case status.Status.IsFailed() || status.Status.IsDeprecated():
+			return apicommon.StateFailed
+		case status.Status.IsSucceeded() || status.Status.IsSkipped():
+			continue
+		}
+		state = apicommon.StatePending
+		// a dependency that has not completed yet will never be executed if one of its own dependencies failed
+		if getDependencyState(dependency, dependencies, tasks, newStatus, oldStatus, visited) == apicommon.StateFailed {
+			return apicommon.StateFailed
+		}
+	}
+	return state
+}
+
+// shouldExecute evaluates the execution condition of the task and marks the task as Skipped if it does not hold,
+// or as Failed if the condition could not be evaluated
+func (r Handler) shouldExecute(phaseCtx context.Context, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, taskName string, checkType apicommon.CheckType, taskStatus *klcv1beta1.ItemStatus) bool {
+	execute, err := common.ShouldExecute(phaseCtx, piWrapper, taskName, checkType)
+	if err != nil {
+		r.Log.Error(err, "Could not evaluate execution condition of task",
+			"taskDefinition", taskName,
+			"namespace", piWrapper.GetNamespace(),
+		)
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", reconcileObject, apicommon.PhaseStateFailed, fmt.Sprintf("could not evaluate execution condition of task %s: %s", taskName, err.Error()), piWrapper.GetVersion())
+		taskStatus.Status = apicommon.StateFailed
+		return false
+	}
+	if !execute {
+		r.Log.Info("Execution condition of task does not hold, skipping task",
+			"taskDefinition", taskName,
+			"namespace", piWrapper.GetNamespace(),
+		)
+		taskStatus.Status = apicommon.StateSkipped
 	}
-	return tasks, statuses
+	return execute
 }
 
 func (r Handler) handleTaskNotExists(ctx context.Context, phaseCtx context.Context, taskCreateAttributes CreateTaskAttributes, taskName string, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, task *klcv1beta1.KeptnTask, taskStatus *klcv1beta1.ItemStatus) error {
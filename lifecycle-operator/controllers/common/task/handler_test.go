@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
@@ -22,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -430,6 +432,235 @@ func TestTaskHandler(t *testing.T) {
 			getSpanCalls:    1,
 			unbindSpanCalls: 1,
 		},
+		{
+			name: "task waits for its dependency",
+			object: &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1beta1.KeptnAppVersionSpec{
+					KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+						DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+							PreDeploymentTasks: []string{"migrate", "warmup"},
+							PreDeploymentTaskDependencies: []v1beta1.TaskDependency{
+								{Name: "warmup", DependsOn: []string{"migrate"}},
+							},
+						},
+					},
+				},
+			},
+			taskDef: &v1beta1.KeptnTaskDefinition{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "migrate",
+				},
+			},
+			taskObj: v1beta1.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			},
+			wantStatus: []v1beta1.ItemStatus{
+				{
+					DefinitionName: "migrate",
+					Status:         apicommon.StatePending,
+					Name:           "pre-migrate-",
+				},
+				{
+					DefinitionName: "warmup",
+					Status:         apicommon.StatePending,
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 2, Pending: 2},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 0,
+		},
+		{
+			name: "task is started once its dependency succeeded",
+			object: &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1beta1.KeptnAppVersionSpec{
+					KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+						DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+							PreDeploymentTasks: []string{"migrate", "warmup"},
+							PreDeploymentTaskDependencies: []v1beta1.TaskDependency{
+								{Name: "warmup", DependsOn: []string{"migrate"}},
+							},
+						},
+					},
+				},
+				Status: v1beta1.KeptnAppVersionStatus{
+					PreDeploymentTaskStatus: []v1beta1.ItemStatus{
+						{
+							DefinitionName: "migrate",
+							Status:         apicommon.StateSucceeded,
+							Name:           "pre-migrate-",
+						},
+					},
+				},
+			},
+			taskDef: &v1beta1.KeptnTaskDefinition{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "warmup",
+				},
+			},
+			taskObj: v1beta1.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			},
+			wantStatus: []v1beta1.ItemStatus{
+				{
+					DefinitionName: "migrate",
+					Status:         apicommon.StateSucceeded,
+					Name:           "pre-migrate-",
+				},
+				{
+					DefinitionName: "warmup",
+					Status:         apicommon.StatePending,
+					Name:           "pre-warmup-",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 2, Succeeded: 1, Pending: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 0,
+		},
+		{
+			name: "downstream tasks are deprecated if a dependency failed",
+			object: &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1beta1.KeptnAppVersionSpec{
+					KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+						DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+							PostDeploymentTasks: []string{"notify", "test", "report"},
+							PostDeploymentTaskDependencies: []v1beta1.TaskDependency{
+								{Name: "notify", DependsOn: []string{"report"}},
+								{Name: "report", DependsOn: []string{"test"}},
+							},
+						},
+					},
+				},
+				Status: v1beta1.KeptnAppVersionStatus{
+					PostDeploymentTaskStatus: []v1beta1.ItemStatus{
+						{
+							DefinitionName: "test",
+							Status:         apicommon.StateFailed,
+							Name:           "post-test-",
+						},
+					},
+				},
+			},
+			taskObj: v1beta1.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PostDeploymentCheckType,
+			},
+			wantStatus: []v1beta1.ItemStatus{
+				{
+					DefinitionName: "notify",
+					Status:         apicommon.StateDeprecated,
+				},
+				{
+					DefinitionName: "test",
+					Status:         apicommon.StateFailed,
+					Name:           "post-test-",
+				},
+				{
+					DefinitionName: "report",
+					Status:         apicommon.StateDeprecated,
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 3, Failed: 1, Deprecated: 2},
+			wantErr:         nil,
+			getSpanCalls:    0,
+			unbindSpanCalls: 0,
+		},
+		{
+			name: "task is skipped if its execution condition does not hold",
+			object: &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1beta1.KeptnAppVersionSpec{
+					KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+						DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+							PreDeploymentTasks: []string{"migrate", "warmup"},
+							PreDeploymentTaskDependencies: []v1beta1.TaskDependency{
+								{Name: "warmup", DependsOn: []string{"migrate"}},
+							},
+							ExecutionConditions: []v1beta1.ExecutionCondition{
+								{Name: "migrate", When: "version.split('.')[0] != previousVersion.split('.')[0]"},
+							},
+						},
+					},
+					KeptnAppSpec: v1beta1.KeptnAppSpec{
+						Version: "1.2.0",
+					},
+					PreviousVersion: "1.1.0",
+				},
+			},
+			taskDef: &v1beta1.KeptnTaskDefinition{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "warmup",
+				},
+			},
+			taskObj: v1beta1.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			},
+			wantStatus: []v1beta1.ItemStatus{
+				{
+					DefinitionName: "migrate",
+					Status:         apicommon.StateSkipped,
+				},
+				{
+					DefinitionName: "warmup",
+					Status:         apicommon.StatePending,
+					Name:           "pre-warmup-",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 2, Pending: 1, Skipped: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 0,
+		},
+		{
+			name: "task fails if its execution condition cannot be evaluated",
+			object: &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1beta1.KeptnAppVersionSpec{
+					KeptnAppContextSpec: v1beta1.KeptnAppContextSpec{
+						DeploymentTaskSpec: v1beta1.DeploymentTaskSpec{
+							PreDeploymentTasks: []string{"migrate"},
+							ExecutionConditions: []v1beta1.ExecutionCondition{
+								{Name: "migrate", When: "metadata.commitMessage.contains('[migrate]')"},
+							},
+						},
+					},
+				},
+			},
+			taskObj: v1beta1.KeptnTask{},
+			createAttr: CreateTaskAttributes{
+				CheckType: apicommon.PreDeploymentCheckType,
+			},
+			wantStatus: []v1beta1.ItemStatus{
+				{
+					DefinitionName: "migrate",
+					Status:         apicommon.StateFailed,
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 1, Failed: 1},
+			wantErr:         nil,
+			getSpanCalls:    0,
+			unbindSpanCalls: 0,
+		},
 	}
 	config.Instance().SetDefaultNamespace(testcommon.KeptnNamespace)
 
@@ -542,6 +773,200 @@ func TestTaskHandler_createTask(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_createTaskWithOutputsOfPreviousTasks(t *testing.T) {
+	err := v1beta1.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	appVersion := &v1beta1.KeptnAppVersion{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-app-1.0.0",
+			Namespace: "namespace",
+			UID:       "app-version-uid",
+		},
+	}
+	controlledBy := func(uid types.UID) []v1.OwnerReference {
+		isController := true
+		return []v1.OwnerReference{{
+			APIVersion: "lifecycle.keptn.sh/v1beta1",
+			Kind:       "KeptnAppVersion",
+			Name:       appVersion.Name,
+			UID:        uid,
+			Controller: &isController,
+		}}
+	}
+	previousTask := func(name, definition string, owner types.UID, endTime v1.Time, outputs map[string]string) *v1beta1.KeptnTask {
+		return &v1beta1.KeptnTask{
+			ObjectMeta: v1.ObjectMeta{
+				Name:            name,
+				Namespace:       "namespace",
+				Labels:          map[string]string{apicommon.ParentUIDLabel: string(owner)},
+				OwnerReferences: controlledBy(owner),
+			},
+			Spec: v1beta1.KeptnTaskSpec{
+				TaskDefinition: definition,
+			},
+			Status: v1beta1.KeptnTaskStatus{
+				EndTime: endTime,
+				Outputs: outputs,
+			},
+		}
+	}
+	now := time.Now()
+
+	handler := Handler{
+		SpanHandler: &telemetryfake.ISpanHandlerMock{},
+		Log:         ctrl.Log.WithName("controller"),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Client: fake.NewClientBuilder().WithObjects(
+			previousTask("pre-snapshot-1", "snapshot", appVersion.UID, v1.NewTime(now.Add(-time.Minute)), map[string]string{"snapshotId": "old"}),
+			previousTask("pre-snapshot-2", "snapshot", appVersion.UID, v1.NewTime(now), map[string]string{"snapshotId": "new"}),
+			previousTask("pre-migrate", "migrate", appVersion.UID, v1.NewTime(now), nil),
+			previousTask("pre-other", "other", "other-app-version-uid", v1.NewTime(now), map[string]string{"foo": "bar"}),
+		).Build(),
+		Tracer: noop.NewTracerProvider().Tracer("tracer"),
+		Scheme: scheme.Scheme,
+	}
+
+	name, err := handler.CreateKeptnTask(context.TODO(), context.TODO(), "namespace", appVersion, CreateTaskAttributes{
+		CheckType: apicommon.RollbackCheckType,
+		Definition: v1beta1.KeptnTaskDefinition{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "restore",
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	task := &v1beta1.KeptnTask{}
+	err = handler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: name}, task)
+	require.Nil(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"snapshot": {"snapshotId": "new"},
+	}, task.Spec.Context.Outputs)
+	// the task is labeled with its parent, so that its outputs can be looked up by subsequent tasks
+	require.Equal(t, string(appVersion.UID), task.Labels[apicommon.ParentUIDLabel])
+}
+
+func TestTaskHandler_createOnFailureTask(t *testing.T) {
+	err := v1beta1.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name            string
+		status          v1beta1.KeptnAppVersionStatus
+		objects         []client.Object
+		wantFailedItems []v1beta1.FailedItem
+	}{
+		{
+			name: "failed pre-deployment task",
+			status: v1beta1.KeptnAppVersionStatus{
+				FailedPhase: apicommon.PhaseAppPreDeployment.ShortName,
+				PreDeploymentTaskStatus: []v1beta1.ItemStatus{
+					{DefinitionName: "snapshot", Name: "pre-snapshot", Status: apicommon.StateSucceeded},
+					{DefinitionName: "migrate", Name: "pre-migrate", Status: apicommon.StateFailed},
+				},
+			},
+			objects: []client.Object{
+				&v1beta1.KeptnTask{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "pre-migrate",
+						Namespace: "namespace",
+					},
+					Status: v1beta1.KeptnTaskStatus{
+						Status:  apicommon.StateFailed,
+						Reason:  "BackoffLimitExceeded",
+						Message: "Job has reached the specified backoff limit",
+					},
+				},
+			},
+			wantFailedItems: []v1beta1.FailedItem{
+				{
+					Name:           "pre-migrate",
+					DefinitionName: "migrate",
+					Reason:         "BackoffLimitExceeded",
+					Message:        "Job has reached the specified backoff limit",
+				},
+			},
+		},
+		{
+			name: "failed pre-deployment evaluation and analysis",
+			status: v1beta1.KeptnAppVersionStatus{
+				FailedPhase: apicommon.PhaseAppPreEvaluation.ShortName,
+				PreDeploymentEvaluationTaskStatus: []v1beta1.ItemStatus{
+					{DefinitionName: "slo", Name: "pre-eval-slo", Status: apicommon.StateFailed},
+				},
+				PreDeploymentAnalysisStatus: []v1beta1.ItemStatus{
+					{DefinitionName: "canary", Name: "pre-eval-canary", Status: apicommon.StateFailed},
+				},
+			},
+			objects: []client.Object{
+				&v1beta1.KeptnEvaluation{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "pre-eval-slo",
+						Namespace: "namespace",
+					},
+					Status: v1beta1.KeptnEvaluationStatus{
+						OverallStatus: apicommon.StateFailed,
+						EvaluationStatus: map[string]v1beta1.EvaluationStatusItem{
+							"latency":    {Status: apicommon.StateFailed, Message: "value '12' did not meet objective '<10'"},
+							"error-rate": {Status: apicommon.StateFailed, Message: "value '3' did not meet objective '<1'"},
+							"cpu":        {Status: apicommon.StateSucceeded, Message: "value '1' met objective '<2'"},
+						},
+					},
+				},
+			},
+			wantFailedItems: []v1beta1.FailedItem{
+				{
+					Name:           "pre-eval-slo",
+					DefinitionName: "slo",
+					Message:        "value '3' did not meet objective '<1'; value '12' did not meet objective '<10'",
+				},
+				{
+					Name:           "pre-eval-canary",
+					DefinitionName: "canary",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appVersion := &v1beta1.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "my-app-1.0.0",
+					Namespace: "namespace",
+				},
+				Status: tt.status,
+			}
+
+			handler := Handler{
+				SpanHandler: &telemetryfake.ISpanHandlerMock{},
+				Log:         ctrl.Log.WithName("controller"),
+				EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				Client:      fake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+				Tracer:      noop.NewTracerProvider().Tracer("tracer"),
+				Scheme:      scheme.Scheme,
+			}
+
+			name, err := handler.CreateKeptnTask(context.TODO(), context.TODO(), "namespace", appVersion, CreateTaskAttributes{
+				CheckType: apicommon.OnFailureCheckType,
+				Definition: v1beta1.KeptnTaskDefinition{
+					ObjectMeta: v1.ObjectMeta{
+						Name: "cleanup",
+					},
+				},
+			})
+			require.Nil(t, err)
+
+			task := &v1beta1.KeptnTask{}
+			err = handler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: name}, task)
+			require.Nil(t, err)
+			require.Equal(t, tt.status.FailedPhase, task.Spec.Context.FailedPhase)
+			require.Equal(t, tt.wantFailedItems, task.Spec.Context.FailedItems)
+		})
+	}
+}
+
 func Test_injectKeptnContext(t *testing.T) {
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
@@ -569,5 +994,32 @@ func Test_injectKeptnContext(t *testing.T) {
 		},
 		task.Spec.Context.Metadata,
 	)
+	require.Equal(t, task.Spec.Context.Metadata["traceparent"], task.Annotations[apicommon.TraceParentAnnotation])
+}
+
+func Test_injectKeptnContext_WithoutAppMetadata(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
+	tp := sdktrace.NewTracerProvider()
+
+	ctx, span := tp.Tracer("keptn").Start(context.TODO(), "my-span")
+	defer span.End()
+
+	definitionAnnotations := map[string]string{"foo": "bar"}
+	task := &v1beta1.KeptnTask{
+		ObjectMeta: v1.ObjectMeta{Annotations: definitionAnnotations},
+	}
+	injectKeptnContext(ctx, task)
+
+	require.Nil(t, task.Spec.Context.Metadata)
+	require.Equal(t, map[string]string{
+		"foo": "bar",
+		apicommon.TraceParentAnnotation: fmt.Sprintf(
+			"00-%s-%s-01",
+			span.SpanContext().TraceID().String(),
+			span.SpanContext().SpanID().String(),
+		),
+	}, task.Annotations)
+	// the annotations of the KeptnTaskDefinition are not modified
+	require.Equal(t, map[string]string{"foo": "bar"}, definitionAnnotations)
 }
@@ -11,10 +11,13 @@ import (
 const (
 	FunctionRuntimeImageKey = "FUNCTION_RUNNER_IMAGE"
 	PythonRuntimeImageKey   = "PYTHON_RUNNER_IMAGE"
+	WasmRuntimeImageKey     = "WASM_RUNNER_IMAGE"
 	FunctionScriptMountPath = "/var/data/function.ts"
 	PythonScriptMountPath   = "/var/data/function.py"
+	WasmModuleMountPath     = "/var/data/module.wasm"
 	FunctionScriptKey       = "js"
 	PythonScriptKey         = "python"
+	WasmModuleKey           = "wasm"
 )
 
 func GetRuntimeSpec(def *klcv1beta1.KeptnTaskDefinition) *klcv1beta1.RuntimeSpec {
@@ -28,6 +31,9 @@ func GetRuntimeSpec(def *klcv1beta1.KeptnTaskDefinition) *klcv1beta1.RuntimeSpec
 	if !IsRuntimeEmpty(def.Spec.Python) {
 		return def.Spec.Python
 	}
+	if !IsRuntimeEmpty(def.Spec.Wasm) {
+		return def.Spec.Wasm
+	}
 
 	return nil
 }
@@ -50,8 +56,10 @@ func IsInline(spec *klcv1beta1.RuntimeSpec) bool {
 
 func GetRuntimeImage(def *klcv1beta1.KeptnTaskDefinition) string {
 	image := os.Getenv(FunctionRuntimeImageKey)
-	if !IsRuntimeEmpty(def.Spec.Python) && IsRuntimeEmpty(def.Spec.Function) && IsRuntimeEmpty(def.Spec.Deno) {
+	if IsPython(def) {
 		image = os.Getenv(PythonRuntimeImageKey)
+	} else if IsWasm(def) {
+		image = os.Getenv(WasmRuntimeImageKey)
 	}
 	return image
 }
@@ -65,12 +73,24 @@ func GetCmName(functionName string, spec *klcv1beta1.RuntimeSpec) string {
 
 func GetRuntimeMountPath(def *klcv1beta1.KeptnTaskDefinition) string {
 	path := FunctionScriptMountPath
-	if !IsRuntimeEmpty(def.Spec.Python) && IsRuntimeEmpty(def.Spec.Function) && IsRuntimeEmpty(def.Spec.Deno) {
+	if IsPython(def) {
 		path = PythonScriptMountPath
+	} else if IsWasm(def) {
+		path = WasmModuleMountPath
 	}
 	return path
 }
 
+// IsPython returns true if the python runtime is used to execute the KeptnTaskDefinition
+func IsPython(def *klcv1beta1.KeptnTaskDefinition) bool {
+	return !IsRuntimeEmpty(def.Spec.Python) && IsRuntimeEmpty(def.Spec.Function) && IsRuntimeEmpty(def.Spec.Deno)
+}
+
+// IsWasm returns true if the WebAssembly runtime is used to execute the KeptnTaskDefinition
+func IsWasm(def *klcv1beta1.KeptnTaskDefinition) bool {
+	return !IsRuntimeEmpty(def.Spec.Wasm) && IsRuntimeEmpty(def.Spec.Function) && IsRuntimeEmpty(def.Spec.Deno) && IsRuntimeEmpty(def.Spec.Python)
+}
+
 // check if either the functions or container spec is set
 func SpecExists(definition *klcv1beta1.KeptnTaskDefinition) bool {
 	if definition == nil {
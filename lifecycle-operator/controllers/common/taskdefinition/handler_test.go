@@ -13,6 +13,7 @@ func TestGetRuntimeImage(t *testing.T) {
 
 	t.Setenv(FunctionRuntimeImageKey, FunctionScriptKey)
 	t.Setenv(PythonRuntimeImageKey, PythonScriptKey)
+	t.Setenv(WasmRuntimeImageKey, WasmModuleKey)
 	tests := []struct {
 		name string
 		def  *klcv1beta1.KeptnTaskDefinition
@@ -91,6 +92,37 @@ func TestGetRuntimeImage(t *testing.T) {
 			},
 			want: FunctionScriptKey,
 		},
+		{
+			name: WasmModuleKey,
+			def: &klcv1beta1.KeptnTaskDefinition{
+				Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+					Wasm: &klcv1beta1.RuntimeSpec{
+						HttpReference: klcv1beta1.HttpReference{
+							Url: "testy.com",
+						},
+					},
+				},
+			},
+			want: WasmModuleKey,
+		},
+		{
+			name: "deno and wasm defined, deno wins",
+			def: &klcv1beta1.KeptnTaskDefinition{
+				Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+					Deno: &klcv1beta1.RuntimeSpec{
+						HttpReference: klcv1beta1.HttpReference{
+							Url: "testy.com",
+						},
+					},
+					Wasm: &klcv1beta1.RuntimeSpec{
+						HttpReference: klcv1beta1.HttpReference{
+							Url: "testy.com",
+						},
+					},
+				},
+			},
+			want: FunctionScriptKey,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -124,6 +156,23 @@ func TestGetRuntimeSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: WasmModuleKey,
+			def: &klcv1beta1.KeptnTaskDefinition{
+				Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+					Wasm: &klcv1beta1.RuntimeSpec{
+						HttpReference: klcv1beta1.HttpReference{
+							Url: "testy.com",
+						},
+					},
+				},
+			},
+			want: &klcv1beta1.RuntimeSpec{
+				HttpReference: klcv1beta1.HttpReference{
+					Url: "testy.com",
+				},
+			},
+		},
 		{
 			name: FunctionScriptKey,
 			def: &klcv1beta1.KeptnTaskDefinition{
@@ -301,6 +350,17 @@ func TestGetRuntimeMountPath(t *testing.T) {
 			},
 			want: FunctionScriptMountPath,
 		},
+		{
+			name: WasmModuleKey,
+			def: &klcv1beta1.KeptnTaskDefinition{
+				Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+					Wasm: &klcv1beta1.RuntimeSpec{
+						CmdParameters: "hi",
+					},
+				},
+			},
+			want: WasmModuleMountPath,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3,8 +3,11 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
+	lifecyclev1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"go.opentelemetry.io/otel/metric"
@@ -106,3 +109,35 @@ func ObserveActiveInstances(ctx context.Context, client client.Client, reconcile
 
 	return nil
 }
+
+// ObserveDORAMetrics observes the DORA metrics stored in the status of each KeptnApp
+func ObserveDORAMetrics(ctx context.Context, client client.Client, deploymentFrequencyGauge metric.Float64ObservableGauge, leadTimeGauge metric.Float64ObservableGauge, changeFailureRateGauge metric.Float64ObservableGauge, timeToRestoreGauge metric.Float64ObservableGauge, o metric.Observer) error {
+	apps := &lifecyclev1beta1.KeptnAppList{}
+	err := client.List(ctx, apps)
+	if err != nil {
+		return fmt.Errorf(controllererrors.ErrCannotRetrieveInstancesMsg, err)
+	}
+
+	for _, app := range apps.Items {
+		dora := app.Status.DORAMetrics
+		if dora == nil {
+			continue
+		}
+		attributes := metric.WithAttributes(common.AppName.String(app.Name), common.AppNamespace.String(app.Namespace))
+
+		if deploymentFrequency, err := strconv.ParseFloat(dora.DeploymentFrequency, 64); err == nil {
+			o.ObserveFloat64(deploymentFrequencyGauge, deploymentFrequency, attributes)
+		}
+		if changeFailureRate, err := strconv.ParseFloat(dora.ChangeFailureRate, 64); err == nil {
+			o.ObserveFloat64(changeFailureRateGauge, changeFailureRate, attributes)
+		}
+		if dora.LeadTimeForChanges != nil {
+			o.ObserveFloat64(leadTimeGauge, dora.LeadTimeForChanges.Seconds(), attributes)
+		}
+		if dora.TimeToRestore != nil {
+			o.ObserveFloat64(timeToRestoreGauge, dora.TimeToRestore.Seconds(), attributes)
+		}
+	}
+
+	return nil
+}
@@ -377,6 +377,57 @@ func TestMetrics_ObserveDeploymentInterval(t *testing.T) {
 	}
 }
 
+type namedGauge struct {
+	noop.Float64ObservableGauge
+	name string
+}
+
+type recordingObserver struct {
+	noop.Observer
+	values map[metric.Float64Observable]float64
+}
+
+func (o *recordingObserver) ObserveFloat64(obsrv metric.Float64Observable, value float64, _ ...metric.ObserveOption) {
+	o.values[obsrv] = value
+}
+
+func TestMetrics_ObserveDORAMetrics(t *testing.T) {
+	deploymentFrequencyGauge := namedGauge{name: "deploymentfrequency"}
+	leadTimeGauge := namedGauge{name: "leadtime"}
+	changeFailureRateGauge := namedGauge{name: "changefailurerate"}
+	timeToRestoreGauge := namedGauge{name: "timetorestore"}
+
+	err := lifecyclev1beta1.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+	client := fake.NewClientBuilder().WithLists(&lifecyclev1beta1.KeptnAppList{
+		Items: []lifecyclev1beta1.KeptnApp{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "namespace"},
+				Status: lifecyclev1beta1.KeptnAppStatus{
+					DORAMetrics: &lifecyclev1beta1.DORAMetrics{
+						DeploymentFrequency: "1.50",
+						ChangeFailureRate:   "0.25",
+						LeadTimeForChanges:  &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-metrics", Namespace: "namespace"},
+			},
+		},
+	}).Build()
+
+	observer := &recordingObserver{values: map[metric.Float64Observable]float64{}}
+	err = ObserveDORAMetrics(context.TODO(), client, deploymentFrequencyGauge, leadTimeGauge, changeFailureRateGauge, timeToRestoreGauge, observer)
+	require.Nil(t, err)
+
+	require.Equal(t, map[metric.Float64Observable]float64{
+		deploymentFrequencyGauge: 1.5,
+		leadTimeGauge:            3600,
+		changeFailureRateGauge:   0.25,
+	}, observer.values)
+}
+
 func TestGetPredecessor(t *testing.T) {
 	now := time.Now()
 	appVersions := &lifecyclev1beta1.KeptnAppVersionList{
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"sync"
 	"time"
 
 	lifecyclev1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/metric"
@@ -37,6 +40,10 @@ type otelConfig struct {
 	OtelExporter   *trace.SpanExporter
 
 	lastAppliedCollectorURL string
+	lastAppliedTracing      *optionsv1alpha1.TracingSpec
+	// previousTracerProviders are kept until the operator shuts down, since the spans of running phases,
+	// which are held by the SpanHandler, are still exported by the tracer provider that started them
+	previousTracerProviders []*trace.TracerProvider
 
 	mtx     sync.RWMutex
 	tracers map[string]ITracer
@@ -56,26 +63,49 @@ func GetOtelInstance() *otelConfig {
 	return otelInstance
 }
 
-func (o *otelConfig) InitOtelCollector(otelCollectorUrl string) error {
-	if o.lastAppliedCollectorURL == otelCollectorUrl {
+// InitOtelCollector sets up the tracer provider for the given collector URL and tracing configuration.
+// If either of them has changed since the last call, the tracer provider is rebuilt.
+// The previous tracer provider exports its pending spans, but is not shut down,
+// so that the spans it has started and which have not ended yet are still exported.
+func (o *otelConfig) InitOtelCollector(otelCollectorUrl string, tracing *optionsv1alpha1.TracingSpec) error {
+	if o.lastAppliedCollectorURL == otelCollectorUrl && reflect.DeepEqual(o.lastAppliedTracing, tracing) {
 		return nil
 	}
-	tpOptions, otelExporter, err := GetOTelTracerProviderOptions(otelCollectorUrl)
+	tpOptions, otelExporter, err := GetOTelTracerProviderOptions(otelCollectorUrl, tracing)
 	if err != nil {
 		return err
 	}
 
+	previousTracerProvider := o.TracerProvider
 	o.TracerProvider = trace.NewTracerProvider(tpOptions...)
 	otel.SetTracerProvider(o.TracerProvider)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 	o.OtelExporter = &otelExporter
 	o.cleanTracers()
 	o.lastAppliedCollectorURL = otelCollectorUrl
+	o.lastAppliedTracing = tracing.DeepCopy()
+	if previousTracerProvider != nil {
+		o.previousTracerProviders = append(o.previousTracerProviders, previousTracerProvider)
+		go flushTracerProvider(previousTracerProvider)
+	}
 	logger.Info("Successfully initialized OTel collector")
 	return nil
 }
 
+func flushTracerProvider(tp *trace.TracerProvider) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := tp.ForceFlush(ctx); err != nil {
+		logger.Error(err, "Could not flush previous tracer provider")
+	}
+}
+
 func (o *otelConfig) ShutDown() {
+	for _, tp := range o.previousTracerProviders {
+		if err := tp.Shutdown(context.Background()); err != nil {
+			logger.Error(err, "Could not shut down previous tracer provider")
+		}
+	}
 	if err := o.TracerProvider.Shutdown(context.Background()); err != nil {
 		os.Exit(1)
 	}
@@ -96,7 +126,7 @@ func (o *otelConfig) cleanTracers() {
 	o.tracers = map[string]ITracer{}
 }
 
-func GetOTelTracerProviderOptions(oTelCollectorUrl string) ([]trace.TracerProviderOption, trace.SpanExporter, error) {
+func GetOTelTracerProviderOptions(oTelCollectorUrl string, tracing *optionsv1alpha1.TracingSpec) ([]trace.TracerProviderOption, trace.SpanExporter, error) {
 	var tracerProviderOptions []trace.TracerProviderOption
 	var otelExporter trace.SpanExporter
 
@@ -118,7 +148,7 @@ func GetOTelTracerProviderOptions(oTelCollectorUrl string) ([]trace.TracerProvid
 		}
 		tracerProviderOptions = append(tracerProviderOptions, trace.WithBatcher(stdOutExp))
 	}
-	tracerProviderOptions = append(tracerProviderOptions, trace.WithResource(newResource()))
+	tracerProviderOptions = append(tracerProviderOptions, trace.WithResource(newResource(tracing)), trace.WithSampler(newSampler(tracing)))
 
 	return tracerProviderOptions, otelExporter, nil
 }
@@ -146,13 +176,23 @@ func newOTelExporter(oTelCollectorUrl string) (trace.SpanExporter, error) {
 	return traceExporter, nil
 }
 
-func newResource() *resource.Resource {
-	r := resource.NewWithAttributes(
-		semconv.SchemaURL,
+func newResource(tracing *optionsv1alpha1.TracingSpec) *resource.Resource {
+	attributes := []attribute.KeyValue{}
+	if tracing != nil {
+		for key, value := range tracing.ResourceAttributes {
+			attributes = append(attributes, attribute.String(key, value))
+		}
+	}
+	// the attributes set by Keptn take precedence over the configured ones
+	attributes = append(attributes,
 		semconv.TelemetrySDKLanguageGo,
 		semconv.ServiceNameKey.String("lifecycle-operator"),
 		semconv.ServiceVersionKey.String(buildVersion+"-"+gitCommit+"-"+buildTime),
 	)
+	r := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		attributes...,
+	)
 	return r
 }
 
@@ -193,11 +233,34 @@ func SetUpKeptnMeters(meter interfaces.IMeter, mgr client.Client) {
 		logger.Error(err, "unable to initialize workload deployment duration OTel gauge")
 	}
 
+	appDeploymentFrequencyGauge, err := meter.Float64ObservableGauge("keptn.app.dora.deploymentfrequency", metric.WithDescription("a gauge of the average number of app deployments per day"))
+	if err != nil {
+		logger.Error(err, "unable to initialize app deployment frequency OTel gauge")
+	}
+
+	appLeadTimeGauge, err := meter.Float64ObservableGauge("keptn.app.dora.leadtime", metric.WithDescription("a gauge of the average lead time for changes of apps"), metric.WithUnit("s"))
+	if err != nil {
+		logger.Error(err, "unable to initialize app lead time OTel gauge")
+	}
+
+	appChangeFailureRateGauge, err := meter.Float64ObservableGauge("keptn.app.dora.changefailurerate", metric.WithDescription("a gauge of the ratio of failed app deployments"))
+	if err != nil {
+		logger.Error(err, "unable to initialize app change failure rate OTel gauge")
+	}
+
+	appTimeToRestoreGauge, err := meter.Float64ObservableGauge("keptn.app.dora.timetorestore", metric.WithDescription("a gauge of the average time to restore apps after a failed deployment"), metric.WithUnit("s"))
+	if err != nil {
+		logger.Error(err, "unable to initialize app time to restore OTel gauge")
+	}
+
 	_, err = meter.RegisterCallback(
 		func(ctx context.Context, o metric.Observer) error {
 			observeActiveInstances(ctx, mgr, deploymentActiveGauge, appActiveGauge, taskActiveGauge, evaluationActiveGauge, o)
 			observeDeploymentInterval(ctx, mgr, appDeploymentIntervalGauge, workloadDeploymentIntervalGauge, o)
 			observeDuration(ctx, mgr, appDeploymentDurationGauge, workloadDeploymentDurationGauge, o)
+			if err := ObserveDORAMetrics(ctx, mgr, appDeploymentFrequencyGauge, appLeadTimeGauge, appChangeFailureRateGauge, appTimeToRestoreGauge, o); err != nil {
+				logger.Error(err, "unable to gather app DORA metrics")
+			}
 			return nil
 		},
 		deploymentActiveGauge,
@@ -208,6 +271,10 @@ func SetUpKeptnMeters(meter interfaces.IMeter, mgr client.Client) {
 		appDeploymentDurationGauge,
 		workloadDeploymentIntervalGauge,
 		workloadDeploymentDurationGauge,
+		appDeploymentFrequencyGauge,
+		appLeadTimeGauge,
+		appChangeFailureRateGauge,
+		appTimeToRestoreGauge,
 	)
 	if err != nil {
 		fmt.Println("Failed to register callback")
@@ -298,6 +365,18 @@ func SetUpKeptnTaskMeters(meter interfaces.IMeter) common.KeptnMeters {
 	if err != nil {
 		logger.Error(err, "unable to initialize promotion OTel counter")
 	}
+	prunedCount, err := meter.Int64Counter("keptn.pruned.count", metric.WithDescription("a simple counter for Keptn resources deleted by the retention policy"))
+	if err != nil {
+		logger.Error(err, "unable to initialize pruned OTel counter")
+	}
+	cloudEventsDeliveredCount, err := meter.Int64Counter("keptn.cloudevents.delivered.count", metric.WithDescription("a simple counter for Cloud Events delivered to a sink"))
+	if err != nil {
+		logger.Error(err, "unable to initialize delivered Cloud Events OTel counter")
+	}
+	cloudEventsFailedCount, err := meter.Int64Counter("keptn.cloudevents.failed.count", metric.WithDescription("a simple counter for Cloud Events that could not be delivered to a sink"))
+	if err != nil {
+		logger.Error(err, "unable to initialize failed Cloud Events OTel counter")
+	}
 
 	meters := common.KeptnMeters{
 		TaskCount:          taskCount,
@@ -309,6 +388,10 @@ func SetUpKeptnTaskMeters(meter interfaces.IMeter) common.KeptnMeters {
 		EvaluationCount:    evaluationCount,
 		EvaluationDuration: evaluationDuration,
 		PromotionCount:     promotionCount,
+		PrunedCount:        prunedCount,
+
+		CloudEventsDeliveredCount: cloudEventsDeliveredCount,
+		CloudEventsFailedCount:    cloudEventsFailedCount,
 	}
 	return meters
 }
@@ -1,15 +1,19 @@
 package telemetry
 
 import (
+	"context"
 	"net"
 	"testing"
 
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/interfaces/fake"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"google.golang.org/grpc"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -44,7 +48,7 @@ func TestGetOTelTracerProviderOptions(t *testing.T) {
 			args: args{
 				oTelCollectorUrl: "",
 			},
-			wantArrayLength: 2,
+			wantArrayLength: 3,
 		},
 		{
 			name: "Test with wrong URL",
@@ -59,13 +63,13 @@ func TestGetOTelTracerProviderOptions(t *testing.T) {
 			args: args{
 				oTelCollectorUrl: "localhost:9000",
 			},
-			wantArrayLength: 2,
+			wantArrayLength: 3,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// TODO also test underline return
-			got, _, err := GetOTelTracerProviderOptions(tt.args.oTelCollectorUrl)
+			got, _, err := GetOTelTracerProviderOptions(tt.args.oTelCollectorUrl, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetOTelTracerProviderOptions() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -159,6 +163,9 @@ func TestSetUpKeptnTaskMeters(t *testing.T) {
 	require.NotNil(t, got.EvaluationCount)
 	require.NotNil(t, got.EvaluationDuration)
 	require.NotNil(t, got.PromotionCount)
+	require.NotNil(t, got.PrunedCount)
+	require.NotNil(t, got.CloudEventsDeliveredCount)
+	require.NotNil(t, got.CloudEventsFailedCount)
 }
 
 func TestSetUpKeptnTaskMeters_ErrorCase(t *testing.T) {
@@ -191,6 +198,9 @@ func TestSetUpKeptnTaskMeters_ErrorCase(t *testing.T) {
 	require.Nil(t, got.EvaluationCount)
 	require.Nil(t, got.EvaluationDuration)
 	require.Nil(t, got.PromotionCount)
+	require.Nil(t, got.PrunedCount)
+	require.Nil(t, got.CloudEventsDeliveredCount)
+	require.Nil(t, got.CloudEventsFailedCount)
 }
 
 func Test_otelConfig_GetTracer(t *testing.T) {
@@ -222,7 +232,7 @@ func Test_otelConfig_InitOtelCollector_ReInitWithSameURL(t *testing.T) {
 	defer s.Stop()
 
 	o := GetOtelInstance()
-	err = o.InitOtelCollector("localhost:9000")
+	err = o.InitOtelCollector("localhost:9000", nil)
 
 	require.Nil(t, err)
 
@@ -233,7 +243,7 @@ func Test_otelConfig_InitOtelCollector_ReInitWithSameURL(t *testing.T) {
 	require.Len(t, o.tracers, 1)
 
 	// init with the same URL again
-	err = o.InitOtelCollector("localhost:9000")
+	err = o.InitOtelCollector("localhost:9000", nil)
 
 	require.Nil(t, err)
 
@@ -273,7 +283,7 @@ func Test_otelConfig_InitOtelCollector_ReInitWithDifferentURL(t *testing.T) {
 	defer s.Stop()
 
 	o := GetOtelInstance()
-	err = o.InitOtelCollector("localhost:9000")
+	err = o.InitOtelCollector("localhost:9000", nil)
 
 	require.Nil(t, err)
 
@@ -284,7 +294,7 @@ func Test_otelConfig_InitOtelCollector_ReInitWithDifferentURL(t *testing.T) {
 	require.Len(t, o.tracers, 1)
 
 	// init with a different URL
-	err = o.InitOtelCollector("localhost:9001")
+	err = o.InitOtelCollector("localhost:9001", nil)
 
 	require.Nil(t, err)
 
@@ -292,3 +302,69 @@ func Test_otelConfig_InitOtelCollector_ReInitWithDifferentURL(t *testing.T) {
 	// i.e. the tracers should have been cleaned up
 	require.Empty(t, o.tracers)
 }
+
+func Test_otelConfig_InitOtelCollector_ReInitWithDifferentTracing(t *testing.T) {
+	o := GetOtelInstance()
+	tracing := &optionsv1alpha1.TracingSpec{
+		SamplingRatio:      "0.5",
+		ResourceAttributes: map[string]string{"k8s.cluster.name": "production"},
+	}
+	err := o.InitOtelCollector("", tracing)
+	require.Nil(t, err)
+	require.Equal(t, tracing, o.lastAppliedTracing)
+
+	tracer := o.GetTracer("my-tracer")
+	require.NotNil(t, tracer)
+	require.Len(t, o.tracers, 1)
+
+	// init with the same tracing configuration again
+	err = o.InitOtelCollector("", tracing.DeepCopy())
+	require.Nil(t, err)
+	require.Len(t, o.tracers, 1)
+
+	// init with a different sampling ratio
+	previousTracerProvider := o.TracerProvider
+	err = o.InitOtelCollector("", &optionsv1alpha1.TracingSpec{SamplingRatio: "0.1"})
+	require.Nil(t, err)
+	require.Empty(t, o.tracers)
+	require.NotSame(t, previousTracerProvider, o.TracerProvider)
+}
+
+func Test_otelConfig_InitOtelCollector_ReInitKeepsRunningSpans(t *testing.T) {
+	o := GetOtelInstance()
+	exporter := tracetest.NewInMemoryExporter()
+	o.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	o.lastAppliedTracing = nil
+	o.lastAppliedCollectorURL = "previous"
+
+	previousTracerProvider := o.TracerProvider
+	_, span := previousTracerProvider.Tracer("my-tracer").Start(context.TODO(), "phase")
+
+	err := o.InitOtelCollector("", nil)
+	require.Nil(t, err)
+
+	// a span that ends after the tracer provider has been replaced is still exported by the previous tracer provider
+	span.End()
+	require.Len(t, exporter.GetSpans(), 1)
+	require.Contains(t, o.previousTracerProviders, previousTracerProvider)
+}
+
+func Test_newResource(t *testing.T) {
+	r := newResource(&optionsv1alpha1.TracingSpec{
+		ResourceAttributes: map[string]string{
+			"k8s.cluster.name":       "production-eu",
+			"deployment.environment": "production",
+			"service.name":           "my-service",
+		},
+	})
+
+	value, ok := r.Set().Value("k8s.cluster.name")
+	require.True(t, ok)
+	require.Equal(t, "production-eu", value.AsString())
+	value, ok = r.Set().Value("deployment.environment")
+	require.True(t, ok)
+	require.Equal(t, "production", value.AsString())
+	value, ok = r.Set().Value("service.name")
+	require.True(t, ok)
+	require.Equal(t, "lifecycle-operator", value.AsString())
+}
@@ -0,0 +1,56 @@
+package telemetry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// newSampler returns a sampler that follows the sampling decision of the parent span.
+// Root spans are sampled with the ratio configured for the namespace of the reconciled resource,
+// or with the default ratio if the namespace has no sampling ratio of its own.
+func newSampler(tracing *optionsv1alpha1.TracingSpec) trace.Sampler {
+	if tracing == nil {
+		return trace.ParentBased(trace.AlwaysSample())
+	}
+	root := &namespaceSampler{
+		defaultSampler: trace.TraceIDRatioBased(tracing.GetSamplingRatio()),
+		namespaces:     map[string]trace.Sampler{},
+	}
+	for namespace, ratio := range tracing.GetNamespaceSamplingRatios() {
+		root.namespaces[namespace] = trace.TraceIDRatioBased(ratio)
+	}
+	return trace.ParentBased(root)
+}
+
+// namespaceSampler samples spans with the sampler of the namespace given in the k8s.namespace.name attribute of the span
+type namespaceSampler struct {
+	defaultSampler trace.Sampler
+	namespaces     map[string]trace.Sampler
+}
+
+func (s *namespaceSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	for _, attr := range p.Attributes {
+		if attr.Key != semconv.K8SNamespaceNameKey {
+			continue
+		}
+		if sampler, ok := s.namespaces[attr.Value.AsString()]; ok {
+			return sampler.ShouldSample(p)
+		}
+		break
+	}
+	return s.defaultSampler.ShouldSample(p)
+}
+
+func (s *namespaceSampler) Description() string {
+	namespaces := make([]string, 0, len(s.namespaces))
+	for namespace, sampler := range s.namespaces {
+		namespaces = append(namespaces, fmt.Sprintf("%s:%s", namespace, sampler.Description()))
+	}
+	sort.Strings(namespaces)
+	return fmt.Sprintf("NamespaceSampler{default:%s,namespaces:[%s]}", s.defaultSampler.Description(), strings.Join(namespaces, ","))
+}
@@ -0,0 +1,58 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	sampler := newSampler(&optionsv1alpha1.TracingSpec{
+		SamplingRatio: "0",
+		Namespaces: []optionsv1alpha1.NamespaceSamplingPolicy{
+			{Namespace: "production", SamplingRatio: "1"},
+		},
+	})
+	traceID := trace.TraceID{1, 2, 3, 4}
+
+	sample := func(ctx context.Context, namespace string) sdktrace.SamplingDecision {
+		return sampler.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: ctx,
+			TraceID:       traceID,
+			Name:          "span",
+			Attributes:    []attribute.KeyValue{semconv.K8SNamespaceNameKey.String(namespace)},
+		}).Decision
+	}
+
+	// root spans are sampled with the ratio of their namespace
+	require.Equal(t, sdktrace.Drop, sample(context.TODO(), "staging"))
+	require.Equal(t, sdktrace.RecordAndSample, sample(context.TODO(), "production"))
+
+	// child spans follow the decision of their parent
+	sampledParent := trace.ContextWithSpanContext(context.TODO(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	require.Equal(t, sdktrace.RecordAndSample, sample(sampledParent, "staging"))
+	notSampledParent := trace.ContextWithSpanContext(context.TODO(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{1},
+	}))
+	require.Equal(t, sdktrace.Drop, sample(notSampledParent, "production"))
+}
+
+func TestNewSampler_Defaults(t *testing.T) {
+	require.Equal(t, "ParentBased{root:AlwaysOnSampler,remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}", newSampler(nil).Description())
+
+	// invalid ratios fall back to sampling all traces
+	require.Equal(t, 1.0, optionsv1alpha1.TracingSpec{SamplingRatio: "2"}.GetSamplingRatio())
+	require.Equal(t, 1.0, optionsv1alpha1.TracingSpec{}.GetSamplingRatio())
+	require.Equal(t, 0.25, optionsv1alpha1.TracingSpec{SamplingRatio: "0.25"}.GetSamplingRatio())
+}
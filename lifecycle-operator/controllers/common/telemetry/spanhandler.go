@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -49,6 +50,8 @@ func (r *Handler) GetSpan(ctx context.Context, tracer ITracer, reconcileObject c
 		spanName,
 		trace.WithSpanKind(trace.SpanKindConsumer),
 		trace.WithLinks(links...),
+		// the namespace is passed on start of the span, so that the sampler can take it into account
+		trace.WithAttributes(semconv.K8SNamespaceNameKey.String(reconcileObject.GetNamespace())),
 	)
 	piWrapper.SetSpanAttributes(span)
 
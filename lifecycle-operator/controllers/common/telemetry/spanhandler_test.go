@@ -133,7 +133,8 @@ func TestSpanHandler_GetSpanWithAttributes(t *testing.T) {
 	attributes := span.(trace.ReadOnlySpan).Attributes()
 	require.NotNil(t, attributes)
 
-	// the total number of attributes should be 5 (i.e. the workload specific ones + the additional one)
-	require.Len(t, attributes, 5)
-	require.Equal(t, "bar", attributes[4].Value.AsString())
+	// the total number of attributes should be 6 (i.e. the namespace used for sampling + the workload specific ones + the additional one)
+	require.Len(t, attributes, 6)
+	require.Equal(t, "k8s.namespace.name", string(attributes[0].Key))
+	require.Equal(t, "bar", attributes[5].Value.AsString())
 }
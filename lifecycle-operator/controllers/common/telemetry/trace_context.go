@@ -0,0 +1,119 @@
+package telemetry
+
+import (
+	"fmt"
+
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"go.opentelemetry.io/otel/propagation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TraceParentEnvVar = "TRACEPARENT"
+	TraceStateEnvVar  = "TRACESTATE"
+
+	traceParentKey = "traceparent"
+	traceStateKey  = "tracestate"
+)
+
+// SetTraceContextAnnotations stores the W3C trace context of the carrier in the keptn.sh/traceparent
+// and keptn.sh/tracestate annotations of the object. The annotations of the object are copied before they are modified,
+// since they may be shared with the resource the object has been generated from.
+func SetTraceContextAnnotations(meta *metav1.ObjectMeta, carrier propagation.MapCarrier) {
+	traceParent := carrier.Get(traceParentKey)
+	if traceParent == "" {
+		return
+	}
+	annotations := make(map[string]string, len(meta.Annotations)+2)
+	for key, value := range meta.Annotations {
+		annotations[key] = value
+	}
+	annotations[apicommon.TraceParentAnnotation] = traceParent
+	if traceState := carrier.Get(traceStateKey); traceState != "" {
+		annotations[apicommon.TraceStateAnnotation] = traceState
+	} else {
+		delete(annotations, apicommon.TraceStateAnnotation)
+	}
+	meta.Annotations = annotations
+}
+
+// GetTraceContextFromAnnotations returns the W3C trace context stored in the annotations of an object
+func GetTraceContextFromAnnotations(meta metav1.ObjectMeta) propagation.MapCarrier {
+	carrier := propagation.MapCarrier{}
+	if traceParent := meta.Annotations[apicommon.TraceParentAnnotation]; traceParent != "" {
+		carrier.Set(traceParentKey, traceParent)
+	}
+	if traceState := meta.Annotations[apicommon.TraceStateAnnotation]; traceState != "" {
+		carrier.Set(traceStateKey, traceState)
+	}
+	return carrier
+}
+
+// InjectTraceContext annotates a pod with the W3C trace context of the carrier and exposes it to all of its containers
+// via the TRACEPARENT and TRACESTATE environment variables, so that the processes running in the pod
+// can continue the trace of the deployment. Environment variables defined by the user take precedence.
+func InjectTraceContext(meta *metav1.ObjectMeta, spec *corev1.PodSpec, carrier propagation.MapCarrier) {
+	traceParent := carrier.Get(traceParentKey)
+	if traceParent == "" {
+		return
+	}
+	SetTraceContextAnnotations(meta, carrier)
+
+	env := []corev1.EnvVar{{Name: TraceParentEnvVar, Value: traceParent}}
+	if traceState := carrier.Get(traceStateKey); traceState != "" {
+		env = append(env, corev1.EnvVar{Name: TraceStateEnvVar, Value: traceState})
+	}
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Env = mergeEnv(spec.InitContainers[i].Env, env)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Env = mergeEnv(spec.Containers[i].Env, env)
+	}
+}
+
+// InjectTraceContextFromAnnotations exposes the W3C trace context stored in the keptn.sh/traceparent and keptn.sh/tracestate
+// annotations of a pod to all of its containers via the TRACEPARENT and TRACESTATE environment variables.
+// The values are resolved by the kubelet when the containers are started, so the annotations can still be set
+// after the pod has been created, e.g. when its scheduling gate is removed. Environment variables defined by the user take precedence.
+func InjectTraceContextFromAnnotations(spec *corev1.PodSpec) {
+	env := []corev1.EnvVar{
+		getAnnotationEnvVar(TraceParentEnvVar, apicommon.TraceParentAnnotation),
+		getAnnotationEnvVar(TraceStateEnvVar, apicommon.TraceStateAnnotation),
+	}
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Env = mergeEnv(spec.InitContainers[i].Env, env)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Env = mergeEnv(spec.Containers[i].Env, env)
+	}
+}
+
+func getAnnotationEnvVar(name string, annotation string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fmt.Sprintf("metadata.annotations['%s']", annotation),
+			},
+		},
+	}
+}
+
+func mergeEnv(env []corev1.EnvVar, additional []corev1.EnvVar) []corev1.EnvVar {
+	for _, envVar := range additional {
+		if !hasEnv(env, envVar.Name) {
+			env = append(env, envVar)
+		}
+	}
+	return env
+}
+
+func hasEnv(env []corev1.EnvVar, name string) bool {
+	for _, envVar := range env {
+		if envVar.Name == name {
+			return true
+		}
+	}
+	return false
+}
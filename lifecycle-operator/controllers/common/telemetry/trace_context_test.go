@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"testing"
+
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetTraceContextAnnotations(t *testing.T) {
+	original := map[string]string{"foo": "bar", apicommon.TraceStateAnnotation: "old=state"}
+	meta := metav1.ObjectMeta{Annotations: original}
+
+	SetTraceContextAnnotations(&meta, propagation.MapCarrier{})
+	require.Equal(t, original, meta.Annotations)
+
+	SetTraceContextAnnotations(&meta, propagation.MapCarrier{"traceparent": "00-trace-span-01"})
+	require.Equal(t, map[string]string{
+		"foo":                           "bar",
+		apicommon.TraceParentAnnotation: "00-trace-span-01",
+	}, meta.Annotations)
+	require.Equal(t, map[string]string{"foo": "bar", apicommon.TraceStateAnnotation: "old=state"}, original)
+
+	require.Equal(t, propagation.MapCarrier{"traceparent": "00-trace-span-01"}, GetTraceContextFromAnnotations(meta))
+}
+
+func TestInjectTraceContext(t *testing.T) {
+	meta := metav1.ObjectMeta{}
+	spec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init"}},
+		Containers: []corev1.Container{
+			{Name: "app", Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}},
+			{Name: "sidecar", Env: []corev1.EnvVar{{Name: TraceStateEnvVar, Value: "custom"}}},
+		},
+	}
+
+	InjectTraceContext(&meta, &spec, propagation.MapCarrier{"traceparent": "00-trace-span-01", "tracestate": "vendor=value"})
+
+	require.Equal(t, map[string]string{
+		apicommon.TraceParentAnnotation: "00-trace-span-01",
+		apicommon.TraceStateAnnotation:  "vendor=value",
+	}, meta.Annotations)
+	require.Equal(t, []corev1.EnvVar{
+		{Name: TraceParentEnvVar, Value: "00-trace-span-01"},
+		{Name: TraceStateEnvVar, Value: "vendor=value"},
+	}, spec.InitContainers[0].Env)
+	require.Equal(t, []corev1.EnvVar{
+		{Name: "FOO", Value: "bar"},
+		{Name: TraceParentEnvVar, Value: "00-trace-span-01"},
+		{Name: TraceStateEnvVar, Value: "vendor=value"},
+	}, spec.Containers[0].Env)
+	require.Equal(t, []corev1.EnvVar{
+		{Name: TraceStateEnvVar, Value: "custom"},
+		{Name: TraceParentEnvVar, Value: "00-trace-span-01"},
+	}, spec.Containers[1].Env)
+}
+
+func TestInjectTraceContext_NoTraceContext(t *testing.T) {
+	meta := metav1.ObjectMeta{}
+	spec := corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}
+
+	InjectTraceContext(&meta, &spec, nil)
+
+	require.Nil(t, meta.Annotations)
+	require.Nil(t, spec.Containers[0].Env)
+}
@@ -0,0 +1,72 @@
+package workloadkind
+
+import (
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type IRegistry interface {
+	Set(name string, rules *Rules)
+	Delete(name string)
+	Get(kind string) (*Rules, bool)
+	IsOwnerSupported(owner metav1.OwnerReference) bool
+}
+
+// Registry holds the rules of all valid KeptnWorkloadKinds.
+// Since the name of a KeptnWorkloadKind is the lowercase kind of the workload resource,
+// the rules can be looked up by either of them.
+type Registry struct {
+	mtx   sync.RWMutex
+	rules map[string]*Rules
+}
+
+var instance *Registry
+var once = sync.Once{}
+
+func Instance() *Registry {
+	once.Do(func() {
+		instance = NewRegistry()
+	})
+	return instance
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		rules: map[string]*Rules{},
+	}
+}
+
+// Set stores the rules of the KeptnWorkloadKind with the given name
+func (r *Registry) Set(name string, rules *Rules) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.rules[name] = rules
+}
+
+// Delete removes the rules of the KeptnWorkloadKind with the given name
+func (r *Registry) Delete(name string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	delete(r.rules, name)
+}
+
+// Get returns the rules for the given kind of workload resource
+func (r *Registry) Get(kind string) (*Rules, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	rules, ok := r.rules[strings.ToLower(kind)]
+	return rules, ok
+}
+
+// IsOwnerSupported returns whether the kind of the given owner has been registered via a KeptnWorkloadKind.
+// Kinds with the same name in a different API group are not supported.
+func (r *Registry) IsOwnerSupported(owner metav1.OwnerReference) bool {
+	rules, ok := r.Get(owner.Kind)
+	if !ok {
+		return false
+	}
+	return rules.GroupVersionKind.GroupKind() == schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind).GroupKind()
+}
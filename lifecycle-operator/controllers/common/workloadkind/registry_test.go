@@ -0,0 +1,52 @@
+package workloadkind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	owner := metav1.OwnerReference{APIVersion: "kubevirt.io/v1", Kind: "VirtualMachineInstance"}
+	rules := &Rules{
+		GroupVersionKind: schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachineInstance"},
+	}
+
+	_, ok := registry.Get("VirtualMachineInstance")
+	require.False(t, ok)
+	require.False(t, registry.IsOwnerSupported(owner))
+
+	registry.Set("virtualmachineinstance", rules)
+
+	got, ok := registry.Get("VirtualMachineInstance")
+	require.True(t, ok)
+	require.Equal(t, rules, got)
+	require.True(t, registry.IsOwnerSupported(owner))
+	// a kind with the same name in another group is not supported
+	require.False(t, registry.IsOwnerSupported(metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "VirtualMachineInstance"}))
+
+	registry.Delete("virtualmachineinstance")
+
+	_, ok = registry.Get("VirtualMachineInstance")
+	require.False(t, ok)
+	require.False(t, registry.IsOwnerSupported(owner))
+}
+
+func TestRegistry_SetReplacesOwnerKind(t *testing.T) {
+	registry := NewRegistry()
+	oldOwner := metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "MyWorkload"}
+	newOwner := metav1.OwnerReference{APIVersion: "example.org/v1", Kind: "MyWorkload"}
+
+	registry.Set("myworkload", &Rules{GroupVersionKind: schema.FromAPIVersionAndKind(oldOwner.APIVersion, oldOwner.Kind)})
+	require.True(t, registry.IsOwnerSupported(oldOwner))
+
+	registry.Set("myworkload", &Rules{GroupVersionKind: schema.FromAPIVersionAndKind(newOwner.APIVersion, newOwner.Kind)})
+	require.False(t, registry.IsOwnerSupported(oldOwner))
+	require.True(t, registry.IsOwnerSupported(newOwner))
+
+	registry.Delete("myworkload")
+	require.False(t, registry.IsOwnerSupported(newOwner))
+}
@@ -0,0 +1,112 @@
+package workloadkind
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// selfVariable is the name under which the workload resource is available in the expressions
+const selfVariable = "self"
+
+// Rules contains the compiled expressions of a KeptnWorkloadKind
+type Rules struct {
+	GroupVersionKind schema.GroupVersionKind
+	OwnerChain       []klcv1beta1.WorkloadKindOwner
+	running          cel.Program
+	failed           cel.Program
+	version          cel.Program
+}
+
+// NewRules compiles the expressions of the given KeptnWorkloadKind
+func NewRules(workloadKind *klcv1beta1.KeptnWorkloadKind) (*Rules, error) {
+	env, err := cel.NewEnv(cel.Variable(selfVariable, cel.DynType), ext.Strings())
+	if err != nil {
+		return nil, err
+	}
+
+	rules := &Rules{
+		GroupVersionKind: schema.FromAPIVersionAndKind(workloadKind.Spec.APIVersion, workloadKind.Spec.Kind),
+		OwnerChain:       workloadKind.Spec.OwnerChain,
+	}
+
+	if rules.running, err = compile(env, workloadKind.Spec.Running, cel.BoolType); err != nil {
+		return nil, fmt.Errorf("running: %w", err)
+	}
+	if workloadKind.Spec.Failed != "" {
+		if rules.failed, err = compile(env, workloadKind.Spec.Failed, cel.BoolType); err != nil {
+			return nil, fmt.Errorf("failed: %w", err)
+		}
+	}
+	if workloadKind.Spec.Version != "" {
+		if rules.version, err = compile(env, workloadKind.Spec.Version, cel.StringType); err != nil {
+			return nil, fmt.Errorf("version: %w", err)
+		}
+	}
+	return rules, nil
+}
+
+// IsRunning returns whether the given workload resource is running
+func (r *Rules) IsRunning(obj map[string]interface{}) (bool, error) {
+	return evalBool(r.running, obj)
+}
+
+// IsFailed returns whether the deployment of the given workload resource failed
+func (r *Rules) IsFailed(obj map[string]interface{}) (bool, error) {
+	if r.failed == nil {
+		return false, nil
+	}
+	return evalBool(r.failed, obj)
+}
+
+// GetVersion returns the version of the given workload resource, or an empty string if no version expression is set
+func (r *Rules) GetVersion(obj map[string]interface{}) (string, error) {
+	if r.version == nil {
+		return "", nil
+	}
+	out, err := eval(r.version, obj)
+	if err != nil {
+		return "", err
+	}
+	version, ok := out.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: expected string, got %T", controllererrors.ErrUnexpectedWorkloadKindResult, out)
+	}
+	return version, nil
+}
+
+func compile(env *cel.Env, expression string, outputType *cel.Type) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("%w: %w", controllererrors.ErrInvalidWorkloadKindExpression, issues.Err())
+	}
+	// fields of the workload resource are dynamically typed, so the type can only be verified at runtime in that case
+	if ast.OutputType() != cel.DynType && !outputType.IsAssignableType(ast.OutputType()) {
+		return nil, fmt.Errorf("%w: expected %s, got %s", controllererrors.ErrInvalidWorkloadKindExpression, outputType, ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+func evalBool(program cel.Program, obj map[string]interface{}) (bool, error) {
+	out, err := eval(program, obj)
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: expected bool, got %T", controllererrors.ErrUnexpectedWorkloadKindResult, out)
+	}
+	return result, nil
+}
+
+func eval(program cel.Program, obj map[string]interface{}) (interface{}, error) {
+	out, _, err := program.Eval(map[string]interface{}{selfVariable: obj})
+	if err != nil {
+		return nil, err
+	}
+	return out.Value(), nil
+}
@@ -0,0 +1,174 @@
+package workloadkind
+
+import (
+	"testing"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    klcv1beta1.KeptnWorkloadKindSpec
+		wantErr error
+	}{
+		{
+			name: "valid expressions",
+			spec: klcv1beta1.KeptnWorkloadKindSpec{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachineInstance",
+				Running:    "has(self.status) && self.status.phase == 'Running'",
+				Failed:     "has(self.status) && self.status.phase == 'Failed'",
+				Version:    "self.metadata.labels['version']",
+			},
+		},
+		{
+			name: "invalid syntax",
+			spec: klcv1beta1.KeptnWorkloadKindSpec{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachineInstance",
+				Running:    "self.status.phase ==",
+			},
+			wantErr: controllererrors.ErrInvalidWorkloadKindExpression,
+		},
+		{
+			name: "running expression does not return bool",
+			spec: klcv1beta1.KeptnWorkloadKindSpec{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachineInstance",
+				Running:    "'running'",
+			},
+			wantErr: controllererrors.ErrInvalidWorkloadKindExpression,
+		},
+		{
+			name: "version expression does not return string",
+			spec: klcv1beta1.KeptnWorkloadKindSpec{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachineInstance",
+				Running:    "true",
+				Version:    "1 + 1",
+			},
+			wantErr: controllererrors.ErrInvalidWorkloadKindExpression,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := NewRules(&klcv1beta1.KeptnWorkloadKind{Spec: tt.spec})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachineInstance"}, rules.GroupVersionKind)
+		})
+	}
+}
+
+func TestRules_Evaluate(t *testing.T) {
+	rules, err := NewRules(&klcv1beta1.KeptnWorkloadKind{
+		Spec: klcv1beta1.KeptnWorkloadKindSpec{
+			APIVersion: "serving.knative.dev/v1",
+			Kind:       "Service",
+			Running:    "has(self.status) && self.status.conditions.exists(c, c.type == 'Ready' && c.status == 'True')",
+			Failed:     "has(self.status) && self.status.conditions.exists(c, c.type == 'Ready' && c.status == 'False')",
+			Version:    "self.spec.template.spec.containers[0].image.split(':')[1]",
+		},
+	})
+	require.Nil(t, err)
+
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"image": "podtato-head:0.3.0"},
+					},
+				},
+			},
+		},
+	}
+
+	running, err := rules.IsRunning(obj)
+	require.Nil(t, err)
+	require.False(t, running)
+
+	failed, err := rules.IsFailed(obj)
+	require.Nil(t, err)
+	require.False(t, failed)
+
+	version, err := rules.GetVersion(obj)
+	require.Nil(t, err)
+	require.Equal(t, "0.3.0", version)
+
+	obj["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+		},
+	}
+
+	running, err = rules.IsRunning(obj)
+	require.Nil(t, err)
+	require.True(t, running)
+
+	obj["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False"},
+		},
+	}
+
+	failed, err = rules.IsFailed(obj)
+	require.Nil(t, err)
+	require.True(t, failed)
+}
+
+func TestRules_EvaluateOptionalExpressions(t *testing.T) {
+	rules, err := NewRules(&klcv1beta1.KeptnWorkloadKind{
+		Spec: klcv1beta1.KeptnWorkloadKindSpec{
+			APIVersion: "example.com/v1",
+			Kind:       "MyWorkload",
+			Running:    "self.status.ready",
+		},
+	})
+	require.Nil(t, err)
+
+	failed, err := rules.IsFailed(map[string]interface{}{})
+	require.Nil(t, err)
+	require.False(t, failed)
+
+	version, err := rules.GetVersion(map[string]interface{}{})
+	require.Nil(t, err)
+	require.Empty(t, version)
+}
+
+func TestRules_EvaluateUnexpectedResult(t *testing.T) {
+	rules, err := NewRules(&klcv1beta1.KeptnWorkloadKind{
+		Spec: klcv1beta1.KeptnWorkloadKindSpec{
+			APIVersion: "example.com/v1",
+			Kind:       "MyWorkload",
+			Running:    "self.status.ready",
+			Version:    "self.status.ready",
+		},
+	})
+	require.Nil(t, err)
+
+	obj := map[string]interface{}{
+		"status": map[string]interface{}{
+			"ready": "yes",
+		},
+	}
+
+	_, err = rules.IsRunning(obj)
+	require.ErrorIs(t, err, controllererrors.ErrUnexpectedWorkloadKindResult)
+
+	_, err = rules.IsRunning(map[string]interface{}{})
+	require.NotNil(t, err)
+
+	obj["status"] = map[string]interface{}{
+		"ready": true,
+	}
+	_, err = rules.GetVersion(obj)
+	require.ErrorIs(t, err, controllererrors.ErrUnexpectedWorkloadKindResult)
+}
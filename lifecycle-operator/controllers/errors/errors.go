@@ -17,12 +17,20 @@ var ErrNoValues = fmt.Errorf("no values")
 var ErrInvalidOperator = fmt.Errorf("invalid operator")
 var ErrCannotMarshalParams = fmt.Errorf("could not marshal parameters")
 var ErrNoTaskDefinitionSpec = fmt.Errorf("the TaskDefinition specs are empty")
+var ErrInvalidTaskOutputs = fmt.Errorf("task outputs must be a JSON object or a list of key=value pairs")
 var ErrUnsupportedWorkloadVersionResourceReference = fmt.Errorf("unsupported Resource Reference")
 var ErrCannotGetKeptnTaskDefinition = fmt.Errorf("cannot retrieve KeptnTaskDefinition")
 var ErrCannotGetKeptnEvaluationDefinition = fmt.Errorf("cannot retrieve KeptnEvaluationDefinition")
 var ErrNoMatchingAppVersionFound = fmt.Errorf("no matching KeptnAppVersion found")
+var ErrNoPreviousRevisionFound = fmt.Errorf("no previous revision found to restore")
+var ErrInvalidWorkloadKindExpression = fmt.Errorf("invalid KeptnWorkloadKind expression")
+var ErrUnexpectedWorkloadKindResult = fmt.Errorf("unexpected result of KeptnWorkloadKind expression")
+var ErrUnexpectedHttpStatus = fmt.Errorf("unexpected HTTP status code")
+var ErrHttpAssertionFailed = fmt.Errorf("HTTP assertion failed")
+var ErrInvalidTaskTemplate = fmt.Errorf("invalid template")
 
 var ErrCannotRetrieveConfigMsg = "could not retrieve KeptnConfig: %w"
+var ErrCannotRetrieveWorkloadKindMsg = "could not retrieve KeptnWorkloadKind: %w"
 var ErrCannotRetrieveInstancesMsg = "could not retrieve instances: %w"
 var ErrCannotFetchAppMsg = "could not retrieve KeptnApp: %w"
 var ErrCannotFetchAppVersionMsg = "could not retrieve KeptnappVersion: %w"
@@ -0,0 +1,256 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dorametrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultWindow is the time frame the DORA metrics are computed for if no window is configured
+const DefaultWindow = 30 * 24 * time.Hour
+
+// requeueInterval is the interval in which the DORA metrics are recomputed, since the window moves on
+// even if no KeptnAppVersion changes
+const requeueInterval = time.Hour
+
+// DORAMetricsReconciler computes the DORA metrics of a KeptnApp from its KeptnAppVersions
+type DORAMetricsReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+	Window time.Duration
+}
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapps/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions,verbs=get;list;watch
+
+// Reconcile computes the deployment frequency, lead time for changes, change failure rate and time to restore
+// of a KeptnApp and stores them in its status.
+func (r *DORAMetricsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info("Computing DORA metrics", "app", req.NamespacedName)
+
+	app := &klcv1beta1.KeptnApp{}
+	err := r.Get(ctx, req.NamespacedName, app)
+	if errors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf(controllererrors.ErrCannotFetchAppMsg, err)
+	}
+
+	appVersions := &klcv1beta1.KeptnAppVersionList{}
+	if err := r.List(ctx, appVersions, client.InNamespace(app.Namespace)); err != nil {
+		return reconcile.Result{}, fmt.Errorf(controllererrors.ErrCannotRetrieveInstancesMsg, err)
+	}
+	items := []klcv1beta1.KeptnAppVersion{}
+	for _, appVersion := range appVersions.Items {
+		if appVersion.Spec.AppName == app.Name {
+			items = append(items, appVersion)
+		}
+	}
+
+	metrics := computeDORAMetrics(items, time.Now(), r.getWindow())
+	if isUnchanged(app.Status.DORAMetrics, metrics) {
+		return reconcile.Result{RequeueAfter: requeueInterval}, nil
+	}
+	app.Status.DORAMetrics = &metrics
+	if err := r.Status().Update(ctx, app); err != nil {
+		r.Log.Error(err, "could not update DORA metrics of KeptnApp", "app", req.NamespacedName)
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DORAMetricsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("dorametrics").
+		// status updates of the KeptnApp, including the ones of this controller, must not trigger a reconciliation
+		For(&klcv1beta1.KeptnApp{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&klcv1beta1.KeptnAppVersion{},
+			handler.EnqueueRequestsFromMapFunc(getAppForAppVersion),
+			builder.WithPredicates(completionPredicate()),
+		).
+		Complete(r)
+}
+
+// completionPredicate filters the events of KeptnAppVersions to the ones that can change the DORA metrics,
+// which are the completion of a KeptnAppVersion and its deletion
+func completionPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isCompleted(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !isCompleted(e.ObjectOld) && isCompleted(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+func isCompleted(obj client.Object) bool {
+	appVersion, ok := obj.(*klcv1beta1.KeptnAppVersion)
+	if !ok {
+		return false
+	}
+	return appVersion.Status.Status.IsSucceeded() || appVersion.Status.Status.IsFailed()
+}
+
+// isUnchanged checks if the computed DORA metrics equal the current ones, apart from their update time
+func isUnchanged(current *klcv1beta1.DORAMetrics, metrics klcv1beta1.DORAMetrics) bool {
+	if current == nil {
+		return false
+	}
+	previous := current.DeepCopy()
+	previous.LastUpdateTime = metrics.LastUpdateTime
+	return equality.Semantic.DeepEqual(*previous, metrics)
+}
+
+func (r *DORAMetricsReconciler) getWindow() time.Duration {
+	if r.Window <= 0 {
+		return DefaultWindow
+	}
+	return r.Window
+}
+
+// getAppForAppVersion returns the KeptnApp a KeptnAppVersion belongs to
+func getAppForAppVersion(_ context.Context, obj client.Object) []reconcile.Request {
+	appVersion, ok := obj.(*klcv1beta1.KeptnAppVersion)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: appVersion.Namespace, Name: appVersion.Spec.AppName}},
+	}
+}
+
+// computeDORAMetrics computes the DORA metrics from the KeptnAppVersions of a KeptnApp that completed within the window.
+// Deprecated KeptnAppVersions have been superseded by another revision and are not counted as deployments.
+func computeDORAMetrics(appVersions []klcv1beta1.KeptnAppVersion, now time.Time, window time.Duration) klcv1beta1.DORAMetrics {
+	sort.SliceStable(appVersions, func(i, j int) bool {
+		return appVersions[i].Status.EndTime.Before(&appVersions[j].Status.EndTime)
+	})
+
+	start := now.Add(-window)
+	deployments, failed := 0, 0
+	leadTimes := []time.Duration{}
+	restoreTimes := []time.Duration{}
+	var failedSince *time.Time
+	for i := range appVersions {
+		appVersion := &appVersions[i]
+		state := appVersion.Status.Status
+		if !state.IsSucceeded() && !state.IsFailed() {
+			continue
+		}
+		endTime := appVersion.Status.EndTime.Time
+		inWindow := endTime.After(start)
+
+		if state.IsFailed() {
+			// the outage starts with the first of several consecutive failed deployments
+			if failedSince == nil {
+				failedSince = &endTime
+			}
+			if inWindow {
+				deployments++
+				failed++
+			}
+			continue
+		}
+
+		if inWindow {
+			deployments++
+			if failedSince != nil {
+				restoreTimes = append(restoreTimes, endTime.Sub(*failedSince))
+			}
+			if leadTime, ok := getLeadTime(appVersion); ok {
+				leadTimes = append(leadTimes, leadTime)
+			}
+		}
+		failedSince = nil
+	}
+
+	metrics := klcv1beta1.DORAMetrics{
+		Window:                metav1.Duration{Duration: window},
+		DeploymentCount:       deployments,
+		FailedDeploymentCount: failed,
+		DeploymentFrequency:   formatFloat(float64(deployments) / (window.Hours() / 24)),
+		ChangeFailureRate:     formatFloat(0),
+		LeadTimeForChanges:    average(leadTimes),
+		TimeToRestore:         average(restoreTimes),
+		LastUpdateTime:        metav1.NewTime(now),
+	}
+	if deployments > 0 {
+		metrics.ChangeFailureRate = formatFloat(float64(failed) / float64(deployments))
+	}
+	return metrics
+}
+
+// getLeadTime returns the time from the commit, given by the commitTimestamp metadata, to the end of the deployment
+func getLeadTime(appVersion *klcv1beta1.KeptnAppVersion) (time.Duration, bool) {
+	commitTimestamp, ok := appVersion.Spec.Metadata[apicommon.CommitTimestampMetadataKey]
+	if !ok {
+		return 0, false
+	}
+	commitTime, err := time.Parse(time.RFC3339, commitTimestamp)
+	if err != nil || commitTime.After(appVersion.Status.EndTime.Time) {
+		return 0, false
+	}
+	return appVersion.Status.EndTime.Sub(commitTime), true
+}
+
+func average(durations []time.Duration) *metav1.Duration {
+	if len(durations) == 0 {
+		return nil
+	}
+	var sum time.Duration
+	for _, duration := range durations {
+		sum += duration
+	}
+	return &metav1.Duration{Duration: sum / time.Duration(len(durations))}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
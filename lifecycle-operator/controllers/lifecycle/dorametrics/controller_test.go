@@ -0,0 +1,147 @@
+package dorametrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var now = time.Now().Truncate(time.Second)
+
+func getAppVersion(name string, state apicommon.KeptnState, age time.Duration, commitAge time.Duration) klcv1beta1.KeptnAppVersion {
+	appVersion := klcv1beta1.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnAppVersionSpec{
+			AppName: "my-app",
+		},
+		Status: klcv1beta1.KeptnAppVersionStatus{
+			Status:  state,
+			EndTime: metav1.NewTime(now.Add(-age)),
+		},
+	}
+	if commitAge > 0 {
+		appVersion.Spec.Metadata = map[string]string{
+			apicommon.CommitTimestampMetadataKey: now.Add(-commitAge).Format(time.RFC3339),
+		}
+	}
+	return appVersion
+}
+
+func TestComputeDORAMetrics(t *testing.T) {
+	appVersions := []klcv1beta1.KeptnAppVersion{
+		// outside of the window, but starts the outage restored by v3
+		getAppVersion("v1", apicommon.StateFailed, 11*24*time.Hour, 0),
+		getAppVersion("v2", apicommon.StateFailed, 9*24*time.Hour, 0),
+		getAppVersion("v3", apicommon.StateSucceeded, 8*24*time.Hour, 8*24*time.Hour+2*time.Hour),
+		getAppVersion("v4", apicommon.StateFailed, 5*24*time.Hour, 0),
+		getAppVersion("v5", apicommon.StateSucceeded, 5*24*time.Hour-4*time.Hour, 5*24*time.Hour),
+		getAppVersion("v5-revision", apicommon.StateDeprecated, 2*time.Hour, 0),
+		getAppVersion("v6", apicommon.StateProgressing, 0, 0),
+	}
+
+	metrics := computeDORAMetrics(appVersions, now, 10*24*time.Hour)
+
+	require.Equal(t, 10*24*time.Hour, metrics.Window.Duration)
+	require.Equal(t, 4, metrics.DeploymentCount)
+	require.Equal(t, 2, metrics.FailedDeploymentCount)
+	require.Equal(t, "0.40", metrics.DeploymentFrequency)
+	require.Equal(t, "0.50", metrics.ChangeFailureRate)
+	// (2h + 4h) / 2
+	require.Equal(t, 3*time.Hour, metrics.LeadTimeForChanges.Duration)
+	// (3d + 4h) / 2
+	require.Equal(t, 38*time.Hour, metrics.TimeToRestore.Duration)
+	require.Equal(t, now, metrics.LastUpdateTime.Time)
+}
+
+func TestComputeDORAMetrics_NoDeployments(t *testing.T) {
+	metrics := computeDORAMetrics(nil, now, DefaultWindow)
+
+	require.Zero(t, metrics.DeploymentCount)
+	require.Equal(t, "0.00", metrics.DeploymentFrequency)
+	require.Equal(t, "0.00", metrics.ChangeFailureRate)
+	require.Nil(t, metrics.LeadTimeForChanges)
+	require.Nil(t, metrics.TimeToRestore)
+}
+
+func TestDORAMetricsReconciler_Reconcile(t *testing.T) {
+	app := &klcv1beta1.KeptnApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "default",
+		},
+	}
+	succeeded := getAppVersion("v1", apicommon.StateSucceeded, time.Hour, 2*time.Hour)
+	otherApp := getAppVersion("other-v1", apicommon.StateFailed, time.Hour, 0)
+	otherApp.Spec.AppName = "other-app"
+
+	fakeClient := testcommon.NewTestClient(app, &succeeded, &otherApp)
+	r := &DORAMetricsReconciler{
+		Client: fakeClient,
+		Scheme: fakeClient.Scheme(),
+		Log:    testr.New(t),
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-app", Namespace: "default"}}
+	result, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.Equal(t, requeueInterval, result.RequeueAfter)
+
+	err = fakeClient.Get(context.TODO(), req.NamespacedName, app)
+	require.Nil(t, err)
+	require.NotNil(t, app.Status.DORAMetrics)
+	require.Equal(t, DefaultWindow, app.Status.DORAMetrics.Window.Duration)
+	require.Equal(t, 1, app.Status.DORAMetrics.DeploymentCount)
+	require.Equal(t, "0.00", app.Status.DORAMetrics.ChangeFailureRate)
+	require.Equal(t, time.Hour, app.Status.DORAMetrics.LeadTimeForChanges.Duration)
+
+	// unchanged metrics are not updated
+	resourceVersion := app.ResourceVersion
+	_, err = r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	err = fakeClient.Get(context.TODO(), req.NamespacedName, app)
+	require.Nil(t, err)
+	require.Equal(t, resourceVersion, app.ResourceVersion)
+
+	// a missing KeptnApp is ignored
+	_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "missing", Namespace: "default"}})
+	require.Nil(t, err)
+}
+
+func TestCompletionPredicate(t *testing.T) {
+	progressing := getAppVersion("v1", apicommon.StateProgressing, 0, 0)
+	succeeded := getAppVersion("v1", apicommon.StateSucceeded, 0, 0)
+	failed := getAppVersion("v1", apicommon.StateFailed, 0, 0)
+
+	p := completionPredicate()
+
+	require.True(t, p.Create(event.CreateEvent{Object: &succeeded}))
+	require.False(t, p.Create(event.CreateEvent{Object: &progressing}))
+	require.True(t, p.Update(event.UpdateEvent{ObjectOld: &progressing, ObjectNew: &succeeded}))
+	require.True(t, p.Update(event.UpdateEvent{ObjectOld: &progressing, ObjectNew: &failed}))
+	require.False(t, p.Update(event.UpdateEvent{ObjectOld: &succeeded, ObjectNew: &succeeded}))
+	require.False(t, p.Update(event.UpdateEvent{ObjectOld: &progressing, ObjectNew: &progressing}))
+	require.True(t, p.Delete(event.DeleteEvent{Object: &succeeded}))
+	require.False(t, p.Generic(event.GenericEvent{Object: &succeeded}))
+}
+
+func TestGetAppForAppVersion(t *testing.T) {
+	appVersion := getAppVersion("v1", apicommon.StateSucceeded, time.Hour, 0)
+
+	requests := getAppForAppVersion(context.TODO(), &appVersion)
+	require.Equal(t, types.NamespacedName{Name: "my-app", Namespace: "default"}, requests[0].NamespacedName)
+
+	require.Empty(t, getAppForAppVersion(context.TODO(), &klcv1beta1.KeptnApp{}))
+}
@@ -39,30 +39,63 @@ import (
 //			GetEndTimeFunc: func() time.Time {
 //				panic("mock out the GetEndTime method")
 //			},
+//			GetExecutionConditionsFunc: func() []klcv1beta1.ExecutionCondition {
+//				panic("mock out the GetExecutionConditions method")
+//			},
+//			GetFailedItemStatusFunc: func() []klcv1beta1.ItemStatus {
+//				panic("mock out the GetFailedItemStatus method")
+//			},
+//			GetFailedPhaseFunc: func() string {
+//				panic("mock out the GetFailedPhase method")
+//			},
 //			GetNamespaceFunc: func() string {
 //				panic("mock out the GetNamespace method")
 //			},
+//			GetOnFailureTaskStatusFunc: func() []klcv1beta1.ItemStatus {
+//				panic("mock out the GetOnFailureTaskStatus method")
+//			},
+//			GetOnFailureTasksFunc: func() []string {
+//				panic("mock out the GetOnFailureTasks method")
+//			},
 //			GetParentNameFunc: func() string {
 //				panic("mock out the GetParentName method")
 //			},
+//			GetPostDeploymentAnalysesFunc: func() []string {
+//				panic("mock out the GetPostDeploymentAnalyses method")
+//			},
+//			GetPostDeploymentAnalysisStatusFunc: func() []klcv1beta1.ItemStatus {
+//				panic("mock out the GetPostDeploymentAnalysisStatus method")
+//			},
 //			GetPostDeploymentEvaluationTaskStatusFunc: func() []klcv1beta1.ItemStatus {
 //				panic("mock out the GetPostDeploymentEvaluationTaskStatus method")
 //			},
 //			GetPostDeploymentEvaluationsFunc: func() []string {
 //				panic("mock out the GetPostDeploymentEvaluations method")
 //			},
+//			GetPostDeploymentTaskDependenciesFunc: func() []klcv1beta1.TaskDependency {
+//				panic("mock out the GetPostDeploymentTaskDependencies method")
+//			},
 //			GetPostDeploymentTaskStatusFunc: func() []klcv1beta1.ItemStatus {
 //				panic("mock out the GetPostDeploymentTaskStatus method")
 //			},
 //			GetPostDeploymentTasksFunc: func() []string {
 //				panic("mock out the GetPostDeploymentTasks method")
 //			},
+//			GetPreDeploymentAnalysesFunc: func() []string {
+//				panic("mock out the GetPreDeploymentAnalyses method")
+//			},
+//			GetPreDeploymentAnalysisStatusFunc: func() []klcv1beta1.ItemStatus {
+//				panic("mock out the GetPreDeploymentAnalysisStatus method")
+//			},
 //			GetPreDeploymentEvaluationTaskStatusFunc: func() []klcv1beta1.ItemStatus {
 //				panic("mock out the GetPreDeploymentEvaluationTaskStatus method")
 //			},
 //			GetPreDeploymentEvaluationsFunc: func() []string {
 //				panic("mock out the GetPreDeploymentEvaluations method")
 //			},
+//			GetPreDeploymentTaskDependenciesFunc: func() []klcv1beta1.TaskDependency {
+//				panic("mock out the GetPreDeploymentTaskDependencies method")
+//			},
 //			GetPreDeploymentTaskStatusFunc: func() []klcv1beta1.ItemStatus {
 //				panic("mock out the GetPreDeploymentTaskStatus method")
 //			},
@@ -78,6 +111,12 @@ import (
 //			GetPromotionTasksFunc: func() []string {
 //				panic("mock out the GetPromotionTasks method")
 //			},
+//			GetRollbackTaskStatusFunc: func() []klcv1beta1.ItemStatus {
+//				panic("mock out the GetRollbackTaskStatus method")
+//			},
+//			GetRollbackTasksFunc: func() []string {
+//				panic("mock out the GetRollbackTasks method")
+//			},
 //			GetSpanAttributesFunc: func() []attribute.KeyValue {
 //				panic("mock out the GetSpanAttributes method")
 //			},
@@ -87,6 +126,9 @@ import (
 //			GetStateFunc: func() apicommon.KeptnState {
 //				panic("mock out the GetState method")
 //			},
+//			GetTraceIdFunc: func() map[string]string {
+//				panic("mock out the GetTraceId method")
+//			},
 //			GetVersionFunc: func() string {
 //				panic("mock out the GetVersion method")
 //			},
@@ -130,30 +172,63 @@ type PhaseItemMock struct {
 	// GetEndTimeFunc mocks the GetEndTime method.
 	GetEndTimeFunc func() time.Time
 
+	// GetExecutionConditionsFunc mocks the GetExecutionConditions method.
+	GetExecutionConditionsFunc func() []klcv1beta1.ExecutionCondition
+
+	// GetFailedItemStatusFunc mocks the GetFailedItemStatus method.
+	GetFailedItemStatusFunc func() []klcv1beta1.ItemStatus
+
+	// GetFailedPhaseFunc mocks the GetFailedPhase method.
+	GetFailedPhaseFunc func() string
+
 	// GetNamespaceFunc mocks the GetNamespace method.
 	GetNamespaceFunc func() string
 
+	// GetOnFailureTaskStatusFunc mocks the GetOnFailureTaskStatus method.
+	GetOnFailureTaskStatusFunc func() []klcv1beta1.ItemStatus
+
+	// GetOnFailureTasksFunc mocks the GetOnFailureTasks method.
+	GetOnFailureTasksFunc func() []string
+
 	// GetParentNameFunc mocks the GetParentName method.
 	GetParentNameFunc func() string
 
+	// GetPostDeploymentAnalysesFunc mocks the GetPostDeploymentAnalyses method.
+	GetPostDeploymentAnalysesFunc func() []string
+
+	// GetPostDeploymentAnalysisStatusFunc mocks the GetPostDeploymentAnalysisStatus method.
+	GetPostDeploymentAnalysisStatusFunc func() []klcv1beta1.ItemStatus
+
 	// GetPostDeploymentEvaluationTaskStatusFunc mocks the GetPostDeploymentEvaluationTaskStatus method.
 	GetPostDeploymentEvaluationTaskStatusFunc func() []klcv1beta1.ItemStatus
 
 	// GetPostDeploymentEvaluationsFunc mocks the GetPostDeploymentEvaluations method.
 	GetPostDeploymentEvaluationsFunc func() []string
 
+	// GetPostDeploymentTaskDependenciesFunc mocks the GetPostDeploymentTaskDependencies method.
+	GetPostDeploymentTaskDependenciesFunc func() []klcv1beta1.TaskDependency
+
 	// GetPostDeploymentTaskStatusFunc mocks the GetPostDeploymentTaskStatus method.
 	GetPostDeploymentTaskStatusFunc func() []klcv1beta1.ItemStatus
 
 	// GetPostDeploymentTasksFunc mocks the GetPostDeploymentTasks method.
 	GetPostDeploymentTasksFunc func() []string
 
+	// GetPreDeploymentAnalysesFunc mocks the GetPreDeploymentAnalyses method.
+	GetPreDeploymentAnalysesFunc func() []string
+
+	// GetPreDeploymentAnalysisStatusFunc mocks the GetPreDeploymentAnalysisStatus method.
+	GetPreDeploymentAnalysisStatusFunc func() []klcv1beta1.ItemStatus
+
 	// GetPreDeploymentEvaluationTaskStatusFunc mocks the GetPreDeploymentEvaluationTaskStatus method.
 	GetPreDeploymentEvaluationTaskStatusFunc func() []klcv1beta1.ItemStatus
 
 	// GetPreDeploymentEvaluationsFunc mocks the GetPreDeploymentEvaluations method.
 	GetPreDeploymentEvaluationsFunc func() []string
 
+	// GetPreDeploymentTaskDependenciesFunc mocks the GetPreDeploymentTaskDependencies method.
+	GetPreDeploymentTaskDependenciesFunc func() []klcv1beta1.TaskDependency
+
 	// GetPreDeploymentTaskStatusFunc mocks the GetPreDeploymentTaskStatus method.
 	GetPreDeploymentTaskStatusFunc func() []klcv1beta1.ItemStatus
 
@@ -169,6 +244,12 @@ type PhaseItemMock struct {
 	// GetPromotionTasksFunc mocks the GetPromotionTasks method.
 	GetPromotionTasksFunc func() []string
 
+	// GetRollbackTaskStatusFunc mocks the GetRollbackTaskStatus method.
+	GetRollbackTaskStatusFunc func() []klcv1beta1.ItemStatus
+
+	// GetRollbackTasksFunc mocks the GetRollbackTasks method.
+	GetRollbackTasksFunc func() []string
+
 	// GetSpanAttributesFunc mocks the GetSpanAttributes method.
 	GetSpanAttributesFunc func() []attribute.KeyValue
 
@@ -178,6 +259,9 @@ type PhaseItemMock struct {
 	// GetStateFunc mocks the GetState method.
 	GetStateFunc func() apicommon.KeptnState
 
+	// GetTraceIdFunc mocks the GetTraceId method.
+	GetTraceIdFunc func() map[string]string
+
 	// GetVersionFunc mocks the GetVersion method.
 	GetVersionFunc func() string
 
@@ -226,30 +310,63 @@ type PhaseItemMock struct {
 		// GetEndTime holds details about calls to the GetEndTime method.
 		GetEndTime []struct {
 		}
+		// GetExecutionConditions holds details about calls to the GetExecutionConditions method.
+		GetExecutionConditions []struct {
+		}
+		// GetFailedItemStatus holds details about calls to the GetFailedItemStatus method.
+		GetFailedItemStatus []struct {
+		}
+		// GetFailedPhase holds details about calls to the GetFailedPhase method.
+		GetFailedPhase []struct {
+		}
 		// GetNamespace holds details about calls to the GetNamespace method.
 		GetNamespace []struct {
 		}
+		// GetOnFailureTaskStatus holds details about calls to the GetOnFailureTaskStatus method.
+		GetOnFailureTaskStatus []struct {
+		}
+		// GetOnFailureTasks holds details about calls to the GetOnFailureTasks method.
+		GetOnFailureTasks []struct {
+		}
 		// GetParentName holds details about calls to the GetParentName method.
 		GetParentName []struct {
 		}
+		// GetPostDeploymentAnalyses holds details about calls to the GetPostDeploymentAnalyses method.
+		GetPostDeploymentAnalyses []struct {
+		}
+		// GetPostDeploymentAnalysisStatus holds details about calls to the GetPostDeploymentAnalysisStatus method.
+		GetPostDeploymentAnalysisStatus []struct {
+		}
 		// GetPostDeploymentEvaluationTaskStatus holds details about calls to the GetPostDeploymentEvaluationTaskStatus method.
 		GetPostDeploymentEvaluationTaskStatus []struct {
 		}
 		// GetPostDeploymentEvaluations holds details about calls to the GetPostDeploymentEvaluations method.
 		GetPostDeploymentEvaluations []struct {
 		}
+		// GetPostDeploymentTaskDependencies holds details about calls to the GetPostDeploymentTaskDependencies method.
+		GetPostDeploymentTaskDependencies []struct {
+		}
 		// GetPostDeploymentTaskStatus holds details about calls to the GetPostDeploymentTaskStatus method.
 		GetPostDeploymentTaskStatus []struct {
 		}
 		// GetPostDeploymentTasks holds details about calls to the GetPostDeploymentTasks method.
 		GetPostDeploymentTasks []struct {
 		}
+		// GetPreDeploymentAnalyses holds details about calls to the GetPreDeploymentAnalyses method.
+		GetPreDeploymentAnalyses []struct {
+		}
+		// GetPreDeploymentAnalysisStatus holds details about calls to the GetPreDeploymentAnalysisStatus method.
+		GetPreDeploymentAnalysisStatus []struct {
+		}
 		// GetPreDeploymentEvaluationTaskStatus holds details about calls to the GetPreDeploymentEvaluationTaskStatus method.
 		GetPreDeploymentEvaluationTaskStatus []struct {
 		}
 		// GetPreDeploymentEvaluations holds details about calls to the GetPreDeploymentEvaluations method.
 		GetPreDeploymentEvaluations []struct {
 		}
+		// GetPreDeploymentTaskDependencies holds details about calls to the GetPreDeploymentTaskDependencies method.
+		GetPreDeploymentTaskDependencies []struct {
+		}
 		// GetPreDeploymentTaskStatus holds details about calls to the GetPreDeploymentTaskStatus method.
 		GetPreDeploymentTaskStatus []struct {
 		}
@@ -265,6 +382,12 @@ type PhaseItemMock struct {
 		// GetPromotionTasks holds details about calls to the GetPromotionTasks method.
 		GetPromotionTasks []struct {
 		}
+		// GetRollbackTaskStatus holds details about calls to the GetRollbackTaskStatus method.
+		GetRollbackTaskStatus []struct {
+		}
+		// GetRollbackTasks holds details about calls to the GetRollbackTasks method.
+		GetRollbackTasks []struct {
+		}
 		// GetSpanAttributes holds details about calls to the GetSpanAttributes method.
 		GetSpanAttributes []struct {
 		}
@@ -274,6 +397,9 @@ type PhaseItemMock struct {
 		// GetState holds details about calls to the GetState method.
 		GetState []struct {
 		}
+		// GetTraceId holds details about calls to the GetTraceId method.
+		GetTraceId []struct {
+		}
 		// GetVersion holds details about calls to the GetVersion method.
 		GetVersion []struct {
 		}
@@ -303,22 +429,36 @@ type PhaseItemMock struct {
 	lockGetAppName                            sync.RWMutex
 	lockGetCurrentPhase                       sync.RWMutex
 	lockGetEndTime                            sync.RWMutex
+	lockGetExecutionConditions                sync.RWMutex
+	lockGetFailedItemStatus                   sync.RWMutex
+	lockGetFailedPhase                        sync.RWMutex
 	lockGetNamespace                          sync.RWMutex
+	lockGetOnFailureTaskStatus                sync.RWMutex
+	lockGetOnFailureTasks                     sync.RWMutex
 	lockGetParentName                         sync.RWMutex
+	lockGetPostDeploymentAnalyses             sync.RWMutex
+	lockGetPostDeploymentAnalysisStatus       sync.RWMutex
 	lockGetPostDeploymentEvaluationTaskStatus sync.RWMutex
 	lockGetPostDeploymentEvaluations          sync.RWMutex
+	lockGetPostDeploymentTaskDependencies     sync.RWMutex
 	lockGetPostDeploymentTaskStatus           sync.RWMutex
 	lockGetPostDeploymentTasks                sync.RWMutex
+	lockGetPreDeploymentAnalyses              sync.RWMutex
+	lockGetPreDeploymentAnalysisStatus        sync.RWMutex
 	lockGetPreDeploymentEvaluationTaskStatus  sync.RWMutex
 	lockGetPreDeploymentEvaluations           sync.RWMutex
+	lockGetPreDeploymentTaskDependencies      sync.RWMutex
 	lockGetPreDeploymentTaskStatus            sync.RWMutex
 	lockGetPreDeploymentTasks                 sync.RWMutex
 	lockGetPreviousVersion                    sync.RWMutex
 	lockGetPromotionTaskStatus                sync.RWMutex
 	lockGetPromotionTasks                     sync.RWMutex
+	lockGetRollbackTaskStatus                 sync.RWMutex
+	lockGetRollbackTasks                      sync.RWMutex
 	lockGetSpanAttributes                     sync.RWMutex
 	lockGetStartTime                          sync.RWMutex
 	lockGetState                              sync.RWMutex
+	lockGetTraceId                            sync.RWMutex
 	lockGetVersion                            sync.RWMutex
 	lockIsEndTimeSet                          sync.RWMutex
 	lockSetCurrentPhase                       sync.RWMutex
@@ -538,6 +678,87 @@ func (mock *PhaseItemMock) GetEndTimeCalls() []struct {
 	return calls
 }
 
+// GetExecutionConditions calls GetExecutionConditionsFunc.
+func (mock *PhaseItemMock) GetExecutionConditions() []klcv1beta1.ExecutionCondition {
+	if mock.GetExecutionConditionsFunc == nil {
+		panic("PhaseItemMock.GetExecutionConditionsFunc: method is nil but PhaseItem.GetExecutionConditions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetExecutionConditions.Lock()
+	mock.calls.GetExecutionConditions = append(mock.calls.GetExecutionConditions, callInfo)
+	mock.lockGetExecutionConditions.Unlock()
+	return mock.GetExecutionConditionsFunc()
+}
+
+// GetExecutionConditionsCalls gets all the calls that were made to GetExecutionConditions.
+// Check the length with:
+//
+//	len(phaseItem.GetExecutionConditionsCalls())
+func (mock *PhaseItemMock) GetExecutionConditionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetExecutionConditions.RLock()
+	calls = mock.calls.GetExecutionConditions
+	mock.lockGetExecutionConditions.RUnlock()
+	return calls
+}
+
+// GetFailedItemStatus calls GetFailedItemStatusFunc.
+func (mock *PhaseItemMock) GetFailedItemStatus() []klcv1beta1.ItemStatus {
+	if mock.GetFailedItemStatusFunc == nil {
+		panic("PhaseItemMock.GetFailedItemStatusFunc: method is nil but PhaseItem.GetFailedItemStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetFailedItemStatus.Lock()
+	mock.calls.GetFailedItemStatus = append(mock.calls.GetFailedItemStatus, callInfo)
+	mock.lockGetFailedItemStatus.Unlock()
+	return mock.GetFailedItemStatusFunc()
+}
+
+// GetFailedItemStatusCalls gets all the calls that were made to GetFailedItemStatus.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetFailedItemStatusCalls())
+func (mock *PhaseItemMock) GetFailedItemStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetFailedItemStatus.RLock()
+	calls = mock.calls.GetFailedItemStatus
+	mock.lockGetFailedItemStatus.RUnlock()
+	return calls
+}
+
+// GetFailedPhase calls GetFailedPhaseFunc.
+func (mock *PhaseItemMock) GetFailedPhase() string {
+	if mock.GetFailedPhaseFunc == nil {
+		panic("PhaseItemMock.GetFailedPhaseFunc: method is nil but PhaseItem.GetFailedPhase was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetFailedPhase.Lock()
+	mock.calls.GetFailedPhase = append(mock.calls.GetFailedPhase, callInfo)
+	mock.lockGetFailedPhase.Unlock()
+	return mock.GetFailedPhaseFunc()
+}
+
+// GetFailedPhaseCalls gets all the calls that were made to GetFailedPhase.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetFailedPhaseCalls())
+func (mock *PhaseItemMock) GetFailedPhaseCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetFailedPhase.RLock()
+	calls = mock.calls.GetFailedPhase
+	mock.lockGetFailedPhase.RUnlock()
+	return calls
+}
+
 // GetNamespace calls GetNamespaceFunc.
 func (mock *PhaseItemMock) GetNamespace() string {
 	if mock.GetNamespaceFunc == nil {
@@ -565,6 +786,60 @@ func (mock *PhaseItemMock) GetNamespaceCalls() []struct {
 	return calls
 }
 
+// GetOnFailureTaskStatus calls GetOnFailureTaskStatusFunc.
+func (mock *PhaseItemMock) GetOnFailureTaskStatus() []klcv1beta1.ItemStatus {
+	if mock.GetOnFailureTaskStatusFunc == nil {
+		panic("PhaseItemMock.GetOnFailureTaskStatusFunc: method is nil but PhaseItem.GetOnFailureTaskStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetOnFailureTaskStatus.Lock()
+	mock.calls.GetOnFailureTaskStatus = append(mock.calls.GetOnFailureTaskStatus, callInfo)
+	mock.lockGetOnFailureTaskStatus.Unlock()
+	return mock.GetOnFailureTaskStatusFunc()
+}
+
+// GetOnFailureTaskStatusCalls gets all the calls that were made to GetOnFailureTaskStatus.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetOnFailureTaskStatusCalls())
+func (mock *PhaseItemMock) GetOnFailureTaskStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetOnFailureTaskStatus.RLock()
+	calls = mock.calls.GetOnFailureTaskStatus
+	mock.lockGetOnFailureTaskStatus.RUnlock()
+	return calls
+}
+
+// GetOnFailureTasks calls GetOnFailureTasksFunc.
+func (mock *PhaseItemMock) GetOnFailureTasks() []string {
+	if mock.GetOnFailureTasksFunc == nil {
+		panic("PhaseItemMock.GetOnFailureTasksFunc: method is nil but PhaseItem.GetOnFailureTasks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetOnFailureTasks.Lock()
+	mock.calls.GetOnFailureTasks = append(mock.calls.GetOnFailureTasks, callInfo)
+	mock.lockGetOnFailureTasks.Unlock()
+	return mock.GetOnFailureTasksFunc()
+}
+
+// GetOnFailureTasksCalls gets all the calls that were made to GetOnFailureTasks.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetOnFailureTasksCalls())
+func (mock *PhaseItemMock) GetOnFailureTasksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetOnFailureTasks.RLock()
+	calls = mock.calls.GetOnFailureTasks
+	mock.lockGetOnFailureTasks.RUnlock()
+	return calls
+}
+
 // GetParentName calls GetParentNameFunc.
 func (mock *PhaseItemMock) GetParentName() string {
 	if mock.GetParentNameFunc == nil {
@@ -592,6 +867,60 @@ func (mock *PhaseItemMock) GetParentNameCalls() []struct {
 	return calls
 }
 
+// GetPostDeploymentAnalyses calls GetPostDeploymentAnalysesFunc.
+func (mock *PhaseItemMock) GetPostDeploymentAnalyses() []string {
+	if mock.GetPostDeploymentAnalysesFunc == nil {
+		panic("PhaseItemMock.GetPostDeploymentAnalysesFunc: method is nil but PhaseItem.GetPostDeploymentAnalyses was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPostDeploymentAnalyses.Lock()
+	mock.calls.GetPostDeploymentAnalyses = append(mock.calls.GetPostDeploymentAnalyses, callInfo)
+	mock.lockGetPostDeploymentAnalyses.Unlock()
+	return mock.GetPostDeploymentAnalysesFunc()
+}
+
+// GetPostDeploymentAnalysesCalls gets all the calls that were made to GetPostDeploymentAnalyses.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetPostDeploymentAnalysesCalls())
+func (mock *PhaseItemMock) GetPostDeploymentAnalysesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPostDeploymentAnalyses.RLock()
+	calls = mock.calls.GetPostDeploymentAnalyses
+	mock.lockGetPostDeploymentAnalyses.RUnlock()
+	return calls
+}
+
+// GetPostDeploymentAnalysisStatus calls GetPostDeploymentAnalysisStatusFunc.
+func (mock *PhaseItemMock) GetPostDeploymentAnalysisStatus() []klcv1beta1.ItemStatus {
+	if mock.GetPostDeploymentAnalysisStatusFunc == nil {
+		panic("PhaseItemMock.GetPostDeploymentAnalysisStatusFunc: method is nil but PhaseItem.GetPostDeploymentAnalysisStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPostDeploymentAnalysisStatus.Lock()
+	mock.calls.GetPostDeploymentAnalysisStatus = append(mock.calls.GetPostDeploymentAnalysisStatus, callInfo)
+	mock.lockGetPostDeploymentAnalysisStatus.Unlock()
+	return mock.GetPostDeploymentAnalysisStatusFunc()
+}
+
+// GetPostDeploymentAnalysisStatusCalls gets all the calls that were made to GetPostDeploymentAnalysisStatus.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetPostDeploymentAnalysisStatusCalls())
+func (mock *PhaseItemMock) GetPostDeploymentAnalysisStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPostDeploymentAnalysisStatus.RLock()
+	calls = mock.calls.GetPostDeploymentAnalysisStatus
+	mock.lockGetPostDeploymentAnalysisStatus.RUnlock()
+	return calls
+}
+
 // GetPostDeploymentEvaluationTaskStatus calls GetPostDeploymentEvaluationTaskStatusFunc.
 func (mock *PhaseItemMock) GetPostDeploymentEvaluationTaskStatus() []klcv1beta1.ItemStatus {
 	if mock.GetPostDeploymentEvaluationTaskStatusFunc == nil {
@@ -646,6 +975,33 @@ func (mock *PhaseItemMock) GetPostDeploymentEvaluationsCalls() []struct {
 	return calls
 }
 
+// GetPostDeploymentTaskDependencies calls GetPostDeploymentTaskDependenciesFunc.
+func (mock *PhaseItemMock) GetPostDeploymentTaskDependencies() []klcv1beta1.TaskDependency {
+	if mock.GetPostDeploymentTaskDependenciesFunc == nil {
+		panic("PhaseItemMock.GetPostDeploymentTaskDependenciesFunc: method is nil but PhaseItem.GetPostDeploymentTaskDependencies was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPostDeploymentTaskDependencies.Lock()
+	mock.calls.GetPostDeploymentTaskDependencies = append(mock.calls.GetPostDeploymentTaskDependencies, callInfo)
+	mock.lockGetPostDeploymentTaskDependencies.Unlock()
+	return mock.GetPostDeploymentTaskDependenciesFunc()
+}
+
+// GetPostDeploymentTaskDependenciesCalls gets all the calls that were made to GetPostDeploymentTaskDependencies.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetPostDeploymentTaskDependenciesCalls())
+func (mock *PhaseItemMock) GetPostDeploymentTaskDependenciesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPostDeploymentTaskDependencies.RLock()
+	calls = mock.calls.GetPostDeploymentTaskDependencies
+	mock.lockGetPostDeploymentTaskDependencies.RUnlock()
+	return calls
+}
+
 // GetPostDeploymentTaskStatus calls GetPostDeploymentTaskStatusFunc.
 func (mock *PhaseItemMock) GetPostDeploymentTaskStatus() []klcv1beta1.ItemStatus {
 	if mock.GetPostDeploymentTaskStatusFunc == nil {
@@ -700,6 +1056,60 @@ func (mock *PhaseItemMock) GetPostDeploymentTasksCalls() []struct {
 	return calls
 }
 
+// GetPreDeploymentAnalyses calls GetPreDeploymentAnalysesFunc.
+func (mock *PhaseItemMock) GetPreDeploymentAnalyses() []string {
+	if mock.GetPreDeploymentAnalysesFunc == nil {
+		panic("PhaseItemMock.GetPreDeploymentAnalysesFunc: method is nil but PhaseItem.GetPreDeploymentAnalyses was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPreDeploymentAnalyses.Lock()
+	mock.calls.GetPreDeploymentAnalyses = append(mock.calls.GetPreDeploymentAnalyses, callInfo)
+	mock.lockGetPreDeploymentAnalyses.Unlock()
+	return mock.GetPreDeploymentAnalysesFunc()
+}
+
+// GetPreDeploymentAnalysesCalls gets all the calls that were made to GetPreDeploymentAnalyses.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetPreDeploymentAnalysesCalls())
+func (mock *PhaseItemMock) GetPreDeploymentAnalysesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPreDeploymentAnalyses.RLock()
+	calls = mock.calls.GetPreDeploymentAnalyses
+	mock.lockGetPreDeploymentAnalyses.RUnlock()
+	return calls
+}
+
+// GetPreDeploymentAnalysisStatus calls GetPreDeploymentAnalysisStatusFunc.
+func (mock *PhaseItemMock) GetPreDeploymentAnalysisStatus() []klcv1beta1.ItemStatus {
+	if mock.GetPreDeploymentAnalysisStatusFunc == nil {
+		panic("PhaseItemMock.GetPreDeploymentAnalysisStatusFunc: method is nil but PhaseItem.GetPreDeploymentAnalysisStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPreDeploymentAnalysisStatus.Lock()
+	mock.calls.GetPreDeploymentAnalysisStatus = append(mock.calls.GetPreDeploymentAnalysisStatus, callInfo)
+	mock.lockGetPreDeploymentAnalysisStatus.Unlock()
+	return mock.GetPreDeploymentAnalysisStatusFunc()
+}
+
+// GetPreDeploymentAnalysisStatusCalls gets all the calls that were made to GetPreDeploymentAnalysisStatus.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetPreDeploymentAnalysisStatusCalls())
+func (mock *PhaseItemMock) GetPreDeploymentAnalysisStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPreDeploymentAnalysisStatus.RLock()
+	calls = mock.calls.GetPreDeploymentAnalysisStatus
+	mock.lockGetPreDeploymentAnalysisStatus.RUnlock()
+	return calls
+}
+
 // GetPreDeploymentEvaluationTaskStatus calls GetPreDeploymentEvaluationTaskStatusFunc.
 func (mock *PhaseItemMock) GetPreDeploymentEvaluationTaskStatus() []klcv1beta1.ItemStatus {
 	if mock.GetPreDeploymentEvaluationTaskStatusFunc == nil {
@@ -754,6 +1164,33 @@ func (mock *PhaseItemMock) GetPreDeploymentEvaluationsCalls() []struct {
 	return calls
 }
 
+// GetPreDeploymentTaskDependencies calls GetPreDeploymentTaskDependenciesFunc.
+func (mock *PhaseItemMock) GetPreDeploymentTaskDependencies() []klcv1beta1.TaskDependency {
+	if mock.GetPreDeploymentTaskDependenciesFunc == nil {
+		panic("PhaseItemMock.GetPreDeploymentTaskDependenciesFunc: method is nil but PhaseItem.GetPreDeploymentTaskDependencies was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPreDeploymentTaskDependencies.Lock()
+	mock.calls.GetPreDeploymentTaskDependencies = append(mock.calls.GetPreDeploymentTaskDependencies, callInfo)
+	mock.lockGetPreDeploymentTaskDependencies.Unlock()
+	return mock.GetPreDeploymentTaskDependenciesFunc()
+}
+
+// GetPreDeploymentTaskDependenciesCalls gets all the calls that were made to GetPreDeploymentTaskDependencies.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetPreDeploymentTaskDependenciesCalls())
+func (mock *PhaseItemMock) GetPreDeploymentTaskDependenciesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPreDeploymentTaskDependencies.RLock()
+	calls = mock.calls.GetPreDeploymentTaskDependencies
+	mock.lockGetPreDeploymentTaskDependencies.RUnlock()
+	return calls
+}
+
 // GetPreDeploymentTaskStatus calls GetPreDeploymentTaskStatusFunc.
 func (mock *PhaseItemMock) GetPreDeploymentTaskStatus() []klcv1beta1.ItemStatus {
 	if mock.GetPreDeploymentTaskStatusFunc == nil {
@@ -889,6 +1326,60 @@ func (mock *PhaseItemMock) GetPromotionTasksCalls() []struct {
 	return calls
 }
 
+// GetRollbackTaskStatus calls GetRollbackTaskStatusFunc.
+func (mock *PhaseItemMock) GetRollbackTaskStatus() []klcv1beta1.ItemStatus {
+	if mock.GetRollbackTaskStatusFunc == nil {
+		panic("PhaseItemMock.GetRollbackTaskStatusFunc: method is nil but PhaseItem.GetRollbackTaskStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetRollbackTaskStatus.Lock()
+	mock.calls.GetRollbackTaskStatus = append(mock.calls.GetRollbackTaskStatus, callInfo)
+	mock.lockGetRollbackTaskStatus.Unlock()
+	return mock.GetRollbackTaskStatusFunc()
+}
+
+// GetRollbackTaskStatusCalls gets all the calls that were made to GetRollbackTaskStatus.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetRollbackTaskStatusCalls())
+func (mock *PhaseItemMock) GetRollbackTaskStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRollbackTaskStatus.RLock()
+	calls = mock.calls.GetRollbackTaskStatus
+	mock.lockGetRollbackTaskStatus.RUnlock()
+	return calls
+}
+
+// GetRollbackTasks calls GetRollbackTasksFunc.
+func (mock *PhaseItemMock) GetRollbackTasks() []string {
+	if mock.GetRollbackTasksFunc == nil {
+		panic("PhaseItemMock.GetRollbackTasksFunc: method is nil but PhaseItem.GetRollbackTasks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetRollbackTasks.Lock()
+	mock.calls.GetRollbackTasks = append(mock.calls.GetRollbackTasks, callInfo)
+	mock.lockGetRollbackTasks.Unlock()
+	return mock.GetRollbackTasksFunc()
+}
+
+// GetRollbackTasksCalls gets all the calls that were made to GetRollbackTasks.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetRollbackTasksCalls())
+func (mock *PhaseItemMock) GetRollbackTasksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRollbackTasks.RLock()
+	calls = mock.calls.GetRollbackTasks
+	mock.lockGetRollbackTasks.RUnlock()
+	return calls
+}
+
 // GetSpanAttributes calls GetSpanAttributesFunc.
 func (mock *PhaseItemMock) GetSpanAttributes() []attribute.KeyValue {
 	if mock.GetSpanAttributesFunc == nil {
@@ -970,6 +1461,33 @@ func (mock *PhaseItemMock) GetStateCalls() []struct {
 	return calls
 }
 
+// GetTraceId calls GetTraceIdFunc.
+func (mock *PhaseItemMock) GetTraceId() map[string]string {
+	if mock.GetTraceIdFunc == nil {
+		panic("PhaseItemMock.GetTraceIdFunc: method is nil but PhaseItem.GetTraceId was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTraceId.Lock()
+	mock.calls.GetTraceId = append(mock.calls.GetTraceId, callInfo)
+	mock.lockGetTraceId.Unlock()
+	return mock.GetTraceIdFunc()
+}
+
+// GetTraceIdCalls gets all the calls that were made to GetTraceId.
+// Check the length with:
+//
+//	len(phaseItem.GetTraceIdCalls())
+func (mock *PhaseItemMock) GetTraceIdCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTraceId.RLock()
+	calls = mock.calls.GetTraceId
+	mock.lockGetTraceId.RUnlock()
+	return calls
+}
+
 // GetVersion calls GetVersionFunc.
 func (mock *PhaseItemMock) GetVersion() string {
 	if mock.GetVersionFunc == nil {
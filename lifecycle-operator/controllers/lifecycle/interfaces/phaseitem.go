@@ -30,14 +30,28 @@ type PhaseItem interface {
 	GetAppName() string
 	GetPreDeploymentTasks() []string
 	GetPostDeploymentTasks() []string
+	GetPreDeploymentTaskDependencies() []klcv1beta1.TaskDependency
+	GetPostDeploymentTaskDependencies() []klcv1beta1.TaskDependency
+	GetExecutionConditions() []klcv1beta1.ExecutionCondition
+	GetTraceId() map[string]string
 	GetPromotionTasks() []string
+	GetRollbackTasks() []string
+	GetOnFailureTasks() []string
 	GetPreDeploymentTaskStatus() []klcv1beta1.ItemStatus
 	GetPostDeploymentTaskStatus() []klcv1beta1.ItemStatus
 	GetPromotionTaskStatus() []klcv1beta1.ItemStatus
+	GetRollbackTaskStatus() []klcv1beta1.ItemStatus
+	GetOnFailureTaskStatus() []klcv1beta1.ItemStatus
+	GetFailedPhase() string
+	GetFailedItemStatus() []klcv1beta1.ItemStatus
 	GetPreDeploymentEvaluations() []string
 	GetPostDeploymentEvaluations() []string
 	GetPreDeploymentEvaluationTaskStatus() []klcv1beta1.ItemStatus
 	GetPostDeploymentEvaluationTaskStatus() []klcv1beta1.ItemStatus
+	GetPreDeploymentAnalyses() []string
+	GetPostDeploymentAnalyses() []string
+	GetPreDeploymentAnalysisStatus() []klcv1beta1.ItemStatus
+	GetPostDeploymentAnalysisStatus() []klcv1beta1.ItemStatus
 	GenerateTask(taskDefinition klcv1beta1.KeptnTaskDefinition, checkType apicommon.CheckType) klcv1beta1.KeptnTask
 	GenerateEvaluation(evaluationDefinition klcv1beta1.KeptnEvaluationDefinition, checkType apicommon.CheckType) klcv1beta1.KeptnEvaluation
 	GetSpanAttributes() []attribute.KeyValue
@@ -117,6 +131,22 @@ func (pw PhaseItemWrapper) GetPostDeploymentTasks() []string {
 	return pw.Obj.GetPostDeploymentTasks()
 }
 
+func (pw PhaseItemWrapper) GetPreDeploymentTaskDependencies() []klcv1beta1.TaskDependency {
+	return pw.Obj.GetPreDeploymentTaskDependencies()
+}
+
+func (pw PhaseItemWrapper) GetPostDeploymentTaskDependencies() []klcv1beta1.TaskDependency {
+	return pw.Obj.GetPostDeploymentTaskDependencies()
+}
+
+func (pw PhaseItemWrapper) GetExecutionConditions() []klcv1beta1.ExecutionCondition {
+	return pw.Obj.GetExecutionConditions()
+}
+
+func (pw PhaseItemWrapper) GetTraceId() map[string]string {
+	return pw.Obj.GetTraceId()
+}
+
 func (pw PhaseItemWrapper) GetPreDeploymentTaskStatus() []klcv1beta1.ItemStatus {
 	return pw.Obj.GetPreDeploymentTaskStatus()
 }
@@ -141,6 +171,22 @@ func (pw PhaseItemWrapper) GetPostDeploymentEvaluationTaskStatus() []klcv1beta1.
 	return pw.Obj.GetPostDeploymentEvaluationTaskStatus()
 }
 
+func (pw PhaseItemWrapper) GetPreDeploymentAnalyses() []string {
+	return pw.Obj.GetPreDeploymentAnalyses()
+}
+
+func (pw PhaseItemWrapper) GetPostDeploymentAnalyses() []string {
+	return pw.Obj.GetPostDeploymentAnalyses()
+}
+
+func (pw PhaseItemWrapper) GetPreDeploymentAnalysisStatus() []klcv1beta1.ItemStatus {
+	return pw.Obj.GetPreDeploymentAnalysisStatus()
+}
+
+func (pw PhaseItemWrapper) GetPostDeploymentAnalysisStatus() []klcv1beta1.ItemStatus {
+	return pw.Obj.GetPostDeploymentAnalysisStatus()
+}
+
 func (pw PhaseItemWrapper) GenerateTask(taskDefinition klcv1beta1.KeptnTaskDefinition, checkType apicommon.CheckType) klcv1beta1.KeptnTask {
 	return pw.Obj.GenerateTask(taskDefinition, checkType)
 }
@@ -168,3 +214,27 @@ func (pw PhaseItemWrapper) GetPromotionTasks() []string {
 func (pw PhaseItemWrapper) GetPromotionTaskStatus() []klcv1beta1.ItemStatus {
 	return pw.Obj.GetPromotionTaskStatus()
 }
+
+func (pw PhaseItemWrapper) GetRollbackTasks() []string {
+	return pw.Obj.GetRollbackTasks()
+}
+
+func (pw PhaseItemWrapper) GetRollbackTaskStatus() []klcv1beta1.ItemStatus {
+	return pw.Obj.GetRollbackTaskStatus()
+}
+
+func (pw PhaseItemWrapper) GetOnFailureTasks() []string {
+	return pw.Obj.GetOnFailureTasks()
+}
+
+func (pw PhaseItemWrapper) GetOnFailureTaskStatus() []klcv1beta1.ItemStatus {
+	return pw.Obj.GetOnFailureTaskStatus()
+}
+
+func (pw PhaseItemWrapper) GetFailedPhase() string {
+	return pw.Obj.GetFailedPhase()
+}
+
+func (pw PhaseItemWrapper) GetFailedItemStatus() []klcv1beta1.ItemStatus {
+	return pw.Obj.GetFailedItemStatus()
+}
@@ -77,6 +77,18 @@ func TestPhaseItem(t *testing.T) {
 		GetPostDeploymentTasksFunc: func() []string {
 			return nil
 		},
+		GetPreDeploymentTaskDependenciesFunc: func() []v1beta1.TaskDependency {
+			return nil
+		},
+		GetPostDeploymentTaskDependenciesFunc: func() []v1beta1.TaskDependency {
+			return nil
+		},
+		GetExecutionConditionsFunc: func() []v1beta1.ExecutionCondition {
+			return nil
+		},
+		GetTraceIdFunc: func() map[string]string {
+			return nil
+		},
 		GetPreDeploymentTaskStatusFunc: func() []v1beta1.ItemStatus {
 			return nil
 		},
@@ -95,12 +107,42 @@ func TestPhaseItem(t *testing.T) {
 		GetPostDeploymentEvaluationTaskStatusFunc: func() []v1beta1.ItemStatus {
 			return nil
 		},
+		GetPreDeploymentAnalysesFunc: func() []string {
+			return nil
+		},
+		GetPostDeploymentAnalysesFunc: func() []string {
+			return nil
+		},
+		GetPreDeploymentAnalysisStatusFunc: func() []v1beta1.ItemStatus {
+			return nil
+		},
+		GetPostDeploymentAnalysisStatusFunc: func() []v1beta1.ItemStatus {
+			return nil
+		},
 		GetPromotionTasksFunc: func() []string {
 			return []string{}
 		},
 		GetPromotionTaskStatusFunc: func() []v1beta1.ItemStatus {
 			return []v1beta1.ItemStatus{}
 		},
+		GetRollbackTasksFunc: func() []string {
+			return []string{}
+		},
+		GetRollbackTaskStatusFunc: func() []v1beta1.ItemStatus {
+			return []v1beta1.ItemStatus{}
+		},
+		GetOnFailureTasksFunc: func() []string {
+			return []string{}
+		},
+		GetOnFailureTaskStatusFunc: func() []v1beta1.ItemStatus {
+			return []v1beta1.ItemStatus{}
+		},
+		GetFailedPhaseFunc: func() string {
+			return ""
+		},
+		GetFailedItemStatusFunc: func() []v1beta1.ItemStatus {
+			return []v1beta1.ItemStatus{}
+		},
 		GenerateTaskFunc: func(taskDefinition v1beta1.KeptnTaskDefinition, checkType apicommon.CheckType) v1beta1.KeptnTask {
 			return v1beta1.KeptnTask{}
 		},
@@ -163,6 +205,18 @@ func TestPhaseItem(t *testing.T) {
 	_ = wrapper.GetPostDeploymentTasks()
 	require.Len(t, phaseItemMock.GetPostDeploymentTasksCalls(), 1)
 
+	_ = wrapper.GetPreDeploymentTaskDependencies()
+	require.Len(t, phaseItemMock.GetPreDeploymentTaskDependenciesCalls(), 1)
+
+	_ = wrapper.GetPostDeploymentTaskDependencies()
+	require.Len(t, phaseItemMock.GetPostDeploymentTaskDependenciesCalls(), 1)
+
+	_ = wrapper.GetExecutionConditions()
+	require.Len(t, phaseItemMock.GetExecutionConditionsCalls(), 1)
+
+	_ = wrapper.GetTraceId()
+	require.Len(t, phaseItemMock.GetTraceIdCalls(), 1)
+
 	_ = wrapper.GetPreDeploymentTaskStatus()
 	require.Len(t, phaseItemMock.GetPreDeploymentTaskStatusCalls(), 1)
 
@@ -181,6 +235,18 @@ func TestPhaseItem(t *testing.T) {
 	_ = wrapper.GetPostDeploymentEvaluationTaskStatus()
 	require.Len(t, phaseItemMock.GetPostDeploymentEvaluationTaskStatusCalls(), 1)
 
+	_ = wrapper.GetPreDeploymentAnalyses()
+	require.Len(t, phaseItemMock.GetPreDeploymentAnalysesCalls(), 1)
+
+	_ = wrapper.GetPostDeploymentAnalyses()
+	require.Len(t, phaseItemMock.GetPostDeploymentAnalysesCalls(), 1)
+
+	_ = wrapper.GetPreDeploymentAnalysisStatus()
+	require.Len(t, phaseItemMock.GetPreDeploymentAnalysisStatusCalls(), 1)
+
+	_ = wrapper.GetPostDeploymentAnalysisStatus()
+	require.Len(t, phaseItemMock.GetPostDeploymentAnalysisStatusCalls(), 1)
+
 	_ = wrapper.GenerateTask(v1beta1.KeptnTaskDefinition{}, apicommon.PostDeploymentCheckType)
 	require.Len(t, phaseItemMock.GenerateTaskCalls(), 1)
 
@@ -199,4 +265,22 @@ func TestPhaseItem(t *testing.T) {
 	_ = wrapper.GetPromotionTasks()
 	require.Len(t, phaseItemMock.GetPromotionTasksCalls(), 1)
 
+	_ = wrapper.GetRollbackTaskStatus()
+	require.Len(t, phaseItemMock.GetRollbackTaskStatusCalls(), 1)
+
+	_ = wrapper.GetRollbackTasks()
+	require.Len(t, phaseItemMock.GetRollbackTasksCalls(), 1)
+
+	_ = wrapper.GetOnFailureTaskStatus()
+	require.Len(t, phaseItemMock.GetOnFailureTaskStatusCalls(), 1)
+
+	_ = wrapper.GetOnFailureTasks()
+	require.Len(t, phaseItemMock.GetOnFailureTasksCalls(), 1)
+
+	_ = wrapper.GetFailedPhase()
+	require.Len(t, phaseItemMock.GetFailedPhaseCalls(), 1)
+
+	_ = wrapper.GetFailedItemStatus()
+	require.Len(t, phaseItemMock.GetFailedItemStatusCalls(), 1)
+
 }
@@ -68,6 +68,13 @@ type KeptnAppVersionReconciler struct {
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions/finalizers,verbs=update
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnworkloadversions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnfreezewindows,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=analyses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=analysisdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets;controllerrevisions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -121,7 +128,7 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcilePreDep)
 		if !result.Continue {
-			return result.Result, err
+			return r.handlePhaseResult(ctx, ctxAppTrace, appVersion, result, err)
 		}
 	}
 
@@ -132,7 +139,7 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcilePreEval)
 		if !result.Continue {
-			return result.Result, err
+			return r.handlePhaseResult(ctx, ctxAppTrace, appVersion, result, err)
 		}
 	}
 
@@ -143,7 +150,7 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcileAppDep)
 		if !result.Continue {
-			return result.Result, err
+			return r.handlePhaseResult(ctx, ctxAppTrace, appVersion, result, err)
 		}
 	}
 
@@ -154,7 +161,7 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcilePostDep)
 		if !result.Continue {
-			return result.Result, err
+			return r.handlePhaseResult(ctx, ctxAppTrace, appVersion, result, err)
 		}
 	}
 
@@ -165,7 +172,7 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcilePostEval)
 		if !result.Continue {
-			return result.Result, err
+			return r.handlePhaseResult(ctx, ctxAppTrace, appVersion, result, err)
 		}
 	}
 
@@ -176,7 +183,7 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		result, err := r.PhaseHandler.HandlePhase(ctx, ctxAppTrace, r.getTracer(), appVersion, currentPhase, reconcilePromotionFunc)
 		if !result.Continue {
-			return result.Result, err
+			return r.handlePhaseResult(ctx, ctxAppTrace, appVersion, result, err)
 		}
 	}
 
@@ -227,7 +234,8 @@ func (r *KeptnAppVersionReconciler) setupSpansContexts(ctx context.Context, appV
 	ctxAppTrace := otel.GetTextMapPropagator().Extract(context.TODO(), appTraceContextCarrier)
 	ctxAppTrace = appcontext.WithAppMetadata(ctxAppTrace, appVersion.Spec.Metadata)
 	endFunc := func() {
-		if appVersion.IsEndTimeSet() {
+		// the app count is increased once the on-failure and rollback phases of a failed KeptnAppVersion have been completed
+		if appVersion.IsEndTimeSet() && !appVersion.IsOnFailureRequired() && !appVersion.IsRollbackRequired() {
 			r.Log.Info("Increasing app count")
 			attrs := appVersion.GetMetricsAttributes()
 			r.Meters.AppCount.Add(ctx, 1, metric.WithAttributes(attrs...))
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	lfcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
@@ -368,6 +369,69 @@ func TestKeptnAppVersionReconciler_ReconcilePromotionPhaseFails(t *testing.T) {
 	require.Equal(t, apicommon.PhasePromotion, mockPhaseHandler.HandlePhaseCalls()[0].PhaseMoqParam)
 }
 
+func TestKeptnAppVersionReconciler_reconcilePhaseBlockedByFreezeWindow(t *testing.T) {
+	start := metav1.NewTime(time.Now().Add(-time.Hour))
+	freezeWindow := &lfcv1beta1.KeptnFreezeWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-freeze"},
+		Spec: lfcv1beta1.KeptnFreezeWindowSpec{
+			Start:    &start,
+			Duration: metav1.Duration{Duration: 2 * time.Hour},
+			Message:  "release freeze",
+		},
+	}
+	appVersion := &lfcv1beta1.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp-1.0.0",
+			Namespace: "default",
+		},
+		Spec: lfcv1beta1.KeptnAppVersionSpec{
+			KeptnAppSpec: lfcv1beta1.KeptnAppSpec{
+				Version: "1.0.0",
+			},
+			KeptnAppContextSpec: lfcv1beta1.KeptnAppContextSpec{
+				DeploymentTaskSpec: lfcv1beta1.DeploymentTaskSpec{
+					PreDeploymentTasks: []string{"my-pre-deployment-task"},
+				},
+			},
+			AppName: "myapp",
+		},
+	}
+
+	taskDefinition := &lfcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pre-deployment-task",
+			Namespace: "default",
+		},
+		Spec: lfcv1beta1.KeptnTaskDefinitionSpec{
+			Container: &lfcv1beta1.ContainerSpec{},
+		},
+	}
+
+	r, _, _ := setupReconciler(appVersion, freezeWindow, taskDefinition)
+
+	state, err := r.reconcilePhase(context.TODO(), context.TODO(), appVersion, apicommon.PreDeploymentCheckType)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StatePending, state)
+	require.Equal(t, apicommon.StatePending, appVersion.Status.PreDeploymentStatus)
+	require.Empty(t, appVersion.Status.PreDeploymentTaskStatus)
+	require.NotNil(t, appVersion.Status.FreezeWindow)
+	require.Equal(t, "release-freeze", appVersion.Status.FreezeWindow.Name)
+
+	// no KeptnTask is created while the deployment is blocked
+	tasks := &lfcv1beta1.KeptnTaskList{}
+	require.Nil(t, r.Client.List(context.TODO(), tasks))
+	require.Empty(t, tasks.Items)
+
+	// the freeze window is bypassed with the override annotation
+	appVersion.Annotations = map[string]string{apicommon.FreezeOverrideAnnotation: "hotfix"}
+
+	_, err = r.reconcilePhase(context.TODO(), context.TODO(), appVersion, apicommon.PreDeploymentCheckType)
+	require.Nil(t, err)
+	require.True(t, appVersion.Status.FreezeWindow.Overridden)
+	require.Equal(t, "hotfix", appVersion.Status.FreezeWindow.OverrideReason)
+	require.Len(t, appVersion.Status.PreDeploymentTaskStatus, 1)
+}
+
 func createFinishedAppVersionStatus() lfcv1beta1.KeptnAppVersionStatus {
 	return lfcv1beta1.KeptnAppVersionStatus{
 		CurrentPhase:                       apicommon.PhaseCompleted.ShortName,
@@ -459,6 +523,9 @@ func setupReconciler(objs ...client.Object) (*KeptnAppVersionReconciler, chan st
 			ReconcileEvaluationsFunc: func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, evaluationCreateAttributes evaluation.CreateEvaluationAttributes) ([]lfcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
 				return []lfcv1beta1.ItemStatus{}, apicommon.StatusSummary{}, nil
 			},
+			ReconcileAnalysesFunc: func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]lfcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
+				return []lfcv1beta1.ItemStatus{}, apicommon.StatusSummary{}, nil
+			},
 		},
 	}
 	return r, recorder.Events, spanRecorder
@@ -0,0 +1,55 @@
+package keptnappversion
+
+import (
+	"context"
+	"fmt"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/task"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func (r *KeptnAppVersionReconciler) handleOnFailurePhase(ctx context.Context, ctxAppTrace context.Context, appVersion *klcv1beta1.KeptnAppVersion) (ctrl.Result, error) {
+	reconcileOnFailure := func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+		return r.reconcileOnFailure(ctx, phaseCtx, appVersion)
+	}
+	return r.handleFailurePhase(ctxAppTrace, appVersion, apicommon.PhaseAppOnFailure, reconcileOnFailure)
+}
+
+// reconcileOnFailure executes the onFailureTasks of the KeptnAppVersion.
+// The tasks receive the failed phase and its failed items as part of their context.
+func (r *KeptnAppVersionReconciler) reconcileOnFailure(ctx context.Context, phaseCtx context.Context, appVersion *klcv1beta1.KeptnAppVersion) (apicommon.KeptnState, error) {
+	taskHandler := task.Handler{
+		Client:      r.Client,
+		EventSender: r.EventSender,
+		Log:         r.Log,
+		Tracer:      r.getTracer(),
+		Scheme:      r.Scheme,
+		SpanHandler: r.SpanHandler,
+	}
+
+	taskCreateAttributes := task.CreateTaskAttributes{
+		SpanName:  fmt.Sprintf(apicommon.CreateAppTaskSpanName, apicommon.OnFailureCheckType),
+		CheckType: apicommon.OnFailureCheckType,
+	}
+
+	newStatus, summary, err := taskHandler.ReconcileTasks(ctx, phaseCtx, appVersion, taskCreateAttributes)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	overallState := apicommon.GetOverallState(summary)
+	if !overallState.IsCompleted() {
+		overallState = apicommon.StateProgressing
+	}
+	appVersion.Status.OnFailureStatus = overallState
+	appVersion.Status.OnFailureTaskStatus = newStatus
+
+	// Write Status Field
+	err = r.Client.Status().Update(ctx, appVersion)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	return appVersion.Status.OnFailureStatus, nil
+}
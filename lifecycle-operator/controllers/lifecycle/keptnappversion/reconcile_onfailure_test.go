@@ -0,0 +1,90 @@
+package keptnappversion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	lfcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
+	phasefake "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase/fake"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestKeptnAppVersionReconciler_ReconcileOnFailure(t *testing.T) {
+	failedAppVersion := createRollbackAppVersion("myapp-2.0.0", "2.0.0", "1.0.0", "2.0.0", apicommon.StateFailed)
+	failedAppVersion.Spec.RollbackStrategy = lfcv1beta1.RollbackStrategyNone
+	failedAppVersion.Spec.OnFailureTasks = []string{"cleanup"}
+	failedAppVersion.Status.FailedPhase = apicommon.PhaseAppPostEvaluation.ShortName
+	failedAppVersion.Status.PostDeploymentEvaluationTaskStatus = []lfcv1beta1.ItemStatus{
+		{DefinitionName: "slo", Name: "post-eval-slo", Status: apicommon.StateFailed},
+	}
+
+	taskDefinition := &lfcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cleanup",
+			Namespace: "default",
+		},
+	}
+
+	r, eventChannel, _ := setupReconciler(failedAppVersion, taskDefinition)
+	r.PhaseHandler = &phasefake.MockHandler{HandlePhaseFunc: func(ctx context.Context, ctxTrace context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phaseMoqParam apicommon.KeptnPhaseType, reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)) (phase.PhaseResult, error) {
+		return phase.PhaseResult{Continue: false, Result: ctrl.Result{}}, nil
+	}}
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: "default",
+			Name:      "myapp-2.0.0",
+		},
+	}
+
+	// the first reconciliation creates the on-failure task
+	result, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.True(t, result.Requeue)
+
+	event := <-eventChannel
+	require.True(t, strings.Contains(event, "AppOnFailureTasksStarted"), "no AppOnFailureTasksStarted found in %s", event)
+
+	appVersion := &lfcv1beta1.KeptnAppVersion{}
+	err = r.Client.Get(context.TODO(), req.NamespacedName, appVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, appVersion.Status.OnFailureStatus)
+	require.Equal(t, apicommon.PhaseAppOnFailure.ShortName, appVersion.Status.CurrentPhase)
+	require.Len(t, appVersion.Status.OnFailureTaskStatus, 1)
+
+	task := &lfcv1beta1.KeptnTask{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: appVersion.Status.OnFailureTaskStatus[0].Name}, task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.OnFailureCheckType, task.Spec.Type)
+	require.Equal(t, apicommon.PhaseAppPostEvaluation.ShortName, task.Spec.Context.FailedPhase)
+	require.Equal(t, []lfcv1beta1.FailedItem{{Name: "post-eval-slo", DefinitionName: "slo"}}, task.Spec.Context.FailedItems)
+
+	// once the task has finished, the on-failure phase is completed without changing the overall state
+	task.Status.Status = apicommon.StateSucceeded
+	err = r.Client.Update(context.TODO(), task)
+	require.Nil(t, err)
+
+	result, err = r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+
+	// skip the status change event of the task
+	event = <-eventChannel
+	for strings.Contains(event, "ReconcileTask") {
+		event = <-eventChannel
+	}
+	require.True(t, strings.Contains(event, "AppOnFailureTasksFinished"), "no AppOnFailureTasksFinished found in %s", event)
+
+	err = r.Client.Get(context.TODO(), req.NamespacedName, appVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, appVersion.Status.OnFailureStatus)
+	require.Equal(t, apicommon.StateFailed, appVersion.Status.Status)
+}
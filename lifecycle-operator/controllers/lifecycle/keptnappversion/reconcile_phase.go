@@ -7,10 +7,32 @@ import (
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/freezewindow"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/task"
 )
 
 func (r *KeptnAppVersionReconciler) reconcilePhase(ctx context.Context, phaseCtx context.Context, appVersion *klcv1beta1.KeptnAppVersion, checkType apicommon.CheckType) (apicommon.KeptnState, error) {
+	// deployments are held back before the first pre-deployment task is created while a KeptnFreezeWindow is active
+	if checkType == apicommon.PreDeploymentCheckType && len(appVersion.Status.PreDeploymentTaskStatus) == 0 {
+		freezeWindowHandler := freezewindow.Handler{
+			Client:      r.Client,
+			EventSender: r.EventSender,
+			Log:         r.Log,
+		}
+		freezeWindowStatus, err := freezeWindowHandler.ReconcileFreezeWindows(ctx, apicommon.PhaseAppPreDeployment, appVersion, appVersion.GetAppName(), appVersion.Status.FreezeWindow)
+		if err != nil {
+			return apicommon.StateUnknown, err
+		}
+		appVersion.Status.FreezeWindow = freezeWindowStatus
+		if freezeWindowStatus.IsBlocking() {
+			appVersion.Status.PreDeploymentStatus = apicommon.StatePending
+			if err := r.Client.Status().Update(ctx, appVersion); err != nil {
+				return apicommon.StateUnknown, err
+			}
+			return apicommon.StatePending, nil
+		}
+	}
+
 	taskHandler := task.Handler{
 		Client:      r.Client,
 		EventSender: r.EventSender,
@@ -21,15 +21,22 @@ func (r *KeptnAppVersionReconciler) reconcilePrePostEvaluation(ctx context.Conte
 		return apicommon.StateUnknown, err
 	}
 
-	overallState := apicommon.GetOverallStateBlockedDeployment(state, r.Config.GetBlockDeployment())
+	newAnalysisStatus, analysisState, err := r.EvaluationHandler.ReconcileAnalyses(ctx, phaseCtx, appVersion, checkType)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	overallState := apicommon.GetOverallStateBlockedDeployment(state.Add(analysisState), r.Config.GetBlockDeployment())
 
 	switch checkType {
 	case apicommon.PreDeploymentEvaluationCheckType:
 		appVersion.Status.PreDeploymentEvaluationStatus = overallState
 		appVersion.Status.PreDeploymentEvaluationTaskStatus = newStatus
+		appVersion.Status.PreDeploymentAnalysisStatus = newAnalysisStatus
 	case apicommon.PostDeploymentEvaluationCheckType:
 		appVersion.Status.PostDeploymentEvaluationStatus = overallState
 		appVersion.Status.PostDeploymentEvaluationTaskStatus = newStatus
+		appVersion.Status.PostDeploymentAnalysisStatus = newAnalysisStatus
 	}
 
 	// Write Status Field
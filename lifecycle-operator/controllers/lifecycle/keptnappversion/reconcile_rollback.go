@@ -0,0 +1,331 @@
+package keptnappversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	operatorcommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/task"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"go.opentelemetry.io/otel/codes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// handlePhaseResult is invoked whenever a phase of the KeptnAppVersion did not allow to continue with the next phase.
+// If the KeptnAppVersion has failed, the on-failure phase and the rollback phase are executed, if they have been configured.
+func (r *KeptnAppVersionReconciler) handlePhaseResult(ctx context.Context, ctxAppTrace context.Context, appVersion *klcv1beta1.KeptnAppVersion, result phase.PhaseResult, err error) (ctrl.Result, error) {
+	if err != nil {
+		return result.Result, err
+	}
+	if appVersion.IsOnFailureRequired() {
+		onFailureResult, err := r.handleOnFailurePhase(ctx, ctxAppTrace, appVersion)
+		if err != nil || appVersion.IsOnFailureRequired() {
+			return onFailureResult, err
+		}
+	}
+	if appVersion.IsRollbackRequired() {
+		return r.handleRollbackPhase(ctx, ctxAppTrace, appVersion)
+	}
+	return result.Result, nil
+}
+
+func (r *KeptnAppVersionReconciler) handleRollbackPhase(ctx context.Context, ctxAppTrace context.Context, appVersion *klcv1beta1.KeptnAppVersion) (ctrl.Result, error) {
+	reconcileRollback := func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+		return r.reconcileRollback(ctx, phaseCtx, appVersion)
+	}
+	return r.handleFailurePhase(ctxAppTrace, appVersion, apicommon.PhaseAppRollback, reconcileRollback)
+}
+
+// handleFailurePhase executes a phase that is only run once the KeptnAppVersion has failed.
+// In contrast to the regular phases, the outcome of these phases does not change the overall state of the KeptnAppVersion.
+func (r *KeptnAppVersionReconciler) handleFailurePhase(ctxAppTrace context.Context, appVersion *klcv1beta1.KeptnAppVersion, currentPhase apicommon.KeptnPhaseType, reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)) (ctrl.Result, error) {
+	requeueResult := ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}
+
+	if appVersion.Status.CurrentPhase != currentPhase.ShortName {
+		r.EventSender.Emit(currentPhase, "Normal", appVersion, apicommon.PhaseStateStarted, "has started", appVersion.GetVersion())
+		appVersion.Status.CurrentPhase = currentPhase.ShortName
+	}
+
+	spanPhaseCtx, spanPhaseTrace, err := r.SpanHandler.GetSpan(ctxAppTrace, r.getTracer(), appVersion, currentPhase.ShortName)
+	if err != nil {
+		r.Log.Error(err, "could not get span")
+	}
+
+	state, err := reconcilePhase(spanPhaseCtx)
+	if err != nil {
+		spanPhaseTrace.AddEvent(currentPhase.LongName + " could not get reconciled")
+		r.EventSender.Emit(currentPhase, "Warning", appVersion, apicommon.PhaseStateReconcileError, "could not get reconciled", appVersion.GetVersion())
+		return requeueResult, err
+	}
+
+	if !state.IsCompleted() {
+		return requeueResult, nil
+	}
+
+	if state.IsFailed() {
+		spanPhaseTrace.AddEvent(currentPhase.LongName + " has failed")
+		spanPhaseTrace.SetStatus(codes.Error, "Failed")
+		r.EventSender.Emit(currentPhase, "Warning", appVersion, apicommon.PhaseStateFailed, "has failed", appVersion.GetVersion())
+	} else {
+		spanPhaseTrace.AddEvent(currentPhase.LongName + " has succeeded")
+		spanPhaseTrace.SetStatus(codes.Ok, "Succeeded")
+		r.EventSender.Emit(currentPhase, "Normal", appVersion, apicommon.PhaseStateFinished, "has finished", appVersion.GetVersion())
+	}
+	spanPhaseTrace.End()
+	if err := r.SpanHandler.UnbindSpan(appVersion, currentPhase.ShortName); err != nil {
+		r.Log.Error(err, controllererrors.ErrCouldNotUnbindSpan, appVersion.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *KeptnAppVersionReconciler) reconcileRollback(ctx context.Context, phaseCtx context.Context, appVersion *klcv1beta1.KeptnAppVersion) (apicommon.KeptnState, error) {
+	// the previous version is restored only once, at the beginning of the rollback phase
+	if appVersion.Status.RollbackStatus != apicommon.StateProgressing {
+		appVersion.Status.RollbackStatus = apicommon.StateProgressing
+		if appVersion.Spec.RollbackStrategy == klcv1beta1.RollbackStrategyRestorePreviousVersion {
+			appVersion.Status.RollbackWorkloadStatus = r.restorePreviousVersion(ctx, appVersion)
+		}
+	}
+
+	taskHandler := task.Handler{
+		Client:      r.Client,
+		EventSender: r.EventSender,
+		Log:         r.Log,
+		Tracer:      r.getTracer(),
+		Scheme:      r.Scheme,
+		SpanHandler: r.SpanHandler,
+	}
+
+	taskCreateAttributes := task.CreateTaskAttributes{
+		SpanName:  fmt.Sprintf(apicommon.CreateAppTaskSpanName, apicommon.RollbackCheckType),
+		CheckType: apicommon.RollbackCheckType,
+	}
+
+	newStatus, summary, err := taskHandler.ReconcileTasks(ctx, phaseCtx, appVersion, taskCreateAttributes)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	for _, w := range appVersion.Status.RollbackWorkloadStatus {
+		summary.Total++
+		summary = apicommon.UpdateStatusSummary(w.Status, summary)
+	}
+
+	overallState := apicommon.GetOverallState(summary)
+	if !overallState.IsCompleted() {
+		overallState = apicommon.StateProgressing
+	}
+	appVersion.Status.RollbackStatus = overallState
+	appVersion.Status.RollbackTaskStatus = newStatus
+
+	// Write Status Field
+	err = r.Client.Status().Update(ctx, appVersion)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	return appVersion.Status.RollbackStatus, nil
+}
+
+// restorePreviousVersion restores the revisions of all workloads that have been changed compared to the
+// last KeptnAppVersion with the version stored in Spec.PreviousVersion
+func (r *KeptnAppVersionReconciler) restorePreviousVersion(ctx context.Context, appVersion *klcv1beta1.KeptnAppVersion) []klcv1beta1.WorkloadStatus {
+	phase := apicommon.PhaseAppRollback
+	result := []klcv1beta1.WorkloadStatus{}
+
+	if appVersion.Spec.PreviousVersion == "" {
+		r.Log.Info("No previous version to restore", "appVersion", appVersion.Name)
+		return result
+	}
+
+	previousAppVersion, err := r.getPreviousAppVersion(ctx, appVersion)
+	if err != nil {
+		r.Log.Error(err, "Could not retrieve previous KeptnAppVersion", "appVersion", appVersion.Name, "previousVersion", appVersion.Spec.PreviousVersion)
+		r.EventSender.Emit(phase, "Warning", appVersion, apicommon.PhaseStateNotFound, fmt.Sprintf("could not find KeptnAppVersion with version %s", appVersion.Spec.PreviousVersion), appVersion.GetVersion())
+		return result
+	}
+
+	for _, w := range appVersion.Spec.Workloads {
+		previousWorkload, ok := getWorkloadRef(previousAppVersion.Spec.Workloads, w.Name)
+		// workloads which have not been part of the previous version or have not been changed do not need to be restored
+		if !ok || previousWorkload.Version == w.Version {
+			continue
+		}
+
+		state := apicommon.StateSucceeded
+		if err := r.restoreWorkloadVersion(ctx, appVersion, previousWorkload); err != nil {
+			r.Log.Error(err, "Could not restore previous version of workload", "workload", w.Name, "version", previousWorkload.Version)
+			r.EventSender.Emit(phase, "Warning", appVersion, apicommon.PhaseStateFailed, fmt.Sprintf("could not restore version %s of KeptnWorkload %s", previousWorkload.Version, w.Name), appVersion.GetVersion())
+			state = apicommon.StateFailed
+		}
+
+		result = append(result, klcv1beta1.WorkloadStatus{
+			Workload: previousWorkload,
+			Status:   state,
+		})
+	}
+	return result
+}
+
+// getPreviousAppVersion returns the most recent KeptnAppVersion of the previous version of the KeptnApp.
+// Succeeded KeptnAppVersions are preferred over KeptnAppVersions in any other state.
+func (r *KeptnAppVersionReconciler) getPreviousAppVersion(ctx context.Context, appVersion *klcv1beta1.KeptnAppVersion) (*klcv1beta1.KeptnAppVersion, error) {
+	appVersionList := &klcv1beta1.KeptnAppVersionList{}
+	if err := r.Client.List(ctx, appVersionList, client.InNamespace(appVersion.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var previous *klcv1beta1.KeptnAppVersion
+	for i := range appVersionList.Items {
+		candidate := &appVersionList.Items[i]
+		if candidate.Spec.AppName != appVersion.Spec.AppName || candidate.Spec.Version != appVersion.Spec.PreviousVersion {
+			continue
+		}
+		if previous == nil ||
+			(candidate.Status.Status.IsSucceeded() && !previous.Status.Status.IsSucceeded()) ||
+			(candidate.Status.Status.IsSucceeded() == previous.Status.Status.IsSucceeded() && previous.CreationTimestamp.Before(&candidate.CreationTimestamp)) {
+			previous = candidate
+		}
+	}
+
+	if previous == nil {
+		return nil, controllererrors.ErrNoMatchingAppVersionFound
+	}
+	return previous, nil
+}
+
+func getWorkloadRef(workloads []klcv1beta1.KeptnWorkloadRef, name string) (klcv1beta1.KeptnWorkloadRef, bool) {
+	for _, w := range workloads {
+		if w.Name == name {
+			return w, true
+		}
+	}
+	return klcv1beta1.KeptnWorkloadRef{}, false
+}
+
+func (r *KeptnAppVersionReconciler) restoreWorkloadVersion(ctx context.Context, appVersion *klcv1beta1.KeptnAppVersion, workload klcv1beta1.KeptnWorkloadRef) error {
+	workloadVersion := &klcv1beta1.KeptnWorkloadVersion{}
+	workloadVersionName := getWorkloadVersionName(appVersion.Spec.AppName, workload.Name, workload.Version)
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: appVersion.Namespace, Name: workloadVersionName}, workloadVersion); err != nil {
+		return fmt.Errorf(controllererrors.ErrCannotRetrieveWorkloadVersionMsg, err)
+	}
+
+	resource := workloadVersion.Spec.ResourceReference
+	switch resource.Kind {
+	case "ReplicaSet":
+		return r.restoreReplicaSet(ctx, resource, appVersion.Namespace)
+	case "StatefulSet":
+		return r.restoreControllerRevision(ctx, &appsv1.StatefulSet{}, workloadVersion)
+	case "DaemonSet":
+		return r.restoreControllerRevision(ctx, &appsv1.DaemonSet{}, workloadVersion)
+	default:
+		return controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+}
+
+// restoreReplicaSet applies the pod template of the given ReplicaSet to its owning Deployment or Rollout
+func (r *KeptnAppVersionReconciler) restoreReplicaSet(ctx context.Context, resource klcv1beta1.ResourceReference, namespace string) error {
+	rep := &appsv1.ReplicaSet{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, rep); err != nil {
+		return err
+	}
+
+	for _, ownerRef := range rep.OwnerReferences {
+		switch ownerRef.Kind {
+		case "Deployment":
+			deployment := &appsv1.Deployment{}
+			if err := r.Client.Get(ctx, types.NamespacedName{Name: ownerRef.Name, Namespace: namespace}, deployment); err != nil {
+				return err
+			}
+			deployment.Spec.Template = *rep.Spec.Template.DeepCopy()
+			delete(deployment.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+			return r.Client.Update(ctx, deployment)
+		case "Rollout":
+			rollout := &argov1alpha1.Rollout{}
+			if err := r.Client.Get(ctx, types.NamespacedName{Name: ownerRef.Name, Namespace: namespace}, rollout); err != nil {
+				return err
+			}
+			rollout.Spec.Template = *rep.Spec.Template.DeepCopy()
+			delete(rollout.Spec.Template.Labels, argov1alpha1.DefaultRolloutUniqueLabelKey)
+			return r.Client.Update(ctx, rollout)
+		}
+	}
+	return controllererrors.ErrNoPreviousRevisionFound
+}
+
+// restoreControllerRevision restores the revision of a StatefulSet or DaemonSet whose pod template matches the given KeptnWorkloadVersion
+func (r *KeptnAppVersionReconciler) restoreControllerRevision(ctx context.Context, obj client.Object, workloadVersion *klcv1beta1.KeptnWorkloadVersion) error {
+	namespace := workloadVersion.Namespace
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: workloadVersion.Spec.ResourceReference.Name, Namespace: namespace}, obj); err != nil {
+		return err
+	}
+
+	revisionList := &appsv1.ControllerRevisionList{}
+	if err := r.Client.List(ctx, revisionList, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	var revision *appsv1.ControllerRevision
+	for i := range revisionList.Items {
+		candidate := &revisionList.Items[i]
+		if !metav1.IsControlledBy(candidate, obj) {
+			continue
+		}
+		version, err := getRevisionVersion(candidate)
+		if err != nil {
+			r.Log.Error(err, "Could not determine version of ControllerRevision", "revision", candidate.Name)
+			continue
+		}
+		// if the same version has been rolled out several times, the most recent revision is restored
+		if version == workloadVersion.Spec.Version && (revision == nil || revision.Revision < candidate.Revision) {
+			revision = candidate
+		}
+	}
+	if revision == nil {
+		return fmt.Errorf("%w: version %s of %s %s", controllererrors.ErrNoPreviousRevisionFound, workloadVersion.Spec.Version, workloadVersion.Spec.ResourceReference.Kind, workloadVersion.Spec.ResourceReference.Name)
+	}
+
+	return r.Client.Patch(ctx, obj, client.RawPatch(types.StrategicMergePatchType, revision.Data.Raw))
+}
+
+// getRevisionVersion returns the version of the pod template stored in the given ControllerRevision.
+// The version is derived in the same way as the pod mutator derives the version of a KeptnWorkload from its pods.
+func getRevisionVersion(revision *appsv1.ControllerRevision) (string, error) {
+	data := struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(revision.Data.Raw, &data); err != nil {
+		return "", err
+	}
+
+	template := data.Spec.Template
+	if version := getLabelOrAnnotation(template.ObjectMeta, apicommon.VersionAnnotation, apicommon.K8sRecommendedVersionAnnotations); version != "" {
+		return version, nil
+	}
+	return operatorcommon.CalculateVersion(template.Spec, getLabelOrAnnotation(template.ObjectMeta, apicommon.ContainerNameAnnotation))
+}
+
+func getLabelOrAnnotation(meta metav1.ObjectMeta, keys ...string) string {
+	for _, key := range keys {
+		if meta.Annotations[key] != "" {
+			return meta.Annotations[key]
+		}
+		if meta.Labels[key] != "" {
+			return meta.Labels[key]
+		}
+	}
+	return ""
+}
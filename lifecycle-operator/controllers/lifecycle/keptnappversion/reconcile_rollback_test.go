@@ -0,0 +1,325 @@
+package keptnappversion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	lfcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
+	phasefake "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase/fake"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestKeptnAppVersionReconciler_ReconcileRollback(t *testing.T) {
+	failedAppVersion := createRollbackAppVersion("myapp-2.0.0", "2.0.0", "1.0.0", "2.0.0", apicommon.StateFailed)
+	previousAppVersion := createRollbackAppVersion("myapp-1.0.0", "1.0.0", "", "1.0.0", apicommon.StateDeprecated)
+
+	workloadVersion := &lfcv1beta1.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp-mywl-1.0.0",
+			Namespace: "default",
+		},
+		Spec: lfcv1beta1.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: lfcv1beta1.KeptnWorkloadSpec{
+				AppName: "myapp",
+				Version: "1.0.0",
+				ResourceReference: lfcv1beta1.ResourceReference{
+					Kind: "ReplicaSet",
+					Name: "mywl-old",
+				},
+			},
+			WorkloadName: "myapp-mywl",
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mywl",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: createPodTemplate("2.0.0"),
+		},
+	}
+
+	oldTemplate := createPodTemplate("1.0.0")
+	oldTemplate.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = "abc"
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mywl-old",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "Deployment",
+					Name: "mywl",
+				},
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: oldTemplate,
+		},
+	}
+
+	r, eventChannel, _ := setupReconciler(failedAppVersion, previousAppVersion, workloadVersion, deployment, replicaSet)
+	r.PhaseHandler = &phasefake.MockHandler{HandlePhaseFunc: func(ctx context.Context, ctxTrace context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phaseMoqParam apicommon.KeptnPhaseType, reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)) (phase.PhaseResult, error) {
+		return phase.PhaseResult{Continue: false, Result: ctrl.Result{}}, nil
+	}}
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: "default",
+			Name:      "myapp-2.0.0",
+		},
+	}
+
+	result, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+
+	for _, e := range []string{"AppRollbackStarted", "AppRollbackFinished"} {
+		event := <-eventChannel
+		require.True(t, strings.Contains(event, e), "no %s found in %s", e, event)
+	}
+
+	restoredDeployment := &appsv1.Deployment{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "mywl"}, restoredDeployment)
+	require.Nil(t, err)
+	require.Equal(t, "image:1.0.0", restoredDeployment.Spec.Template.Spec.Containers[0].Image)
+	require.NotContains(t, restoredDeployment.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	appVersion := &lfcv1beta1.KeptnAppVersion{}
+	err = r.Client.Get(context.TODO(), req.NamespacedName, appVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateSucceeded, appVersion.Status.RollbackStatus)
+	require.Equal(t, apicommon.StateFailed, appVersion.Status.Status)
+	require.Equal(t, apicommon.PhaseAppRollback.ShortName, appVersion.Status.CurrentPhase)
+	require.Equal(t, []lfcv1beta1.WorkloadStatus{
+		{
+			Workload: lfcv1beta1.KeptnWorkloadRef{Name: "mywl", Version: "1.0.0"},
+			Status:   apicommon.StateSucceeded,
+		},
+	}, appVersion.Status.RollbackWorkloadStatus)
+}
+
+func TestKeptnAppVersionReconciler_ReconcileRollbackNotConfigured(t *testing.T) {
+	appVersion := createRollbackAppVersion("myapp-2.0.0", "2.0.0", "1.0.0", "2.0.0", apicommon.StateFailed)
+	appVersion.Spec.RollbackStrategy = ""
+
+	r, _, spanHandler := setupReconciler(appVersion)
+	r.PhaseHandler = &phasefake.MockHandler{HandlePhaseFunc: func(ctx context.Context, ctxTrace context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phaseMoqParam apicommon.KeptnPhaseType, reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)) (phase.PhaseResult, error) {
+		return phase.PhaseResult{Continue: false, Result: ctrl.Result{}}, nil
+	}}
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: "default",
+			Name:      "myapp-2.0.0",
+		},
+	}
+
+	result, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+
+	// only the span of the app version has been requested, no rollback phase has been started
+	require.Len(t, spanHandler.GetSpanCalls(), 1)
+}
+
+func TestKeptnAppVersionReconciler_restoreControllerRevision(t *testing.T) {
+	sts, revisions := createStatefulSetRevisions("1.0.0", "2.0.0", "3.0.0")
+
+	r, _, _ := setupReconciler(append(revisions, sts)...)
+
+	// the revision of the previous version is restored, even though it is not the one right before the current revision
+	err := r.restoreControllerRevision(context.TODO(), &appsv1.StatefulSet{}, createStatefulSetWorkloadVersion("1.0.0"))
+	require.Nil(t, err)
+
+	restored := &appsv1.StatefulSet{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "mysts"}, restored)
+	require.Nil(t, err)
+	require.Equal(t, "image:1.0.0", restored.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestKeptnAppVersionReconciler_restoreControllerRevisionNoMatchingRevision(t *testing.T) {
+	sts, revisions := createStatefulSetRevisions("1.0.0", "2.0.0")
+
+	r, _, _ := setupReconciler(append(revisions, sts)...)
+
+	err := r.restoreControllerRevision(context.TODO(), &appsv1.StatefulSet{}, createStatefulSetWorkloadVersion("0.9.0"))
+	require.ErrorIs(t, err, controllererrors.ErrNoPreviousRevisionFound)
+
+	// the StatefulSet has not been changed
+	current := &appsv1.StatefulSet{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "mysts"}, current)
+	require.Nil(t, err)
+	require.Equal(t, "image:2.0.0", current.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestKeptnAppVersionReconciler_restoreControllerRevisionNoPreviousRevision(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mysts",
+			Namespace: "default",
+		},
+	}
+
+	r, _, _ := setupReconciler(sts)
+
+	err := r.restoreControllerRevision(context.TODO(), &appsv1.StatefulSet{}, createStatefulSetWorkloadVersion("1.0.0"))
+	require.NotNil(t, err)
+}
+
+func Test_getRevisionVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "version annotation",
+			data: `{"spec":{"template":{"metadata":{"annotations":{"keptn.sh/version":"1.2.3"}},"spec":{"containers":[{"name":"app","image":"image:1.0.0"}]}}}}`,
+			want: "1.2.3",
+		},
+		{
+			name: "recommended version label",
+			data: `{"spec":{"template":{"metadata":{"labels":{"app.kubernetes.io/version":"1.2.3"}},"spec":{"containers":[{"name":"app","image":"image:1.0.0"}]}}}}`,
+			want: "1.2.3",
+		},
+		{
+			name: "image tag",
+			data: `{"spec":{"template":{"spec":{"containers":[{"image":"image:1.0.0"}]}}}}`,
+			want: "1.0.0",
+		},
+		{
+			name: "image tag of container specified by annotation",
+			data: `{"spec":{"template":{"metadata":{"annotations":{"keptn.sh/container":"app"}},"spec":{"containers":[{"name":"sidecar","image":"sidecar:9.9.9"},{"name":"app","image":"image:1.0.0"}]}}}}`,
+			want: "1.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getRevisionVersion(&appsv1.ControllerRevision{Data: runtime.RawExtension{Raw: []byte(tt.data)}})
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// createStatefulSetRevisions returns a StatefulSet with one ControllerRevision for each of the given versions.
+// The StatefulSet is currently at the last of the given versions.
+func createStatefulSetRevisions(versions ...string) (*appsv1.StatefulSet, []client.Object) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mysts",
+			Namespace: "default",
+			UID:       "sts-uid",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: createPodTemplate(versions[len(versions)-1]),
+		},
+	}
+
+	controller := true
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Name:       "mysts",
+			UID:        "sts-uid",
+			Controller: &controller,
+		},
+	}
+
+	revisions := []client.Object{}
+	for i, version := range versions {
+		revisions = append(revisions, &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("mysts-%d", i+1), Namespace: "default", OwnerReferences: ownerRefs},
+			Data:       runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"keptn.sh/version":"%[1]s"}},"spec":{"containers":[{"name":"app","image":"image:%[1]s"}]}}}}`, version))},
+			Revision:   int64(i + 1),
+		})
+	}
+	return sts, revisions
+}
+
+func createStatefulSetWorkloadVersion(version string) *lfcv1beta1.KeptnWorkloadVersion {
+	return &lfcv1beta1.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myapp-mywl-" + version,
+			Namespace: "default",
+		},
+		Spec: lfcv1beta1.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: lfcv1beta1.KeptnWorkloadSpec{
+				Version: version,
+				ResourceReference: lfcv1beta1.ResourceReference{
+					Kind: "StatefulSet",
+					Name: "mysts",
+				},
+			},
+		},
+	}
+}
+
+func createRollbackAppVersion(name, version, previousVersion, workloadVersion string, state apicommon.KeptnState) *lfcv1beta1.KeptnAppVersion {
+	return &lfcv1beta1.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: lfcv1beta1.KeptnAppVersionSpec{
+			KeptnAppSpec: lfcv1beta1.KeptnAppSpec{
+				Version: version,
+				Workloads: []lfcv1beta1.KeptnWorkloadRef{
+					{
+						Name:    "mywl",
+						Version: workloadVersion,
+					},
+				},
+			},
+			KeptnAppContextSpec: lfcv1beta1.KeptnAppContextSpec{
+				RollbackStrategy: lfcv1beta1.RollbackStrategyRestorePreviousVersion,
+			},
+			AppName:         "myapp",
+			PreviousVersion: previousVersion,
+		},
+		Status: lfcv1beta1.KeptnAppVersionStatus{
+			CurrentPhase:                   apicommon.PhaseAppPostEvaluation.ShortName,
+			PreDeploymentStatus:            apicommon.StateSucceeded,
+			PreDeploymentEvaluationStatus:  apicommon.StateSucceeded,
+			WorkloadOverallStatus:          apicommon.StateSucceeded,
+			PostDeploymentStatus:           apicommon.StateSucceeded,
+			PostDeploymentEvaluationStatus: apicommon.StateFailed,
+			PromotionStatus:                apicommon.StateDeprecated,
+			Status:                         state,
+		},
+	}
+}
+
+func createPodTemplate(version string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app": "mywl",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "image:" + version,
+				},
+			},
+		},
+	}
+}
@@ -4,10 +4,43 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// checkObjective evaluates the value of the status item against the objective and returns the resulting state.
+// If the objective defines a Target, its failure and warning criteria are used, otherwise the EvaluationTarget is checked.
+// If the objective defines a Baseline, the change of the value compared to the baseline value is checked as well.
+func checkObjective(objective klcv1beta1.Objective, item *klcv1beta1.EvaluationStatusItem) (apicommon.KeptnState, error) {
+	state := apicommon.StateSucceeded
+	if objective.Target != nil {
+		targetState, err := checkTarget(objective.Target, item)
+		if err != nil || targetState.IsFailed() {
+			return apicommon.StateFailed, err
+		}
+		state = targetState
+	} else if objective.EvaluationTarget != "" || objective.Baseline == nil {
+		check, err := checkValue(objective, item)
+		if err != nil || !check {
+			return apicommon.StateFailed, err
+		}
+	}
+
+	if objective.Baseline != nil {
+		baselineState, err := checkBaseline(objective.Baseline, item)
+		if err != nil || baselineState.IsFailed() {
+			return apicommon.StateFailed, err
+		}
+		if baselineState.IsWarning() {
+			state = apicommon.StateWarning
+		}
+	}
+	return state, nil
+}
+
 func checkValue(objective klcv1beta1.Objective, item *klcv1beta1.EvaluationStatusItem) (bool, error) {
 
 	if len(item.Value) == 0 || len(objective.EvaluationTarget) == 0 {
@@ -37,3 +70,160 @@ func checkValue(objective klcv1beta1.Objective, item *klcv1beta1.EvaluationStatu
 		return false, fmt.Errorf("invalid operator")
 	}
 }
+
+// checkTarget returns Failed if the value meets the failure criteria of the target,
+// Warning if it meets the warning criteria, and Succeeded otherwise
+func checkTarget(target *klcv1beta1.Target, item *klcv1beta1.EvaluationStatusItem) (apicommon.KeptnState, error) {
+	if len(item.Value) == 0 {
+		return apicommon.StateFailed, fmt.Errorf("no values")
+	}
+
+	value, err := parseValue(item.Value)
+	if err != nil {
+		return apicommon.StateFailed, err
+	}
+
+	return checkTargetValue(target, value)
+}
+
+// checkBaseline checks the change of the value of the status item compared to its baseline value
+// against the criteria of the baseline. If there is no baseline value, the criteria are not evaluated.
+func checkBaseline(baseline *klcv1beta1.Baseline, item *klcv1beta1.EvaluationStatusItem) (apicommon.KeptnState, error) {
+	if len(item.BaselineValue) == 0 {
+		return apicommon.StateSucceeded, nil
+	}
+	if len(item.Value) == 0 {
+		return apicommon.StateFailed, fmt.Errorf("no values")
+	}
+
+	value, err := parseValue(item.Value)
+	if err != nil {
+		return apicommon.StateFailed, err
+	}
+	baselineValue, err := parseValue(item.BaselineValue)
+	if err != nil {
+		return apicommon.StateFailed, err
+	}
+
+	return checkTargetValue(&baseline.Target, getDelta(baseline.DeltaType, value, baselineValue))
+}
+
+// getDelta returns the change of the value compared to the baseline value, either in percent of the baseline value
+// or as absolute difference. A change compared to a baseline value of zero is an infinite change in percent.
+func getDelta(deltaType klcv1beta1.BaselineDeltaType, value float64, baselineValue float64) float64 {
+	delta := value - baselineValue
+	if deltaType == klcv1beta1.BaselineDeltaTypeAbsolute || delta == 0 {
+		return delta
+	}
+	if baselineValue == 0 {
+		return math.Inf(int(math.Copysign(1, delta)))
+	}
+	return delta / math.Abs(baselineValue) * 100
+}
+
+func checkTargetValue(target *klcv1beta1.Target, value float64) (apicommon.KeptnState, error) {
+	if target.Failure != nil {
+		fulfilled, err := checkOperator(value, target.Failure)
+		if err != nil || fulfilled {
+			return apicommon.StateFailed, err
+		}
+	}
+
+	if target.Warning != nil {
+		fulfilled, err := checkOperator(value, target.Warning)
+		if err != nil {
+			return apicommon.StateFailed, err
+		}
+		if fulfilled {
+			return apicommon.StateWarning, nil
+		}
+	}
+
+	return apicommon.StateSucceeded, nil
+}
+
+func checkOperator(value float64, op *klcv1beta1.Operator) (bool, error) {
+	switch {
+	case op.EqualTo != nil:
+		return value == op.EqualTo.GetFloatValue(), nil
+	case op.LessThanOrEqual != nil:
+		return value <= op.LessThanOrEqual.GetFloatValue(), nil
+	case op.LessThan != nil:
+		return value < op.LessThan.GetFloatValue(), nil
+	case op.GreaterThanOrEqual != nil:
+		return value >= op.GreaterThanOrEqual.GetFloatValue(), nil
+	case op.GreaterThan != nil:
+		return value > op.GreaterThan.GetFloatValue(), nil
+	case op.InRange != nil:
+		return value >= op.InRange.LowBound.AsApproximateFloat64() && value <= op.InRange.HighBound.AsApproximateFloat64(), nil
+	case op.NotInRange != nil:
+		return value < op.NotInRange.LowBound.AsApproximateFloat64() || value > op.NotInRange.HighBound.AsApproximateFloat64(), nil
+	default:
+		return false, fmt.Errorf("no operator defined")
+	}
+}
+
+// parseValue parses the value of a KeptnMetric, which can either be a plain number
+// or a quantity such as '2Gi' or '5m', where the suffix 'm' stands for milli
+func parseValue(value string) (float64, error) {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		if math.IsNaN(f) {
+			return 0, fmt.Errorf("value is not a number")
+		}
+		return f, nil
+	}
+	if q, err := resource.ParseQuantity(value); err == nil {
+		return q.AsApproximateFloat64(), nil
+	}
+	return 0, fmt.Errorf("could not parse value '%s'", value)
+}
+
+// getObjectiveTarget returns a human-readable representation of the target of the objective
+func getObjectiveTarget(objective klcv1beta1.Objective) string {
+	var criteria []string
+	if objective.Target != nil {
+		criteria = append(criteria, getTargetCriteria(objective.Target, "", "")...)
+	} else if objective.EvaluationTarget != "" {
+		criteria = append(criteria, objective.EvaluationTarget)
+	}
+	if objective.Baseline != nil {
+		unit := "%"
+		if objective.Baseline.DeltaType == klcv1beta1.BaselineDeltaTypeAbsolute {
+			unit = ""
+		}
+		criteria = append(criteria, getTargetCriteria(&objective.Baseline.Target, "baseline ", unit)...)
+	}
+	return strings.Join(criteria, ", ")
+}
+
+func getTargetCriteria(target *klcv1beta1.Target, prefix string, unit string) []string {
+	var criteria []string
+	if target.Failure != nil {
+		criteria = append(criteria, prefix+"failure: "+getOperatorString(target.Failure)+unit)
+	}
+	if target.Warning != nil {
+		criteria = append(criteria, prefix+"warning: "+getOperatorString(target.Warning)+unit)
+	}
+	return criteria
+}
+
+func getOperatorString(op *klcv1beta1.Operator) string {
+	switch {
+	case op.EqualTo != nil:
+		return "==" + op.EqualTo.FixedValue.String()
+	case op.LessThanOrEqual != nil:
+		return "<=" + op.LessThanOrEqual.FixedValue.String()
+	case op.LessThan != nil:
+		return "<" + op.LessThan.FixedValue.String()
+	case op.GreaterThanOrEqual != nil:
+		return ">=" + op.GreaterThanOrEqual.FixedValue.String()
+	case op.GreaterThan != nil:
+		return ">" + op.GreaterThan.FixedValue.String()
+	case op.InRange != nil:
+		return fmt.Sprintf("in [%s,%s]", op.InRange.LowBound.String(), op.InRange.HighBound.String())
+	case op.NotInRange != nil:
+		return fmt.Sprintf("not in [%s,%s]", op.NotInRange.LowBound.String(), op.NotInRange.HighBound.String())
+	default:
+		return ""
+	}
+}
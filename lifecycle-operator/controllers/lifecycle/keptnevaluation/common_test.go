@@ -1,10 +1,13 @@
 package keptnevaluation
 
 import (
+	"math"
 	"testing"
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestCheckValue(t *testing.T) {
@@ -222,3 +225,387 @@ func TestCheckValue(t *testing.T) {
 
 	}
 }
+
+func TestCheckObjective(t *testing.T) {
+	fixed := func(v string) *klcv1beta1.OperatorValue {
+		return &klcv1beta1.OperatorValue{FixedValue: resource.MustParse(v)}
+	}
+	tests := []struct {
+		name          string
+		obj           klcv1beta1.Objective
+		value         string
+		baselineValue string
+		result        apicommon.KeptnState
+		err           bool
+	}{
+		{
+			name:   "legacy target met",
+			obj:    klcv1beta1.Objective{EvaluationTarget: "<10"},
+			value:  "5",
+			result: apicommon.StateSucceeded,
+		},
+		{
+			name:   "legacy target not met",
+			obj:    klcv1beta1.Objective{EvaluationTarget: "<10"},
+			value:  "15",
+			result: apicommon.StateFailed,
+		},
+		{
+			name:   "legacy target garbage",
+			obj:    klcv1beta1.Objective{EvaluationTarget: "-10"},
+			value:  "15",
+			result: apicommon.StateFailed,
+			err:    true,
+		},
+		{
+			name: "target takes precedence over legacy target",
+			obj: klcv1beta1.Objective{
+				EvaluationTarget: "<10",
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{GreaterThan: fixed("20")},
+				},
+			},
+			value:  "15",
+			result: apicommon.StateSucceeded,
+		},
+		{
+			name: "failure criteria met",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{GreaterThanOrEqual: fixed("10")},
+					Warning: &klcv1beta1.Operator{GreaterThanOrEqual: fixed("5")},
+				},
+			},
+			value:  "10",
+			result: apicommon.StateFailed,
+		},
+		{
+			name: "warning criteria met",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{GreaterThanOrEqual: fixed("10")},
+					Warning: &klcv1beta1.Operator{GreaterThanOrEqual: fixed("5")},
+				},
+			},
+			value:  "5",
+			result: apicommon.StateWarning,
+		},
+		{
+			name: "no criteria met",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{GreaterThanOrEqual: fixed("10")},
+					Warning: &klcv1beta1.Operator{GreaterThanOrEqual: fixed("5")},
+				},
+			},
+			value:  "4.9",
+			result: apicommon.StateSucceeded,
+		},
+		{
+			name: "milli suffix is not parsed as minutes",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{GreaterThan: fixed("1")},
+				},
+			},
+			value:  "5m",
+			result: apicommon.StateSucceeded,
+		},
+		{
+			name: "duration is not a valid value",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{GreaterThan: fixed("1")},
+				},
+			},
+			value:  "500ms",
+			result: apicommon.StateFailed,
+			err:    true,
+		},
+		{
+			name: "quantity with binary suffix",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{LessThanOrEqual: fixed("2Gi")},
+				},
+			},
+			value:  "3Gi",
+			result: apicommon.StateSucceeded,
+		},
+		{
+			name: "in range",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{NotInRange: &klcv1beta1.RangeValue{LowBound: resource.MustParse("2"), HighBound: resource.MustParse("5")}},
+					Warning: &klcv1beta1.Operator{InRange: &klcv1beta1.RangeValue{LowBound: resource.MustParse("4"), HighBound: resource.MustParse("5")}},
+				},
+			},
+			value:  "5",
+			result: apicommon.StateWarning,
+		},
+		{
+			name: "not in range",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{NotInRange: &klcv1beta1.RangeValue{LowBound: resource.MustParse("2"), HighBound: resource.MustParse("5")}},
+				},
+			},
+			value:  "1",
+			result: apicommon.StateFailed,
+		},
+		{
+			name: "equal to",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{EqualTo: fixed("0")},
+				},
+			},
+			value:  "0",
+			result: apicommon.StateFailed,
+		},
+		{
+			name: "no operator",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{},
+				},
+			},
+			value:  "1",
+			result: apicommon.StateFailed,
+			err:    true,
+		},
+		{
+			name: "unparsable value",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{LessThan: fixed("1")},
+				},
+			},
+			value:  "garbage",
+			result: apicommon.StateFailed,
+			err:    true,
+		},
+		{
+			name: "empty value",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{LessThan: fixed("1")},
+				},
+			},
+			result: apicommon.StateFailed,
+			err:    true,
+		},
+		{
+			name: "baseline regression above failure threshold",
+			obj: klcv1beta1.Objective{
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "111",
+			baselineValue: "100",
+			result:        apicommon.StateFailed,
+		},
+		{
+			name: "baseline regression of milli quantities within thresholds",
+			obj: klcv1beta1.Objective{
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "210m",
+			baselineValue: "200m",
+			result:        apicommon.StateSucceeded,
+		},
+		{
+			name: "baseline regression in percent with warning",
+			obj: klcv1beta1.Objective{
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "0.108",
+			baselineValue: "0.1",
+			result:        apicommon.StateWarning,
+		},
+		{
+			name: "baseline improvement",
+			obj: klcv1beta1.Objective{
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "50",
+			baselineValue: "100",
+			result:        apicommon.StateSucceeded,
+		},
+		{
+			name: "baseline absolute delta",
+			obj: klcv1beta1.Objective{
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypeAbsolute,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "111",
+			baselineValue: "100",
+			result:        apicommon.StateFailed,
+		},
+		{
+			name: "no baseline value available",
+			obj: klcv1beta1.Objective{
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:  "1000",
+			result: apicommon.StateSucceeded,
+		},
+		{
+			name: "target fails although baseline is met",
+			obj: klcv1beta1.Objective{
+				Target: &klcv1beta1.Target{
+					Failure: &klcv1beta1.Operator{GreaterThan: fixed("50")},
+				},
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "100",
+			baselineValue: "100",
+			result:        apicommon.StateFailed,
+		},
+		{
+			name: "legacy target met and baseline warning",
+			obj: klcv1beta1.Objective{
+				EvaluationTarget: "<200",
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "107",
+			baselineValue: "100",
+			result:        apicommon.StateWarning,
+		},
+		{
+			name: "unparsable baseline value",
+			obj: klcv1beta1.Objective{
+				Baseline: &klcv1beta1.Baseline{
+					DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+					Target: klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{GreaterThan: fixed("10")},
+						Warning: &klcv1beta1.Operator{GreaterThan: fixed("5")},
+					},
+				},
+			},
+			value:         "100",
+			baselineValue: "garbage",
+			result:        apicommon.StateFailed,
+			err:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, e := checkObjective(tt.obj, &klcv1beta1.EvaluationStatusItem{Value: tt.value, BaselineValue: tt.baselineValue})
+			require.Equal(t, tt.result, r)
+			if tt.err {
+				require.NotNil(t, e)
+			} else {
+				require.Nil(t, e)
+			}
+		})
+	}
+}
+
+func TestGetObjectiveTarget(t *testing.T) {
+	require.Equal(t, "<10", getObjectiveTarget(klcv1beta1.Objective{EvaluationTarget: "<10"}))
+	require.Equal(t, "failure: not in [2,5], warning: <=500m", getObjectiveTarget(klcv1beta1.Objective{
+		Target: &klcv1beta1.Target{
+			Failure: &klcv1beta1.Operator{NotInRange: &klcv1beta1.RangeValue{LowBound: resource.MustParse("2"), HighBound: resource.MustParse("5")}},
+			Warning: &klcv1beta1.Operator{LessThanOrEqual: &klcv1beta1.OperatorValue{FixedValue: resource.MustParse("500m")}},
+		},
+	}))
+	require.Equal(t, "<10, baseline failure: >10%", getObjectiveTarget(klcv1beta1.Objective{
+		EvaluationTarget: "<10",
+		Baseline: &klcv1beta1.Baseline{
+			Target: klcv1beta1.Target{
+				Failure: &klcv1beta1.Operator{GreaterThan: &klcv1beta1.OperatorValue{FixedValue: resource.MustParse("10")}},
+			},
+		},
+	}))
+	require.Equal(t, "baseline warning: >=100m", getObjectiveTarget(klcv1beta1.Objective{
+		Baseline: &klcv1beta1.Baseline{
+			DeltaType: klcv1beta1.BaselineDeltaTypeAbsolute,
+			Target: klcv1beta1.Target{
+				Warning: &klcv1beta1.Operator{GreaterThanOrEqual: &klcv1beta1.OperatorValue{FixedValue: resource.MustParse("100m")}},
+			},
+		},
+	}))
+}
+
+func TestGetDelta(t *testing.T) {
+	require.Equal(t, 10.0, getDelta(klcv1beta1.BaselineDeltaTypePercent, 110, 100))
+	require.Equal(t, -50.0, getDelta(klcv1beta1.BaselineDeltaTypePercent, -150, -100))
+	require.Equal(t, 10.0, getDelta(klcv1beta1.BaselineDeltaTypeAbsolute, 110, 100))
+	require.Equal(t, 0.0, getDelta(klcv1beta1.BaselineDeltaTypePercent, 0, 0))
+	require.True(t, math.IsInf(getDelta(klcv1beta1.BaselineDeltaTypePercent, 1, 0), 1))
+	require.True(t, math.IsInf(getDelta(klcv1beta1.BaselineDeltaTypePercent, -1, 0), -1))
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "number", value: "10.5", want: 10.5},
+		{name: "quantity", value: "2Ki", want: 2048},
+		{name: "milli quantity", value: "5m", want: 0.005},
+		{name: "duration", value: "500ms", wantErr: true},
+		{name: "not a number", value: "nan", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseValue(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
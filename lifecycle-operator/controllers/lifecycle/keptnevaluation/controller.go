@@ -82,7 +82,7 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, nil
 	}
 
-	if !evaluation.Status.OverallStatus.IsSucceeded() {
+	if !isEvaluationFinished(evaluation) {
 		evaluationDefinition, err := controllercommon.GetEvaluationDefinition(r.Client, r.Log, ctx, evaluation.Spec.EvaluationDefinition, req.NamespacedName.Namespace)
 		if err != nil {
 			if errors.IsNotFound(err) {
@@ -97,7 +97,7 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	}
 
-	if !evaluation.Status.OverallStatus.IsSucceeded() {
+	if !isEvaluationFinished(evaluation) {
 		if err := r.handleEvaluationIncomplete(ctx, evaluation); err != nil {
 			return ctrl.Result{Requeue: true}, err
 		}
@@ -146,14 +146,16 @@ func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evalu
 		K8sClient: r.Client,
 	}
 
+	baselineValues := r.getBaselineValues(ctx, evaluation, evaluationDefinition)
+
 	for _, query := range evaluationDefinition.Spec.Objectives {
-		newStatus, statusSummary = r.evaluateObjective(ctx, evaluation, statusSummary, newStatus, query, provider)
+		newStatus, statusSummary = r.evaluateObjective(ctx, evaluation, statusSummary, newStatus, query, provider, baselineValues)
 	}
 
 	evaluation.Status.RetryCount++
 	evaluation.Status.EvaluationStatus = newStatus
 	if apicommon.GetOverallState(statusSummary) == apicommon.StateSucceeded {
-		evaluation.Status.OverallStatus = apicommon.StateSucceeded
+		evaluation.Status.OverallStatus = getSucceededEvaluationState(newStatus)
 	} else {
 		evaluation.Status.OverallStatus = apicommon.StateProgressing
 	}
@@ -161,7 +163,7 @@ func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evalu
 	return evaluation
 }
 
-func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evaluation *klcv1beta1.KeptnEvaluation, statusSummary apicommon.StatusSummary, newStatus map[string]klcv1beta1.EvaluationStatusItem, objective klcv1beta1.Objective, provider *keptnmetric.KeptnMetricProvider) (map[string]klcv1beta1.EvaluationStatusItem, apicommon.StatusSummary) {
+func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evaluation *klcv1beta1.KeptnEvaluation, statusSummary apicommon.StatusSummary, newStatus map[string]klcv1beta1.EvaluationStatusItem, objective klcv1beta1.Objective, provider *keptnmetric.KeptnMetricProvider, baselineValues map[string]string) (map[string]klcv1beta1.EvaluationStatusItem, apicommon.StatusSummary) {
 	if _, ok := evaluation.Status.EvaluationStatus[objective.KeptnMetricRef.Name]; !ok {
 		evaluation.AddEvaluationStatus(objective)
 	}
@@ -183,25 +185,122 @@ func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evalu
 	}
 
 	statusItem.Value = value
+	if objective.Baseline != nil {
+		statusItem.BaselineValue = baselineValues[objective.KeptnMetricRef.Name]
+	}
 	// Evaluating SLO
-	check, err := checkValue(objective, statusItem)
+	state, err := checkObjective(objective, statusItem)
 	if err != nil {
 		statusItem.Message = err.Error()
 		r.Log.Error(err, "Could not check objective result")
 		return updateStatusSummary(statusSummary, statusItem, newStatus, objective)
 	}
 	// if there is no error, we set the message depending on if the value passed the objective, or not
-	if check {
-		statusItem.Status = apicommon.StateSucceeded
-		statusItem.Message = fmt.Sprintf("value '%s' met objective '%s'", value, objective.EvaluationTarget)
-	} else {
-		statusItem.Message = fmt.Sprintf("value '%s' did not meet objective '%s'", value, objective.EvaluationTarget)
+	statusItem.Status = state
+	switch state {
+	case apicommon.StateSucceeded:
+		statusItem.Message = fmt.Sprintf("value '%s' met objective '%s'", value, getObjectiveTarget(objective))
+	case apicommon.StateWarning:
+		statusItem.Message = fmt.Sprintf("value '%s' met warning criteria of objective '%s'", value, getObjectiveTarget(objective))
+	default:
+		statusItem.Message = fmt.Sprintf("value '%s' did not meet objective '%s'", value, getObjectiveTarget(objective))
+	}
+	if objective.Baseline != nil {
+		statusItem.Message += getBaselineMessage(statusItem)
 	}
 	return updateStatusSummary(statusSummary, statusItem, newStatus, objective)
 }
 
+func getBaselineMessage(statusItem *klcv1beta1.EvaluationStatusItem) string {
+	if statusItem.BaselineValue == "" {
+		return "; no baseline value of the previous version available"
+	}
+	return fmt.Sprintf(" compared to baseline value '%s'", statusItem.BaselineValue)
+}
+
+// getBaselineValues returns the values recorded for each KeptnMetric by the KeptnEvaluation of the previous version
+// that uses the same KeptnEvaluationDefinition. Evaluations of the post-deployment phase are preferred,
+// since they capture the values at the end of the deployment of the previous version.
+func (r *KeptnEvaluationReconciler) getBaselineValues(ctx context.Context, evaluation *klcv1beta1.KeptnEvaluation, evaluationDefinition *klcv1beta1.KeptnEvaluationDefinition) map[string]string {
+	if evaluation.Spec.PreviousVersion == "" || !hasBaselineObjectives(evaluationDefinition) {
+		return nil
+	}
+
+	evaluations := &klcv1beta1.KeptnEvaluationList{}
+	if err := r.Client.List(ctx, evaluations, client.InNamespace(evaluation.Namespace)); err != nil {
+		r.Log.Error(err, "Could not retrieve KeptnEvaluations of previous version", "previousVersion", evaluation.Spec.PreviousVersion)
+		return nil
+	}
+
+	var baseline *klcv1beta1.KeptnEvaluation
+	for i := range evaluations.Items {
+		candidate := &evaluations.Items[i]
+		if !isPreviousVersionEvaluation(evaluation, candidate) || !candidate.Status.OverallStatus.IsCompleted() {
+			continue
+		}
+		if baseline == nil || isPreferredBaseline(candidate, baseline) {
+			baseline = candidate
+		}
+	}
+	if baseline == nil {
+		return nil
+	}
+
+	values := make(map[string]string, len(baseline.Status.EvaluationStatus))
+	for name, item := range baseline.Status.EvaluationStatus {
+		if item.Value != "" {
+			values[name] = item.Value
+		}
+	}
+	return values
+}
+
+func hasBaselineObjectives(evaluationDefinition *klcv1beta1.KeptnEvaluationDefinition) bool {
+	for _, objective := range evaluationDefinition.Spec.Objectives {
+		if objective.Baseline != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isPreviousVersionEvaluation(evaluation *klcv1beta1.KeptnEvaluation, candidate *klcv1beta1.KeptnEvaluation) bool {
+	if candidate.Spec.EvaluationDefinition != evaluation.Spec.EvaluationDefinition || candidate.Spec.AppName != evaluation.Spec.AppName {
+		return false
+	}
+	if evaluation.Spec.Workload != "" {
+		return candidate.Spec.Workload == evaluation.Spec.Workload && candidate.Spec.WorkloadVersion == evaluation.Spec.PreviousVersion
+	}
+	return candidate.Spec.Workload == "" && candidate.Spec.AppVersion == evaluation.Spec.PreviousVersion
+}
+
+func isPreferredBaseline(candidate *klcv1beta1.KeptnEvaluation, current *klcv1beta1.KeptnEvaluation) bool {
+	candidateIsPost := candidate.Spec.Type == apicommon.PostDeploymentEvaluationCheckType
+	currentIsPost := current.Spec.Type == apicommon.PostDeploymentEvaluationCheckType
+	if candidateIsPost != currentIsPost {
+		return candidateIsPost
+	}
+	return candidate.Status.EndTime.After(current.Status.EndTime.Time)
+}
+
+// getSucceededEvaluationState returns Warning if one of the objectives of an otherwise successful
+// evaluation finished with a warning, and Succeeded otherwise
+func getSucceededEvaluationState(statuses map[string]klcv1beta1.EvaluationStatusItem) apicommon.KeptnState {
+	for _, status := range statuses {
+		if status.Status.IsWarning() {
+			return apicommon.StateWarning
+		}
+	}
+	return apicommon.StateSucceeded
+}
+
+// isEvaluationFinished returns true if all objectives of the evaluation have been met, possibly with a warning
+func isEvaluationFinished(evaluation *klcv1beta1.KeptnEvaluation) bool {
+	return evaluation.Status.OverallStatus.IsSucceeded() || evaluation.Status.OverallStatus.IsWarning()
+}
+
 func updateStatusSummary(statusSummary apicommon.StatusSummary, statusItem *klcv1beta1.EvaluationStatusItem, newStatus map[string]klcv1beta1.EvaluationStatusItem, objective klcv1beta1.Objective) (map[string]klcv1beta1.EvaluationStatusItem, apicommon.StatusSummary) {
-	statusSummary = apicommon.UpdateStatusSummary(statusItem.Status, statusSummary)
+	statusSummary = apicommon.UpdateCheckStatusSummary(statusItem.Status, statusSummary)
 	newStatus[objective.KeptnMetricRef.Name] = *statusItem
 	return newStatus, statusSummary
 }
@@ -14,6 +14,7 @@ import (
 	metricsapi "github.com/keptn/lifecycle-toolkit/lifecycle-operator/test/api/metrics/v1beta1"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -245,6 +246,204 @@ func TestKeptnEvaluationReconciler_Reconcile_SucceedEvaluation_withDefinitionInD
 	require.Equal(t, "value '10' met objective '<11'", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
 }
 
+func TestKeptnEvaluationReconciler_Reconcile_WarningEvaluation(t *testing.T) {
+
+	const namespace = "my-namespace"
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-metric",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "10",
+			RawValue: []byte("10"),
+		},
+	}
+
+	evaluationDefinition := &klcv1beta1.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnEvaluationDefinitionSpec{
+			Objectives: []klcv1beta1.Objective{
+				{
+					KeptnMetricRef: klcv1beta1.KeptnMetricReference{
+						Name:      metric.Name,
+						Namespace: namespace,
+					},
+					Target: &klcv1beta1.Target{
+						Failure: &klcv1beta1.Operator{
+							GreaterThan: &klcv1beta1.OperatorValue{FixedValue: resource.MustParse("20")},
+						},
+						Warning: &klcv1beta1.Operator{
+							GreaterThanOrEqual: &klcv1beta1.OperatorValue{FixedValue: resource.MustParse("10")},
+						},
+					},
+				},
+			},
+			FailureConditions: klcv1beta1.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	evaluation := &klcv1beta1.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnEvaluationSpec{
+			EvaluationDefinition: evaluationDefinition.Name,
+			FailureConditions: klcv1beta1.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t, metric, evaluationDefinition, evaluation)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.False(t, reconcile.Requeue)
+
+	updatedEvaluation := &klcv1beta1.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, common.StateWarning, updatedEvaluation.Status.OverallStatus)
+	require.Equal(t, common.StateWarning, updatedEvaluation.Status.EvaluationStatus[metric.Name].Status)
+	require.Equal(t, "value '10' met warning criteria of objective 'failure: >20, warning: >=10'", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
+}
+
+func TestKeptnEvaluationReconciler_Reconcile_BaselineEvaluation(t *testing.T) {
+
+	const namespace = "my-namespace"
+	metric := &metricsapi.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-metric",
+			Namespace: namespace,
+		},
+		Status: metricsapi.KeptnMetricStatus{
+			Value:    "115",
+			RawValue: []byte("115"),
+		},
+	}
+
+	evaluationDefinition := &klcv1beta1.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-definition",
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnEvaluationDefinitionSpec{
+			Objectives: []klcv1beta1.Objective{
+				{
+					KeptnMetricRef: klcv1beta1.KeptnMetricReference{
+						Name:      metric.Name,
+						Namespace: namespace,
+					},
+					Baseline: &klcv1beta1.Baseline{
+						DeltaType: klcv1beta1.BaselineDeltaTypePercent,
+						Target: klcv1beta1.Target{
+							Failure: &klcv1beta1.Operator{
+								GreaterThan: &klcv1beta1.OperatorValue{FixedValue: resource.MustParse("10")},
+							},
+						},
+					},
+				},
+			},
+			FailureConditions: klcv1beta1.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	previousEvaluation := func(name string, checkType common.CheckType, version string, value string) *klcv1beta1.KeptnEvaluation {
+		return &klcv1beta1.KeptnEvaluation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: klcv1beta1.KeptnEvaluationSpec{
+				AppName:              "my-app",
+				Workload:             "my-workload",
+				WorkloadVersion:      version,
+				EvaluationDefinition: evaluationDefinition.Name,
+				Type:                 checkType,
+			},
+			Status: klcv1beta1.KeptnEvaluationStatus{
+				OverallStatus: common.StateSucceeded,
+				EvaluationStatus: map[string]klcv1beta1.EvaluationStatusItem{
+					metric.Name: {Value: value, Status: common.StateSucceeded},
+				},
+			},
+		}
+	}
+
+	evaluation := &klcv1beta1.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-evaluation",
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnEvaluationSpec{
+			AppName:              "my-app",
+			Workload:             "my-workload",
+			WorkloadVersion:      "v2",
+			PreviousVersion:      "v1",
+			EvaluationDefinition: evaluationDefinition.Name,
+			Type:                 common.PreDeploymentEvaluationCheckType,
+			FailureConditions: klcv1beta1.FailureConditions{
+				Retries: 1,
+			},
+		},
+	}
+
+	reconciler, fakeClient := setupReconcilerAndClient(t,
+		metric,
+		evaluationDefinition,
+		evaluation,
+		previousEvaluation("pre-eval-v1", common.PreDeploymentEvaluationCheckType, "v1", "110"),
+		previousEvaluation("post-eval-v1", common.PostDeploymentEvaluationCheckType, "v1", "100"),
+		previousEvaluation("post-eval-v0", common.PostDeploymentEvaluationCheckType, "v0", "50"),
+	)
+
+	request := controllerruntime.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      evaluation.Name,
+		},
+	}
+
+	reconcile, err := reconciler.Reconcile(context.TODO(), request)
+
+	require.Nil(t, err)
+	require.True(t, reconcile.Requeue)
+
+	updatedEvaluation := &klcv1beta1.KeptnEvaluation{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: namespace,
+		Name:      evaluation.Name,
+	}, updatedEvaluation)
+
+	require.Nil(t, err)
+
+	require.Equal(t, common.StateFailed, updatedEvaluation.Status.EvaluationStatus[metric.Name].Status)
+	require.Equal(t, "100", updatedEvaluation.Status.EvaluationStatus[metric.Name].BaselineValue)
+	require.Equal(t, "value '115' did not meet objective 'baseline failure: >10%' compared to baseline value '100'", updatedEvaluation.Status.EvaluationStatus[metric.Name].Message)
+}
+
 func setupReconcilerAndClient(t *testing.T, objects ...client.Object) (*KeptnEvaluationReconciler, client.Client) {
 	scheme := runtime.NewScheme()
 
@@ -0,0 +1,270 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keptnpromotionpipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const traceParentKey = "traceparent"
+
+// KeptnPromotionPipelineReconciler reconciles a KeptnPromotionPipeline object
+type KeptnPromotionPipelineReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	EventSender eventsender.IEvent
+	Log         logr.Logger
+}
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnpromotionpipelines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnpromotionpipelines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnapps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappcontexts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnappversions,verbs=get;list;watch
+
+// Reconcile tracks the KeptnAppVersion deployed in each stage of a KeptnPromotionPipeline and promotes
+// the KeptnApp into the next stage as soon as its KeptnAppVersion has succeeded.
+func (r *KeptnPromotionPipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info("Searching for KeptnPromotionPipeline", "name", req.Name)
+
+	pipeline := &klcv1beta1.KeptnPromotionPipeline{}
+	err := r.Get(ctx, req.NamespacedName, pipeline)
+	if errors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not retrieve KeptnPromotionPipeline: %w", err)
+	}
+
+	apps := make([]*klcv1beta1.KeptnApp, len(pipeline.Spec.Stages))
+	appVersions := make([]*klcv1beta1.KeptnAppVersion, len(pipeline.Spec.Stages))
+	stageStatuses := make([]klcv1beta1.PromotionStageStatus, len(pipeline.Spec.Stages))
+	for i, stage := range pipeline.Spec.Stages {
+		apps[i], appVersions[i], err = r.getAppVersion(ctx, pipeline.Spec.AppName, stage.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		stageStatuses[i] = newStageStatus(pipeline, stage, apps[i], appVersions[i])
+	}
+
+	for i := 0; i < len(pipeline.Spec.Stages)-1; i++ {
+		if !r.isReadyForPromotion(pipeline.Spec.Stages[i], apps[i+1], appVersions[i]) {
+			continue
+		}
+		if err := r.promote(ctx, pipeline, pipeline.Spec.Stages[i+1], apps[i], appVersions[i]); err != nil {
+			r.EventSender.Emit(apicommon.PhasePromoteApp, "Warning", pipeline, apicommon.PhaseStateFailed, fmt.Sprintf("could not promote KeptnApp %s into stage %s: %s", pipeline.Spec.AppName, pipeline.Spec.Stages[i+1].Name, err.Error()), apps[i].Spec.Version)
+			return ctrl.Result{}, err
+		}
+		r.EventSender.Emit(apicommon.PhasePromoteApp, "Normal", pipeline, apicommon.PhaseStateFinished, fmt.Sprintf("promoted KeptnApp %s from stage %s into stage %s", pipeline.Spec.AppName, pipeline.Spec.Stages[i].Name, pipeline.Spec.Stages[i+1].Name), apps[i].Spec.Version)
+		stageStatuses[i+1].Version = apps[i].Spec.Version
+		stageStatuses[i+1].AppVersionName = ""
+		stageStatuses[i+1].Status = apicommon.StatePending
+		stageStatuses[i+1].TraceParent = ""
+		stageStatuses[i+1].PromotionTime = metav1.Now()
+	}
+
+	pipeline.Status.Stages = stageStatuses
+	return ctrl.Result{}, r.Status().Update(ctx, pipeline)
+}
+
+// getAppVersion returns the KeptnApp of the given namespace and its current KeptnAppVersion.
+// Both are nil if they do not exist (yet).
+func (r *KeptnPromotionPipelineReconciler) getAppVersion(ctx context.Context, appName string, namespace string) (*klcv1beta1.KeptnApp, *klcv1beta1.KeptnAppVersion, error) {
+	app := &klcv1beta1.KeptnApp{}
+	err := r.Get(ctx, types.NamespacedName{Name: appName, Namespace: namespace}, app)
+	if errors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf(controllererrors.ErrCannotFetchAppMsg, err)
+	}
+
+	appVersion := &klcv1beta1.KeptnAppVersion{}
+	err = r.Get(ctx, types.NamespacedName{Name: app.GetAppVersionName(), Namespace: namespace}, appVersion)
+	if errors.IsNotFound(err) {
+		return app, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf(controllererrors.ErrCannotFetchAppVersionMsg, err)
+	}
+	return app, appVersion, nil
+}
+
+// isReadyForPromotion returns whether the KeptnAppVersion of a stage has succeeded, including all evaluations
+// required by the stage, and has not been promoted into the next stage yet
+func (r *KeptnPromotionPipelineReconciler) isReadyForPromotion(stage klcv1beta1.PromotionStage, nextApp *klcv1beta1.KeptnApp, appVersion *klcv1beta1.KeptnAppVersion) bool {
+	if appVersion == nil || !appVersion.Status.Status.IsSucceeded() {
+		return false
+	}
+	if nextApp != nil && nextApp.Annotations[apicommon.PromotedFromAnnotation] == appVersion.Name {
+		return false
+	}
+	for _, evaluation := range stage.Evaluations {
+		if !hasSucceededEvaluation(appVersion, evaluation) {
+			r.Log.Info("Required evaluation has not succeeded, KeptnAppVersion is not promoted", "appVersion", appVersion.Name, "namespace", appVersion.Namespace, "evaluation", evaluation)
+			return false
+		}
+	}
+	return true
+}
+
+// promote copies the KeptnApp and its KeptnAppContext into the namespace of the target stage.
+// The KeptnAppContext is updated first, since it is only read when the new KeptnAppVersion is created.
+func (r *KeptnPromotionPipelineReconciler) promote(ctx context.Context, pipeline *klcv1beta1.KeptnPromotionPipeline, target klcv1beta1.PromotionStage, app *klcv1beta1.KeptnApp, appVersion *klcv1beta1.KeptnAppVersion) error {
+	appContext := &klcv1beta1.KeptnAppContext{}
+	err := r.Get(ctx, types.NamespacedName{Name: pipeline.Spec.AppName, Namespace: target.Namespace}, appContext)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	contextExists := err == nil
+	if !contextExists {
+		appContext = &klcv1beta1.KeptnAppContext{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pipeline.Spec.AppName,
+				Namespace: target.Namespace,
+			},
+		}
+	}
+	// linking the trace of the previous stage, which in turn links all stages before, results in a single trace per release
+	appContext.Spec.SpanLinks = appendUnique(slices.Clone(appVersion.Spec.SpanLinks), appVersion.Spec.TraceId[traceParentKey])
+	appContext.Spec.PreDeploymentTasks = appendUnique(appContext.Spec.PreDeploymentTasks, target.ApprovalTask)
+	appContext.Spec.PostDeploymentEvaluations = appendUnique(appContext.Spec.PostDeploymentEvaluations, target.Evaluations...)
+	if contextExists {
+		err = r.Update(ctx, appContext)
+	} else {
+		err = r.Create(ctx, appContext)
+	}
+	if err != nil {
+		return err
+	}
+
+	targetApp := &klcv1beta1.KeptnApp{}
+	err = r.Get(ctx, types.NamespacedName{Name: pipeline.Spec.AppName, Namespace: target.Namespace}, targetApp)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if errors.IsNotFound(err) {
+		targetApp = &klcv1beta1.KeptnApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        pipeline.Spec.AppName,
+				Namespace:   target.Namespace,
+				Annotations: map[string]string{apicommon.PromotedFromAnnotation: appVersion.Name},
+			},
+			Spec: *app.Spec.DeepCopy(),
+		}
+		return r.Create(ctx, targetApp)
+	}
+
+	if targetApp.Spec.Version == app.Spec.Version {
+		// a new KeptnAppVersion is only created if the spec of the KeptnApp changes
+		targetApp.Spec.Revision++
+	}
+	targetApp.Spec.Version = app.Spec.Version
+	targetApp.Spec.Workloads = slices.Clone(app.Spec.Workloads)
+	if targetApp.Annotations == nil {
+		targetApp.Annotations = map[string]string{}
+	}
+	targetApp.Annotations[apicommon.PromotedFromAnnotation] = appVersion.Name
+	return r.Update(ctx, targetApp)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KeptnPromotionPipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&klcv1beta1.KeptnPromotionPipeline{}).
+		Watches(&klcv1beta1.KeptnAppVersion{}, handler.EnqueueRequestsFromMapFunc(r.getPipelinesForAppVersion)).
+		Complete(r)
+}
+
+// getPipelinesForAppVersion returns the KeptnPromotionPipelines containing the KeptnApp of a KeptnAppVersion
+func (r *KeptnPromotionPipelineReconciler) getPipelinesForAppVersion(ctx context.Context, obj client.Object) []reconcile.Request {
+	appVersion, ok := obj.(*klcv1beta1.KeptnAppVersion)
+	if !ok {
+		return nil
+	}
+
+	pipelines := &klcv1beta1.KeptnPromotionPipelineList{}
+	if err := r.List(ctx, pipelines); err != nil {
+		r.Log.Error(err, "Could not list KeptnPromotionPipelines")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, pipeline := range pipelines.Items {
+		if pipeline.Spec.AppName == appVersion.Spec.AppName && pipeline.GetStageIndex(appVersion.Namespace) >= 0 {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pipeline.Name}})
+		}
+	}
+	return requests
+}
+
+func newStageStatus(pipeline *klcv1beta1.KeptnPromotionPipeline, stage klcv1beta1.PromotionStage, app *klcv1beta1.KeptnApp, appVersion *klcv1beta1.KeptnAppVersion) klcv1beta1.PromotionStageStatus {
+	status := klcv1beta1.PromotionStageStatus{
+		Name:      stage.Name,
+		Namespace: stage.Namespace,
+		Status:    apicommon.StatePending,
+	}
+	// the promotion time is only known when the stage is promoted, so it is kept from the previous status
+	for _, previous := range pipeline.Status.Stages {
+		if previous.Name == stage.Name && previous.Namespace == stage.Namespace {
+			status.PromotionTime = previous.PromotionTime
+		}
+	}
+	if app != nil {
+		status.Version = app.Spec.Version
+	}
+	if appVersion != nil {
+		status.AppVersionName = appVersion.Name
+		status.Status = appVersion.Status.Status
+		status.TraceParent = appVersion.Spec.TraceId[traceParentKey]
+	}
+	return status
+}
+
+func hasSucceededEvaluation(appVersion *klcv1beta1.KeptnAppVersion, definitionName string) bool {
+	for _, item := range appVersion.Status.PostDeploymentEvaluationTaskStatus {
+		if item.DefinitionName == definitionName && item.Status.IsSucceeded() {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, newValues ...string) []string {
+	for _, value := range newValues {
+		if value != "" && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
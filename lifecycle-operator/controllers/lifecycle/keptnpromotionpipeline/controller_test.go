@@ -0,0 +1,236 @@
+package keptnpromotionpipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const sourceTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func getPipeline() *klcv1beta1.KeptnPromotionPipeline {
+	return &klcv1beta1.KeptnPromotionPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-pipeline",
+		},
+		Spec: klcv1beta1.KeptnPromotionPipelineSpec{
+			AppName: "my-app",
+			Stages: []klcv1beta1.PromotionStage{
+				{
+					Name:        "dev",
+					Namespace:   "dev",
+					Evaluations: []string{"smoke"},
+				},
+				{
+					Name:         "prod",
+					Namespace:    "prod",
+					ApprovalTask: "approve",
+					Evaluations:  []string{"slo"},
+				},
+			},
+		},
+	}
+}
+
+func getSourceApp() (*klcv1beta1.KeptnApp, *klcv1beta1.KeptnAppVersion) {
+	app := &klcv1beta1.KeptnApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-app",
+			Namespace:  "dev",
+			Generation: 1,
+		},
+		Spec: klcv1beta1.KeptnAppSpec{
+			Version: "1.0.0",
+			Workloads: []klcv1beta1.KeptnWorkloadRef{
+				{Name: "my-workload", Version: "1.0.0"},
+			},
+		},
+	}
+	appVersion := &klcv1beta1.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GetAppVersionName(),
+			Namespace: "dev",
+		},
+		Spec: klcv1beta1.KeptnAppVersionSpec{
+			KeptnAppSpec: app.Spec,
+			KeptnAppContextSpec: klcv1beta1.KeptnAppContextSpec{
+				SpanLinks: []string{"00-11111111111111111111111111111111-2222222222222222-01"},
+			},
+			AppName: "my-app",
+			TraceId: map[string]string{"traceparent": sourceTraceParent},
+		},
+		Status: klcv1beta1.KeptnAppVersionStatus{
+			Status: apicommon.StateSucceeded,
+			PostDeploymentEvaluationTaskStatus: []klcv1beta1.ItemStatus{
+				{DefinitionName: "smoke", Status: apicommon.StateSucceeded},
+			},
+		},
+	}
+	return app, appVersion
+}
+
+func newReconciler(fakeClient client.Client, t *testing.T) *KeptnPromotionPipelineReconciler {
+	return &KeptnPromotionPipelineReconciler{
+		Client:      fakeClient,
+		Scheme:      fakeClient.Scheme(),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         testr.New(t),
+	}
+}
+
+func TestKeptnPromotionPipelineReconciler_Reconcile(t *testing.T) {
+	pipeline := getPipeline()
+	app, appVersion := getSourceApp()
+
+	fakeClient := testcommon.NewTestClient(pipeline, app, appVersion)
+	r := newReconciler(fakeClient, t)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: pipeline.Name}}
+	_, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	promotedApp := &klcv1beta1.KeptnApp{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "my-app", Namespace: "prod"}, promotedApp)
+	require.Nil(t, err)
+	require.Equal(t, app.Spec, promotedApp.Spec)
+	require.Equal(t, appVersion.Name, promotedApp.Annotations[apicommon.PromotedFromAnnotation])
+
+	promotedContext := &klcv1beta1.KeptnAppContext{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "my-app", Namespace: "prod"}, promotedContext)
+	require.Nil(t, err)
+	require.Equal(t, []string{"00-11111111111111111111111111111111-2222222222222222-01", sourceTraceParent}, promotedContext.Spec.SpanLinks)
+	require.Equal(t, []string{"approve"}, promotedContext.Spec.PreDeploymentTasks)
+	require.Equal(t, []string{"slo"}, promotedContext.Spec.PostDeploymentEvaluations)
+
+	result := &klcv1beta1.KeptnPromotionPipeline{}
+	err = fakeClient.Get(context.TODO(), req.NamespacedName, result)
+	require.Nil(t, err)
+	require.Len(t, result.Status.Stages, 2)
+	require.Equal(t, "dev", result.Status.Stages[0].Name)
+	require.Equal(t, appVersion.Name, result.Status.Stages[0].AppVersionName)
+	require.Equal(t, apicommon.StateSucceeded, result.Status.Stages[0].Status)
+	require.Equal(t, sourceTraceParent, result.Status.Stages[0].TraceParent)
+	require.Equal(t, "prod", result.Status.Stages[1].Name)
+	require.Equal(t, "1.0.0", result.Status.Stages[1].Version)
+	require.Equal(t, apicommon.StatePending, result.Status.Stages[1].Status)
+	require.False(t, result.Status.Stages[1].PromotionTime.IsZero())
+
+	// the same KeptnAppVersion is promoted only once
+	_, err = r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "my-app", Namespace: "prod"}, promotedApp)
+	require.Nil(t, err)
+	require.Zero(t, promotedApp.Spec.Revision)
+}
+
+func TestKeptnPromotionPipelineReconciler_ReconcileSameVersionBumpsRevision(t *testing.T) {
+	pipeline := getPipeline()
+	app, appVersion := getSourceApp()
+	targetApp := &klcv1beta1.KeptnApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "prod",
+		},
+		Spec: klcv1beta1.KeptnAppSpec{
+			Version:  "1.0.0",
+			Revision: 1,
+		},
+	}
+	targetContext := &klcv1beta1.KeptnAppContext{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "prod",
+		},
+		Spec: klcv1beta1.KeptnAppContextSpec{
+			DeploymentTaskSpec: klcv1beta1.DeploymentTaskSpec{
+				PreDeploymentTasks: []string{"approve", "notify"},
+			},
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(pipeline, app, appVersion, targetApp, targetContext)
+	r := newReconciler(fakeClient, t)
+
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: pipeline.Name}})
+	require.Nil(t, err)
+
+	promotedApp := &klcv1beta1.KeptnApp{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "my-app", Namespace: "prod"}, promotedApp)
+	require.Nil(t, err)
+	require.Equal(t, uint(2), promotedApp.Spec.Revision)
+	require.Equal(t, app.Spec.Workloads, promotedApp.Spec.Workloads)
+
+	promotedContext := &klcv1beta1.KeptnAppContext{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "my-app", Namespace: "prod"}, promotedContext)
+	require.Nil(t, err)
+	require.Equal(t, []string{"approve", "notify"}, promotedContext.Spec.PreDeploymentTasks)
+}
+
+func TestKeptnPromotionPipelineReconciler_ReconcileNotPromoted(t *testing.T) {
+	tests := []struct {
+		name   string
+		status apicommon.KeptnState
+		evals  []klcv1beta1.ItemStatus
+	}{
+		{
+			name:   "app version not succeeded",
+			status: apicommon.StateProgressing,
+			evals:  []klcv1beta1.ItemStatus{{DefinitionName: "smoke", Status: apicommon.StateSucceeded}},
+		},
+		{
+			name:   "required evaluation failed",
+			status: apicommon.StateSucceeded,
+			evals:  []klcv1beta1.ItemStatus{{DefinitionName: "smoke", Status: apicommon.StateFailed}},
+		},
+		{
+			name:   "required evaluation missing",
+			status: apicommon.StateSucceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := getPipeline()
+			app, appVersion := getSourceApp()
+			appVersion.Status.Status = tt.status
+			appVersion.Status.PostDeploymentEvaluationTaskStatus = tt.evals
+
+			fakeClient := testcommon.NewTestClient(pipeline, app, appVersion)
+			r := newReconciler(fakeClient, t)
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: pipeline.Name}})
+			require.Nil(t, err)
+
+			err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "my-app", Namespace: "prod"}, &klcv1beta1.KeptnApp{})
+			require.True(t, errors.IsNotFound(err))
+		})
+	}
+}
+
+func TestKeptnPromotionPipelineReconciler_getPipelinesForAppVersion(t *testing.T) {
+	pipeline := getPipeline()
+	_, appVersion := getSourceApp()
+	otherAppVersion := appVersion.DeepCopy()
+	otherAppVersion.Namespace = "other"
+
+	fakeClient := testcommon.NewTestClient(pipeline)
+	r := newReconciler(fakeClient, t)
+
+	requests := r.getPipelinesForAppVersion(context.TODO(), appVersion)
+	require.Len(t, requests, 1)
+	require.Equal(t, pipeline.Name, requests[0].Name)
+
+	require.Empty(t, r.getPipelinesForAppVersion(context.TODO(), otherAppVersion))
+}
@@ -0,0 +1,74 @@
+package keptntask
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// approvalExecutor executes KeptnTasks that wait for the decision of an approver
+type approvalExecutor struct {
+	r *KeptnTaskReconciler
+}
+
+func (e *approvalExecutor) Create(_ context.Context, _ ctrl.Request, task *klcv1beta1.KeptnTask, _ *klcv1beta1.KeptnTaskDefinition) error {
+	e.r.runApprovalTask(task)
+	return nil
+}
+
+func (e *approvalExecutor) UpdateStatus(_ context.Context, task *klcv1beta1.KeptnTask) error {
+	if task.Status.Approval == nil {
+		return newExecutionNotStartedError(task)
+	}
+	e.r.runApprovalTask(task)
+	return nil
+}
+
+// runApprovalTask keeps the KeptnTask pending until the decision of an approver has been recorded in its annotations
+// by the KeptnTask mutating webhook, or until the timeout of the KeptnTask has been exceeded
+func (r *KeptnTaskReconciler) runApprovalTask(task *klcv1beta1.KeptnTask) {
+	if task.Status.Approval == nil {
+		task.Status.Approval = &klcv1beta1.ApprovalTaskStatus{}
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Normal", task, apicommon.PhaseStateStarted, "is waiting for approval", "")
+	}
+	task.Status.Status = apicommon.StatePending
+
+	annotations := task.GetAnnotations()
+	decision := annotations[apicommon.ApprovalAnnotation]
+	if decision == "" {
+		if task.Spec.Timeout.Duration > 0 && time.Since(task.Status.StartTime.Time) > task.Spec.Timeout.Duration {
+			task.Status.Status = apicommon.StateFailed
+			task.Status.Reason = "DeadlineExceeded"
+			task.Status.Message = "KeptnTask was not approved within the specified deadline"
+			r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateReconcileTimeout, "was not approved within the specified deadline", "")
+		}
+		return
+	}
+
+	user := annotations[apicommon.ApprovalUserAnnotation]
+	decisionTime := metav1.Now()
+	if t, err := time.Parse(time.RFC3339, annotations[apicommon.ApprovalTimeAnnotation]); err == nil {
+		decisionTime = metav1.NewTime(t)
+	}
+	task.Status.Approval = &klcv1beta1.ApprovalTaskStatus{
+		Decision: decision,
+		User:     user,
+		Time:     decisionTime,
+	}
+
+	if decision == apicommon.ApprovalApproved {
+		task.Status.Status = apicommon.StateSucceeded
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Normal", task, apicommon.PhaseStateFinished, fmt.Sprintf("has been approved by %s", user), "")
+		return
+	}
+
+	task.Status.Status = apicommon.StateFailed
+	task.Status.Reason = "Rejected"
+	task.Status.Message = fmt.Sprintf("KeptnTask has been rejected by %s", user)
+	r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateFailed, fmt.Sprintf("has been rejected by %s", user), "")
+}
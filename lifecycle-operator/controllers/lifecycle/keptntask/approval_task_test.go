@@ -0,0 +1,134 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnTaskReconciler_createJob_withApprovalSpec(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-approval-task-definition"
+
+	taskDefinition := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskDefinitionName,
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Approval: &klcv1beta1.ApprovalSpec{Users: []string{"jane"}},
+		},
+	}
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+	recorder := record.NewFakeRecorder(100)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(recorder),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.SetStartTime()
+
+	err := r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+
+	require.Empty(t, task.Status.JobName)
+	require.Equal(t, apicommon.StatePending, task.Status.Status)
+	require.Equal(t, &klcv1beta1.ApprovalTaskStatus{}, task.Status.Approval)
+	require.Len(t, recorder.Events, 1)
+}
+
+func TestKeptnTaskReconciler_runApprovalTask(t *testing.T) {
+	approvalTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		startTime    time.Time
+		wantStatus   apicommon.KeptnState
+		wantReason   string
+		wantApproval *klcv1beta1.ApprovalTaskStatus
+	}{
+		{
+			name:         "waiting for approval",
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StatePending,
+			wantApproval: &klcv1beta1.ApprovalTaskStatus{},
+		},
+		{
+			name: "approved",
+			annotations: map[string]string{
+				apicommon.ApprovalAnnotation:     apicommon.ApprovalApproved,
+				apicommon.ApprovalUserAnnotation: "jane",
+				apicommon.ApprovalTimeAnnotation: approvalTime.Format(time.RFC3339),
+			},
+			startTime:  time.Now(),
+			wantStatus: apicommon.StateSucceeded,
+			wantApproval: &klcv1beta1.ApprovalTaskStatus{
+				Decision: apicommon.ApprovalApproved,
+				User:     "jane",
+				Time:     metav1.NewTime(approvalTime),
+			},
+		},
+		{
+			name: "rejected",
+			annotations: map[string]string{
+				apicommon.ApprovalAnnotation:     apicommon.ApprovalRejected,
+				apicommon.ApprovalUserAnnotation: "jane",
+				apicommon.ApprovalTimeAnnotation: approvalTime.Format(time.RFC3339),
+			},
+			startTime:  time.Now(),
+			wantStatus: apicommon.StateFailed,
+			wantReason: "Rejected",
+			wantApproval: &klcv1beta1.ApprovalTaskStatus{
+				Decision: apicommon.ApprovalRejected,
+				User:     "jane",
+				Time:     metav1.NewTime(approvalTime),
+			},
+		},
+		{
+			name:         "deadline exceeded",
+			startTime:    time.Now().Add(-10 * time.Minute),
+			wantStatus:   apicommon.StateFailed,
+			wantReason:   "DeadlineExceeded",
+			wantApproval: &klcv1beta1.ApprovalTaskStatus{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := testcommon.NewTestClient()
+			r := &KeptnTaskReconciler{
+				Client:      fakeClient,
+				EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				Log:         ctrl.Log.WithName("task-controller"),
+				Scheme:      fakeClient.Scheme(),
+			}
+
+			task := makeTask("my-task", "default", "my-approval-task-definition")
+			task.Annotations = tt.annotations
+			task.Spec.Timeout = metav1.Duration{Duration: 5 * time.Minute}
+			task.Status.StartTime = metav1.NewTime(tt.startTime)
+
+			r.runApprovalTask(task)
+
+			require.Equal(t, tt.wantStatus, task.Status.Status)
+			require.Equal(t, tt.wantReason, task.Status.Reason)
+			require.Equal(t, tt.wantApproval.Decision, task.Status.Approval.Decision)
+			require.Equal(t, tt.wantApproval.User, task.Status.Approval.User)
+			require.True(t, tt.wantApproval.Time.Equal(&task.Status.Approval.Time))
+		})
+	}
+}
@@ -41,6 +41,8 @@ type KeptnTaskReconciler struct {
 	EventSender eventsender.IEvent
 	Log         logr.Logger
 	Meters      apicommon.KeptnMeters
+
+	httpRequests httpRequestTracker
 }
 
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;list;watch;create;update;patch;delete
@@ -49,6 +51,9 @@ type KeptnTaskReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;get;update;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get;list
+// +kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=create;get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
 
 func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	requestInfo := controllercommon.GetRequestInfo(req)
@@ -59,6 +64,7 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if errors.IsNotFound(err) {
 			// taking down all associated K8s resources is handled by K8s
 			r.Log.Info("KeptnTask resource not found. Ignoring since object must be deleted", "requestInfo", requestInfo)
+			r.httpRequests.forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		r.Log.Error(err, "Failed to get the KeptnTask")
@@ -74,27 +80,34 @@ func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}()
 
-	job, err := r.getJob(ctx, task.Status.JobName, req.Namespace)
-	if err != nil && !errors.IsNotFound(err) {
-		r.Log.Error(err, "Could not check if job is running")
-		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
-	}
-
-	if job == nil {
-		err = r.createJob(ctx, req, task)
+	if !task.Status.Status.IsCompleted() {
+		var definition *klcv1beta1.KeptnTaskDefinition
+		if !isExecutedInResource(task) {
+			var err error
+			definition, err = r.getTaskDefinition(ctx, req, task)
+			if err != nil {
+				r.Log.Error(err, "could not execute KeptnTask")
+				return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+			}
+		}
+		err := r.getTaskExecutor(task, definition).UpdateStatus(ctx, task)
+		if errors.IsNotFound(err) {
+			err = r.createJob(ctx, req, task)
+			if err != nil {
+				r.Log.Error(err, "could not create Job")
+			} else if !task.Status.Status.IsCompleted() && task.Status.Approval == nil {
+				// KeptnTasks waiting for approval remain pending until a decision has been made
+				task.Status.Status = apicommon.StateProgressing
+			}
+			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		}
 		if err != nil {
-			r.Log.Error(err, "could not create Job")
-		} else {
-			task.Status.Status = apicommon.StateProgressing
+			r.Log.Error(err, "Could not check if task is running")
+			return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
 		}
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
 	}
 
-	if !task.Status.Status.IsCompleted() {
-		r.updateTaskStatus(job, task)
-		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
-	}
-
 	r.Log.Info("Finished Reconciling KeptnTask", "requestInfo", requestInfo)
 
 	// Task is completed at this place
@@ -0,0 +1,45 @@
+package keptntask
+
+import (
+	"context"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// externalExecutor executes KeptnTasks whose result is reported by an external system
+type externalExecutor struct {
+	r *KeptnTaskReconciler
+}
+
+func (e *externalExecutor) Create(_ context.Context, _ ctrl.Request, task *klcv1beta1.KeptnTask, _ *klcv1beta1.KeptnTaskDefinition) error {
+	e.r.runExternalTask(task)
+	return nil
+}
+
+func (e *externalExecutor) UpdateStatus(_ context.Context, task *klcv1beta1.KeptnTask) error {
+	// the KeptnTask is set to progressing once the external system has been notified
+	if task.Status.Status != apicommon.StateProgressing {
+		return newExecutionNotStartedError(task)
+	}
+	e.r.runExternalTask(task)
+	return nil
+}
+
+// runExternalTask keeps the KeptnTask running until the external system executing it has reported the result
+// with a CloudEvent, or until the timeout of the KeptnTask has been exceeded
+func (r *KeptnTaskReconciler) runExternalTask(task *klcv1beta1.KeptnTask) {
+	// the KeptnTask is set to progressing once the external system has been notified
+	if task.Status.Status != apicommon.StateProgressing {
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Normal", task, apicommon.PhaseStateStarted, "is waiting for the result of an external system", "")
+	}
+
+	if task.Spec.Timeout.Duration > 0 && time.Since(task.Status.StartTime.Time) > task.Spec.Timeout.Duration {
+		task.Status.Status = apicommon.StateFailed
+		task.Status.Reason = "DeadlineExceeded"
+		task.Status.Message = "the result of the KeptnTask was not reported within the specified deadline"
+		r.EventSender.Emit(apicommon.PhaseReconcileTask, "Warning", task, apicommon.PhaseStateReconcileTimeout, "was not completed by the external system within the specified deadline", "")
+	}
+}
@@ -0,0 +1,75 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnTaskReconciler_createJob_withExternalSpec(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-external-task-definition"
+
+	taskDefinition := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskDefinitionName,
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			External: &klcv1beta1.ExternalSpec{},
+		},
+	}
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+	recorder := record.NewFakeRecorder(100)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(recorder),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.SetStartTime()
+
+	err := r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+
+	require.Empty(t, task.Status.JobName)
+	require.False(t, task.Status.Status.IsCompleted())
+	require.Len(t, recorder.Events, 1)
+
+	// no further event is sent while the KeptnTask is waiting for the result
+	task.Status.Status = apicommon.StateProgressing
+	err = r.getTaskExecutor(task, taskDefinition).UpdateStatus(context.TODO(), task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Len(t, recorder.Events, 1)
+}
+
+func TestKeptnTaskReconciler_runExternalTask_timeout(t *testing.T) {
+	r := &KeptnTaskReconciler{
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+	}
+
+	task := makeTask("my-task", "default", "my-external-task-definition")
+	task.Spec.Timeout = metav1.Duration{Duration: time.Minute}
+	task.Status.Status = apicommon.StateProgressing
+	task.Status.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+	r.runExternalTask(task)
+
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, "DeadlineExceeded", task.Status.Reason)
+}
@@ -0,0 +1,322 @@
+package keptntask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// maxHttpResponseSize is the maximum number of bytes read from the body of a response
+const maxHttpResponseSize = 1 << 20
+
+// maxHttpResponseSummary is the maximum number of characters of the response stored in the status of the KeptnTask
+const maxHttpResponseSummary = 1024
+
+// defaultHttpTimeout is the timeout of a single request if the HTTP spec does not define one
+const defaultHttpTimeout = 30 * time.Second
+
+// httpExecutor executes KeptnTasks by sending the HTTP request defined in the KeptnTaskDefinition
+type httpExecutor struct {
+	r    *KeptnTaskReconciler
+	spec *klcv1beta1.HttpSpec
+}
+
+func (e *httpExecutor) Create(ctx context.Context, _ ctrl.Request, task *klcv1beta1.KeptnTask, definition *klcv1beta1.KeptnTaskDefinition) error {
+	e.r.runHttpTask(ctx, task, definition.Spec.Http)
+	return nil
+}
+
+// UpdateStatus resends the HTTP request until it succeeds or the retries of the KeptnTask are exhausted
+func (e *httpExecutor) UpdateStatus(ctx context.Context, task *klcv1beta1.KeptnTask) error {
+	if task.Status.Http == nil {
+		return newExecutionNotStartedError(task)
+	}
+	e.r.runHttpTask(ctx, task, e.spec)
+	return nil
+}
+
+// runHttpTask sends the request defined in the HTTP spec of a KeptnTaskDefinition in the background and updates
+// the status of the KeptnTask according to the response, the retries and the timeout of the KeptnTask.
+// The response is polled on every reconciliation, so that slow endpoints do not block the workers of the controller.
+func (r *KeptnTaskReconciler) runHttpTask(ctx context.Context, task *klcv1beta1.KeptnTask, spec *klcv1beta1.HttpSpec) {
+	if task.Status.Http == nil {
+		task.Status.Http = &klcv1beta1.HttpTaskStatus{}
+	}
+
+	if task.Spec.Timeout.Duration > 0 && time.Since(task.Status.StartTime.Time) > task.Spec.Timeout.Duration {
+		r.httpRequests.forget(types.NamespacedName{Namespace: task.Namespace, Name: task.Name})
+		task.Status.Status = apicommon.StateFailed
+		task.Status.Reason = "DeadlineExceeded"
+		task.Status.Message = "KeptnTask was active longer than specified deadline"
+		return
+	}
+
+	response, pending := r.httpRequests.poll(task)
+	if pending {
+		task.Status.Status = apicommon.StateProgressing
+		return
+	}
+	if response == nil {
+		task.Status.Http.Attempts++
+		req, err := r.newHttpRequest(ctx, task, spec)
+		if err != nil {
+			r.handleHttpError(task, err)
+			return
+		}
+		r.httpRequests.send(task, req, getHttpTimeout(task, spec))
+		task.Status.Status = apicommon.StateProgressing
+		return
+	}
+
+	if err := checkHttpResponse(task, spec, response); err != nil {
+		r.handleHttpError(task, err)
+		return
+	}
+
+	task.Status.Status = apicommon.StateSucceeded
+	task.Status.Message = ""
+	task.Status.Reason = ""
+}
+
+// handleHttpError fails the KeptnTask if the failed request cannot succeed on a retry or if the retries
+// of the KeptnTask are exhausted, otherwise the request is retried on the next reconciliation
+func (r *KeptnTaskReconciler) handleHttpError(task *klcv1beta1.KeptnTask, err error) {
+	r.Log.Error(err, "HTTP request of KeptnTask failed", "task", task.Name, "namespace", task.Namespace)
+	if errors.Is(err, controllererrors.ErrInvalidTaskTemplate) {
+		// a template that cannot be rendered will not succeed on a retry, therefore the task is failed right away
+		task.Status.Status = apicommon.StateFailed
+		task.Status.Reason = "InvalidTemplate"
+		task.Status.Message = err.Error()
+		return
+	}
+	task.Status.Message = err.Error()
+	retries := int32(0)
+	if task.Spec.Retries != nil {
+		retries = *task.Spec.Retries
+	}
+	if task.Status.Http.Attempts > retries {
+		task.Status.Status = apicommon.StateFailed
+		task.Status.Reason = "BackoffLimitExceeded"
+		return
+	}
+	task.Status.Status = apicommon.StateProgressing
+}
+
+// newHttpRequest renders the request defined in the HTTP spec of a KeptnTaskDefinition.
+// The request is not bound to the context of the reconciliation, since it is sent in the background.
+func (r *KeptnTaskReconciler) newHttpRequest(ctx context.Context, task *klcv1beta1.KeptnTask, spec *klcv1beta1.HttpSpec) (*http.Request, error) {
+	data := taskTemplateData{
+		Context:    task.Spec.Context,
+		Parameters: task.Spec.Parameters.Inline,
+	}
+	url, err := renderTemplate("url", spec.Url, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: url: %w", controllererrors.ErrInvalidTaskTemplate, err)
+	}
+	body, err := renderTemplate("body", spec.Body, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: body: %w", controllererrors.ErrInvalidTaskTemplate, err)
+	}
+
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(context.WithoutCancel(ctx), method, url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for _, header := range spec.Headers {
+		value, err := r.getHttpHeaderValue(ctx, task.Namespace, header)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(header.Name, value)
+	}
+	return req, nil
+}
+
+// checkHttpResponse records the response in the status of the KeptnTask and checks it against
+// the expected status codes and assertions of the HTTP spec
+func checkHttpResponse(task *klcv1beta1.KeptnTask, spec *klcv1beta1.HttpSpec, response *httpResponse) error {
+	if response.err != nil {
+		return response.err
+	}
+	task.Status.Http.StatusCode = int32(response.statusCode)
+	task.Status.Http.Response = apicommon.TruncateString(string(response.body), maxHttpResponseSummary)
+
+	if !isExpectedHttpStatus(response.statusCode, spec.ExpectedStatus) {
+		return fmt.Errorf("%w: %d", controllererrors.ErrUnexpectedHttpStatus, response.statusCode)
+	}
+	return checkHttpAssertions(response.body, spec.Assertions)
+}
+
+// getHttpTimeout returns the timeout of a single request, which is bounded by the time left until the
+// deadline of the KeptnTask so that a request sent in the background does not outlive the task
+func getHttpTimeout(task *klcv1beta1.KeptnTask, spec *klcv1beta1.HttpSpec) time.Duration {
+	timeout := spec.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultHttpTimeout
+	}
+	if task.Spec.Timeout.Duration > 0 {
+		remaining := task.Spec.Timeout.Duration - time.Since(task.Status.StartTime.Time)
+		if remaining > 0 && remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return timeout
+}
+
+func (r *KeptnTaskReconciler) getHttpHeaderValue(ctx context.Context, namespace string, header klcv1beta1.HttpHeader) (string, error) {
+	if header.SecretKeyRef == nil {
+		return header.Value, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: header.SecretKeyRef.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("could not retrieve value of header %s: %w", header.Name, err)
+	}
+	value, ok := secret.Data[header.SecretKeyRef.Key]
+	if !ok {
+		return "", fmt.Errorf("could not retrieve value of header %s: key %s not found in Secret %s", header.Name, header.SecretKeyRef.Key, header.SecretKeyRef.Name)
+	}
+	return string(value), nil
+}
+
+func isExpectedHttpStatus(statusCode int, expected []int32) bool {
+	if len(expected) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+	for _, code := range expected {
+		if int(code) == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func checkHttpAssertions(body []byte, assertions []klcv1beta1.HttpAssertion) error {
+	if len(assertions) == 0 {
+		return nil
+	}
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Errorf("%w: response is not valid JSON: %w", controllererrors.ErrHttpAssertionFailed, err)
+	}
+	for _, assertion := range assertions {
+		path := assertion.JSONPath
+		if !strings.Contains(path, "{") {
+			path = "{" + path + "}"
+		}
+		jp := jsonpath.New("assertion")
+		if err := jp.Parse(path); err != nil {
+			return fmt.Errorf("%w: %s: %w", controllererrors.ErrHttpAssertionFailed, assertion.JSONPath, err)
+		}
+		buf := &bytes.Buffer{}
+		if err := jp.Execute(buf, data); err != nil {
+			return fmt.Errorf("%w: %s: %w", controllererrors.ErrHttpAssertionFailed, assertion.JSONPath, err)
+		}
+		if assertion.Value != "" && buf.String() != assertion.Value {
+			return fmt.Errorf("%w: %s is %q, expected %q", controllererrors.ErrHttpAssertionFailed, assertion.JSONPath, buf.String(), assertion.Value)
+		}
+	}
+	return nil
+}
+
+// httpRequestTracker keeps track of the HTTP requests of KeptnTasks which are sent in the background
+type httpRequestTracker struct {
+	mtx      sync.Mutex
+	requests map[types.NamespacedName]*httpRequest
+}
+
+// httpRequest is a request sent for the KeptnTask with the given UID
+type httpRequest struct {
+	uid      types.UID
+	done     bool
+	response httpResponse
+}
+
+// httpResponse is the outcome of a request sent for a KeptnTask
+type httpResponse struct {
+	statusCode int
+	body       []byte
+	err        error
+}
+
+// send sends the request in the background and records its response for the given KeptnTask
+func (t *httpRequestTracker) send(task *klcv1beta1.KeptnTask, req *http.Request, timeout time.Duration) {
+	request := &httpRequest{uid: task.UID}
+	t.mtx.Lock()
+	if t.requests == nil {
+		t.requests = map[types.NamespacedName]*httpRequest{}
+	}
+	t.requests[types.NamespacedName{Namespace: task.Namespace, Name: task.Name}] = request
+	t.mtx.Unlock()
+
+	go func() {
+		response := doHttpRequest(req, timeout)
+		t.mtx.Lock()
+		defer t.mtx.Unlock()
+		request.response = response
+		request.done = true
+	}()
+}
+
+// poll returns the response of the request sent for the given KeptnTask once it is available and forgets
+// the request afterwards. If the request has not been answered yet, it is reported as pending.
+// No response is returned if no request has been sent, e.g. after a restart of the lifecycle operator.
+func (t *httpRequestTracker) poll(task *klcv1beta1.KeptnTask) (*httpResponse, bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	key := types.NamespacedName{Namespace: task.Namespace, Name: task.Name}
+	request, ok := t.requests[key]
+	if !ok {
+		return nil, false
+	}
+	if request.uid != task.UID {
+		// the request has been sent for a previous KeptnTask with the same name
+		delete(t.requests, key)
+		return nil, false
+	}
+	if !request.done {
+		return nil, true
+	}
+	delete(t.requests, key)
+	return &request.response, false
+}
+
+// forget drops the request sent for the KeptnTask with the given name, e.g. if the KeptnTask has been deleted
+func (t *httpRequestTracker) forget(name types.NamespacedName) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	delete(t.requests, name)
+}
+
+func doHttpRequest(req *http.Request, timeout time.Duration) httpResponse {
+	httpClient := &http.Client{Timeout: timeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return httpResponse{err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHttpResponseSize))
+	if err != nil {
+		return httpResponse{err: err}
+	}
+	return httpResponse{statusCode: resp.StatusCode, body: body}
+}
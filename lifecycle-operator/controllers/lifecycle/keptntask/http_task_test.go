@@ -0,0 +1,338 @@
+package keptntask
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnTaskReconciler_createJob_withHttpSpec(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-http-task-definition"
+
+	var receivedPath, receivedToken, receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		receivedPath = req.URL.Path
+		receivedToken = req.Header.Get("Authorization")
+		body, _ := io.ReadAll(req.Body)
+		receivedBody = string(body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"status":"healthy","checks":[{"name":"db"}]}`))
+	}))
+	defer server.Close()
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-token", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("Bearer secret")},
+	}
+	taskDefinition := makeHttpTaskDefinition(taskDefinitionName, namespace, &klcv1beta1.HttpSpec{
+		Method: http.MethodPost,
+		Url:    server.URL + "/apps/{{ .Context.AppName }}/{{ .Parameters.stage }}",
+		Body:   `{"version":"{{ .Context.AppVersion }}"}`,
+		Headers: []klcv1beta1.HttpHeader{
+			{
+				Name: "Authorization",
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "my-token"},
+					Key:                  "token",
+				},
+			},
+		},
+		ExpectedStatus: []int32{http.StatusCreated},
+		Assertions: []klcv1beta1.HttpAssertion{
+			{JSONPath: "{.status}", Value: "healthy"},
+			{JSONPath: ".checks[0].name"},
+		},
+		Timeout: metav1.Duration{Duration: 5 * time.Second},
+	})
+	fakeClient := testcommon.NewTestClient(secret, taskDefinition)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.Spec.Parameters.Inline = map[string]string{"stage": "prod"}
+	task.SetStartTime()
+
+	err := r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+
+	// the request is sent in the background and its response is picked up by the next reconciliation
+	require.False(t, task.Status.Status.IsCompleted())
+	waitForHttpResponse(t, r, task)
+	err = r.getTaskExecutor(task, taskDefinition).UpdateStatus(context.TODO(), task)
+	require.Nil(t, err)
+
+	require.Empty(t, task.Status.JobName)
+	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+	require.Equal(t, "/apps/my-app/prod", receivedPath)
+	require.Equal(t, "Bearer secret", receivedToken)
+	require.Equal(t, `{"version":"0.1.0"}`, receivedBody)
+	require.Equal(t, &klcv1beta1.HttpTaskStatus{
+		Attempts:   1,
+		StatusCode: http.StatusCreated,
+		Response:   `{"status":"healthy","checks":[{"name":"db"}]}`,
+	}, task.Status.Http)
+}
+
+func TestKeptnTaskReconciler_runHttpTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/slow":
+			time.Sleep(time.Second)
+		case "/unhealthy":
+			_, _ = w.Write([]byte(`{"status":"unhealthy"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"healthy"}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		spec         *klcv1beta1.HttpSpec
+		retries      int32
+		attempts     int32
+		startTime    time.Time
+		wantStatus   apicommon.KeptnState
+		wantReason   string
+		wantAttempts int32
+		wantCode     int32
+	}{
+		{
+			name:         "any 2xx status succeeds",
+			spec:         &klcv1beta1.HttpSpec{Url: server.URL + "/health"},
+			retries:      2,
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StateSucceeded,
+			wantAttempts: 1,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "unexpected status is retried",
+			spec:         &klcv1beta1.HttpSpec{Url: server.URL + "/unavailable"},
+			retries:      2,
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StateProgressing,
+			wantAttempts: 1,
+			wantCode:     http.StatusServiceUnavailable,
+		},
+		{
+			name:         "unexpected status fails after retries",
+			spec:         &klcv1beta1.HttpSpec{Url: server.URL + "/unavailable"},
+			retries:      2,
+			attempts:     2,
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StateFailed,
+			wantReason:   "BackoffLimitExceeded",
+			wantAttempts: 3,
+			wantCode:     http.StatusServiceUnavailable,
+		},
+		{
+			name: "failed assertion",
+			spec: &klcv1beta1.HttpSpec{
+				Url:        server.URL + "/unhealthy",
+				Assertions: []klcv1beta1.HttpAssertion{{JSONPath: "{.status}", Value: "healthy"}},
+			},
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StateFailed,
+			wantReason:   "BackoffLimitExceeded",
+			wantAttempts: 1,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name: "missing assertion value",
+			spec: &klcv1beta1.HttpSpec{
+				Url:        server.URL + "/health",
+				Assertions: []klcv1beta1.HttpAssertion{{JSONPath: "{.checks}"}},
+			},
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StateFailed,
+			wantReason:   "BackoffLimitExceeded",
+			wantAttempts: 1,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "request times out and is retried",
+			spec:         &klcv1beta1.HttpSpec{Url: server.URL + "/slow", Timeout: metav1.Duration{Duration: 50 * time.Millisecond}},
+			retries:      2,
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StateProgressing,
+			wantAttempts: 1,
+		},
+		{
+			name:         "invalid template fails without retry",
+			spec:         &klcv1beta1.HttpSpec{Url: server.URL + "/{{ .Context.WorkloadName"},
+			retries:      2,
+			startTime:    time.Now(),
+			wantStatus:   apicommon.StateFailed,
+			wantReason:   "InvalidTemplate",
+			wantAttempts: 1,
+		},
+		{
+			name:       "deadline exceeded",
+			spec:       &klcv1beta1.HttpSpec{Url: server.URL + "/health"},
+			retries:    2,
+			startTime:  time.Now().Add(-10 * time.Minute),
+			wantStatus: apicommon.StateFailed,
+			wantReason: "DeadlineExceeded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := testcommon.NewTestClient()
+			r := &KeptnTaskReconciler{
+				Client:      fakeClient,
+				EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				Log:         ctrl.Log.WithName("task-controller"),
+				Scheme:      fakeClient.Scheme(),
+			}
+
+			task := makeTask("my-task", "default", "my-http-task-definition")
+			retries := tt.retries
+			task.Spec.Retries = &retries
+			task.Spec.Timeout = metav1.Duration{Duration: 5 * time.Minute}
+			task.Status.StartTime = metav1.NewTime(tt.startTime)
+			task.Status.Http = &klcv1beta1.HttpTaskStatus{Attempts: tt.attempts}
+
+			r.runHttpTask(context.TODO(), task, tt.spec)
+			if _, pending := r.httpRequests.poll(task); pending {
+				waitForHttpResponse(t, r, task)
+				r.runHttpTask(context.TODO(), task, tt.spec)
+			}
+
+			require.Equal(t, tt.wantStatus, task.Status.Status)
+			require.Equal(t, tt.wantReason, task.Status.Reason)
+			require.Equal(t, tt.wantAttempts, task.Status.Http.Attempts)
+			require.Equal(t, tt.wantCode, task.Status.Http.StatusCode)
+			if tt.wantStatus == apicommon.StateSucceeded {
+				require.Empty(t, task.Status.Message)
+			} else {
+				require.NotEmpty(t, task.Status.Message)
+			}
+		})
+	}
+}
+
+func TestKeptnTaskReconciler_runHttpTaskDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	fakeClient := testcommon.NewTestClient()
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", "default", "my-http-task-definition")
+	task.UID = "first"
+	task.Spec.Timeout = metav1.Duration{Duration: 5 * time.Minute}
+	task.SetStartTime()
+	spec := &klcv1beta1.HttpSpec{Url: server.URL}
+
+	r.runHttpTask(context.TODO(), task, spec)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Equal(t, int32(1), task.Status.Http.Attempts)
+
+	// a pending request is not sent again
+	r.runHttpTask(context.TODO(), task, spec)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Equal(t, int32(1), task.Status.Http.Attempts)
+
+	// a request of a previous KeptnTask with the same name is ignored
+	recreated := makeTask("my-task", "default", "my-http-task-definition")
+	recreated.UID = "second"
+	response, pending := r.httpRequests.poll(recreated)
+	require.Nil(t, response)
+	require.False(t, pending)
+}
+
+func waitForHttpResponse(t *testing.T, r *KeptnTaskReconciler, task *klcv1beta1.KeptnTask) {
+	require.Eventually(t, func() bool {
+		r.httpRequests.mtx.Lock()
+		defer r.httpRequests.mtx.Unlock()
+		request, ok := r.httpRequests.requests[types.NamespacedName{Namespace: task.Namespace, Name: task.Name}]
+		return !ok || request.done
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func makeHttpTaskDefinition(name, namespace string, spec *klcv1beta1.HttpSpec) *klcv1beta1.KeptnTaskDefinition {
+	return &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Http: spec,
+		},
+	}
+}
+
+func Test_getHttpTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		specTimeout time.Duration
+		taskTimeout time.Duration
+		startTime   time.Time
+		want        time.Duration
+	}{
+		{
+			name:        "timeout of the HTTP spec",
+			specTimeout: 10 * time.Second,
+			taskTimeout: 5 * time.Minute,
+			startTime:   time.Now(),
+			want:        10 * time.Second,
+		},
+		{
+			name:        "default timeout",
+			taskTimeout: 5 * time.Minute,
+			startTime:   time.Now(),
+			want:        defaultHttpTimeout,
+		},
+		{
+			name:        "bounded by the deadline of the task",
+			specTimeout: 10 * time.Second,
+			taskTimeout: 5 * time.Minute,
+			startTime:   time.Now().Add(-4*time.Minute - 58*time.Second),
+			want:        2 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := makeTask("my-task", "default", "my-http-task-definition")
+			task.Spec.Timeout = metav1.Duration{Duration: tt.taskTimeout}
+			task.Status.StartTime = metav1.NewTime(tt.startTime)
+
+			got := getHttpTimeout(task, &klcv1beta1.HttpSpec{Timeout: metav1.Duration{Duration: tt.specTimeout}})
+
+			require.LessOrEqual(t, got, tt.want)
+			require.Greater(t, got, tt.want-time.Second)
+		})
+	}
+}
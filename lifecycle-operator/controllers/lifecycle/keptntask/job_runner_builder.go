@@ -29,6 +29,8 @@ type BuilderOptions struct {
 	Image         string
 	MountPath     string
 	ConfigMap     string
+	// templateParameters enables rendering the parameters of the task as templates
+	templateParameters bool
 }
 
 func NewJobRunnerBuilder(options BuilderOptions) JobRunnerBuilder {
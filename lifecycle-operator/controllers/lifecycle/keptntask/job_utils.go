@@ -2,27 +2,56 @@ package keptntask
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
 	taskdefinition "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/taskdefinition"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func (r *KeptnTaskReconciler) createJob(ctx context.Context, req ctrl.Request, task *klcv1beta1.KeptnTask) error {
-	jobName := ""
+	definition, err := r.getTaskDefinition(ctx, req, task)
+	if err != nil {
+		return err
+	}
+
+	err = r.getTaskExecutor(task, definition).Create(ctx, req, task, definition)
+	if errors.Is(err, controllererrors.ErrInvalidTaskTemplate) {
+		// a template that cannot be rendered will not succeed on a retry, therefore the task is failed right away
+		task.Status.Status = apicommon.StateFailed
+		task.Status.Reason = "InvalidTemplate"
+		task.Status.Message = err.Error()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !task.Status.Status.IsCompleted() {
+		task.Status.Status = apicommon.StatePending
+	}
+
+	return nil
+}
+
+func (r *KeptnTaskReconciler) getTaskDefinition(ctx context.Context, req ctrl.Request, task *klcv1beta1.KeptnTask) (*klcv1beta1.KeptnTaskDefinition, error) {
 	definition, err := controllercommon.GetTaskDefinition(r.Client, r.Log, ctx, task.Spec.TaskDefinition, req.Namespace)
 	if err != nil {
-		if errors.IsNotFound(err) {
+		if k8serrors.IsNotFound(err) {
 			r.Log.Info("TaskDefinition for Task not found",
 				"task", task.Name,
 				"taskDefinition", task.Spec.TaskDefinition,
@@ -38,20 +67,9 @@ func (r *KeptnTaskReconciler) createJob(ctx context.Context, req ctrl.Request, t
 		}
 		r.Log.Error(err, fmt.Sprintf("could not find KeptnTaskDefinition: %s ", task.Spec.TaskDefinition))
 		r.EventSender.Emit(apicommon.PhaseCreateTask, "Warning", task, apicommon.PhaseStateNotFound, fmt.Sprintf("could not find KeptnTaskDefinition: %s ", task.Spec.TaskDefinition), "")
-		return err
-	}
-
-	if taskdefinition.SpecExists(definition) {
-		jobName, err = r.createFunctionJob(ctx, req, task, definition)
-		if err != nil {
-			return err
-		}
+		return nil, err
 	}
-
-	task.Status.JobName = jobName
-	task.Status.Status = apicommon.StatePending
-
-	return nil
+	return definition, nil
 }
 
 func (r *KeptnTaskReconciler) createFunctionJob(ctx context.Context, req ctrl.Request, task *klcv1beta1.KeptnTask, definition *klcv1beta1.KeptnTaskDefinition) (string, error) {
@@ -82,6 +100,91 @@ func (r *KeptnTaskReconciler) updateTaskStatus(job *batchv1.Job, task *klcv1beta
 	}
 }
 
+// updateTaskOutputs copies the outputs written by the Job executing the task
+// to the termination message of its container into the status of the task
+func (r *KeptnTaskReconciler) updateTaskOutputs(ctx context.Context, job *batchv1.Job, task *klcv1beta1.KeptnTask) {
+	if job.Spec.Selector == nil {
+		return
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		r.Log.Error(err, "could not parse selector of Job", "job", job.Name)
+		return
+	}
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		r.Log.Error(err, "could not retrieve pods of Job", "job", job.Name)
+		return
+	}
+
+	// the pod of the latest attempt contains the outputs
+	var latest *corev1.ContainerStateTerminated
+	for _, pod := range pods.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			terminated := containerStatus.State.Terminated
+			if terminated == nil || terminated.Message == "" {
+				continue
+			}
+			if latest == nil || terminated.FinishedAt.After(latest.FinishedAt.Time) {
+				latest = terminated
+			}
+		}
+	}
+	if latest == nil {
+		return
+	}
+
+	outputs, err := parseTaskOutputs(latest.Message)
+	if err != nil {
+		r.Log.Error(err, "could not parse outputs of Job", "job", job.Name)
+		return
+	}
+	task.Status.Outputs = outputs
+}
+
+// parseTaskOutputs parses a termination message containing either a JSON object
+// or a list of key=value pairs separated by newlines
+func parseTaskOutputs(message string) (map[string]string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(message, "{") {
+		values := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(message), &values); err != nil {
+			return nil, fmt.Errorf("%w: %w", controllererrors.ErrInvalidTaskOutputs, err)
+		}
+		outputs := make(map[string]string, len(values))
+		for key, value := range values {
+			if str, ok := value.(string); ok {
+				outputs[key] = str
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", controllererrors.ErrInvalidTaskOutputs, err)
+			}
+			outputs[key] = string(encoded)
+		}
+		return outputs, nil
+	}
+
+	outputs := map[string]string{}
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || key == "" {
+			return nil, controllererrors.ErrInvalidTaskOutputs
+		}
+		outputs[key] = value
+	}
+	return outputs, nil
+}
+
 func (r *KeptnTaskReconciler) getJob(ctx context.Context, jobName string, namespace string) (*batchv1.Job, error) {
 	job := &batchv1.Job{}
 	err := r.Client.Get(ctx, types.NamespacedName{Name: jobName, Namespace: namespace}, job)
@@ -123,16 +226,17 @@ func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *klcv1beta1.
 	}
 
 	builderOpt := BuilderOptions{
-		Client:        r.Client,
-		req:           request,
-		Log:           r.Log,
-		task:          task,
-		containerSpec: definition.Spec.Container,
-		funcSpec:      taskdefinition.GetRuntimeSpec(definition),
-		eventSender:   r.EventSender,
-		Image:         taskdefinition.GetRuntimeImage(definition),
-		MountPath:     taskdefinition.GetRuntimeMountPath(definition),
-		ConfigMap:     definition.Status.Function.ConfigMap,
+		Client:             r.Client,
+		req:                request,
+		Log:                r.Log,
+		task:               task,
+		containerSpec:      definition.Spec.Container,
+		funcSpec:           taskdefinition.GetRuntimeSpec(definition),
+		eventSender:        r.EventSender,
+		Image:              taskdefinition.GetRuntimeImage(definition),
+		MountPath:          taskdefinition.GetRuntimeMountPath(definition),
+		ConfigMap:          definition.Status.Function.ConfigMap,
+		templateParameters: definition.Spec.TemplateParameters,
 	}
 
 	builder := NewJobRunnerBuilder(builderOpt)
@@ -155,6 +259,7 @@ func (r *KeptnTaskReconciler) generateJob(ctx context.Context, task *klcv1beta1.
 	}
 
 	job.Spec.Template.Spec.Containers = []corev1.Container{*container}
+	telemetry.InjectTraceContext(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, telemetry.GetTraceContextFromAnnotations(task.ObjectMeta))
 
 	return job, nil
 }
@@ -3,12 +3,14 @@ package keptntask
 import (
 	"context"
 	"testing"
+	"time"
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -86,6 +88,69 @@ func TestKeptnTaskReconciler_createJob(t *testing.T) {
 	}, resultingJob.Annotations)
 }
 
+func TestKeptnTaskReconciler_generateJob_withTraceContext(t *testing.T) {
+	namespace := "default"
+	taskDefinition := makeTaskDefinitionWithServiceAccount("my-task-definition", namespace, "svcAccname", nil, nil, nil)
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+	task := makeTask("my-task", namespace, taskDefinition.Name)
+	task.Annotations = map[string]string{
+		apicommon.TraceParentAnnotation: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		apicommon.TraceStateAnnotation:  "vendor=value",
+	}
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	resultingJob, err := r.generateJob(context.TODO(), task, taskDefinition, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}})
+	require.Nil(t, err)
+
+	require.Equal(t, task.Annotations, resultingJob.Spec.Template.Annotations)
+	require.Subset(t, resultingJob.Spec.Template.Spec.Containers[0].Env, []v1.EnvVar{
+		{Name: "TRACEPARENT", Value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{Name: "TRACESTATE", Value: "vendor=value"},
+	})
+}
+
+func TestKeptnTaskReconciler_createJob_withInvalidParameterTemplate(t *testing.T) {
+	namespace := "default"
+	cmName := "my-cmd"
+	taskDefinitionName := "my-task-definition"
+
+	cm := makeConfigMap(cmName, namespace)
+
+	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, namespace, cmName)
+	taskDefinition.Spec.Function.Parameters.Inline["foo"] = "{{ .Context.AppName"
+	taskDefinition.Spec.TemplateParameters = true
+	taskDefinition.Status.Function.ConfigMap = cmName
+	fakeClient := testcommon.NewTestClient(cm, taskDefinition)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+
+	err := r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+
+	require.Empty(t, task.Status.JobName)
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, "InvalidTemplate", task.Status.Reason)
+	require.Contains(t, task.Status.Message, "parameter foo")
+
+	jobs := &batchv1.JobList{}
+	err = fakeClient.List(context.TODO(), jobs)
+	require.Nil(t, err)
+	require.Empty(t, jobs.Items)
+}
+
 func TestKeptnTaskReconciler_createJob_withTaskDefInDefaultNamespace(t *testing.T) {
 	namespace := "default"
 	cmName := "my-cmd"
@@ -201,6 +266,101 @@ func TestKeptnTaskReconciler_updateTaskStatus(t *testing.T) {
 	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
 }
 
+func TestKeptnTaskReconciler_updateTaskOutputs(t *testing.T) {
+	namespace := "default"
+	now := time.Now()
+
+	job := makeJob("my.job", namespace, batchv1.JobStatus{})
+	job.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "job-uid"}}
+
+	makePod := func(name string, labels map[string]string, finishedAt time.Time, message string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    labels,
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						State: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{
+								FinishedAt: metav1.NewTime(finishedAt),
+								Message:    message,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		job,
+		makePod("first-attempt", job.Spec.Selector.MatchLabels, now.Add(-time.Minute), `{"snapshotId": "first"}`),
+		makePod("second-attempt", job.Spec.Selector.MatchLabels, now, `{"snapshotId": "second", "size": 42}`),
+		makePod("other-job", map[string]string{"controller-uid": "other"}, now.Add(time.Minute), `{"snapshotId": "other"}`),
+	).Build()
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, "my-task-definition")
+	r.updateTaskOutputs(context.TODO(), job, task)
+
+	require.Equal(t, map[string]string{"snapshotId": "second", "size": "42"}, task.Status.Outputs)
+}
+
+func Test_parseTaskOutputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "empty message",
+			message: "  ",
+			want:    nil,
+		},
+		{
+			name:    "json object",
+			message: `{"snapshotId": "abc", "count": 3, "nested": {"foo": "bar"}}`,
+			want:    map[string]string{"snapshotId": "abc", "count": "3", "nested": `{"foo":"bar"}`},
+		},
+		{
+			name:    "key value pairs",
+			message: "snapshotId=abc\n\nurl=http://example.com?a=b\n",
+			want:    map[string]string{"snapshotId": "abc", "url": "http://example.com?a=b"},
+		},
+		{
+			name:    "invalid json",
+			message: `{"snapshotId": `,
+			wantErr: true,
+		},
+		{
+			name:    "plain text",
+			message: "task finished",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTaskOutputs(tt.message)
+			if tt.wantErr {
+				require.ErrorIs(t, err, controllererrors.ErrInvalidTaskOutputs)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestKeptnTaskReconciler_generateJob(t *testing.T) {
 	namespace := "default"
 	taskName := "my-task"
@@ -14,7 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-// RuntimeBuilder implements container builder interface for Deno/Python
+// RuntimeBuilder implements container builder interface for Deno/Python/Wasm
 type RuntimeBuilder struct {
 	options BuilderOptions
 }
@@ -26,7 +26,7 @@ func NewRuntimeBuilder(options BuilderOptions) *RuntimeBuilder {
 	}
 }
 
-// RuntimeExecutionParams stores parameters related to Deno/Python container creation
+// RuntimeExecutionParams stores parameters related to Deno/Python/Wasm container creation
 type RuntimeExecutionParams struct {
 	ConfigMap        string
 	Parameters       map[string]string
@@ -166,6 +166,14 @@ func (fb *RuntimeBuilder) getParams(ctx context.Context) (*RuntimeExecutionParam
 	if fb.options.task.Spec.SecureParameters.Secret != "" {
 		params.SecureParameters = fb.options.task.Spec.SecureParameters.Secret
 	}
+
+	if !fb.options.templateParameters {
+		return &params, nil
+	}
+	if err := renderParameters(&params); err != nil {
+		fb.options.eventSender.Emit(apicommon.PhaseCreateTask, "Warning", fb.options.task, apicommon.PhaseStateFailed, fmt.Sprintf("could not render parameters of KeptnTask: %s ", err.Error()), "")
+		return nil, err
+	}
 	return &params, nil
 }
 
@@ -196,6 +196,56 @@ func TestJSBuilder_getParams(t *testing.T) {
 		},
 	}
 
+	templateDef := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mytemplatedef",
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Function: &klcv1beta1.RuntimeSpec{
+				HttpReference: klcv1beta1.HttpReference{Url: "donothing"},
+				Parameters: klcv1beta1.TaskParameters{
+					Inline: map[string]string{
+						"APP":      "{{ .Context.AppName }}@{{ .Context.AppVersion }}",
+						"PREVIOUS": "{{ .Context.PreviousVersion }}",
+					},
+				},
+				CmdParameters: "--workload={{ .Context.WorkloadName }} --type={{ .Context.ObjectType }}",
+			},
+		},
+	}
+	invalidTemplateDef := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myinvalidtemplatedef",
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Function: &klcv1beta1.RuntimeSpec{
+				HttpReference: klcv1beta1.HttpReference{Url: "donothing"},
+				Parameters: klcv1beta1.TaskParameters{
+					Inline: map[string]string{"APP": "{{ .Context.Unknown }}"},
+				},
+			},
+		},
+	}
+	untemplatedDef := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myuntemplateddef",
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Function: &klcv1beta1.RuntimeSpec{
+				HttpReference: klcv1beta1.HttpReference{Url: "donothing"},
+				Parameters: klcv1beta1.TaskParameters{
+					Inline: map[string]string{"QUERY": `{{ index . "app" }`},
+				},
+				CmdParameters: "--format={{json .}}",
+			},
+		},
+	}
+	templateTask := makeTask("myt5", "default", templateDef.Name)
+	templateTask.Spec.Context.PreviousVersion = "0.0.9"
+
 	tests := []struct {
 		name    string
 		options BuilderOptions
@@ -296,6 +346,74 @@ func TestJSBuilder_getParams(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "parameters are rendered as templates",
+			options: BuilderOptions{
+				Client:      testcommon.NewTestClient(templateDef),
+				eventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				req: ctrl.Request{
+					NamespacedName: types.NamespacedName{Namespace: "default"},
+				},
+				Log:                testr.New(t),
+				funcSpec:           taskdefinition.GetRuntimeSpec(templateDef),
+				task:               templateTask,
+				Image:              taskdefinition.FunctionScriptKey,
+				MountPath:          taskdefinition.FunctionScriptMountPath,
+				templateParameters: true,
+			},
+			params: &RuntimeExecutionParams{
+				Parameters: map[string]string{
+					"APP":      "my-app@0.1.0",
+					"PREVIOUS": "0.0.9",
+				},
+				CmdParameters: "--workload=my-workload --type=Workload",
+				URL:           "donothing",
+				Context:       templateTask.Spec.Context,
+				Image:         taskdefinition.FunctionScriptKey,
+				MountPath:     taskdefinition.FunctionScriptMountPath,
+			},
+			wantErr: false,
+		},
+		{
+			name: "parameters contain an invalid template",
+			options: BuilderOptions{
+				Client:      testcommon.NewTestClient(invalidTemplateDef),
+				eventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				req: ctrl.Request{
+					NamespacedName: types.NamespacedName{Namespace: "default"},
+				},
+				Log:                testr.New(t),
+				funcSpec:           taskdefinition.GetRuntimeSpec(invalidTemplateDef),
+				task:               makeTask("myt6", "default", invalidTemplateDef.Name),
+				templateParameters: true,
+			},
+			wantErr: true,
+			err:     "invalid template: parameter APP",
+		},
+		{
+			name: "parameters are passed unchanged if templating is disabled",
+			options: BuilderOptions{
+				Client:      testcommon.NewTestClient(untemplatedDef),
+				eventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+				req: ctrl.Request{
+					NamespacedName: types.NamespacedName{Namespace: "default"},
+				},
+				Log:       testr.New(t),
+				funcSpec:  taskdefinition.GetRuntimeSpec(untemplatedDef),
+				task:      makeTask("myt7", "default", untemplatedDef.Name),
+				Image:     taskdefinition.FunctionScriptKey,
+				MountPath: taskdefinition.FunctionScriptMountPath,
+			},
+			params: &RuntimeExecutionParams{
+				Parameters:    map[string]string{"QUERY": `{{ index . "app" }`},
+				CmdParameters: "--format={{json .}}",
+				URL:           "donothing",
+				Context:       makeTask("myt7", "default", untemplatedDef.Name).Spec.Context,
+				Image:         taskdefinition.FunctionScriptKey,
+				MountPath:     taskdefinition.FunctionScriptMountPath,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,78 @@
+package keptntask
+
+import (
+	"context"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// TaskExecutor is the interface that describes the operations needed to execute a KeptnTask
+// with a specific backend, such as a Kubernetes Job or a Tekton PipelineRun
+type TaskExecutor interface {
+	// Create creates the resource executing the KeptnTask and records its name in the status of the KeptnTask
+	Create(ctx context.Context, req ctrl.Request, task *klcv1beta1.KeptnTask, definition *klcv1beta1.KeptnTaskDefinition) error
+	// UpdateStatus maps the state of the resource executing the KeptnTask onto the status of the KeptnTask.
+	// A NotFound error is returned if the resource does not exist, or if the execution has not been started yet.
+	UpdateStatus(ctx context.Context, task *klcv1beta1.KeptnTask) error
+}
+
+// getTaskExecutor returns the TaskExecutor for the backend executing the KeptnTask.
+// The backend is selected based on the status of the KeptnTask once its execution has been started
+// in a Job or PipelineRun, and based on the KeptnTaskDefinition otherwise.
+func (r *KeptnTaskReconciler) getTaskExecutor(task *klcv1beta1.KeptnTask, definition *klcv1beta1.KeptnTaskDefinition) TaskExecutor {
+	switch {
+	case task.Status.PipelineRunName != "":
+		return &tektonExecutor{r: r}
+	case task.Status.JobName != "" || definition == nil:
+		return &jobExecutor{r: r}
+	case definition.Spec.Tekton != nil:
+		return &tektonExecutor{r: r}
+	case definition.Spec.Http != nil:
+		return &httpExecutor{r: r, spec: definition.Spec.Http}
+	case definition.Spec.Approval != nil:
+		return &approvalExecutor{r: r}
+	case definition.Spec.External != nil:
+		return &externalExecutor{r: r}
+	default:
+		return &jobExecutor{r: r}
+	}
+}
+
+// isExecutedInResource returns true if the KeptnTask is executed in a resource that is created for it,
+// i.e. a Job or a PipelineRun. Other KeptnTasks are executed based on their KeptnTaskDefinition.
+func isExecutedInResource(task *klcv1beta1.KeptnTask) bool {
+	return task.Status.JobName != "" || task.Status.PipelineRunName != ""
+}
+
+// newExecutionNotStartedError returns the NotFound error for KeptnTasks whose execution has not been started yet
+func newExecutionNotStartedError(task *klcv1beta1.KeptnTask) error {
+	return k8serrors.NewNotFound(klcv1beta1.GroupVersion.WithResource("keptntasks").GroupResource(), task.Name)
+}
+
+// jobExecutor executes KeptnTasks in Kubernetes Jobs
+type jobExecutor struct {
+	r *KeptnTaskReconciler
+}
+
+func (e *jobExecutor) Create(ctx context.Context, req ctrl.Request, task *klcv1beta1.KeptnTask, definition *klcv1beta1.KeptnTaskDefinition) error {
+	jobName, err := e.r.createFunctionJob(ctx, req, task, definition)
+	if err != nil {
+		return err
+	}
+	task.Status.JobName = jobName
+	return nil
+}
+
+func (e *jobExecutor) UpdateStatus(ctx context.Context, task *klcv1beta1.KeptnTask) error {
+	job, err := e.r.getJob(ctx, task.Status.JobName, task.Namespace)
+	if err != nil {
+		return err
+	}
+	e.r.updateTaskStatus(job, task)
+	if task.Status.Status.IsCompleted() {
+		e.r.updateTaskOutputs(ctx, job, task)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnTaskReconciler_getTaskExecutor(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     klcv1beta1.KeptnTaskStatus
+		definition *klcv1beta1.KeptnTaskDefinition
+		want       TaskExecutor
+	}{
+		{
+			name:   "started Job",
+			status: klcv1beta1.KeptnTaskStatus{JobName: "my-job"},
+			want:   &jobExecutor{},
+		},
+		{
+			name:   "started PipelineRun",
+			status: klcv1beta1.KeptnTaskStatus{PipelineRunName: "my-pipeline-run"},
+			want:   &tektonExecutor{},
+		},
+		{
+			name:       "runtime spec",
+			definition: &klcv1beta1.KeptnTaskDefinition{Spec: klcv1beta1.KeptnTaskDefinitionSpec{Deno: &klcv1beta1.RuntimeSpec{}}},
+			want:       &jobExecutor{},
+		},
+		{
+			name:       "tekton spec",
+			definition: &klcv1beta1.KeptnTaskDefinition{Spec: klcv1beta1.KeptnTaskDefinitionSpec{Tekton: &klcv1beta1.TektonSpec{}}},
+			want:       &tektonExecutor{},
+		},
+		{
+			name:       "http spec",
+			definition: &klcv1beta1.KeptnTaskDefinition{Spec: klcv1beta1.KeptnTaskDefinitionSpec{Http: &klcv1beta1.HttpSpec{Url: "http://localhost"}}},
+			want:       &httpExecutor{spec: &klcv1beta1.HttpSpec{Url: "http://localhost"}},
+		},
+		{
+			name:       "approval spec",
+			definition: &klcv1beta1.KeptnTaskDefinition{Spec: klcv1beta1.KeptnTaskDefinitionSpec{Approval: &klcv1beta1.ApprovalSpec{}}},
+			want:       &approvalExecutor{},
+		},
+		{
+			name:       "external spec",
+			definition: &klcv1beta1.KeptnTaskDefinition{Spec: klcv1beta1.KeptnTaskDefinitionSpec{External: &klcv1beta1.ExternalSpec{}}},
+			want:       &externalExecutor{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &KeptnTaskReconciler{}
+			task := makeTask("my-task", "default", "my-task-definition")
+			task.Status = tt.status
+
+			got := r.getTaskExecutor(task, tt.definition)
+
+			require.IsType(t, tt.want, got)
+			if want, ok := tt.want.(*httpExecutor); ok {
+				require.Equal(t, want.spec, got.(*httpExecutor).spec)
+			}
+		})
+	}
+}
+
+func TestTaskExecutor_UpdateStatus_notStarted(t *testing.T) {
+	r := &KeptnTaskReconciler{
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+	}
+	executors := map[string]TaskExecutor{
+		"http":     &httpExecutor{r: r, spec: &klcv1beta1.HttpSpec{Url: "http://localhost"}},
+		"approval": &approvalExecutor{r: r},
+		"external": &externalExecutor{r: r},
+	}
+	for name, executor := range executors {
+		t.Run(name, func(t *testing.T) {
+			task := makeTask("my-task", "default", "my-task-definition")
+			task.SetStartTime()
+
+			err := executor.UpdateStatus(context.TODO(), task)
+
+			require.True(t, k8serrors.IsNotFound(err))
+		})
+	}
+}
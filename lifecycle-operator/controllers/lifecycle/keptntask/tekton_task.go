@@ -0,0 +1,200 @@
+package keptntask
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// TektonPipelineTaskName is the name of the pipeline task executing the Tekton Task referenced by a KeptnTaskDefinition
+const TektonPipelineTaskName = "keptn-task"
+
+var pipelineRunGVK = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "PipelineRun"}
+
+// tektonExecutor executes KeptnTasks in Tekton PipelineRuns
+type tektonExecutor struct {
+	r *KeptnTaskReconciler
+}
+
+func (e *tektonExecutor) Create(ctx context.Context, req ctrl.Request, task *klcv1beta1.KeptnTask, definition *klcv1beta1.KeptnTaskDefinition) error {
+	pipelineRun, err := e.generatePipelineRun(task, definition)
+	if err != nil {
+		e.r.EventSender.Emit(apicommon.PhaseCreateTask, "Warning", task, apicommon.PhaseStateFailed, fmt.Sprintf("could not render parameters of KeptnTask: %s ", err.Error()), "")
+		return err
+	}
+	err = e.r.Client.Create(ctx, pipelineRun)
+	if err != nil {
+		e.r.Log.Error(err, "could not create PipelineRun")
+		e.r.EventSender.Emit(apicommon.PhaseCreateTask, "Warning", task, apicommon.PhaseStateFailed, fmt.Sprintf("could not create PipelineRun: %s ", task.Name), "")
+		return err
+	}
+	task.Status.PipelineRunName = pipelineRun.GetName()
+	return nil
+}
+
+func (e *tektonExecutor) UpdateStatus(ctx context.Context, task *klcv1beta1.KeptnTask) error {
+	pipelineRun := &unstructured.Unstructured{}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	err := e.r.Client.Get(ctx, types.NamespacedName{Name: task.Status.PipelineRunName, Namespace: task.Namespace}, pipelineRun)
+	if err != nil {
+		return err
+	}
+	updateTaskStatusFromPipelineRun(pipelineRun, task)
+	return nil
+}
+
+func (e *tektonExecutor) generatePipelineRun(task *klcv1beta1.KeptnTask, definition *klcv1beta1.KeptnTaskDefinition) (*unstructured.Unstructured, error) {
+	params, err := getPipelineRunParams(task, definition.Spec.Tekton, definition.Spec.TemplateParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{
+		"params": params,
+	}
+	if definition.Spec.Tekton.PipelineRef != "" {
+		spec["pipelineRef"] = map[string]interface{}{"name": definition.Spec.Tekton.PipelineRef}
+	} else {
+		// the Tekton Task is wrapped into a Pipeline which only consists of this Task
+		pipelineTask := map[string]interface{}{
+			"name":    TektonPipelineTaskName,
+			"taskRef": map[string]interface{}{"name": definition.Spec.Tekton.TaskRef},
+			"params":  params,
+		}
+		if task.Spec.Retries != nil {
+			pipelineTask["retries"] = int64(*task.Spec.Retries)
+		}
+		spec["pipelineSpec"] = map[string]interface{}{
+			"tasks": []interface{}{pipelineTask},
+		}
+	}
+	if serviceAccount := definition.GetServiceAccount(); serviceAccount != "" {
+		spec["taskRunTemplate"] = map[string]interface{}{"serviceAccountName": serviceAccount}
+	}
+	if task.Spec.Timeout.Duration > 0 {
+		spec["timeouts"] = map[string]interface{}{"pipeline": task.Spec.Timeout.Duration.String()}
+	}
+
+	pipelineRun := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	pipelineRun.SetName(apicommon.GenerateJobName(task.Name))
+	pipelineRun.SetNamespace(task.Namespace)
+	pipelineRun.SetLabels(task.Labels)
+	pipelineRun.SetAnnotations(task.CreateKeptnAnnotations())
+
+	err = controllerutil.SetControllerReference(task, pipelineRun, e.r.Scheme)
+	if err != nil {
+		e.r.Log.Error(err, "could not set controller reference:")
+	}
+	return pipelineRun, nil
+}
+
+// getPipelineRunParams merges the params of the Tekton spec with the parameters of the KeptnTask,
+// renders them as templates if enabled and adds the context of the KeptnTask as JSON encoded KEPTN_CONTEXT param
+func getPipelineRunParams(task *klcv1beta1.KeptnTask, spec *klcv1beta1.TektonSpec, templateParameters bool) ([]interface{}, error) {
+	values := map[string]string{}
+	for key, value := range spec.Params {
+		values[key] = value
+	}
+	for key, value := range task.Spec.Parameters.Inline {
+		values[key] = value
+	}
+
+	rendered := values
+	if templateParameters {
+		var err error
+		rendered, err = renderTemplates(values, taskTemplateData{Context: task.Spec.Context, Parameters: values})
+		if err != nil {
+			return nil, err
+		}
+	}
+	keptnContext, err := json.Marshal(task.Spec.Context)
+	if err != nil {
+		return nil, err
+	}
+	rendered[KeptnContextEnvVar] = string(keptnContext)
+
+	keys := make([]string, 0, len(rendered))
+	for key := range rendered {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, map[string]interface{}{"name": key, "value": rendered[key]})
+	}
+	return params, nil
+}
+
+// updateTaskStatusFromPipelineRun maps the Succeeded condition, the start and completion time
+// and the results of a PipelineRun onto the status of the KeptnTask
+func updateTaskStatusFromPipelineRun(pipelineRun *unstructured.Unstructured, task *klcv1beta1.KeptnTask) {
+	if startTime, ok := getPipelineRunTime(pipelineRun, "startTime"); ok {
+		task.Status.StartTime = startTime
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Succeeded" {
+			continue
+		}
+		switch condition["status"] {
+		case "True":
+			task.Status.Status = apicommon.StateSucceeded
+		case "False":
+			task.Status.Status = apicommon.StateFailed
+			task.Status.Reason, _, _ = unstructured.NestedString(condition, "reason")
+			task.Status.Message, _, _ = unstructured.NestedString(condition, "message")
+		}
+	}
+	if !task.Status.Status.IsCompleted() {
+		return
+	}
+
+	if completionTime, ok := getPipelineRunTime(pipelineRun, "completionTime"); ok {
+		task.Status.EndTime = completionTime
+	}
+
+	results, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", "results")
+	for _, r := range results {
+		result, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(result, "name")
+		// only string results can be used as outputs of the KeptnTask
+		value, isString := result["value"].(string)
+		if name == "" || !isString {
+			continue
+		}
+		if task.Status.Outputs == nil {
+			task.Status.Outputs = map[string]string{}
+		}
+		task.Status.Outputs[name] = value
+	}
+}
+
+func getPipelineRunTime(pipelineRun *unstructured.Unstructured, field string) (metav1.Time, bool) {
+	value, found, err := unstructured.NestedString(pipelineRun.Object, "status", field)
+	if err != nil || !found {
+		return metav1.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return metav1.Time{}, false
+	}
+	return metav1.NewTime(t), true
+}
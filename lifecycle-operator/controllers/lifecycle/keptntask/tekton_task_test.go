@@ -0,0 +1,217 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnTaskReconciler_createJob_withTektonSpec(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-tekton-task-definition"
+
+	retries := int32(2)
+	taskDefinition := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskDefinitionName,
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Tekton: &klcv1beta1.TektonSpec{
+				TaskRef: "smoke-test",
+				Params: map[string]string{
+					"target": "{{ .Context.WorkloadName }}",
+					"suite":  "default",
+				},
+			},
+			ServiceAccount:     &klcv1beta1.ServiceAccountSpec{Name: "tekton"},
+			TemplateParameters: true,
+		},
+	}
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.Spec.Parameters.Inline = map[string]string{"suite": "full"}
+	task.Spec.Retries = &retries
+	task.Spec.Timeout = metav1.Duration{Duration: 5 * time.Minute}
+
+	err := r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+
+	require.Empty(t, task.Status.JobName)
+	require.NotEmpty(t, task.Status.PipelineRunName)
+	require.Equal(t, apicommon.StatePending, task.Status.Status)
+
+	pipelineRun := &unstructured.Unstructured{}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: task.Status.PipelineRunName}, pipelineRun)
+	require.Nil(t, err)
+
+	require.Equal(t, map[string]string{"label1": "label2"}, pipelineRun.GetLabels())
+	require.NotEmpty(t, pipelineRun.GetOwnerReferences())
+
+	params, _, err := unstructured.NestedSlice(pipelineRun.Object, "spec", "params")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":  "KEPTN_CONTEXT",
+			"value": `{"workloadName":"my-workload","appName":"my-app","appVersion":"0.1.0","workloadVersion":"","taskType":"post","objectType":"Workload"}`,
+		},
+		map[string]interface{}{"name": "suite", "value": "full"},
+		map[string]interface{}{"name": "target", "value": "my-workload"},
+	}, params)
+
+	pipelineTasks, _, err := unstructured.NestedSlice(pipelineRun.Object, "spec", "pipelineSpec", "tasks")
+	require.Nil(t, err)
+	require.Len(t, pipelineTasks, 1)
+	pipelineTask := pipelineTasks[0].(map[string]interface{})
+	require.Equal(t, TektonPipelineTaskName, pipelineTask["name"])
+	require.Equal(t, map[string]interface{}{"name": "smoke-test"}, pipelineTask["taskRef"])
+	require.Equal(t, params, pipelineTask["params"])
+	require.Equal(t, int64(2), pipelineTask["retries"])
+
+	serviceAccount, _, _ := unstructured.NestedString(pipelineRun.Object, "spec", "taskRunTemplate", "serviceAccountName")
+	require.Equal(t, "tekton", serviceAccount)
+	timeout, _, _ := unstructured.NestedString(pipelineRun.Object, "spec", "timeouts", "pipeline")
+	require.Equal(t, "5m0s", timeout)
+}
+
+func TestKeptnTaskReconciler_createJob_withTektonPipelineRef(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-tekton-pipeline-definition"
+
+	taskDefinition := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskDefinitionName,
+			Namespace: namespace,
+		},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Tekton: &klcv1beta1.TektonSpec{PipelineRef: "smoke-tests"},
+		},
+	}
+	fakeClient := testcommon.NewTestClient(taskDefinition)
+
+	r := &KeptnTaskReconciler{
+		Client:      fakeClient,
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+		Log:         ctrl.Log.WithName("task-controller"),
+		Scheme:      fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+
+	err := r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+
+	pipelineRun := &unstructured.Unstructured{}
+	pipelineRun.SetGroupVersionKind(pipelineRunGVK)
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: task.Status.PipelineRunName}, pipelineRun)
+	require.Nil(t, err)
+
+	pipelineRef, _, _ := unstructured.NestedString(pipelineRun.Object, "spec", "pipelineRef", "name")
+	require.Equal(t, "smoke-tests", pipelineRef)
+	_, found, _ := unstructured.NestedMap(pipelineRun.Object, "spec", "pipelineSpec")
+	require.False(t, found)
+
+	// the status of the KeptnTask is mapped from the PipelineRun
+	task.Status.Status = apicommon.StateProgressing
+	err = r.getTaskExecutor(task, nil).UpdateStatus(context.TODO(), task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+}
+
+func Test_updateTaskStatusFromPipelineRun(t *testing.T) {
+	startTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	completionTime := startTime.Add(2 * time.Minute)
+
+	tests := []struct {
+		name        string
+		status      map[string]interface{}
+		wantStatus  apicommon.KeptnState
+		wantReason  string
+		wantMessage string
+		wantEndTime metav1.Time
+		wantOutputs map[string]string
+	}{
+		{
+			name: "running",
+			status: map[string]interface{}{
+				"startTime": startTime.Format(time.RFC3339),
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Succeeded", "status": "Unknown", "reason": "Running"},
+				},
+			},
+			wantStatus: apicommon.StateProgressing,
+		},
+		{
+			name: "succeeded",
+			status: map[string]interface{}{
+				"startTime":      startTime.Format(time.RFC3339),
+				"completionTime": completionTime.Format(time.RFC3339),
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Succeeded", "status": "True", "reason": "Succeeded"},
+				},
+				"results": []interface{}{
+					map[string]interface{}{"name": "reportUrl", "value": "https://example.com/report"},
+					map[string]interface{}{"name": "files", "value": []interface{}{"a", "b"}},
+				},
+			},
+			wantStatus:  apicommon.StateSucceeded,
+			wantEndTime: metav1.NewTime(completionTime),
+			wantOutputs: map[string]string{"reportUrl": "https://example.com/report"},
+		},
+		{
+			name: "failed",
+			status: map[string]interface{}{
+				"startTime":      startTime.Format(time.RFC3339),
+				"completionTime": completionTime.Format(time.RFC3339),
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":    "Succeeded",
+						"status":  "False",
+						"reason":  "Failed",
+						"message": "Tasks Completed: 1 (Failed: 1, Cancelled 0), Skipped: 0",
+					},
+				},
+			},
+			wantStatus:  apicommon.StateFailed,
+			wantReason:  "Failed",
+			wantMessage: "Tasks Completed: 1 (Failed: 1, Cancelled 0), Skipped: 0",
+			wantEndTime: metav1.NewTime(completionTime),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineRun := &unstructured.Unstructured{Object: map[string]interface{}{"status": tt.status}}
+			task := makeTask("my-task", "default", "my-tekton-task-definition")
+			task.Status.Status = apicommon.StateProgressing
+
+			updateTaskStatusFromPipelineRun(pipelineRun, task)
+
+			require.Equal(t, tt.wantStatus, task.Status.Status)
+			require.Equal(t, tt.wantReason, task.Status.Reason)
+			require.Equal(t, tt.wantMessage, task.Status.Message)
+			require.True(t, startTime.Equal(task.Status.StartTime.Time))
+			require.True(t, tt.wantEndTime.Equal(&task.Status.EndTime))
+			require.Equal(t, tt.wantOutputs, task.Status.Outputs)
+		})
+	}
+}
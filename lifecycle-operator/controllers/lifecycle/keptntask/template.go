@@ -0,0 +1,69 @@
+package keptntask
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+)
+
+// taskTemplateData contains the values that can be referenced in the templates
+// of the parameters of a KeptnTask and of the HTTP spec of a KeptnTaskDefinition
+type taskTemplateData struct {
+	Context    klcv1beta1.TaskContext
+	Parameters map[string]string
+}
+
+func renderTemplate(name string, text string, data taskTemplateData) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderParameters renders the parameter values and the command line parameters
+// of a runtime as Go templates, using the context of the KeptnTask they are passed to
+func renderParameters(params *RuntimeExecutionParams) error {
+	data := taskTemplateData{
+		Context:    params.Context,
+		Parameters: params.Parameters,
+	}
+
+	rendered, err := renderTemplates(params.Parameters, data)
+	if err != nil {
+		return err
+	}
+	if len(rendered) > 0 {
+		params.Parameters = rendered
+	}
+
+	cmdParameters, err := renderTemplate("cmdParameters", params.CmdParameters, data)
+	if err != nil {
+		return fmt.Errorf("%w: cmdParameters: %w", controllererrors.ErrInvalidTaskTemplate, err)
+	}
+	params.CmdParameters = cmdParameters
+	return nil
+}
+
+// renderTemplates renders each of the given values as a Go template and returns the results in a new map
+func renderTemplates(values map[string]string, data taskTemplateData) (map[string]string, error) {
+	rendered := make(map[string]string, len(values))
+	for key, value := range values {
+		result, err := renderTemplate(key, value, data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parameter %s: %w", controllererrors.ErrInvalidTaskTemplate, key, err)
+		}
+		rendered[key] = result
+	}
+	return rendered, nil
+}
@@ -76,7 +76,11 @@ func (r *KeptnTaskDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.
 		// generate the updated config map, this is either the existing config map or the inline one
 		functionCm := cm
 		if taskdefinition.IsInline(defSpec) {
-			functionCm = r.generateConfigMap(defSpec, cmName, definition.Namespace)
+			functionCm, err = r.generateConfigMap(defSpec, cmName, definition.Namespace, taskdefinition.IsWasm(definition))
+			if err != nil {
+				r.Log.Error(err, "could not generate ConfigMap for: "+definition.Name)
+				return ctrl.Result{}, nil
+			}
 		}
 		// compare and handle updated and existing
 		r.reconcileConfigMap(ctx, functionCm, cm)
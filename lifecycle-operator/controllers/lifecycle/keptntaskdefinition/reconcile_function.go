@@ -2,6 +2,7 @@ package keptntaskdefinition
 
 import (
 	"context"
+	"encoding/base64"
 	"reflect"
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
@@ -12,18 +13,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func (r *KeptnTaskDefinitionReconciler) generateConfigMap(spec *klcv1beta1.RuntimeSpec, name string, namespace string) *corev1.ConfigMap {
+func (r *KeptnTaskDefinitionReconciler) generateConfigMap(spec *klcv1beta1.RuntimeSpec, name string, namespace string, binary bool) (*corev1.ConfigMap, error) {
 
 	functionCm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
-		Data: map[string]string{
-			"code": spec.Inline.Code,
-		},
 	}
-	return functionCm
+	if binary {
+		// binary code, such as WebAssembly modules, is provided as base64 encoded string
+		code, err := base64.StdEncoding.DecodeString(spec.Inline.Code)
+		if err != nil {
+			return nil, err
+		}
+		functionCm.BinaryData = map[string][]byte{
+			"code": code,
+		}
+		return functionCm, nil
+	}
+	functionCm.Data = map[string]string{
+		"code": spec.Inline.Code,
+	}
+	return functionCm, nil
 }
 
 func (r *KeptnTaskDefinitionReconciler) reconcileConfigMap(ctx context.Context, functionCm *corev1.ConfigMap, cm *corev1.ConfigMap) {
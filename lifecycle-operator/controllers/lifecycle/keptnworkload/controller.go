@@ -162,9 +162,17 @@ func (r *KeptnWorkloadReconciler) createWorkloadVersion(ctx context.Context, wor
 }
 
 func generateWorkloadVersion(previousVersion string, traceContextCarrier map[string]string, w *klcv1beta1.KeptnWorkload) klcv1beta1.KeptnWorkloadVersion {
+	annotations := make(map[string]string, len(traceContextCarrier)+1)
+	for key, value := range traceContextCarrier {
+		annotations[key] = value
+	}
+	// the override of freeze windows is passed on from the KeptnWorkload
+	if reason, ok := w.Annotations[common.FreezeOverrideAnnotation]; ok {
+		annotations[common.FreezeOverrideAnnotation] = reason
+	}
 	return klcv1beta1.KeptnWorkloadVersion{
 		ObjectMeta: metav1.ObjectMeta{
-			Annotations: traceContextCarrier,
+			Annotations: annotations,
 			Name:        operatorcommon.CreateResourceName(common.MaxK8sObjectLength, common.MinKeptnNameLen, w.Name, w.Spec.Version),
 			Namespace:   w.Namespace,
 		},
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
 	"github.com/stretchr/testify/require"
@@ -301,6 +302,10 @@ func TestKeptnWorkload(t *testing.T) {
 			PreviousVersion: "prev",
 		},
 	}, workloadVersion)
+
+	workload.Annotations = map[string]string{apicommon.FreezeOverrideAnnotation: "hotfix"}
+	workloadVersion = generateWorkloadVersion("prev", map[string]string{}, workload)
+	require.Equal(t, map[string]string{apicommon.FreezeOverrideAnnotation: "hotfix"}, workloadVersion.Annotations)
 }
 
 func setupReconciler(objs ...client.Object) (*KeptnWorkloadReconciler, chan string) {
@@ -0,0 +1,100 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keptnworkloadkind
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// KeptnWorkloadKindReconciler reconciles a KeptnWorkloadKind object
+type KeptnWorkloadKindReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	registry workloadkind.IRegistry
+}
+
+func NewReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, registry workloadkind.IRegistry) *KeptnWorkloadKindReconciler {
+	return &KeptnWorkloadKindReconciler{
+		Client:   client,
+		Scheme:   scheme,
+		Log:      log,
+		registry: registry,
+	}
+}
+
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnworkloadkinds,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnworkloadkinds/status,verbs=get;update;patch
+
+// Reconcile compiles the expressions of a KeptnWorkloadKind and makes them available
+// to the pod mutator and the KeptnWorkloadVersion controller.
+func (r *KeptnWorkloadKindReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info("Searching for KeptnWorkloadKind", "name", req.Name)
+
+	workloadKind := &klcv1beta1.KeptnWorkloadKind{}
+	err := r.Get(ctx, req.NamespacedName, workloadKind)
+	if errors.IsNotFound(err) {
+		r.registry.Delete(req.Name)
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf(controllererrors.ErrCannotRetrieveWorkloadKindMsg, err)
+	}
+
+	rules, err := workloadkind.NewRules(workloadKind)
+	if err != nil {
+		r.Log.Error(err, "KeptnWorkloadKind contains invalid expressions", "name", req.Name)
+		r.registry.Delete(req.Name)
+		return ctrl.Result{}, r.updateStatus(ctx, workloadKind, false, err.Error())
+	}
+
+	r.registry.Set(req.Name, rules)
+	return ctrl.Result{}, r.updateStatus(ctx, workloadKind, true, "")
+}
+
+// updateStatus updates the status of the KeptnWorkloadKind only if it changed, since every replica
+// of the lifecycle operator reconciles the same KeptnWorkloadKinds
+func (r *KeptnWorkloadKindReconciler) updateStatus(ctx context.Context, workloadKind *klcv1beta1.KeptnWorkloadKind, ready bool, message string) error {
+	if workloadKind.Status.Ready == ready && workloadKind.Status.Message == message {
+		return nil
+	}
+	workloadKind.Status.Ready = ready
+	workloadKind.Status.Message = message
+	return r.Status().Update(ctx, workloadKind)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The controller does not need leader election, since the registry has to be filled on every replica
+// of the lifecycle operator, as the pod mutating webhook is served by all of them.
+func (r *KeptnWorkloadKindReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	needLeaderElection := false
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&klcv1beta1.KeptnWorkloadKind{}).
+		WithOptions(controller.Options{NeedLeaderElection: &needLeaderElection}).
+		Complete(r)
+}
@@ -0,0 +1,96 @@
+package keptnworkloadkind
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestKeptnWorkloadKindReconciler_Reconcile(t *testing.T) {
+	workloadKind := &klcv1beta1.KeptnWorkloadKind{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "virtualmachineinstance",
+		},
+		Spec: klcv1beta1.KeptnWorkloadKindSpec{
+			APIVersion: "kubevirt.io/v1",
+			Kind:       "VirtualMachineInstance",
+			Running:    "has(self.status) && self.status.phase == 'Running'",
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(workloadKind)
+	registry := workloadkind.NewRegistry()
+	r := NewReconciler(fakeClient, fakeClient.Scheme(), testr.New(t), registry)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: workloadKind.Name}}
+	_, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	_, ok := registry.Get("VirtualMachineInstance")
+	require.True(t, ok)
+
+	result := &klcv1beta1.KeptnWorkloadKind{}
+	err = fakeClient.Get(context.TODO(), req.NamespacedName, result)
+	require.Nil(t, err)
+	require.True(t, result.Status.Ready)
+	require.Empty(t, result.Status.Message)
+
+	// an unchanged status is not updated again
+	_, err = r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	unchanged := &klcv1beta1.KeptnWorkloadKind{}
+	err = fakeClient.Get(context.TODO(), req.NamespacedName, unchanged)
+	require.Nil(t, err)
+	require.Equal(t, result.ResourceVersion, unchanged.ResourceVersion)
+
+	// the rules are removed as soon as the KeptnWorkloadKind is deleted
+	err = fakeClient.Delete(context.TODO(), result)
+	require.Nil(t, err)
+
+	_, err = r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	_, ok = registry.Get("VirtualMachineInstance")
+	require.False(t, ok)
+}
+
+func TestKeptnWorkloadKindReconciler_ReconcileInvalidExpression(t *testing.T) {
+	workloadKind := &klcv1beta1.KeptnWorkloadKind{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "virtualmachineinstance",
+		},
+		Spec: klcv1beta1.KeptnWorkloadKindSpec{
+			APIVersion: "kubevirt.io/v1",
+			Kind:       "VirtualMachineInstance",
+			Running:    "self.status.phase ==",
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(workloadKind)
+	registry := workloadkind.NewRegistry()
+	registry.Set(workloadKind.Name, &workloadkind.Rules{})
+	r := NewReconciler(fakeClient, fakeClient.Scheme(), testr.New(t), registry)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: workloadKind.Name}}
+	_, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	// previously valid rules are removed
+	_, ok := registry.Get("VirtualMachineInstance")
+	require.False(t, ok)
+
+	result := &klcv1beta1.KeptnWorkloadKind{}
+	err = fakeClient.Get(context.TODO(), req.NamespacedName, result)
+	require.Nil(t, err)
+	require.False(t, result.Status.Ready)
+	require.Contains(t, result.Status.Message, "running")
+}
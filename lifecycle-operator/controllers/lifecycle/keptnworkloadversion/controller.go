@@ -32,6 +32,7 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
@@ -50,20 +51,23 @@ import (
 const (
 	traceComponentName        = "keptn/lifecycle-operator/workloadversion"
 	resourceReferenceUIDField = ".spec.resourceReference.uid"
+	resourceReferencePodField = ".spec.resourceReference.pod"
 )
 
 // KeptnWorkloadVersionReconciler reconciles a KeptnWorkloadVersion object
 type KeptnWorkloadVersionReconciler struct {
 	client.Client
-	Scheme            *runtime.Scheme
-	EventSender       eventsender.IEvent
-	Log               logr.Logger
-	Meters            apicommon.KeptnMeters
-	SpanHandler       telemetry.ISpanHandler
-	TracerFactory     telemetry.TracerFactory
-	EvaluationHandler evaluation.IEvaluationHandler
-	PhaseHandler      phase.IHandler
-	Config            config.IConfig
+	Scheme                *runtime.Scheme
+	EventSender           eventsender.IEvent
+	Log                   logr.Logger
+	Meters                apicommon.KeptnMeters
+	SpanHandler           telemetry.ISpanHandler
+	TracerFactory         telemetry.TracerFactory
+	EvaluationHandler     evaluation.IEvaluationHandler
+	PhaseHandler          phase.IHandler
+	Config                config.IConfig
+	WorkloadKinds         workloadkind.IRegistry
+	PromotionTasksEnabled bool
 }
 
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnworkloadversions,verbs=get;list;watch;create;update;patch;delete
@@ -72,10 +76,15 @@ type KeptnWorkloadVersionReconciler struct {
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks/finalizers,verbs=update
+// +kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnfreezewindows,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=analyses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=metrics.keptn.sh,resources=analysisdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;watch;patch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=apps,resources=replicasets;deployments;statefulsets;daemonsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -127,27 +136,32 @@ func (r *KeptnWorkloadVersionReconciler) Reconcile(ctx context.Context, req ctrl
 
 	// Wait for pre-deployment checks of Workload
 	if result, err := r.doPreDeploymentTaskPhase(ctx, workloadVersion, ctxWorkloadTrace); !result.Continue {
-		return result.Result, err
+		return r.handlePhaseResult(ctx, ctxWorkloadTrace, workloadVersion, result, err)
 	}
 
 	// Wait for pre-evaluation checks of Workload
 	if result, err := r.doPreDeploymentEvaluationPhase(ctx, workloadVersion, ctxWorkloadTrace); !result.Continue {
-		return result.Result, err
+		return r.handlePhaseResult(ctx, ctxWorkloadTrace, workloadVersion, result, err)
 	}
 
 	// Wait for deployment of Workload
 	if result, err := r.doDeploymentPhase(ctx, workloadVersion, ctxWorkloadTrace); !result.Continue {
-		return result.Result, err
+		return r.handlePhaseResult(ctx, ctxWorkloadTrace, workloadVersion, result, err)
 	}
 
 	// Wait for post-deployment checks of Workload
 	if result, err := r.doPostDeploymentTaskPhase(ctx, workloadVersion, ctxWorkloadTrace); !result.Continue {
-		return result.Result, err
+		return r.handlePhaseResult(ctx, ctxWorkloadTrace, workloadVersion, result, err)
 	}
 
 	// Wait for post-evaluation checks of Workload
 	if result, err := r.doPostDeploymentEvaluationPhase(ctx, workloadVersion, ctxWorkloadTrace); !result.Continue {
-		return result.Result, err
+		return r.handlePhaseResult(ctx, ctxWorkloadTrace, workloadVersion, result, err)
+	}
+
+	// Wait for promotion tasks of Workload
+	if result, err := r.doPromotionPhase(ctx, workloadVersion, ctxWorkloadTrace); !result.Continue {
+		return r.handlePhaseResult(ctx, ctxWorkloadTrace, workloadVersion, result, err)
 	}
 
 	// WorkloadVersion is completed at this place
@@ -244,6 +258,24 @@ func (r *KeptnWorkloadVersionReconciler) doPostDeploymentEvaluationPhase(ctx con
 	}, nil
 }
 
+func (r *KeptnWorkloadVersionReconciler) doPromotionPhase(ctx context.Context, workloadVersion *klcv1beta1.KeptnWorkloadVersion, ctxWorkloadTrace context.Context) (phase.PhaseResult, error) {
+	if r.PromotionTasksEnabled && !workloadVersion.IsPromotionCompleted() {
+		reconcilePromotion := func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+			return r.reconcilePrePostDeployment(ctx, phaseCtx, workloadVersion, apicommon.PromotionCheckType)
+		}
+		return r.PhaseHandler.HandlePhase(ctx,
+			ctxWorkloadTrace,
+			r.getTracer(),
+			workloadVersion,
+			apicommon.PhaseWorkloadPromotion,
+			reconcilePromotion,
+		)
+	}
+	return phase.PhaseResult{
+		Continue: true,
+	}, nil
+}
+
 func (r *KeptnWorkloadVersionReconciler) finishKeptnWorkloadVersionReconcile(ctx context.Context, workloadVersion *klcv1beta1.KeptnWorkloadVersion, spanWorkloadTrace trace.Span) (ctrl.Result, error) {
 	if !workloadVersion.IsEndTimeSet() {
 		workloadVersion.Status.CurrentPhase = apicommon.PhaseCompleted.ShortName
@@ -260,6 +292,11 @@ func (r *KeptnWorkloadVersionReconciler) finishKeptnWorkloadVersionReconcile(ctx
 
 	attrs := workloadVersion.GetMetricsAttributes()
 
+	// metrics: add promotion count
+	if r.PromotionTasksEnabled && workloadVersion.IsPromotionCompleted() && len(workloadVersion.GetPromotionTaskStatus()) > 0 {
+		r.Meters.PromotionCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
 	// metrics: add deployment duration
 	duration := workloadVersion.Status.EndTime.Time.Sub(workloadVersion.Status.StartTime.Time)
 	r.Meters.DeploymentDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
@@ -287,6 +324,11 @@ func (r *KeptnWorkloadVersionReconciler) SetupWithManager(mgr ctrl.Manager) erro
 	}); err != nil {
 		return err
 	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &klcv1beta1.KeptnWorkloadVersion{}, resourceReferencePodField, func(rawObj client.Object) []string {
+		return controllercommon.KeptnWorkloadVersionResourceRefPodIndexFunc(rawObj)
+	}); err != nil {
+		return err
+	}
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		// predicate disabling the auto reconciliation after updating the object status
 		For(&klcv1beta1.KeptnWorkloadVersion{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
@@ -304,7 +346,8 @@ func (r *KeptnWorkloadVersionReconciler) getCompletionFunc(ctx context.Context,
 	workloadVersion.SetStartTime()
 
 	endFunc := func(workloadVersion *klcv1beta1.KeptnWorkloadVersion) {
-		if workloadVersion.IsEndTimeSet() {
+		// the deployment count is increased once the on-failure phase of a failed KeptnWorkloadVersion has been completed
+		if workloadVersion.IsEndTimeSet() && !workloadVersion.IsOnFailureRequired() {
 			r.Log.Info("Increasing deployment count")
 			attrs := workloadVersion.GetMetricsAttributes()
 			r.Meters.DeploymentCount.Add(ctx, 1, metric.WithAttributes(attrs...))
@@ -20,13 +20,17 @@ import (
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
 	telemetryfake "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry/fake"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	"github.com/magiconair/properties/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -201,6 +205,195 @@ func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnsupportedReference
 	require.Equal(t, apicommon.StateUnknown, keptnState)
 }
 
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_Job(t *testing.T) {
+	completions := int32(2)
+	tests := []struct {
+		name string
+		job  *batchv1.Job
+		want apicommon.KeptnState
+	}{
+		{
+			name: "job without completions succeeded once",
+			job:  makeJob("myjob", "default", nil, 1, false),
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "job did not reach completions",
+			job:  makeJob("myjob", "default", &completions, 1, false),
+			want: apicommon.StateProgressing,
+		},
+		{
+			name: "job reached completions",
+			job:  makeJob("myjob", "default", &completions, 2, false),
+			want: apicommon.StateSucceeded,
+		},
+		{
+			name: "job failed",
+			job:  makeJob("myjob", "default", &completions, 1, true),
+			want: apicommon.StateFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workloadVersion := makeWorkloadVersionWithRef(tt.job.ObjectMeta, "Job")
+			fakeClient := testcommon.NewTestClient(tt.job, workloadVersion)
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client: fakeClient,
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnavailableJob(t *testing.T) {
+
+	job := makeJob("myjob", "default", nil, 0, false)
+	workloadVersion := makeWorkloadVersionWithRef(job.ObjectMeta, "Job")
+
+	// do not put the Job into the cluster
+	fakeClient := testcommon.NewTestClient(workloadVersion)
+
+	r := &KeptnWorkloadVersionReconciler{
+		Client: fakeClient,
+	}
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.NotNil(t, err)
+	require.Equal(t, apicommon.StateUnknown, keptnState)
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_Pod(t *testing.T) {
+	tests := []struct {
+		name  string
+		phase corev1.PodPhase
+		ready corev1.ConditionStatus
+		want  apicommon.KeptnState
+	}{
+		{
+			name:  "pod pending",
+			phase: corev1.PodPending,
+			ready: corev1.ConditionFalse,
+			want:  apicommon.StateProgressing,
+		},
+		{
+			name:  "pod running but not ready",
+			phase: corev1.PodRunning,
+			ready: corev1.ConditionFalse,
+			want:  apicommon.StateProgressing,
+		},
+		{
+			name:  "pod running and ready",
+			phase: corev1.PodRunning,
+			ready: corev1.ConditionTrue,
+			want:  apicommon.StateSucceeded,
+		},
+		{
+			name:  "pod succeeded",
+			phase: corev1.PodSucceeded,
+			ready: corev1.ConditionFalse,
+			want:  apicommon.StateSucceeded,
+		},
+		{
+			name:  "pod failed",
+			phase: corev1.PodFailed,
+			ready: corev1.ConditionFalse,
+			want:  apicommon.StateFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := makePod("mypod", "default", tt.phase, tt.ready)
+			workloadVersion := makeWorkloadVersionWithRef(pod.ObjectMeta, "Pod")
+			fakeClient := testcommon.NewTestClient(pod, workloadVersion)
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client: fakeClient,
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_CustomWorkloadKind(t *testing.T) {
+	rules, err := workloadkind.NewRules(&klcv1beta1.KeptnWorkloadKind{
+		Spec: klcv1beta1.KeptnWorkloadKindSpec{
+			APIVersion: "kubevirt.io/v1",
+			Kind:       "VirtualMachineInstance",
+			Running:    "has(self.status) && self.status.phase == 'Running'",
+			Failed:     "has(self.status) && self.status.phase == 'Failed'",
+		},
+	})
+	require.Nil(t, err)
+	registry := workloadkind.NewRegistry()
+	registry.Set("virtualmachineinstance", rules)
+	defer registry.Delete("virtualmachineinstance")
+
+	tests := []struct {
+		name   string
+		status map[string]interface{}
+		want   apicommon.KeptnState
+	}{
+		{
+			name: "workload without status",
+			want: apicommon.StateProgressing,
+		},
+		{
+			name:   "workload running",
+			status: map[string]interface{}{"phase": "Running"},
+			want:   apicommon.StateSucceeded,
+		},
+		{
+			name:   "workload failed",
+			status: map[string]interface{}{"phase": "Failed"},
+			want:   apicommon.StateFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vmi := &unstructured.Unstructured{}
+			vmi.SetAPIVersion("kubevirt.io/v1")
+			vmi.SetKind("VirtualMachineInstance")
+			vmi.SetName("myvmi")
+			vmi.SetNamespace("default")
+			if tt.status != nil {
+				vmi.Object["status"] = tt.status
+			}
+			workloadVersion := makeWorkloadVersionWithRef(metav1.ObjectMeta{Name: "myvmi"}, "VirtualMachineInstance")
+			fakeClient := testcommon.NewTestClient(vmi, workloadVersion)
+
+			r := &KeptnWorkloadVersionReconciler{
+				Client:        fakeClient,
+				WorkloadKinds: registry,
+			}
+
+			keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, keptnState)
+		})
+	}
+}
+
+func TestKeptnWorkloadVersionReconciler_reconcileDeployment_UnregisteredWorkloadKind(t *testing.T) {
+
+	workloadVersion := makeWorkloadVersionWithRef(metav1.ObjectMeta{Name: "myvmi"}, "VirtualMachineInstance")
+	fakeClient := testcommon.NewTestClient(workloadVersion)
+	r := &KeptnWorkloadVersionReconciler{
+		Client:        fakeClient,
+		WorkloadKinds: workloadkind.NewRegistry(),
+	}
+
+	keptnState, err := r.reconcileDeployment(context.TODO(), workloadVersion)
+	require.ErrorIs(t, err, controllererrors.ErrUnsupportedWorkloadVersionResourceReference)
+	require.Equal(t, apicommon.StateUnknown, keptnState)
+}
+
 func makeReplicaSet(name string, namespace string, wanted *int32, available int32) *appsv1.ReplicaSet {
 
 	return &appsv1.ReplicaSet{
@@ -263,6 +456,60 @@ func makeDaemonSet(name string, namespace string, wanted int32, available int32)
 
 }
 
+func makeJob(name string, namespace string, completions *int32, succeeded int32, failed bool) *batchv1.Job {
+
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(name),
+		},
+		Spec: batchv1.JobSpec{
+			Completions: completions,
+		},
+		Status: batchv1.JobStatus{
+			Succeeded: succeeded,
+		},
+	}
+	if failed {
+		job.Status.Conditions = []batchv1.JobCondition{
+			{
+				Type:   batchv1.JobFailed,
+				Status: corev1.ConditionTrue,
+			},
+		}
+	}
+	return job
+
+}
+
+func makePod(name string, namespace string, phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(name),
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodReady,
+					Status: ready,
+				},
+			},
+		},
+	}
+
+}
+
 func Test_getAppVersionForWorkloadVersion(t *testing.T) {
 	now := time.Now()
 	tests := []struct {
@@ -794,6 +1041,75 @@ func TestKeptnWorkloadVersionReconciler_ReconcileReachCompletion(t *testing.T) {
 	require.Equal(t, "test", metadata["testy"])
 }
 
+func TestKeptnWorkloadVersionReconciler_ReconcilePromotionPhase(t *testing.T) {
+
+	testNamespace := "some-ns"
+
+	wi := &klcv1beta1.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-wi",
+			Namespace: testNamespace,
+		},
+		Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+				AppName:        "some-app",
+				Version:        "1.0.0",
+				PromotionTasks: []string{"my-promotion-task"},
+			},
+			WorkloadName: "some-app-some-workload",
+		},
+		Status: klcv1beta1.KeptnWorkloadVersionStatus{
+			DeploymentStatus:               apicommon.StateSucceeded,
+			PreDeploymentStatus:            apicommon.StateSucceeded,
+			PostDeploymentStatus:           apicommon.StateSucceeded,
+			PreDeploymentEvaluationStatus:  apicommon.StateSucceeded,
+			PostDeploymentEvaluationStatus: apicommon.StateSucceeded,
+			PromotionStatus:                apicommon.StatePending,
+			CurrentPhase:                   apicommon.PhaseWorkloadPostEvaluation.ShortName,
+			Status:                         apicommon.StateProgressing,
+		},
+	}
+
+	app := testcommon.ReturnAppVersion(
+		testNamespace,
+		"some-app",
+		"1.0.0",
+		[]klcv1beta1.KeptnWorkloadRef{
+			{
+				Name:    "some-workload",
+				Version: "1.0.0",
+			},
+		},
+		klcv1beta1.KeptnAppVersionStatus{
+			PreDeploymentEvaluationStatus: apicommon.StateSucceeded,
+		},
+	)
+	r, _, _ := setupReconciler(wi, app)
+
+	mockPhaseHandler := &phasefake.MockHandler{HandlePhaseFunc: func(ctx context.Context, ctxTrace context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phaseMoqParam apicommon.KeptnPhaseType, reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)) (phase.PhaseResult, error) {
+		return phase.PhaseResult{Continue: false, Result: ctrl.Result{Requeue: true}}, nil
+	}}
+	r.PhaseHandler = mockPhaseHandler
+
+	r.PromotionTasksEnabled = true
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: testNamespace,
+			Name:      "some-wi",
+		},
+	}
+
+	result, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	// requeue since the promotion phase is not finished yet
+	require.True(t, result.Requeue)
+
+	// verify that the phase handler was invoked for the promotion phase
+	require.Len(t, mockPhaseHandler.HandlePhaseCalls(), 1)
+	require.Equal(t, apicommon.PhaseWorkloadPromotion, mockPhaseHandler.HandlePhaseCalls()[0].PhaseMoqParam)
+}
+
 func TestKeptnWorkloadVersionReconciler_ReconcileFailed(t *testing.T) {
 
 	testNamespace := "some-ns"
@@ -871,6 +1187,105 @@ func TestKeptnWorkloadVersionReconciler_ReconcileFailed(t *testing.T) {
 	require.False(t, result.Requeue)
 }
 
+func TestKeptnWorkloadVersionReconciler_ReconcileOnFailure(t *testing.T) {
+
+	testNamespace := "some-ns"
+
+	wi := &klcv1beta1.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-wi",
+			Namespace: testNamespace,
+		},
+		Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+				AppName:            "some-app",
+				Version:            "1.0.0",
+				PreDeploymentTasks: []string{"task"},
+				OnFailureTasks:     []string{"cleanup"},
+			},
+			WorkloadName: "some-app-some-workload",
+		},
+		Status: klcv1beta1.KeptnWorkloadVersionStatus{
+			DeploymentStatus:               apicommon.StateDeprecated,
+			PreDeploymentStatus:            apicommon.StateFailed,
+			PostDeploymentStatus:           apicommon.StateDeprecated,
+			PreDeploymentEvaluationStatus:  apicommon.StateDeprecated,
+			PostDeploymentEvaluationStatus: apicommon.StateDeprecated,
+			CurrentPhase:                   apicommon.PhaseWorkloadPreDeployment.ShortName,
+			FailedPhase:                    apicommon.PhaseWorkloadPreDeployment.ShortName,
+			Status:                         apicommon.StateFailed,
+			PreDeploymentTaskStatus: []klcv1beta1.ItemStatus{
+				{
+					Name:           "pre-task",
+					DefinitionName: "task",
+					Status:         apicommon.StateFailed,
+				},
+			},
+		},
+	}
+
+	failedTask := &klcv1beta1.KeptnTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pre-task",
+			Namespace: testNamespace,
+		},
+		Status: klcv1beta1.KeptnTaskStatus{
+			Status:  apicommon.StateFailed,
+			Reason:  "BackoffLimitExceeded",
+			Message: "Job has reached the specified backoff limit",
+		},
+	}
+
+	taskDefinition := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cleanup",
+			Namespace: testNamespace,
+		},
+	}
+
+	r, eventChannel, _ := setupReconciler(wi, failedTask, taskDefinition)
+
+	r.PhaseHandler = &phasefake.MockHandler{HandlePhaseFunc: func(ctx context.Context, ctxTrace context.Context, tracer telemetry.ITracer, reconcileObject client.Object, phaseMoqParam apicommon.KeptnPhaseType, reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)) (phase.PhaseResult, error) {
+		return phase.PhaseResult{Continue: false, Result: ctrl.Result{Requeue: false}}, nil
+	}}
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: testNamespace,
+			Name:      "some-wi",
+		},
+	}
+
+	result, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+
+	// requeue until the on-failure task has finished
+	require.True(t, result.Requeue)
+
+	event := <-eventChannel
+	require.True(t, strings.Contains(event, "WorkloadOnFailureTasksStarted"), "no WorkloadOnFailureTasksStarted found in %s", event)
+
+	workloadVersion := &klcv1beta1.KeptnWorkloadVersion{}
+	err = r.Client.Get(context.TODO(), req.NamespacedName, workloadVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, workloadVersion.Status.OnFailureStatus)
+	require.Equal(t, apicommon.StateFailed, workloadVersion.Status.Status)
+	require.Len(t, workloadVersion.Status.OnFailureTaskStatus, 1)
+
+	task := &klcv1beta1.KeptnTask{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: workloadVersion.Status.OnFailureTaskStatus[0].Name}, task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.PhaseWorkloadPreDeployment.ShortName, task.Spec.Context.FailedPhase)
+	require.Equal(t, []klcv1beta1.FailedItem{
+		{
+			Name:           "pre-task",
+			DefinitionName: "task",
+			Reason:         "BackoffLimitExceeded",
+			Message:        "Job has reached the specified backoff limit",
+		},
+	}, task.Spec.Context.FailedItems)
+}
+
 func TestKeptnWorkloadVersionReconciler_ReconcileCouldNotRetrieveObject(t *testing.T) {
 
 	testNamespace := "some-ns"
@@ -1012,6 +1427,9 @@ func setupReconciler(objs ...client.Object) (*KeptnWorkloadVersionReconciler, ch
 			ReconcileEvaluationsFunc: func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, evaluationCreateAttributes evaluation.CreateEvaluationAttributes) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
 				return []klcv1beta1.ItemStatus{}, apicommon.StatusSummary{}, nil
 			},
+			ReconcileAnalysesFunc: func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
+				return []klcv1beta1.ItemStatus{}, apicommon.StatusSummary{}, nil
+			},
 		},
 	}
 	return r, recorder.Events, tr
@@ -1414,3 +1832,46 @@ func TestKeptnWorkloadVersionReconciler_checkPreEvaluationStatusOfAppErrorWhenUp
 	require.NotNil(t, err)
 	require.True(t, requeue)
 }
+
+func TestKeptnWorkloadVersionReconciler_reconcilePrePostEvaluationWithAnalyses(t *testing.T) {
+	wv := &klcv1beta1.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-wv",
+			Namespace: "default",
+		},
+		Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+			KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+				PostDeploymentEvaluations: []string{"eval"},
+				PostDeploymentAnalyses:    []string{"analysis"},
+			},
+		},
+	}
+
+	r, _, _ := setupReconciler(wv)
+
+	mockEvaluationHandler := r.EvaluationHandler.(*evaluationfake.MockEvaluationHandler)
+	mockEvaluationHandler.ReconcileEvaluationsFunc = func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, evaluationCreateAttributes evaluation.CreateEvaluationAttributes) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
+		return []klcv1beta1.ItemStatus{{DefinitionName: "eval", Status: apicommon.StateSucceeded}}, apicommon.StatusSummary{Total: 1, Succeeded: 1}, nil
+	}
+	analysisState := apicommon.StateProgressing
+	mockEvaluationHandler.ReconcileAnalysesFunc = func(ctx context.Context, phaseCtx context.Context, reconcileObject client.Object, checkType apicommon.CheckType) ([]klcv1beta1.ItemStatus, apicommon.StatusSummary, error) {
+		require.Equal(t, apicommon.PostDeploymentEvaluationCheckType, checkType)
+		return []klcv1beta1.ItemStatus{{DefinitionName: "analysis", Name: "post-eval-analysis-12345", Status: analysisState}},
+			apicommon.UpdateStatusSummary(analysisState, apicommon.StatusSummary{Total: 1}), nil
+	}
+
+	state, err := r.reconcilePrePostEvaluation(context.TODO(), context.TODO(), wv, apicommon.PostDeploymentEvaluationCheckType)
+
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, state)
+	require.Equal(t, []klcv1beta1.ItemStatus{{DefinitionName: "analysis", Name: "post-eval-analysis-12345", Status: apicommon.StateProgressing}}, wv.Status.PostDeploymentAnalysisStatus)
+	require.Equal(t, []klcv1beta1.ItemStatus{{DefinitionName: "eval", Status: apicommon.StateSucceeded}}, wv.Status.PostDeploymentEvaluationTaskStatus)
+
+	analysisState = apicommon.StateFailed
+
+	state, err = r.reconcilePrePostEvaluation(context.TODO(), context.TODO(), wv, apicommon.PostDeploymentEvaluationCheckType)
+
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, state)
+	require.Equal(t, apicommon.StateFailed, wv.Status.PostDeploymentEvaluationStatus)
+}
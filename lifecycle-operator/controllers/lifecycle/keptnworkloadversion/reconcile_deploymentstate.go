@@ -8,32 +8,35 @@ import (
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 func (r *KeptnWorkloadVersionReconciler) reconcileDeployment(ctx context.Context, workloadVersion *klcv1beta1.KeptnWorkloadVersion) (apicommon.KeptnState, error) {
-	var isRunning bool
+	var state apicommon.KeptnState
 	var err error
 
 	switch workloadVersion.Spec.ResourceReference.Kind {
 	case "ReplicaSet":
-		isRunning, err = r.isReplicaSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+		state, err = getRunningState(r.isReplicaSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace))
 	case "StatefulSet":
-		isRunning, err = r.isStatefulSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+		state, err = getRunningState(r.isStatefulSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace))
 	case "DaemonSet":
-		isRunning, err = r.isDaemonSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+		state, err = getRunningState(r.isDaemonSetRunning(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace))
+	case "Job":
+		state, err = r.getJobState(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
+	case "Pod":
+		state, err = r.getPodState(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
 	default:
-		isRunning, err = false, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+		state, err = r.getCustomWorkloadState(ctx, workloadVersion.Spec.ResourceReference, workloadVersion.Namespace)
 	}
 
 	if err != nil {
 		return apicommon.StateUnknown, err
 	}
-	if isRunning {
-		workloadVersion.Status.DeploymentStatus = apicommon.StateSucceeded
-	} else {
-		workloadVersion.Status.DeploymentStatus = apicommon.StateProgressing
-	}
+	workloadVersion.Status.DeploymentStatus = state
 
 	err = r.Client.Status().Update(ctx, workloadVersion)
 	if err != nil {
@@ -42,6 +45,16 @@ func (r *KeptnWorkloadVersionReconciler) reconcileDeployment(ctx context.Context
 	return workloadVersion.Status.DeploymentStatus, nil
 }
 
+func getRunningState(isRunning bool, err error) (apicommon.KeptnState, error) {
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	if isRunning {
+		return apicommon.StateSucceeded, nil
+	}
+	return apicommon.StateProgressing, nil
+}
+
 func (r *KeptnWorkloadVersionReconciler) isReplicaSetRunning(ctx context.Context, resource klcv1beta1.ResourceReference, namespace string) (bool, error) {
 	rep := appsv1.ReplicaSet{}
 	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &rep)
@@ -84,3 +97,78 @@ func (r *KeptnWorkloadVersionReconciler) isRolloutRunning(ctx context.Context, r
 	}
 	return rollout.Status.Replicas == rollout.Status.UpdatedReplicas && rollout.Status.Phase == argov1alpha1.RolloutPhaseHealthy, nil
 }
+
+// getJobState considers a Job deployed once the number of successfully completed pods reaches
+// the wanted completions, CronJob-spawned Jobs are handled the same way
+func (r *KeptnWorkloadVersionReconciler) getJobState(ctx context.Context, resource klcv1beta1.ResourceReference, namespace string) (apicommon.KeptnState, error) {
+	job := batchv1.Job{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &job)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return apicommon.StateFailed, nil
+		}
+	}
+
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	if job.Status.Succeeded >= completions {
+		return apicommon.StateSucceeded, nil
+	}
+	return apicommon.StateProgressing, nil
+}
+
+// getPodState considers a bare Pod deployed once it is running and ready or has completed successfully
+func (r *KeptnWorkloadVersionReconciler) getPodState(ctx context.Context, resource klcv1beta1.ResourceReference, namespace string) (apicommon.KeptnState, error) {
+	pod := corev1.Pod{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, &pod)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return apicommon.StateSucceeded, nil
+	case corev1.PodFailed:
+		return apicommon.StateFailed, nil
+	case corev1.PodRunning:
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return apicommon.StateSucceeded, nil
+			}
+		}
+	}
+	return apicommon.StateProgressing, nil
+}
+
+// getCustomWorkloadState evaluates the rules of the KeptnWorkloadKind registered for the kind of the given resource
+func (r *KeptnWorkloadVersionReconciler) getCustomWorkloadState(ctx context.Context, resource klcv1beta1.ResourceReference, namespace string) (apicommon.KeptnState, error) {
+	if r.WorkloadKinds == nil {
+		return apicommon.StateUnknown, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+	rules, ok := r.WorkloadKinds.Get(resource.Kind)
+	if !ok {
+		return apicommon.StateUnknown, controllererrors.ErrUnsupportedWorkloadVersionResourceReference
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(rules.GroupVersionKind)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: namespace}, obj)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	failed, err := rules.IsFailed(obj.Object)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	if failed {
+		return apicommon.StateFailed, nil
+	}
+	return getRunningState(rules.IsRunning(obj.Object))
+}
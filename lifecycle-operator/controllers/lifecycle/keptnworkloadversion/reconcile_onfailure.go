@@ -0,0 +1,103 @@
+package keptnworkloadversion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/task"
+	controllererrors "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/errors"
+	"go.opentelemetry.io/otel/codes"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// handlePhaseResult is invoked whenever a phase of the KeptnWorkloadVersion did not allow to continue with the next phase.
+// If the KeptnWorkloadVersion has failed and onFailureTasks have been configured, the on-failure phase is executed.
+func (r *KeptnWorkloadVersionReconciler) handlePhaseResult(ctx context.Context, ctxWorkloadTrace context.Context, workloadVersion *klcv1beta1.KeptnWorkloadVersion, result phase.PhaseResult, err error) (ctrl.Result, error) {
+	if err != nil || !workloadVersion.IsOnFailureRequired() {
+		return result.Result, err
+	}
+	return r.handleOnFailurePhase(ctx, ctxWorkloadTrace, workloadVersion)
+}
+
+func (r *KeptnWorkloadVersionReconciler) handleOnFailurePhase(ctx context.Context, ctxWorkloadTrace context.Context, workloadVersion *klcv1beta1.KeptnWorkloadVersion) (ctrl.Result, error) {
+	requeueResult := ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}
+	currentPhase := apicommon.PhaseWorkloadOnFailure
+
+	if workloadVersion.Status.CurrentPhase != currentPhase.ShortName {
+		r.EventSender.Emit(currentPhase, "Normal", workloadVersion, apicommon.PhaseStateStarted, "has started", workloadVersion.GetVersion())
+		workloadVersion.Status.CurrentPhase = currentPhase.ShortName
+	}
+
+	spanPhaseCtx, spanPhaseTrace, err := r.SpanHandler.GetSpan(ctxWorkloadTrace, r.getTracer(), workloadVersion, currentPhase.ShortName)
+	if err != nil {
+		r.Log.Error(err, "could not get span")
+	}
+
+	state, err := r.reconcileOnFailure(ctx, spanPhaseCtx, workloadVersion)
+	if err != nil {
+		spanPhaseTrace.AddEvent(currentPhase.LongName + " could not get reconciled")
+		r.EventSender.Emit(currentPhase, "Warning", workloadVersion, apicommon.PhaseStateReconcileError, "could not get reconciled", workloadVersion.GetVersion())
+		return requeueResult, err
+	}
+
+	if !state.IsCompleted() {
+		return requeueResult, nil
+	}
+
+	if state.IsFailed() {
+		spanPhaseTrace.AddEvent(currentPhase.LongName + " has failed")
+		spanPhaseTrace.SetStatus(codes.Error, "Failed")
+		r.EventSender.Emit(currentPhase, "Warning", workloadVersion, apicommon.PhaseStateFailed, "has failed", workloadVersion.GetVersion())
+	} else {
+		spanPhaseTrace.AddEvent(currentPhase.LongName + " has succeeded")
+		spanPhaseTrace.SetStatus(codes.Ok, "Succeeded")
+		r.EventSender.Emit(currentPhase, "Normal", workloadVersion, apicommon.PhaseStateFinished, "has finished", workloadVersion.GetVersion())
+	}
+	spanPhaseTrace.End()
+	if err := r.SpanHandler.UnbindSpan(workloadVersion, currentPhase.ShortName); err != nil {
+		r.Log.Error(err, controllererrors.ErrCouldNotUnbindSpan, workloadVersion.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileOnFailure executes the onFailureTasks of the KeptnWorkloadVersion.
+// The tasks receive the failed phase and its failed items as part of their context.
+func (r *KeptnWorkloadVersionReconciler) reconcileOnFailure(ctx context.Context, phaseCtx context.Context, workloadVersion *klcv1beta1.KeptnWorkloadVersion) (apicommon.KeptnState, error) {
+	taskHandler := task.Handler{
+		Client:      r.Client,
+		EventSender: r.EventSender,
+		Log:         r.Log,
+		Tracer:      r.getTracer(),
+		Scheme:      r.Scheme,
+		SpanHandler: r.SpanHandler,
+	}
+
+	taskCreateAttributes := task.CreateTaskAttributes{
+		SpanName:  fmt.Sprintf(apicommon.CreateWorkloadTaskSpanName, apicommon.OnFailureCheckType),
+		CheckType: apicommon.OnFailureCheckType,
+	}
+
+	newStatus, summary, err := taskHandler.ReconcileTasks(ctx, phaseCtx, workloadVersion, taskCreateAttributes)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	overallState := apicommon.GetOverallState(summary)
+	if !overallState.IsCompleted() {
+		overallState = apicommon.StateProgressing
+	}
+	workloadVersion.Status.OnFailureStatus = overallState
+	workloadVersion.Status.OnFailureTaskStatus = newStatus
+
+	// Write Status Field
+	err = r.Client.Status().Update(ctx, workloadVersion)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+	return workloadVersion.Status.OnFailureStatus, nil
+}
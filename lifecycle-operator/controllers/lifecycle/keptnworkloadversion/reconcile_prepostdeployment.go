@@ -7,10 +7,32 @@ import (
 
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/freezewindow"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/task"
 )
 
 func (r *KeptnWorkloadVersionReconciler) reconcilePrePostDeployment(ctx context.Context, phaseCtx context.Context, workloadVersion *klcv1beta1.KeptnWorkloadVersion, checkType apicommon.CheckType) (apicommon.KeptnState, error) {
+	// deployments are held back before the first pre-deployment task is created while a KeptnFreezeWindow is active
+	if checkType == apicommon.PreDeploymentCheckType && len(workloadVersion.Status.PreDeploymentTaskStatus) == 0 {
+		freezeWindowHandler := freezewindow.Handler{
+			Client:      r.Client,
+			EventSender: r.EventSender,
+			Log:         r.Log,
+		}
+		freezeWindowStatus, err := freezeWindowHandler.ReconcileFreezeWindows(ctx, apicommon.PhaseWorkloadPreDeployment, workloadVersion, workloadVersion.GetAppName(), workloadVersion.Status.FreezeWindow)
+		if err != nil {
+			return apicommon.StateUnknown, err
+		}
+		workloadVersion.Status.FreezeWindow = freezeWindowStatus
+		if freezeWindowStatus.IsBlocking() {
+			workloadVersion.Status.PreDeploymentStatus = apicommon.StatePending
+			if err := r.Client.Status().Update(ctx, workloadVersion); err != nil {
+				return apicommon.StateUnknown, err
+			}
+			return apicommon.StatePending, nil
+		}
+	}
+
 	taskHandler := task.Handler{
 		Client:      r.Client,
 		EventSender: r.EventSender,
@@ -39,6 +61,9 @@ func (r *KeptnWorkloadVersionReconciler) reconcilePrePostDeployment(ctx context.
 	case apicommon.PostDeploymentCheckType:
 		workloadVersion.Status.PostDeploymentStatus = overallState
 		workloadVersion.Status.PostDeploymentTaskStatus = newStatus
+	case apicommon.PromotionCheckType:
+		workloadVersion.Status.PromotionStatus = overallState
+		workloadVersion.Status.PromotionTaskStatus = newStatus
 	}
 
 	// Write Status Field
@@ -21,15 +21,22 @@ func (r *KeptnWorkloadVersionReconciler) reconcilePrePostEvaluation(ctx context.
 		return apicommon.StateUnknown, err
 	}
 
-	overallState := apicommon.GetOverallStateBlockedDeployment(state, r.Config.GetBlockDeployment())
+	newAnalysisStatus, analysisState, err := r.EvaluationHandler.ReconcileAnalyses(ctx, phaseCtx, workloadVersion, checkType)
+	if err != nil {
+		return apicommon.StateUnknown, err
+	}
+
+	overallState := apicommon.GetOverallStateBlockedDeployment(state.Add(analysisState), r.Config.GetBlockDeployment())
 
 	switch checkType {
 	case apicommon.PreDeploymentEvaluationCheckType:
 		workloadVersion.Status.PreDeploymentEvaluationStatus = overallState
 		workloadVersion.Status.PreDeploymentEvaluationTaskStatus = newStatus
+		workloadVersion.Status.PreDeploymentAnalysisStatus = newAnalysisStatus
 	case apicommon.PostDeploymentEvaluationCheckType:
 		workloadVersion.Status.PostDeploymentEvaluationStatus = overallState
 		workloadVersion.Status.PostDeploymentEvaluationTaskStatus = newStatus
+		workloadVersion.Status.PostDeploymentAnalysisStatus = newAnalysisStatus
 	}
 
 	// Write Status Field
@@ -9,6 +9,7 @@ import (
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
@@ -45,34 +46,45 @@ func (r *SchedulingGatesReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, fmt.Errorf("could not retrieve pod, %w", err)
 	}
 
-	// check if the owner of the pod is the one that the KeptnWorkloadVersion is referring to
-	owner := pod.GetOwnerReferences()
-	if len(owner) == 0 {
-		return ctrl.Result{}, nil
-	}
-	listOps := &client.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(".spec.resourceReference.uid", string(owner[0].UID)),
-		Namespace:     pod.GetNamespace(),
-	}
-
-	attachedWorkloadVersions := &klcv1beta1.KeptnWorkloadVersionList{}
-
-	if err := r.List(ctx, attachedWorkloadVersions, listOps); err != nil {
+	attachedWorkloadVersions, err := r.getAttachedWorkloadVersions(ctx, pod)
+	if err != nil {
 		r.Log.Error(err, "Could not list WorkloadVersions related to pod", "pod", pod.GetName(), "namespace", pod.GetNamespace())
 		return ctrl.Result{}, err
 	}
 
-	for _, workloadVersion := range attachedWorkloadVersions.Items {
+	for _, workloadVersion := range attachedWorkloadVersions {
 		if workloadVersion.Status.DeploymentStatus.IsCompleted() || workloadVersion.Status.DeploymentStatus == apicommon.StateProgressing {
-			return r.removeGate(ctx, pod)
+			return r.removeGate(ctx, pod, workloadVersion)
 		}
 	}
 	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 
 }
 
-func (r *SchedulingGatesReconciler) removeGate(ctx context.Context, pod *v1.Pod) (ctrl.Result, error) {
+// getAttachedWorkloadVersions returns the KeptnWorkloadVersions referring to the owner of the pod,
+// or to the pod itself if it has no owner
+func (r *SchedulingGatesReconciler) getAttachedWorkloadVersions(ctx context.Context, pod *v1.Pod) ([]klcv1beta1.KeptnWorkloadVersion, error) {
+	// bare pods are referenced by their name, since their UID is not known when their KeptnWorkload is created
+	selector := fields.OneTermEqualSelector(".spec.resourceReference.pod", pod.GetName())
+	if owner := pod.GetOwnerReferences(); len(owner) != 0 {
+		if owner[0].UID == "" {
+			return nil, nil
+		}
+		selector = fields.OneTermEqualSelector(".spec.resourceReference.uid", string(owner[0].UID))
+	}
+
+	workloadVersionList := &klcv1beta1.KeptnWorkloadVersionList{}
+	if err := r.List(ctx, workloadVersionList, client.InNamespace(pod.GetNamespace()), client.MatchingFieldsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return workloadVersionList.Items, nil
+}
+
+// removeGate removes the scheduling gate of the pod and annotates it with the trace context of the KeptnWorkloadVersion.
+// The environment variables of a pod cannot be changed after its creation, hence only the annotation is updated.
+func (r *SchedulingGatesReconciler) removeGate(ctx context.Context, pod *v1.Pod, workloadVersion klcv1beta1.KeptnWorkloadVersion) (ctrl.Result, error) {
 	pod.Spec.SchedulingGates = nil
+	telemetry.SetTraceContextAnnotations(&pod.ObjectMeta, workloadVersion.GetPhaseTraceContext())
 	if len(pod.Annotations) == 0 {
 		pod.Annotations = make(map[string]string, 1)
 	}
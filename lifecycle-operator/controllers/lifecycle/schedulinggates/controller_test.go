@@ -10,6 +10,7 @@ import (
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -67,7 +68,7 @@ func TestSchedulingGatesReconciler_Reconcile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "no owner references",
+			name: "no owner references - no related WorkloadVersions",
 			objects: []client.Object{
 				&v1.Pod{
 					ObjectMeta: metav1.ObjectMeta{
@@ -87,9 +88,111 @@ func TestSchedulingGatesReconciler_Reconcile(t *testing.T) {
 				ctx: context.TODO(),
 				req: req,
 			},
-			want:    controllerruntime.Result{},
+			want: controllerruntime.Result{
+				RequeueAfter: 10 * time.Second,
+			},
 			wantErr: false,
 		},
+		{
+			name: "no owner references - related WorkloadVersion is completed",
+			objects: []client.Object{
+				&klcv1beta1.KeptnWorkloadVersion{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-wlv",
+						Namespace: "my-namespace",
+					},
+					Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+						KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+							ResourceReference: klcv1beta1.ResourceReference{
+								Kind: "Pod",
+								Name: "my-pod",
+							},
+						},
+					},
+					Status: klcv1beta1.KeptnWorkloadVersionStatus{DeploymentStatus: apicommon.StateSucceeded},
+				},
+				&klcv1beta1.KeptnWorkloadVersion{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-other-wlv",
+						Namespace: "my-namespace",
+					},
+					Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+						KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+							ResourceReference: klcv1beta1.ResourceReference{
+								Kind: "Pod",
+								Name: "my-other-pod",
+							},
+						},
+					},
+				},
+				&v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-pod",
+						Namespace: "my-namespace",
+					},
+					Spec: v1.PodSpec{
+						SchedulingGates: []v1.PodSchedulingGate{
+							{
+								Name: apicommon.KeptnGate,
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: req,
+			},
+			want:               controllerruntime.Result{},
+			wantErr:            false,
+			expectGatesRemoved: true,
+		},
+		{
+			name: "owner reference without UID - WorkloadVersion without UID is not related",
+			objects: []client.Object{
+				&klcv1beta1.KeptnWorkloadVersion{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-wlv",
+						Namespace: "my-namespace",
+					},
+					Spec: klcv1beta1.KeptnWorkloadVersionSpec{
+						KeptnWorkloadSpec: klcv1beta1.KeptnWorkloadSpec{
+							ResourceReference: klcv1beta1.ResourceReference{
+								Kind: "ReplicaSet",
+								Name: "my-replicaset",
+							},
+						},
+					},
+					Status: klcv1beta1.KeptnWorkloadVersionStatus{DeploymentStatus: apicommon.StateSucceeded},
+				},
+				&v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-pod",
+						Namespace: "my-namespace",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Kind: "ReplicaSet",
+								Name: "my-replicaset",
+							},
+						},
+					},
+					Spec: v1.PodSpec{
+						SchedulingGates: []v1.PodSchedulingGate{
+							{
+								Name: apicommon.KeptnGate,
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: req,
+			},
+			want:               controllerruntime.Result{RequeueAfter: 10 * time.Second},
+			wantErr:            false,
+			expectGatesRemoved: false,
+		},
 		{
 			name: "no related WorkloadVersions",
 			objects: []client.Object{
@@ -281,6 +384,9 @@ func TestSchedulingGatesReconciler_Reconcile(t *testing.T) {
 				WithIndex(&klcv1beta1.KeptnWorkloadVersion{}, ".spec.resourceReference.uid", func(object client.Object) []string {
 					return common.KeptnWorkloadVersionResourceRefUIDIndexFunc(object)
 				}).
+				WithIndex(&klcv1beta1.KeptnWorkloadVersion{}, ".spec.resourceReference.pod", func(object client.Object) []string {
+					return common.KeptnWorkloadVersionResourceRefPodIndexFunc(object)
+				}).
 				WithInterceptorFuncs(
 					interceptor.Funcs{
 						List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
@@ -335,3 +441,39 @@ func TestSchedulingGatesReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulingGatesReconciler_removeGateSetsTraceContext(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: "my-namespace",
+		},
+		Spec: v1.PodSpec{
+			SchedulingGates: []v1.PodSchedulingGate{{Name: apicommon.KeptnGate}},
+		},
+	}
+	workloadVersion := klcv1beta1.KeptnWorkloadVersion{
+		Status: klcv1beta1.KeptnWorkloadVersionStatus{
+			PhaseTraceIDs: apicommon.PhaseTraceID{
+				apicommon.PhaseWorkloadDeployment.ShortName: propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			},
+		},
+	}
+	mockClient := k8sfake.NewClientBuilder().WithObjects(pod).Build()
+	r := &SchedulingGatesReconciler{
+		Client: mockClient,
+		Scheme: scheme.Scheme,
+		Log:    controllerruntime.Log.WithName("test-appController"),
+	}
+
+	_, err := r.removeGate(context.TODO(), pod, workloadVersion)
+	require.Nil(t, err)
+
+	resultingPod := &v1.Pod{}
+	require.Nil(t, mockClient.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, resultingPod))
+	require.Empty(t, resultingPod.Spec.SchedulingGates)
+	require.Equal(t, map[string]string{
+		apicommon.SchedulingGateRemoved: "true",
+		apicommon.TraceParentAnnotation: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}, resultingPod.Annotations)
+}
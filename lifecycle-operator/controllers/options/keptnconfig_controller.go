@@ -76,8 +76,10 @@ func (r *KeptnConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// reconcile config values
 	r.config.SetCreationRequestTimeout(time.Duration(cfg.Spec.KeptnAppCreationRequestTimeoutSeconds) * time.Second)
 	r.config.SetCloudEventsEndpoint(cfg.Spec.CloudEventsEndpoint)
+	r.config.SetCloudEventsSinks(cfg.Spec.CloudEventsSinks)
 	r.config.SetBlockDeployment(cfg.Spec.BlockDeployment)
 	r.config.SetObservabilityTimeout(cfg.Spec.ObservabilityTimeout)
+	r.config.SetRetention(cfg.Spec.Retention)
 	result, err := r.reconcileOtelCollectorUrl(cfg)
 	if err != nil {
 		return result, err
@@ -91,7 +93,7 @@ func (r *KeptnConfigReconciler) reconcileOtelCollectorUrl(config *optionsv1alpha
 	r.Log.Info(fmt.Sprintf("reconciling Keptn Config: %s", config.Name))
 	otelConfig := telemetry.GetOtelInstance()
 
-	if err := otelConfig.InitOtelCollector(config.Spec.OTelCollectorUrl); err != nil {
+	if err := otelConfig.InitOtelCollector(config.Spec.OTelCollectorUrl, config.Spec.Tracing); err != nil {
 		r.Log.Error(err, "unable to initialize OTel tracer options")
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, err
 	}
@@ -43,6 +43,8 @@ func TestKeptnConfigReconciler_Reconcile(t *testing.T) {
 		blockDeploymentCalls             int
 		wantObservabilityTimeout         metav1.Duration
 		observabilityTimeoutCalls        int
+		wantRetention                    *optionsv1alpha1.RetentionSpec
+		wantCloudEventsSinks             []optionsv1alpha1.CloudEventsSink
 	}{
 		{
 			name: "test 1",
@@ -201,6 +203,15 @@ func TestKeptnConfigReconciler_Reconcile(t *testing.T) {
 					ObservabilityTimeout: metav1.Duration{
 						Duration: time.Duration(10 * time.Minute),
 					},
+					Retention: &optionsv1alpha1.RetentionSpec{
+						RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+							MaxAge: &metav1.Duration{Duration: 720 * time.Hour},
+						},
+						Interval: metav1.Duration{Duration: time.Hour},
+					},
+					CloudEventsSinks: []optionsv1alpha1.CloudEventsSink{
+						{Name: "ci", Endpoint: "http://ci.example.com", Types: []string{"*.Failed"}},
+					},
 				},
 			},
 			want:                             ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second},
@@ -213,6 +224,15 @@ func TestKeptnConfigReconciler_Reconcile(t *testing.T) {
 			wantObservabilityTimeout: metav1.Duration{
 				Duration: time.Duration(10 * time.Minute),
 			},
+			wantRetention: &optionsv1alpha1.RetentionSpec{
+				RetentionPolicy: optionsv1alpha1.RetentionPolicy{
+					MaxAge: &metav1.Duration{Duration: 720 * time.Hour},
+				},
+				Interval: metav1.Duration{Duration: time.Hour},
+			},
+			wantCloudEventsSinks: []optionsv1alpha1.CloudEventsSink{
+				{Name: "ci", Endpoint: "http://ci.example.com", Types: []string{"*.Failed"}},
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -246,6 +266,14 @@ func TestKeptnConfigReconciler_Reconcile(t *testing.T) {
 			if tt.observabilityTimeoutCalls > 0 {
 				require.Equal(t, tt.wantObservabilityTimeout, mockConfig.SetObservabilityTimeoutCalls()[0].Timeout)
 			}
+			if tt.wantRetention != nil {
+				require.Len(t, mockConfig.SetRetentionCalls(), 1)
+				require.Equal(t, tt.wantRetention, mockConfig.SetRetentionCalls()[0].Retention)
+			}
+			if tt.wantCloudEventsSinks != nil {
+				require.Len(t, mockConfig.SetCloudEventsSinksCalls(), 1)
+				require.Equal(t, tt.wantCloudEventsSinks, mockConfig.SetCloudEventsSinksCalls()[0].Sinks)
+			}
 		})
 	}
 }
@@ -349,6 +377,32 @@ func TestKeptnConfigReconciler_reconcileOtelCollectorUrl(t *testing.T) {
 			want:    ctrl.Result{},
 			wantErr: false,
 		},
+		{
+			name: "Test with tracing configuration",
+			fields: fields{
+				Client: nil,
+				Scheme: nil,
+				Log:    ctrl.Log.WithName("test-keptn-config-controller"),
+			},
+			args: args{
+				config: &optionsv1alpha1.KeptnConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-config",
+					},
+					Spec: optionsv1alpha1.KeptnConfigSpec{
+						Tracing: &optionsv1alpha1.TracingSpec{
+							SamplingRatio: "0.1",
+							Namespaces: []optionsv1alpha1.NamespaceSamplingPolicy{
+								{Namespace: "production", SamplingRatio: "1"},
+							},
+							ResourceAttributes: map[string]string{"k8s.cluster.name": "my-cluster"},
+						},
+					},
+				},
+			},
+			want:    ctrl.Result{},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -386,9 +440,11 @@ func setupReconciler(withConfig *optionsv1alpha1.KeptnConfig) *KeptnConfigReconc
 	)
 	r.config = &fakeconfig.MockConfig{
 		SetCloudEventsEndpointFunc:    func(endpoint string) {},
+		SetCloudEventsSinksFunc:       func(sinks []optionsv1alpha1.CloudEventsSink) {},
 		SetCreationRequestTimeoutFunc: func(value time.Duration) {},
 		SetBlockDeploymentFunc:        func(value bool) {},
 		SetObservabilityTimeoutFunc:   func(timeout metav1.Duration) {},
+		SetRetentionFunc:              func(retention *optionsv1alpha1.RetentionSpec) {},
 	}
 	return r
 }
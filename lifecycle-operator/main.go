@@ -23,6 +23,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
+	_ "time/tzdata" // time zones of KeptnFreezeWindows must be resolvable in minimal images
 
 	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	ce "github.com/cloudevents/sdk-go/v2"
@@ -38,20 +40,27 @@ import (
 	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/options/v1alpha1"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/config"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/evaluation"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventreceiver"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/phase"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/retention"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/dorametrics"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnapp"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnappcreationrequest"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnappversion"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnevaluation"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnpromotionpipeline"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptntask"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptntaskdefinition"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnworkload"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnworkloadkind"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/keptnworkloadversion"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/lifecycle/schedulinggates"
 	controlleroptions "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/options"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/webhooks/pod_mutator"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/webhooks/task_mutator"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	metricsapi "go.opentelemetry.io/otel/metric"
@@ -59,6 +68,7 @@ import (
 	"go.opentelemetry.io/otel/trace/noop"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -99,14 +109,23 @@ type envConfig struct {
 	KeptnTaskDefinitionControllerLogLevel     int `envconfig:"KEPTN_TASK_DEFINITION_CONTROLLER_LOG_LEVEL" default:"0"`
 	KeptnWorkloadControllerLogLevel           int `envconfig:"KEPTN_WORKLOAD_CONTROLLER_LOG_LEVEL" default:"0"`
 	KeptnWorkloadVersionControllerLogLevel    int `envconfig:"KEPTN_WORKLOAD_VERSION_CONTROLLER_LOG_LEVEL" default:"0"`
+	KeptnWorkloadKindControllerLogLevel       int `envconfig:"KEPTN_WORKLOAD_KIND_CONTROLLER_LOG_LEVEL" default:"0"`
 	KeptnSchedulingGatesControllerLogLevel    int `envconfig:"KEPTN_SCHEDULING_GATES_CONTROLLER_LOG_LEVEL" default:"0"`
+	KeptnPromotionPipelineControllerLogLevel  int `envconfig:"KEPTN_PROMOTION_PIPELINE_CONTROLLER_LOG_LEVEL" default:"0"`
 	KeptnDoraMetricsPort                      int `envconfig:"KEPTN_DORA_METRICS_PORT" default:"2222"`
+	KeptnDoraMetricsControllerLogLevel        int `envconfig:"KEPTN_DORA_METRICS_CONTROLLER_LOG_LEVEL" default:"0"`
 	KeptnOptionsControllerLogLevel            int `envconfig:"OPTIONS_CONTROLLER_LOG_LEVEL" default:"0"`
 
+	KeptnDoraMetricsWindow time.Duration `envconfig:"KEPTN_DORA_METRICS_WINDOW" default:"720h"`
+
 	SchedulingGatesEnabled bool `envconfig:"SCHEDULING_GATES_ENABLED" default:"false"`
 	PromotionTasksEnabled  bool `envconfig:"PROMOTION_TASKS_ENABLED" default:"false"`
 
 	CertManagerEnabled bool `envconfig:"CERT_MANAGER_ENABLED" default:"true"`
+
+	CloudEventsReceiverEnabled    bool   `envconfig:"CLOUDEVENTS_RECEIVER_ENABLED" default:"false"`
+	CloudEventsReceiverPort       int    `envconfig:"CLOUDEVENTS_RECEIVER_PORT" default:"8090"`
+	CloudEventsReceiverSecretName string `envconfig:"CLOUDEVENTS_RECEIVER_SECRET_NAME" default:"keptn-cloudevents-receiver"`
 }
 
 const KeptnLifecycleActiveMetric = "keptn_lifecycle_active"
@@ -198,7 +217,7 @@ func main() {
 	}
 
 	// Enabling OTel
-	err = telemetry.GetOtelInstance().InitOtelCollector("")
+	err = telemetry.GetOtelInstance().InitOtelCollector("", nil)
 	if err != nil {
 		setupLog.Error(err, "unable to initialize OTel tracer options")
 	}
@@ -211,6 +230,8 @@ func main() {
 		setupLog.Error(err, "failed to create CloudEvent client")
 		os.Exit(1)
 	}
+	ceDispatcher := eventsender.NewDispatcher(ctrl.Log.WithName("CloudEvent Dispatcher"), ceClient, keptnMeters)
+	notifier := eventsender.NewNotifier(ctrl.Log.WithName("Notifier"), mgr.GetClient())
 
 	taskLogger := ctrl.Log.WithName("KeptnTask Controller").V(env.KeptnTaskControllerLogLevel)
 	taskRecorder := mgr.GetEventRecorderFor("keptntask-controller")
@@ -218,7 +239,7 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         taskLogger,
-		EventSender: eventsender.NewEventMultiplexer(taskLogger, taskRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(taskLogger, taskRecorder, ceDispatcher, notifier),
 		Meters:      keptnMeters,
 	}
 	if err = (taskReconciler).SetupWithManager(mgr); err != nil {
@@ -232,7 +253,7 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         taskDefinitionLogger,
-		EventSender: eventsender.NewEventMultiplexer(taskDefinitionLogger, taskDefinitionRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(taskDefinitionLogger, taskDefinitionRecorder, ceDispatcher, notifier),
 	}
 	if err = (taskDefinitionReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnTaskDefinition")
@@ -245,7 +266,7 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         appLogger,
-		EventSender: eventsender.NewEventMultiplexer(appLogger, appRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(appLogger, appRecorder, ceDispatcher, notifier),
 	}
 	if err = (appReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnApp")
@@ -269,7 +290,7 @@ func main() {
 		Client:        mgr.GetClient(),
 		Scheme:        mgr.GetScheme(),
 		Log:           workloadLogger,
-		EventSender:   eventsender.NewEventMultiplexer(workloadLogger, workloadRecorder, ceClient),
+		EventSender:   eventsender.NewEventMultiplexer(workloadLogger, workloadRecorder, ceDispatcher, notifier),
 		TracerFactory: telemetry.GetOtelInstance(),
 	}
 	if err = (workloadReconciler).SetupWithManager(mgr); err != nil {
@@ -278,7 +299,7 @@ func main() {
 	}
 	workloadVersionLogger := ctrl.Log.WithName("KeptnWorkloadVersion Controller").V(env.KeptnWorkloadVersionControllerLogLevel)
 	workloadVersionRecorder := mgr.GetEventRecorderFor("keptnworkloadversion-controller")
-	workloadVersionEventSender := eventsender.NewEventMultiplexer(workloadVersionLogger, workloadVersionRecorder, ceClient)
+	workloadVersionEventSender := eventsender.NewEventMultiplexer(workloadVersionLogger, workloadVersionRecorder, ceDispatcher, notifier)
 	workloadVersionEvaluationHandler := evaluation.NewHandler(
 		mgr.GetClient(),
 		workloadVersionEventSender,
@@ -294,16 +315,18 @@ func main() {
 		spanHandler,
 	)
 	workloadVersionReconciler := &keptnworkloadversion.KeptnWorkloadVersionReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		Log:               workloadVersionLogger,
-		EventSender:       workloadVersionEventSender,
-		Meters:            keptnMeters,
-		TracerFactory:     telemetry.GetOtelInstance(),
-		SpanHandler:       spanHandler,
-		EvaluationHandler: workloadVersionEvaluationHandler,
-		PhaseHandler:      workloadVersionPhaseHandler,
-		Config:            config.Instance(),
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		Log:                   workloadVersionLogger,
+		EventSender:           workloadVersionEventSender,
+		Meters:                keptnMeters,
+		TracerFactory:         telemetry.GetOtelInstance(),
+		SpanHandler:           spanHandler,
+		EvaluationHandler:     workloadVersionEvaluationHandler,
+		PhaseHandler:          workloadVersionPhaseHandler,
+		Config:                config.Instance(),
+		WorkloadKinds:         workloadkind.Instance(),
+		PromotionTasksEnabled: env.PromotionTasksEnabled,
 	}
 	if err = (workloadVersionReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnWorkloadVersion")
@@ -312,7 +335,7 @@ func main() {
 
 	appVersionLogger := ctrl.Log.WithName("KeptnAppVersion Controller").V(env.KeptnAppVersionControllerLogLevel)
 	appVersionRecorder := mgr.GetEventRecorderFor("keptnappversion-controller")
-	appVersionEventSender := eventsender.NewEventMultiplexer(appVersionLogger, appVersionRecorder, ceClient)
+	appVersionEventSender := eventsender.NewEventMultiplexer(appVersionLogger, appVersionRecorder, ceDispatcher, notifier)
 	appVersionEvaluationHandler := evaluation.NewHandler(
 		mgr.GetClient(),
 		appVersionEventSender,
@@ -351,7 +374,7 @@ func main() {
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		Log:         evaluationLogger,
-		EventSender: eventsender.NewEventMultiplexer(evaluationLogger, evaluationRecorder, ceClient),
+		EventSender: eventsender.NewEventMultiplexer(evaluationLogger, evaluationRecorder, ceDispatcher, notifier),
 		Meters:      keptnMeters,
 	}
 	if err = (evaluationReconciler).SetupWithManager(mgr); err != nil {
@@ -370,6 +393,64 @@ func main() {
 		os.Exit(1)
 	}
 
+	workloadKindLogger := ctrl.Log.WithName("KeptnWorkloadKind Controller").V(env.KeptnWorkloadKindControllerLogLevel)
+	workloadKindReconciler := keptnworkloadkind.NewReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		workloadKindLogger,
+		workloadkind.Instance(),
+	)
+	if err = (workloadKindReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KeptnWorkloadKind")
+		os.Exit(1)
+	}
+
+	promotionPipelineLogger := ctrl.Log.WithName("KeptnPromotionPipeline Controller").V(env.KeptnPromotionPipelineControllerLogLevel)
+	promotionPipelineRecorder := mgr.GetEventRecorderFor("keptnpromotionpipeline-controller")
+	promotionPipelineReconciler := &keptnpromotionpipeline.KeptnPromotionPipelineReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Log:         promotionPipelineLogger,
+		EventSender: eventsender.NewEventMultiplexer(promotionPipelineLogger, promotionPipelineRecorder, ceDispatcher, notifier),
+	}
+	if err = (promotionPipelineReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KeptnPromotionPipeline")
+		os.Exit(1)
+	}
+
+	doraMetricsReconciler := &dorametrics.DORAMetricsReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    ctrl.Log.WithName("DORAMetrics Controller").V(env.KeptnDoraMetricsControllerLogLevel),
+		Window: env.KeptnDoraMetricsWindow,
+	}
+	if err = (doraMetricsReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DORAMetrics")
+		os.Exit(1)
+	}
+
+	pruner := retention.NewPruner(mgr.GetClient(), ctrl.Log.WithName("Retention Pruner"), keptnMeters)
+	if err = mgr.Add(pruner); err != nil {
+		setupLog.Error(err, "unable to add runnable", "runnable", "Retention Pruner")
+		os.Exit(1)
+	}
+
+	if env.CloudEventsReceiverEnabled {
+		receiverLogger := ctrl.Log.WithName("CloudEvents Receiver")
+		receiverRecorder := mgr.GetEventRecorderFor("keptn-cloudevents-receiver")
+		receiver := &eventreceiver.Receiver{
+			Client:      mgr.GetClient(),
+			Log:         receiverLogger,
+			EventSender: eventsender.NewEventMultiplexer(receiverLogger, receiverRecorder, ceDispatcher, notifier),
+			Address:     fmt.Sprintf(":%d", env.CloudEventsReceiverPort),
+			Secret:      types.NamespacedName{Namespace: env.PodNamespace, Name: env.CloudEventsReceiverSecretName},
+		}
+		if err = mgr.Add(receiver); err != nil {
+			setupLog.Error(err, "unable to add runnable", "runnable", "CloudEvents Receiver")
+			os.Exit(1)
+		}
+	}
+
 	schedulingGatesLogger := ctrl.Log.WithName("SchedulingGates Controller").V(env.KeptnSchedulingGatesControllerLogLevel)
 	if env.SchedulingGatesEnabled {
 		schedulingGatesReconciler := &schedulinggates.SchedulingGatesReconciler{
@@ -396,6 +477,26 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnTaskDefinition")
 		os.Exit(1)
 	}
+	if err = (&lifecyclev1beta1.KeptnAppContext{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnAppContext")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1beta1.KeptnWorkload{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnWorkload")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1beta1.KeptnFreezeWindow{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnFreezeWindow")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1beta1.KeptnPromotionPipeline{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnPromotionPipeline")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1beta1.KeptnNotificationConfig{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnNotificationConfig")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	telemetry.SetUpKeptnMeters(meter, mgr.GetClient())
@@ -440,9 +541,17 @@ func main() {
 					eventsender.NewEventMultiplexer(
 						webhookLogger,
 						webhookRecorder,
-						ceClient),
+						ceDispatcher, notifier),
 					webhookLogger,
 					env.SchedulingGatesEnabled,
+					workloadkind.Instance(),
+				),
+			},
+			"/mutate-lifecycle-keptn-sh-v1beta1-keptntask": {
+				Handler: task_mutator.NewTaskMutator(
+					mgr.GetClient(),
+					admission.NewDecoder(mgr.GetScheme()),
+					webhookLogger,
 				),
 			},
 		})
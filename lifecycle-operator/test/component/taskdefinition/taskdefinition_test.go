@@ -83,6 +83,42 @@ var _ = Describe("Taskdefinition", Ordered, func() {
 				common.LogErrorIfPresent(err)
 			})
 
+			It("create binary ConfigMap from inline WebAssembly module", func() {
+				By("Create TaskDefinition")
+				taskDefinition = &klcv1beta1.KeptnTaskDefinition{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      taskDefinitionName,
+						Namespace: namespace,
+					},
+					Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+						Wasm: &klcv1beta1.RuntimeSpec{
+							Inline: klcv1beta1.Inline{
+								Code: "AGFzbQEAAAA=",
+							},
+						},
+					},
+				}
+
+				err := k8sClient.Create(context.TODO(), taskDefinition)
+				Expect(err).To(BeNil())
+
+				By("Check if ConfigMap was created")
+
+				configmap = &v1.ConfigMap{}
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(context.TODO(), types.NamespacedName{
+						Namespace: namespace,
+						Name:      "keptnfn-" + taskDefinitionName,
+					}, configmap)
+					g.Expect(err).To(BeNil())
+					g.Expect(configmap.BinaryData["code"]).To(Equal([]byte("\x00asm\x01\x00\x00\x00")))
+
+				}, "30s").Should(Succeed())
+
+				err = k8sClient.Delete(context.TODO(), configmap)
+				common.LogErrorIfPresent(err)
+			})
+
 			It("TaskDefinition referencing existing Configmap defaulting to Deno", func() {
 				By("Create ConfigMap")
 
@@ -1,12 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
-	"hash/fnv"
 	"strings"
 
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	operatorcommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -59,11 +60,13 @@ func getValuesForAnnotations(objMeta *metav1.ObjectMeta, annotationKey string) [
 	return nil
 }
 
-func GetOwnerReference(resource *metav1.ObjectMeta) metav1.OwnerReference {
+// GetOwnerReference returns the reference of the owner of the given resource that is supported to be considered a KeptnWorkload.
+// Besides the built-in kinds, the kinds registered via KeptnWorkloadKinds in the given registry are supported
+func GetOwnerReference(resource *metav1.ObjectMeta, workloadKinds workloadkind.IRegistry) metav1.OwnerReference {
 	reference := metav1.OwnerReference{}
 	if len(resource.OwnerReferences) != 0 {
 		for _, owner := range resource.OwnerReferences {
-			if apicommon.IsOwnerSupported(owner) {
+			if apicommon.IsOwnerSupported(owner) || (workloadKinds != nil && workloadKinds.IsOwnerSupported(owner)) {
 				reference.UID = owner.UID
 				reference.Kind = owner.Kind
 				reference.Name = owner.Name
@@ -74,6 +77,21 @@ func GetOwnerReference(resource *metav1.ObjectMeta) metav1.OwnerReference {
 	return reference
 }
 
+// GetWorkloadOwnerReference returns the reference of the resource the KeptnWorkload of the given pod is based on.
+// Pods without any owner are considered a workload on their own. Note that the UID of such a pod
+// is not known yet when it is created, hence the returned reference does not contain a UID in this case
+func GetWorkloadOwnerReference(pod *corev1.Pod, workloadKinds workloadkind.IRegistry) metav1.OwnerReference {
+	if len(pod.OwnerReferences) == 0 {
+		return metav1.OwnerReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Name:       pod.Name,
+			UID:        pod.UID,
+		}
+	}
+	return GetOwnerReference(&pod.ObjectMeta, workloadKinds)
+}
+
 func setMapKey(myMap map[string]string, key, value string) {
 	if myMap == nil {
 		return
@@ -95,36 +113,13 @@ func initEmptyAnnotations(meta *metav1.ObjectMeta, size int) {
 }
 
 func getImageVersion(image string) (string, error) {
-	splitImage := strings.Split(image, ":")
-	lenImg := len(splitImage) - 1
-	if lenImg >= 1 && splitImage[lenImg] != "" && splitImage[lenImg] != "latest" {
-		return splitImage[lenImg], nil
-	}
-	return "", fmt.Errorf("Invalid image version")
+	return operatorcommon.GetImageVersion(image)
 }
 
 func calculateVersion(pod *corev1.Pod, containerName string) (string, error) {
-	name := ""
-	containerFound := false
-	for _, item := range pod.Spec.Containers {
-		if item.Name == containerName {
-			containerFound = true
-			version, err := getImageVersion(item.Image)
-			if err == nil {
-				return version, nil
-			}
-		}
-		name = name + item.Name + item.Image
-		for _, e := range item.Env {
-			name = name + e.Name + e.Value
-		}
-	}
-
-	if containerName != "" && !containerFound {
+	version, err := operatorcommon.CalculateVersion(pod.Spec, containerName)
+	if errors.Is(err, operatorcommon.ErrContainerNotFound) {
 		return "", fmt.Errorf("The container name '%s' specified in %s does not match any containers in the pod", containerName, apicommon.ContainerNameAnnotation)
 	}
-
-	h := fnv.New32a()
-	h.Write([]byte(name))
-	return fmt.Sprint(h.Sum32()), nil
+	return version, err
 }
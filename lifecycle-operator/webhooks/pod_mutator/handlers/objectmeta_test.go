@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestGetWorkloadName(t *testing.T) {
@@ -475,13 +477,96 @@ func TestGetOwnerReference(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := GetOwnerReference(&tt.args.resource); !reflect.DeepEqual(got, tt.want) {
+			if got := GetOwnerReference(&tt.args.resource, workloadkind.NewRegistry()); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("getOwnerReference() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestGetOwnerReference_RegisteredWorkloadKind(t *testing.T) {
+	owner := metav1.OwnerReference{
+		APIVersion: "kubevirt.io/v1",
+		Kind:       "VirtualMachineInstance",
+		Name:       "my-vmi",
+		UID:        "the-vmi-uid",
+	}
+	resource := &metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{owner}}
+
+	registry := workloadkind.NewRegistry()
+	require.Equal(t, metav1.OwnerReference{}, GetOwnerReference(resource, registry))
+
+	registry.Set("virtualmachineinstance", &workloadkind.Rules{
+		GroupVersionKind: schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachineInstance"},
+	})
+	require.Equal(t, owner, GetOwnerReference(resource, registry))
+
+	// kinds registered in one registry are not supported by another one
+	require.Equal(t, metav1.OwnerReference{}, GetOwnerReference(resource, workloadkind.NewRegistry()))
+}
+
+func TestGetWorkloadOwnerReference(t *testing.T) {
+	ownerRef := metav1.OwnerReference{
+		UID:  "the-job-uid",
+		Kind: "Job",
+		Name: "some-job",
+	}
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want metav1.OwnerReference
+	}{
+		{
+			name: "pod with supported owner",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "some-pod",
+					UID:             "the-pod-uid",
+					OwnerReferences: []metav1.OwnerReference{ownerRef},
+				},
+			},
+			want: ownerRef,
+		},
+		{
+			name: "pod with unsupported owner",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "some-pod",
+					UID:  "the-pod-uid",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Kind: "SomeNonExistentType",
+							UID:  "the-owner-uid",
+						},
+					},
+				},
+			},
+			want: metav1.OwnerReference{},
+		},
+		{
+			name: "pod without owner",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "some-pod",
+					UID:  "the-pod-uid",
+				},
+			},
+			want: metav1.OwnerReference{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       "some-pod",
+				UID:        "the-pod-uid",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetWorkloadOwnerReference(tt.pod, workloadkind.NewRegistry()))
+		})
+	}
+}
+
 func TestSetMapKey(t *testing.T) {
 	testCases := []struct {
 		testName       string
@@ -3,21 +3,27 @@ package handlers
 import (
 	"context"
 	"log"
+	"strings"
 
 	argov1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 type PodAnnotationHandler struct {
-	Client client.Client
-	Log    logr.Logger
+	Client        client.Client
+	Log           logr.Logger
+	WorkloadKinds workloadkind.IRegistry
 }
 
 func (p *PodAnnotationHandler) IsAnnotated(ctx context.Context, req *admission.Request, pod *corev1.Pod) bool {
@@ -30,7 +36,7 @@ func (p *PodAnnotationHandler) IsAnnotated(ctx context.Context, req *admission.R
 }
 
 func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Context, req *admission.Request, pod *corev1.Pod) bool {
-	podOwner := GetOwnerReference(&pod.ObjectMeta)
+	podOwner := GetOwnerReference(&pod.ObjectMeta, p.WorkloadKinds)
 	if podOwner.UID == "" {
 		return false
 	}
@@ -42,7 +48,7 @@ func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Cont
 			return false
 		}
 
-		rsOwner := GetOwnerReference(&rs.ObjectMeta)
+		rsOwner := GetOwnerReference(&rs.ObjectMeta, p.WorkloadKinds)
 		if rsOwner.UID == "" {
 			return false
 		}
@@ -64,9 +70,105 @@ func (p *PodAnnotationHandler) copyAnnotationsIfParentAnnotated(ctx context.Cont
 		ds := &appsv1.DaemonSet{}
 		objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, ds)
 		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
+	case "Job":
+		job := &batchv1.Job{}
+		objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, job)
+		if copyResourceLabelsIfPresent(objectContainerMetaData, pod) {
+			return true
+		}
+
+		// Jobs spawned by a CronJob inherit the annotations of its job template, otherwise fall back to the CronJob itself
+		jobOwner, ok := getCronJobOwnerReference(&job.ObjectMeta)
+		if !ok {
+			return false
+		}
+		cj := &batchv1.CronJob{}
+		objectContainerMetaData = p.fetchParent(ctx, types.NamespacedName{Name: jobOwner.Name, Namespace: req.Namespace}, cj)
+		return copyResourceLabelsIfPresent(objectContainerMetaData, pod)
 	default:
+		return p.copyAnnotationsFromWorkloadKind(ctx, req, pod, podOwner)
+	}
+}
+
+// getCronJobOwnerReference returns the reference of the CronJob that has spawned the given Job, if any.
+// CronJobs do not own pods directly, hence they are not a supported owner of a KeptnWorkload on their own
+func getCronJobOwnerReference(job *metav1.ObjectMeta) (metav1.OwnerReference, bool) {
+	for _, owner := range job.OwnerReferences {
+		if owner.Kind == "CronJob" && owner.UID != "" {
+			return owner, true
+		}
+	}
+	return metav1.OwnerReference{}, false
+}
+
+// copyAnnotationsFromWorkloadKind copies the annotations of a workload resource whose kind has been registered
+// via a KeptnWorkloadKind, or of the first owner in its owner chain that is annotated
+func (p *PodAnnotationHandler) copyAnnotationsFromWorkloadKind(ctx context.Context, req *admission.Request, pod *corev1.Pod, podOwner metav1.OwnerReference) bool {
+	if p.WorkloadKinds == nil {
+		return false
+	}
+	rules, ok := p.WorkloadKinds.Get(podOwner.Kind)
+	if !ok {
+		return false
+	}
+
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(rules.GroupVersionKind)
+	objectContainerMetaData := p.fetchParent(ctx, types.NamespacedName{Name: podOwner.Name, Namespace: req.Namespace}, workload)
+	if objectContainerMetaData == nil {
 		return false
 	}
+
+	version, err := rules.GetVersion(workload.Object)
+	if err != nil {
+		p.Log.Error(err, "Could not evaluate version of workload", "kind", podOwner.Kind, "name", podOwner.Name)
+	}
+	if copyResourceLabelsIfPresent(withVersion(objectContainerMetaData, version), pod) {
+		return true
+	}
+
+	var current client.Object = workload
+	for _, owner := range rules.OwnerChain {
+		ownerRef, found := findOwner(current.GetOwnerReferences(), owner)
+		if !found {
+			return false
+		}
+		parent := &unstructured.Unstructured{}
+		parent.SetAPIVersion(owner.APIVersion)
+		parent.SetKind(owner.Kind)
+		objectContainerMetaData = p.fetchParent(ctx, types.NamespacedName{Name: ownerRef.Name, Namespace: req.Namespace}, parent)
+		if objectContainerMetaData == nil {
+			return false
+		}
+		if copyResourceLabelsIfPresent(withVersion(objectContainerMetaData, version), pod) {
+			return true
+		}
+		current = parent
+	}
+	return false
+}
+
+func findOwner(ownerRefs []metav1.OwnerReference, owner klcv1beta1.WorkloadKindOwner) (metav1.OwnerReference, bool) {
+	for _, ref := range ownerRefs {
+		if owner.Matches(ref) {
+			return ref, true
+		}
+	}
+	return metav1.OwnerReference{}, false
+}
+
+// withVersion sets the given version on the metadata if it is not annotated with a version already
+func withVersion(meta *metav1.ObjectMeta, version string) *metav1.ObjectMeta {
+	if _, gotVersion := GetLabelOrAnnotation(meta, apicommon.VersionAnnotation, apicommon.K8sRecommendedVersionAnnotations); gotVersion || version == "" {
+		return meta
+	}
+	annotations := make(map[string]string, len(meta.Annotations)+1)
+	for key, value := range meta.Annotations {
+		annotations[key] = value
+	}
+	annotations[apicommon.VersionAnnotation] = version
+	meta.Annotations = annotations
+	return meta
 }
 
 func (p *PodAnnotationHandler) fetchParent(ctx context.Context, name types.NamespacedName, objectContainer client.Object) *metav1.ObjectMeta {
@@ -95,6 +197,12 @@ func copyResourceLabelsIfPresent(sourceResource *metav1.ObjectMeta, targetPod *c
 	postDeploymentChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentTaskAnnotation, "")
 	preEvaluationChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PreDeploymentEvaluationAnnotation, "")
 	postEvaluationChecks, _ = GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentEvaluationAnnotation, "")
+	preDeploymentAnalyses, _ := GetLabelOrAnnotation(sourceResource, apicommon.PreDeploymentAnalysisAnnotation, "")
+	postDeploymentAnalyses, _ := GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentAnalysisAnnotation, "")
+	preDeploymentTaskDependencies, _ := GetLabelOrAnnotation(sourceResource, apicommon.PreDeploymentTaskDependencyAnnotation, "")
+	postDeploymentTaskDependencies, _ := GetLabelOrAnnotation(sourceResource, apicommon.PostDeploymentTaskDependencyAnnotation, "")
+	promotionTasks, _ := GetLabelOrAnnotation(sourceResource, apicommon.PromotionTaskAnnotation, "")
+	onFailureTasks, _ := GetLabelOrAnnotation(sourceResource, apicommon.OnFailureTaskAnnotation, "")
 	containerName, _ := GetLabelOrAnnotation(sourceResource, apicommon.ContainerNameAnnotation, "")
 	metadata, _ := GetLabelOrAnnotation(sourceResource, apicommon.MetadataAnnotation, "")
 
@@ -115,9 +223,23 @@ func copyResourceLabelsIfPresent(sourceResource *metav1.ObjectMeta, targetPod *c
 		setMapKey(targetPod.Annotations, apicommon.AppAnnotation, appName)
 		setMapKey(targetPod.Annotations, apicommon.PreDeploymentTaskAnnotation, preDeploymentChecks)
 		setMapKey(targetPod.Annotations, apicommon.PostDeploymentTaskAnnotation, postDeploymentChecks)
+		setMapKey(targetPod.Annotations, apicommon.PreDeploymentTaskDependencyAnnotation, preDeploymentTaskDependencies)
+		setMapKey(targetPod.Annotations, apicommon.PostDeploymentTaskDependencyAnnotation, postDeploymentTaskDependencies)
 		setMapKey(targetPod.Annotations, apicommon.PreDeploymentEvaluationAnnotation, preEvaluationChecks)
 		setMapKey(targetPod.Annotations, apicommon.PostDeploymentEvaluationAnnotation, postEvaluationChecks)
+		setMapKey(targetPod.Annotations, apicommon.PreDeploymentAnalysisAnnotation, preDeploymentAnalyses)
+		setMapKey(targetPod.Annotations, apicommon.PostDeploymentAnalysisAnnotation, postDeploymentAnalyses)
+		setMapKey(targetPod.Annotations, apicommon.PromotionTaskAnnotation, promotionTasks)
+		setMapKey(targetPod.Annotations, apicommon.OnFailureTaskAnnotation, onFailureTasks)
 		setMapKey(targetPod.Annotations, apicommon.MetadataAnnotation, metadata)
+		for key, value := range sourceResource.Annotations {
+			if strings.HasPrefix(key, apicommon.ExecutionConditionAnnotationPrefix) {
+				setMapKey(targetPod.Annotations, key, value)
+			}
+		}
+		if reason, ok := sourceResource.Annotations[apicommon.FreezeOverrideAnnotation]; ok {
+			targetPod.Annotations[apicommon.FreezeOverrideAnnotation] = reason
+		}
 
 		return true
 	}
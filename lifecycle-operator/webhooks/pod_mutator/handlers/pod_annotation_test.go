@@ -6,13 +6,17 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -100,7 +104,61 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 		},
 	}
 
-	fakeClient := testcommon.NewTestClient(rsWithDpOwner, rsWithNoOwner, testDp, testSts, testDs)
+	testJob := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job",
+			UID:       "this-is-the-job-uid",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: workloadName,
+			},
+		},
+	}
+	testJobWithNoAnnotations := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job-no-annotations",
+			UID:       "this-is-the-job-no-annotations-uid",
+			Namespace: testNamespace,
+		},
+	}
+	testCronJobJob := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob-123",
+			UID:       "this-is-the-cronjob-job-uid",
+			Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "CronJob",
+					Name: "test-cronjob",
+					UID:  "this-is-the-cronjob-uid",
+				},
+			},
+		},
+	}
+	testCj := &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob",
+			UID:       "this-is-the-cronjob-uid",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: workloadName,
+			},
+		},
+	}
+
+	fakeClient := testcommon.NewTestClient(rsWithDpOwner, rsWithNoOwner, testDp, testSts, testDs, testJob, testJobWithNoAnnotations, testCronJobJob, testCj)
 
 	type fields struct {
 		Client client.Client
@@ -222,6 +280,90 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "Test fetching of annotated job owner of pod",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: testJob.Name,
+								UID:  testJob.UID,
+								Kind: testJob.Kind,
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Test fetching of cronjob owner of job owner of pod",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: testCronJobJob.Name,
+								UID:  testCronJobJob.UID,
+								Kind: testCronJobJob.Kind,
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Test fetching of not annotated job owner of pod without cronjob owner",
+			fields: fields{
+				Log:    testr.New(t),
+				Client: fakeClient,
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Namespace: testNamespace,
+					},
+				},
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						UID: uid,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								Name: testJobWithNoAnnotations.Name,
+								UID:  testJobWithNoAnnotations.UID,
+								Kind: testJobWithNoAnnotations.Kind,
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "Test that method returns without doing anything when we get a pod with replicaset without owner",
 			fields: fields{
@@ -265,6 +407,132 @@ func TestCopyAnnotationsIfParentAnnotated(t *testing.T) {
 	}
 }
 
+func TestCopyAnnotationsFromWorkloadKind(t *testing.T) {
+	testNamespace := "test-namespace"
+
+	rules, err := workloadkind.NewRules(&klcv1beta1.KeptnWorkloadKind{
+		Spec: klcv1beta1.KeptnWorkloadKindSpec{
+			APIVersion: "kubevirt.io/v1",
+			Kind:       "VirtualMachineInstance",
+			Running:    "true",
+			Version:    "self.metadata.labels['kubevirt.io/version']",
+			OwnerChain: []klcv1beta1.WorkloadKindOwner{
+				{
+					APIVersion: "kubevirt.io/v1",
+					Kind:       "VirtualMachine",
+				},
+			},
+		},
+	})
+	require.Nil(t, err)
+	registry := workloadkind.NewRegistry()
+	registry.Set("virtualmachineinstance", rules)
+	defer registry.Delete("virtualmachineinstance")
+
+	vmi := &unstructured.Unstructured{}
+	vmi.SetAPIVersion("kubevirt.io/v1")
+	vmi.SetKind("VirtualMachineInstance")
+	vmi.SetName("my-vmi")
+	vmi.SetNamespace(testNamespace)
+	vmi.SetLabels(map[string]string{"kubevirt.io/version": "1.2.0"})
+	vmi.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			APIVersion: "kubevirt.io/v1",
+			Kind:       "VirtualMachine",
+			Name:       "my-vm",
+			UID:        "this-is-the-vm-uid",
+		},
+	})
+
+	vm := &unstructured.Unstructured{}
+	vm.SetAPIVersion("kubevirt.io/v1")
+	vm.SetKind("VirtualMachine")
+	vm.SetName("my-vm")
+	vm.SetNamespace(testNamespace)
+	vm.SetAnnotations(map[string]string{
+		apicommon.WorkloadAnnotation: workloadName,
+		apicommon.AppAnnotation:      appname,
+	})
+
+	vmiWithoutOwner := &unstructured.Unstructured{}
+	vmiWithoutOwner.SetAPIVersion("kubevirt.io/v1")
+	vmiWithoutOwner.SetKind("VirtualMachineInstance")
+	vmiWithoutOwner.SetName("my-other-vmi")
+	vmiWithoutOwner.SetNamespace(testNamespace)
+
+	fakeClient := testcommon.NewTestClient(vmi, vm, vmiWithoutOwner)
+	req := &admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Namespace: testNamespace,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		owner         metav1.OwnerReference
+		workloadKinds workloadkind.IRegistry
+		want          bool
+		wantVersion   string
+	}{
+		{
+			name: "annotations are copied from the owner chain",
+			owner: metav1.OwnerReference{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachineInstance",
+				Name:       "my-vmi",
+				UID:        "this-is-the-vmi-uid",
+			},
+			workloadKinds: registry,
+			want:          true,
+			wantVersion:   "1.2.0",
+		},
+		{
+			name: "no annotated owner in owner chain",
+			owner: metav1.OwnerReference{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachineInstance",
+				Name:       "my-other-vmi",
+				UID:        "this-is-the-other-vmi-uid",
+			},
+			workloadKinds: registry,
+			want:          false,
+		},
+		{
+			name: "kind is not registered",
+			owner: metav1.OwnerReference{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachineInstance",
+				Name:       "my-vmi",
+				UID:        "this-is-the-vmi-uid",
+			},
+			workloadKinds: workloadkind.NewRegistry(),
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &PodAnnotationHandler{
+				Client:        fakeClient,
+				Log:           testr.New(t),
+				WorkloadKinds: tt.workloadKinds,
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:             uid,
+					OwnerReferences: []metav1.OwnerReference{tt.owner},
+				},
+			}
+			got := a.copyAnnotationsIfParentAnnotated(context.TODO(), req, pod)
+			require.Equal(t, tt.want, got)
+			if tt.want {
+				require.Equal(t, workloadName, pod.Annotations[apicommon.WorkloadAnnotation])
+				require.Equal(t, appname, pod.Annotations[apicommon.AppAnnotation])
+				require.Equal(t, tt.wantVersion, pod.Annotations[apicommon.VersionAnnotation])
+			}
+		})
+	}
+}
+
 func TestIsAnnotated(t *testing.T) {
 	testNamespace := "test-namespace"
 	rsUidWithDpOwner := types.UID("this-is-the-replicaset-with-dp-owner")
@@ -434,14 +702,21 @@ func TestCopyResourceLabelsIfPresent(t *testing.T) {
 				sourceResource: &metav1.ObjectMeta{
 					Name: "testSourceObject",
 					Annotations: map[string]string{
-						apicommon.WorkloadAnnotation:                 workloadName,
-						apicommon.AppAnnotation:                      lowerAppName,
-						apicommon.VersionAnnotation:                  version,
-						apicommon.PreDeploymentTaskAnnotation:        preDep,
-						apicommon.PostDeploymentTaskAnnotation:       postDep,
-						apicommon.PreDeploymentEvaluationAnnotation:  preEval,
-						apicommon.PostDeploymentEvaluationAnnotation: postEval,
-						apicommon.MetadataAnnotation:                 metadata,
+						apicommon.WorkloadAnnotation:                    workloadName,
+						apicommon.AppAnnotation:                         lowerAppName,
+						apicommon.VersionAnnotation:                     version,
+						apicommon.PreDeploymentTaskAnnotation:           preDep,
+						apicommon.PostDeploymentTaskAnnotation:          postDep,
+						apicommon.PreDeploymentEvaluationAnnotation:     preEval,
+						apicommon.PostDeploymentEvaluationAnnotation:    postEval,
+						apicommon.PreDeploymentAnalysisAnnotation:       "pre-analysis",
+						apicommon.PostDeploymentAnalysisAnnotation:      "post-analysis",
+						apicommon.MetadataAnnotation:                    metadata,
+						apicommon.PreDeploymentTaskDependencyAnnotation: "task2:task1",
+						apicommon.PromotionTaskAnnotation:               "promote",
+						apicommon.OnFailureTaskAnnotation:               "cleanup",
+						apicommon.FreezeOverrideAnnotation:              "hotfix",
+						"keptn.sh/when.task1":                           "version != previousVersion",
 					},
 				},
 				targetPod: &corev1.Pod{
@@ -456,14 +731,21 @@ func TestCopyResourceLabelsIfPresent(t *testing.T) {
 				TypeMeta: metav1.TypeMeta{},
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						apicommon.WorkloadAnnotation:                 workloadName,
-						apicommon.AppAnnotation:                      lowerAppName,
-						apicommon.VersionAnnotation:                  version,
-						apicommon.PreDeploymentTaskAnnotation:        preDep,
-						apicommon.PostDeploymentTaskAnnotation:       postDep,
-						apicommon.PreDeploymentEvaluationAnnotation:  preEval,
-						apicommon.PostDeploymentEvaluationAnnotation: postEval,
-						apicommon.MetadataAnnotation:                 metadata,
+						apicommon.WorkloadAnnotation:                    workloadName,
+						apicommon.AppAnnotation:                         lowerAppName,
+						apicommon.VersionAnnotation:                     version,
+						apicommon.PreDeploymentTaskAnnotation:           preDep,
+						apicommon.PostDeploymentTaskAnnotation:          postDep,
+						apicommon.PreDeploymentEvaluationAnnotation:     preEval,
+						apicommon.PostDeploymentEvaluationAnnotation:    postEval,
+						apicommon.PreDeploymentAnalysisAnnotation:       "pre-analysis",
+						apicommon.PostDeploymentAnalysisAnnotation:      "post-analysis",
+						apicommon.MetadataAnnotation:                    metadata,
+						apicommon.PreDeploymentTaskDependencyAnnotation: "task2:task1",
+						apicommon.PromotionTaskAnnotation:               "promote",
+						apicommon.OnFailureTaskAnnotation:               "cleanup",
+						apicommon.FreezeOverrideAnnotation:              "hotfix",
+						"keptn.sh/when.task1":                           "version != previousVersion",
 					},
 				},
 			},
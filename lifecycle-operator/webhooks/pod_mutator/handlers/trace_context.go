@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	operatorcommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/telemetry"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TraceContextHandler injects the trace context of the KeptnWorkloadVersion a pod belongs to into the pod,
+// so that the application can link its traces to the deployment trace
+type TraceContextHandler struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+// Handle exposes the keptn.sh/traceparent and keptn.sh/tracestate annotations of the pod to its containers
+// via the TRACEPARENT and TRACESTATE environment variables.
+// If the KeptnWorkloadVersion of the pod already exists, the annotations are set to its trace context right away.
+// Otherwise, e.g. on the first rollout of a version, they are set when the scheduling gate of the pod is removed,
+// which happens before the containers are started.
+// Failures are only logged, since a missing trace context must not prevent the pod from being deployed.
+func (t *TraceContextHandler) Handle(ctx context.Context, pod *corev1.Pod, namespace string) error {
+	telemetry.InjectTraceContextFromAnnotations(&pod.Spec)
+
+	version, _ := GetLabelOrAnnotation(&pod.ObjectMeta, apicommon.VersionAnnotation, apicommon.K8sRecommendedVersionAnnotations)
+	workloadName := getWorkloadName(&pod.ObjectMeta, getAppName(&pod.ObjectMeta))
+	workloadVersionName := operatorcommon.CreateResourceName(apicommon.MaxK8sObjectLength, apicommon.MinKeptnNameLen, workloadName, strings.ToLower(version))
+
+	workloadVersion := &klcv1beta1.KeptnWorkloadVersion{}
+	err := t.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: workloadVersionName}, workloadVersion)
+	if errors.IsNotFound(err) {
+		t.Log.Info("WorkloadVersion not found, trace context is set when the scheduling gate is removed", "workloadVersion", workloadVersionName, "namespace", namespace)
+		return nil
+	}
+	if err != nil {
+		t.Log.Error(err, "Could not fetch WorkloadVersion", "workloadVersion", workloadVersionName, "namespace", namespace)
+		return nil
+	}
+
+	telemetry.SetTraceContextAnnotations(&pod.ObjectMeta, workloadVersion.GetPhaseTraceContext())
+	return nil
+}
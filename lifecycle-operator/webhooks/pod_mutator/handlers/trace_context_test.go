@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func newTraceContextTestPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-pod",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: "my-workload",
+				apicommon.VersionAnnotation:  "V1",
+			},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers: []corev1.Container{
+				{Name: "app"},
+				{Name: "sidecar", Env: []corev1.EnvVar{{Name: "TRACEPARENT", Value: "custom"}}},
+			},
+		},
+	}
+}
+
+func getTraceContextEnv() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:      "TRACEPARENT",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['keptn.sh/traceparent']"}},
+		},
+		{
+			Name:      "TRACESTATE",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['keptn.sh/tracestate']"}},
+		},
+	}
+}
+
+func TestTraceContextHandler_Handle(t *testing.T) {
+	workloadVersion := &klcv1beta1.KeptnWorkloadVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: testAppWorkload + "-v1", Namespace: namespace},
+		Status: klcv1beta1.KeptnWorkloadVersionStatus{
+			CurrentPhase: apicommon.PhaseWorkloadPreDeployment.ShortName,
+			PhaseTraceIDs: apicommon.PhaseTraceID{
+				apicommon.PhaseWorkloadPreDeployment.ShortName: propagation.MapCarrier{"traceparent": testTraceParent},
+			},
+		},
+	}
+	handler := TraceContextHandler{Client: testcommon.NewTestClient(workloadVersion), Log: testr.New(t)}
+	pod := newTraceContextTestPod()
+
+	require.Nil(t, handler.Handle(context.TODO(), pod, namespace))
+
+	require.Equal(t, testTraceParent, pod.Annotations[apicommon.TraceParentAnnotation])
+	require.Equal(t, getTraceContextEnv(), pod.Spec.InitContainers[0].Env)
+	require.Equal(t, getTraceContextEnv(), pod.Spec.Containers[0].Env)
+	// environment variables of the user are kept
+	require.Equal(t, append([]corev1.EnvVar{{Name: "TRACEPARENT", Value: "custom"}}, getTraceContextEnv()[1]), pod.Spec.Containers[1].Env)
+}
+
+func TestTraceContextHandler_HandleFirstRollout(t *testing.T) {
+	// the KeptnWorkloadVersion of a new version is created after the admission of its first pod
+	handler := TraceContextHandler{Client: testcommon.NewTestClient(), Log: testr.New(t)}
+	pod := newTraceContextTestPod()
+
+	require.Nil(t, handler.Handle(context.TODO(), pod, namespace))
+
+	// the annotations are set when the scheduling gate is removed, and resolved when the containers start
+	require.NotContains(t, pod.Annotations, apicommon.TraceParentAnnotation)
+	require.Equal(t, getTraceContextEnv(), pod.Spec.InitContainers[0].Env)
+	require.Equal(t, getTraceContextEnv(), pod.Spec.Containers[0].Env)
+}
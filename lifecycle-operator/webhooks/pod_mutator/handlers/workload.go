@@ -10,8 +10,10 @@ import (
 	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,14 +22,15 @@ import (
 )
 
 type WorkloadHandler struct {
-	Client      client.Client
-	Log         logr.Logger
-	EventSender eventsender.IEvent
+	Client        client.Client
+	Log           logr.Logger
+	EventSender   eventsender.IEvent
+	WorkloadKinds workloadkind.IRegistry
 }
 
 func (a *WorkloadHandler) Handle(ctx context.Context, pod *corev1.Pod, namespace string) error {
 
-	newWorkload := generateWorkload(ctx, pod, namespace)
+	newWorkload := generateWorkload(ctx, pod, namespace, a.WorkloadKinds)
 
 	a.Log.Info("Searching for workload")
 
@@ -45,13 +48,21 @@ func (a *WorkloadHandler) Handle(ctx context.Context, pod *corev1.Pod, namespace
 }
 
 func (a *WorkloadHandler) updateWorkload(ctx context.Context, workload *klcv1beta1.KeptnWorkload, newWorkload *klcv1beta1.KeptnWorkload) error {
-	if reflect.DeepEqual(workload.Spec, newWorkload.Spec) {
+	if reflect.DeepEqual(workload.Spec, newWorkload.Spec) && !isFreezeOverrideChanged(workload, newWorkload) {
 		a.Log.Info("Pod not changed, not updating anything")
 		return nil
 	}
 
 	a.Log.Info("Pod changed, updating workload")
 	workload.Spec = newWorkload.Spec
+	if reason, ok := newWorkload.Annotations[apicommon.FreezeOverrideAnnotation]; ok {
+		if workload.Annotations == nil {
+			workload.Annotations = map[string]string{}
+		}
+		workload.Annotations[apicommon.FreezeOverrideAnnotation] = reason
+	} else {
+		delete(workload.Annotations, apicommon.FreezeOverrideAnnotation)
+	}
 
 	err := a.Client.Update(ctx, workload)
 	if err != nil {
@@ -63,6 +74,12 @@ func (a *WorkloadHandler) updateWorkload(ctx context.Context, workload *klcv1bet
 	return nil
 }
 
+func isFreezeOverrideChanged(workload *klcv1beta1.KeptnWorkload, newWorkload *klcv1beta1.KeptnWorkload) bool {
+	reason, ok := workload.Annotations[apicommon.FreezeOverrideAnnotation]
+	newReason, newOk := newWorkload.Annotations[apicommon.FreezeOverrideAnnotation]
+	return ok != newOk || reason != newReason
+}
+
 func (a *WorkloadHandler) createWorkload(ctx context.Context, newWorkload *klcv1beta1.KeptnWorkload) error {
 	a.Log.Info("Creating workload", "workload", newWorkload.Name)
 	err := a.Client.Create(ctx, newWorkload)
@@ -75,7 +92,7 @@ func (a *WorkloadHandler) createWorkload(ctx context.Context, newWorkload *klcv1
 	return nil
 }
 
-func generateWorkload(ctx context.Context, pod *corev1.Pod, namespace string) *klcv1beta1.KeptnWorkload {
+func generateWorkload(ctx context.Context, pod *corev1.Pod, namespace string, workloadKinds workloadkind.IRegistry) *klcv1beta1.KeptnWorkload {
 	version, _ := GetLabelOrAnnotation(&pod.ObjectMeta, apicommon.VersionAnnotation, apicommon.K8sRecommendedVersionAnnotations)
 	version = strings.ToLower(version)
 	preDeploymentTasks := getValuesForAnnotations(&pod.ObjectMeta, apicommon.PreDeploymentTaskAnnotation)
@@ -88,26 +105,42 @@ func generateWorkload(ctx context.Context, pod *corev1.Pod, namespace string) *k
 	traceContextCarrier := propagation.MapCarrier{}
 	otel.GetTextMapPropagator().Inject(ctx, traceContextCarrier)
 
-	ownerRef := GetOwnerReference(&pod.ObjectMeta)
+	// the override of freeze windows is passed on to the KeptnWorkloadVersions of the KeptnWorkload
+	if reason, ok := pod.Annotations[apicommon.FreezeOverrideAnnotation]; ok {
+		traceContextCarrier[apicommon.FreezeOverrideAnnotation] = reason
+	}
+
+	ownerRef := GetWorkloadOwnerReference(pod, workloadKinds)
+
+	// the UID of a bare pod is not yet known at admission time, hence it cannot be used as owner
+	var ownerReferences []metav1.OwnerReference
+	if ownerRef.UID != "" {
+		ownerReferences = []metav1.OwnerReference{ownerRef}
+	}
 
 	return &klcv1beta1.KeptnWorkload{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        getWorkloadName(&pod.ObjectMeta, applicationName),
-			Namespace:   namespace,
-			Annotations: traceContextCarrier,
-			OwnerReferences: []metav1.OwnerReference{
-				ownerRef,
-			},
+			Name:            getWorkloadName(&pod.ObjectMeta, applicationName),
+			Namespace:       namespace,
+			Annotations:     traceContextCarrier,
+			OwnerReferences: ownerReferences,
 		},
 		Spec: klcv1beta1.KeptnWorkloadSpec{
-			AppName:                   applicationName,
-			Version:                   version,
-			ResourceReference:         klcv1beta1.ResourceReference{UID: ownerRef.UID, Kind: ownerRef.Kind, Name: ownerRef.Name},
-			PreDeploymentTasks:        preDeploymentTasks,
-			PostDeploymentTasks:       postDeploymentTasks,
-			PreDeploymentEvaluations:  preDeploymentEvaluation,
-			PostDeploymentEvaluations: postDeploymentEvaluation,
-			Metadata:                  parseWorkloadMetadata(getValuesForAnnotations(&pod.ObjectMeta, apicommon.MetadataAnnotation)),
+			AppName:                        applicationName,
+			Version:                        version,
+			ResourceReference:              klcv1beta1.ResourceReference{UID: ownerRef.UID, Kind: ownerRef.Kind, Name: ownerRef.Name},
+			PreDeploymentTasks:             preDeploymentTasks,
+			PostDeploymentTasks:            postDeploymentTasks,
+			PreDeploymentTaskDependencies:  parseTaskDependencies(getValuesForAnnotations(&pod.ObjectMeta, apicommon.PreDeploymentTaskDependencyAnnotation)),
+			PostDeploymentTaskDependencies: parseTaskDependencies(getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentTaskDependencyAnnotation)),
+			PreDeploymentEvaluations:       preDeploymentEvaluation,
+			PostDeploymentEvaluations:      postDeploymentEvaluation,
+			PreDeploymentAnalyses:          getValuesForAnnotations(&pod.ObjectMeta, apicommon.PreDeploymentAnalysisAnnotation),
+			PostDeploymentAnalyses:         getValuesForAnnotations(&pod.ObjectMeta, apicommon.PostDeploymentAnalysisAnnotation),
+			ExecutionConditions:            parseExecutionConditions(&pod.ObjectMeta),
+			PromotionTasks:                 getValuesForAnnotations(&pod.ObjectMeta, apicommon.PromotionTaskAnnotation),
+			OnFailureTasks:                 getValuesForAnnotations(&pod.ObjectMeta, apicommon.OnFailureTaskAnnotation),
+			Metadata:                       parseWorkloadMetadata(getValuesForAnnotations(&pod.ObjectMeta, apicommon.MetadataAnnotation)),
 		},
 	}
 }
@@ -124,3 +157,43 @@ func parseWorkloadMetadata(annotations []string) map[string]string {
 	}
 	return result
 }
+
+// parseTaskDependencies converts a list of task:dependency pairs into a list of TaskDependencies,
+// e.g. "warmup:migrate,notify:migrate,notify:warmup"
+func parseTaskDependencies(annotations []string) []klcv1beta1.TaskDependency {
+	var result []klcv1beta1.TaskDependency
+	indices := map[string]int{}
+	for _, value := range annotations {
+		split := strings.Split(value, ":")
+
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			continue
+		}
+		i, ok := indices[split[0]]
+		if !ok {
+			i = len(result)
+			indices[split[0]] = i
+			result = append(result, klcv1beta1.TaskDependency{Name: split[0]})
+		}
+		result[i].DependsOn = append(result[i].DependsOn, split[1])
+	}
+	return result
+}
+
+// parseExecutionConditions converts the keptn.sh/when.<name> annotations into a list of ExecutionConditions,
+// e.g. keptn.sh/when.migrate: "metadata.commitMessage.contains('[migrate]')"
+func parseExecutionConditions(objMeta *metav1.ObjectMeta) []klcv1beta1.ExecutionCondition {
+	var result []klcv1beta1.ExecutionCondition
+	for key, value := range objMeta.Annotations {
+		name, found := strings.CutPrefix(key, apicommon.ExecutionConditionAnnotationPrefix)
+		if !found || name == "" || value == "" {
+			continue
+		}
+		result = append(result, klcv1beta1.ExecutionCondition{Name: name, When: value})
+	}
+	// annotations are not ordered, so sort the conditions to keep the spec of the KeptnWorkload stable
+	slices.SortFunc(result, func(a, b klcv1beta1.ExecutionCondition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
@@ -10,6 +10,7 @@ import (
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -42,16 +43,17 @@ func TestHandle(t *testing.T) {
 	wantWorkload := &klcv1beta1.KeptnWorkload{
 		TypeMeta: metav1.TypeMeta{Kind: "KeptnWorkload", APIVersion: "lifecycle.keptn.sh/v1beta1"},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      testAppWorkload,
-			Namespace: namespace,
-			OwnerReferences: []metav1.OwnerReference{
-				{},
-			},
+			Name:            testAppWorkload,
+			Namespace:       namespace,
 			ResourceVersion: "1",
 		},
 		Spec: klcv1beta1.KeptnWorkloadSpec{
 			AppName: TestWorkload,
 			Version: "0.1",
+			ResourceReference: klcv1beta1.ResourceReference{
+				Kind: "Pod",
+				Name: "example-pod",
+			},
 			Metadata: map[string]string{
 				"foo": "bar",
 				"bar": "foo",
@@ -177,12 +179,19 @@ func TestGenerateWorkload(t *testing.T) {
 		{
 			name: "Pod with annotations",
 			podAnnotations: map[string]string{
-				apicommon.VersionAnnotation:                  "v1",
-				apicommon.PreDeploymentTaskAnnotation:        "task1,task2",
-				apicommon.PostDeploymentTaskAnnotation:       "task3,task4",
-				apicommon.PreDeploymentEvaluationAnnotation:  "eval1,eval2",
-				apicommon.PostDeploymentEvaluationAnnotation: "eval3,eval4",
-				apicommon.K8sRecommendedAppAnnotations:       "my-app",
+				apicommon.VersionAnnotation:                     "v1",
+				apicommon.PreDeploymentTaskAnnotation:           "task1,task2",
+				apicommon.PostDeploymentTaskAnnotation:          "task3,task4",
+				apicommon.PreDeploymentEvaluationAnnotation:     "eval1,eval2",
+				apicommon.PostDeploymentEvaluationAnnotation:    "eval3,eval4",
+				apicommon.PreDeploymentAnalysisAnnotation:       "analysis1",
+				apicommon.PostDeploymentAnalysisAnnotation:      "analysis2,analysis3",
+				apicommon.K8sRecommendedAppAnnotations:          "my-app",
+				apicommon.PreDeploymentTaskDependencyAnnotation: "task2:task1",
+				apicommon.PromotionTaskAnnotation:               "promote",
+				apicommon.OnFailureTaskAnnotation:               "cleanup",
+				"keptn.sh/when.task1":                           "version != previousVersion",
+				"keptn.sh/when.eval1":                           "checkType == 'pre-eval'",
 			},
 			expected: &klcv1beta1.KeptnWorkload{
 				ObjectMeta: metav1.ObjectMeta{
@@ -199,14 +208,25 @@ func TestGenerateWorkload(t *testing.T) {
 					},
 				},
 				Spec: klcv1beta1.KeptnWorkloadSpec{
-					AppName:                   "my-app",
-					Version:                   "v1",
-					ResourceReference:         klcv1beta1.ResourceReference{UID: "owner-uid", Kind: "Deployment", Name: "deployment-1"},
-					PreDeploymentTasks:        []string{"task1", "task2"},
-					PostDeploymentTasks:       []string{"task3", "task4"},
+					AppName:             "my-app",
+					Version:             "v1",
+					ResourceReference:   klcv1beta1.ResourceReference{UID: "owner-uid", Kind: "Deployment", Name: "deployment-1"},
+					PreDeploymentTasks:  []string{"task1", "task2"},
+					PostDeploymentTasks: []string{"task3", "task4"},
+					PreDeploymentTaskDependencies: []klcv1beta1.TaskDependency{
+						{Name: "task2", DependsOn: []string{"task1"}},
+					},
 					PreDeploymentEvaluations:  []string{"eval1", "eval2"},
 					PostDeploymentEvaluations: []string{"eval3", "eval4"},
-					Metadata:                  map[string]string{},
+					PreDeploymentAnalyses:     []string{"analysis1"},
+					PostDeploymentAnalyses:    []string{"analysis2", "analysis3"},
+					ExecutionConditions: []klcv1beta1.ExecutionCondition{
+						{Name: "eval1", When: "checkType == 'pre-eval'"},
+						{Name: "task1", When: "version != previousVersion"},
+					},
+					PromotionTasks: []string{"promote"},
+					OnFailureTasks: []string{"cleanup"},
+					Metadata:       map[string]string{},
 				},
 			},
 		},
@@ -255,12 +275,73 @@ func TestGenerateWorkload(t *testing.T) {
 				},
 			}
 
-			result := generateWorkload(ctx, pod, "my-namespace")
+			result := generateWorkload(ctx, pod, "my-namespace", workloadkind.NewRegistry())
 			require.Equal(t, tc.expected, result)
 		})
 	}
 }
 
+func TestUpdateWorkloadFreezeOverrideChanged(t *testing.T) {
+	workload := &klcv1beta1.KeptnWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testAppWorkload,
+			Namespace: namespace,
+		},
+	}
+	newWorkload := workload.DeepCopy()
+	newWorkload.Annotations = map[string]string{apicommon.FreezeOverrideAnnotation: "hotfix"}
+
+	fakeClient := testcommon.NewTestClient(workload)
+	a := &WorkloadHandler{
+		Client:      fakeClient,
+		Log:         testr.New(t),
+		EventSender: eventsender.NewK8sSender(record.NewFakeRecorder(100)),
+	}
+	err := a.updateWorkload(context.TODO(), workload, newWorkload)
+	require.Nil(t, err)
+
+	actualWorkload := &klcv1beta1.KeptnWorkload{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: testAppWorkload, Namespace: namespace}, actualWorkload)
+	require.Nil(t, err)
+	require.Equal(t, "hotfix", actualWorkload.Annotations[apicommon.FreezeOverrideAnnotation])
+}
+
+func TestGenerateWorkloadFreezeOverride(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-pod",
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation:       "my-workload",
+				apicommon.VersionAnnotation:        "v1",
+				apicommon.FreezeOverrideAnnotation: "hotfix",
+			},
+		},
+	}
+
+	result := generateWorkload(context.TODO(), pod, "my-namespace", workloadkind.NewRegistry())
+
+	require.Equal(t, "hotfix", result.Annotations[apicommon.FreezeOverrideAnnotation])
+}
+
+func TestGenerateWorkloadBarePod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-pod",
+			Annotations: map[string]string{
+				apicommon.WorkloadAnnotation: "my-workload",
+				apicommon.VersionAnnotation:  "v1",
+			},
+		},
+	}
+
+	result := generateWorkload(context.TODO(), pod, "my-namespace", workloadkind.NewRegistry())
+
+	// the UID of the pod is not known at admission time, so the workload cannot be owned by it
+	require.Empty(t, result.OwnerReferences)
+	require.Equal(t, klcv1beta1.ResourceReference{Kind: "Pod", Name: "my-pod"}, result.Spec.ResourceReference)
+	require.Equal(t, "v1", result.Spec.Version)
+}
+
 func Test_parseWorkloadMetadata(t *testing.T) {
 	type args struct {
 		annotations []string
@@ -303,3 +384,74 @@ func Test_parseWorkloadMetadata(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseTaskDependencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations []string
+		want        []klcv1beta1.TaskDependency
+	}{
+		{
+			name:        "valid input",
+			annotations: []string{"warmup:migrate", "notify:migrate", "notify:warmup"},
+			want: []klcv1beta1.TaskDependency{
+				{Name: "warmup", DependsOn: []string{"migrate"}},
+				{Name: "notify", DependsOn: []string{"migrate", "warmup"}},
+			},
+		},
+		{
+			name:        "invalid input",
+			annotations: []string{"warmup", "warmup:", ":migrate", "a:b:c"},
+			want:        nil,
+		},
+		{
+			name:        "empty input",
+			annotations: []string{},
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseTaskDependencies(tt.annotations))
+		})
+	}
+}
+
+func Test_parseExecutionConditions(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []klcv1beta1.ExecutionCondition
+	}{
+		{
+			name: "valid input",
+			annotations: map[string]string{
+				"keptn.sh/when.migrate":               "metadata.commitMessage.contains('[migrate]')",
+				"keptn.sh/when.notify":                "now.getDayOfWeek() == 1",
+				apicommon.PreDeploymentTaskAnnotation: "migrate,notify",
+			},
+			want: []klcv1beta1.ExecutionCondition{
+				{Name: "migrate", When: "metadata.commitMessage.contains('[migrate]')"},
+				{Name: "notify", When: "now.getDayOfWeek() == 1"},
+			},
+		},
+		{
+			name: "invalid input",
+			annotations: map[string]string{
+				"keptn.sh/when.":        "version != previousVersion",
+				"keptn.sh/when.migrate": "",
+			},
+			want: nil,
+		},
+		{
+			name:        "no annotations",
+			annotations: nil,
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseExecutionConditions(&metav1.ObjectMeta{Annotations: tt.annotations}))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/webhooks/pod_mutator/handlers"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -19,6 +20,7 @@ import (
 // +kubebuilder:webhook:path=/mutate-v1-pod,mutating=true,failurePolicy=fail,groups="",resources=pods,verbs=create;update,versions=v1,name=mpod.keptn.sh,admissionReviewVersions=v1,sideEffects=None
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets;replicasets,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get
 
 // PodMutatingWebhook annotates Pods
 
@@ -34,6 +36,7 @@ type PodMutatingWebhook struct {
 	Pod                    handlers.PodAnnotationHandler
 	Workload               handlers.K8sHandler
 	App                    handlers.K8sHandler
+	TraceContext           handlers.K8sHandler
 }
 
 func NewPodMutator(
@@ -42,6 +45,7 @@ func NewPodMutator(
 	eventSender eventsender.IEvent,
 	log logr.Logger,
 	schedulingGatesEnabled bool,
+	workloadKinds workloadkind.IRegistry,
 ) *PodMutatingWebhook {
 	return &PodMutatingWebhook{
 		SchedulingGatesEnabled: schedulingGatesEnabled,
@@ -49,9 +53,10 @@ func NewPodMutator(
 		EventSender:            eventSender,
 		Decoder:                decoder,
 		Log:                    log,
-		Pod:                    handlers.PodAnnotationHandler{Client: client, Log: log},
+		Pod:                    handlers.PodAnnotationHandler{Client: client, Log: log, WorkloadKinds: workloadKinds},
 		App:                    &handlers.AppCreationRequestHandler{Log: log, Client: client, EventSender: eventSender},
-		Workload:               &handlers.WorkloadHandler{Log: log, Client: client, EventSender: eventSender},
+		Workload:               &handlers.WorkloadHandler{Log: log, Client: client, EventSender: eventSender, WorkloadKinds: workloadKinds},
+		TraceContext:           &handlers.TraceContextHandler{Log: log, Client: client},
 	}
 }
 
@@ -78,7 +83,7 @@ func (a *PodMutatingWebhook) Handle(ctx context.Context, req admission.Request)
 	}
 
 	// check the OwnerReference of the pod to see if it is supported and intended to be managed by Keptn
-	ownerRef := handlers.GetOwnerReference(&pod.ObjectMeta)
+	ownerRef := handlers.GetWorkloadOwnerReference(pod, a.Pod.WorkloadKinds)
 
 	if ownerRef.Kind == "" {
 		msg := "owner of pod is not supported by lifecycle operator"
@@ -107,6 +112,12 @@ func (a *PodMutatingWebhook) Handle(ctx context.Context, req admission.Request)
 			a.Log.Error(err, "Could not handle App")
 			return admission.Errored(http.StatusBadRequest, err)
 		}
+
+		if a.TraceContext != nil {
+			if err := a.TraceContext.Handle(ctx, pod, req.Namespace); err != nil {
+				a.Log.Error(err, "Could not inject trace context")
+			}
+		}
 	}
 
 	marshaledPod, err := json.Marshal(pod)
@@ -12,6 +12,7 @@ import (
 	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/eventsender"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/workloadkind"
 	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/webhooks/pod_mutator/handlers"
 	fakehandler "github.com/keptn/lifecycle-toolkit/lifecycle-operator/webhooks/pod_mutator/handlers/fake"
 	"github.com/stretchr/testify/require"
@@ -105,9 +106,9 @@ func TestPodMutatingWebhookHandleUnsupportedOwner(t *testing.T) {
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
-					APIVersion: "batchv1",
-					Kind:       "Job",
-					Name:       "my-job",
+					APIVersion: "v1",
+					Kind:       "ReplicationController",
+					Name:       "my-rc",
 					UID:        "1234",
 				},
 			},
@@ -166,7 +167,7 @@ func TestPodMutatingWebhookHandleSingleService(t *testing.T) {
 	decoder := admission.NewDecoder(runtime.NewScheme())
 	log := testr.New(t)
 
-	wh := NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), log, false)
+	wh := NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), log, false, workloadkind.NewRegistry())
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -337,7 +338,7 @@ func TestPodMutatingWebhookHandleSchedulingGates(t *testing.T) {
 	decoder := admission.NewDecoder(runtime.NewScheme())
 
 	wh :=
-		NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), testr.New(t), true)
+		NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), testr.New(t), true, workloadkind.NewRegistry())
 
 	request := generateRequest(pod, t)
 
@@ -349,12 +350,14 @@ func TestPodMutatingWebhookHandleSchedulingGates(t *testing.T) {
 	require.True(t, resp.Allowed)
 
 	expectedValue := []interface{}{map[string]interface{}{"name": apicommon.KeptnGate}}
-	require.Len(t, resp.Patches, 2)
-	if resp.Patches[0].Path == "/spec/schedulingGates" {
-		require.Equal(t, expectedValue, resp.Patches[0].Value)
-	} else {
-		require.Equal(t, expectedValue, resp.Patches[1].Value)
+	// the trace context is exposed to the containers, although the KeptnWorkloadVersion does not exist yet
+	require.Len(t, resp.Patches, 3)
+	patches := map[string]interface{}{}
+	for _, patch := range resp.Patches {
+		patches[patch.Path] = patch.Value
 	}
+	require.Equal(t, expectedValue, patches["/spec/schedulingGates"])
+	require.Contains(t, patches, "/spec/containers/0/env")
 
 	kacr := &klcv1beta1.KeptnAppCreationRequest{}
 
@@ -415,7 +418,7 @@ func TestPodMutatingWebhookHandleSingleServiceAppCreationRequestAlreadyPresent(t
 
 	decoder := admission.NewDecoder(runtime.NewScheme())
 
-	wh := NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), testr.New(t), false)
+	wh := NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), testr.New(t), false, workloadkind.NewRegistry())
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -499,7 +502,7 @@ func TestPodMutatingWebhookHandleMultiService(t *testing.T) {
 
 	pod, _, _, decoder := setupTestData()
 
-	wh := NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), testr.New(t), false)
+	wh := NewPodMutator(fakeClient, decoder, eventsender.NewK8sSender(record.NewFakeRecorder(100)), testr.New(t), false, workloadkind.NewRegistry())
 
 	request := generateRequest(pod, t)
 
@@ -0,0 +1,98 @@
+package task_mutator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	controllercommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/webhooks/pod_mutator/handlers"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-lifecycle-keptn-sh-v1beta1-keptntask,mutating=true,failurePolicy=fail,groups=lifecycle.keptn.sh,resources=keptntasks,verbs=create;update,versions=v1beta1,name=mkeptntask.keptn.sh,admissionReviewVersions=v1,sideEffects=None
+
+// TaskMutatingWebhook records the approval decisions for KeptnTasks
+
+type TaskMutatingWebhook struct {
+	Client  client.Client
+	Decoder handlers.Decoder
+	Log     logr.Logger
+}
+
+func NewTaskMutator(client client.Client, decoder *admission.Decoder, log logr.Logger) *TaskMutatingWebhook {
+	return &TaskMutatingWebhook{
+		Client:  client,
+		Decoder: decoder,
+		Log:     log,
+	}
+}
+
+// Handle checks whether the user setting the approval annotation of a KeptnTask is allowed to approve it,
+// and records the name of the user and the time of the decision.
+func (a *TaskMutatingWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	task := &klcv1beta1.KeptnTask{}
+	if err := a.Decoder.Decode(req, task); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// a KeptnTask that is created with an approval decision is handled like an update of a task without a decision
+	oldTask := &klcv1beta1.KeptnTask{}
+	if req.Operation == admissionv1.Update {
+		if err := a.Decoder.DecodeRaw(req.OldObject, oldTask); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	annotations := task.GetAnnotations()
+	oldAnnotations := oldTask.GetAnnotations()
+	decision := annotations[apicommon.ApprovalAnnotation]
+	oldDecision := oldAnnotations[apicommon.ApprovalAnnotation]
+
+	if decision == oldDecision {
+		if annotations[apicommon.ApprovalUserAnnotation] != oldAnnotations[apicommon.ApprovalUserAnnotation] ||
+			annotations[apicommon.ApprovalTimeAnnotation] != oldAnnotations[apicommon.ApprovalTimeAnnotation] {
+			return admission.Denied("the approval user and time of a KeptnTask cannot be modified")
+		}
+		return admission.Allowed("approval of KeptnTask has not changed")
+	}
+
+	if oldDecision != "" {
+		return admission.Denied(fmt.Sprintf("KeptnTask %s has already been %s", task.Name, oldDecision))
+	}
+	if decision != apicommon.ApprovalApproved && decision != apicommon.ApprovalRejected {
+		return admission.Denied(fmt.Sprintf("the value of %s must be either %s or %s", apicommon.ApprovalAnnotation, apicommon.ApprovalApproved, apicommon.ApprovalRejected))
+	}
+
+	definition, err := controllercommon.GetTaskDefinition(a.Client, a.Log, ctx, task.Spec.TaskDefinition, req.Namespace)
+	if err != nil {
+		a.Log.Error(err, "could not get KeptnTaskDefinition", "taskDefinition", task.Spec.TaskDefinition, "namespace", req.Namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if definition.Spec.Approval == nil {
+		return admission.Denied(fmt.Sprintf("KeptnTaskDefinition %s does not require an approval", definition.Name))
+	}
+	if !definition.Spec.Approval.IsApprover(req.UserInfo.Username, req.UserInfo.Groups) {
+		a.Log.Info("user is not allowed to approve KeptnTask", "user", req.UserInfo.Username, "task", task.Name, "namespace", req.Namespace)
+		return admission.Denied(fmt.Sprintf("user %s is not allowed to approve KeptnTask %s", req.UserInfo.Username, task.Name))
+	}
+
+	annotations[apicommon.ApprovalUserAnnotation] = req.UserInfo.Username
+	annotations[apicommon.ApprovalTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	task.SetAnnotations(annotations)
+
+	a.Log.Info("KeptnTask has been "+decision, "user", req.UserInfo.Username, "task", task.Name, "namespace", req.Namespace)
+
+	marshaledTask, err := json.Marshal(task)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledTask)
+}
@@ -0,0 +1,196 @@
+package task_mutator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	klcv1beta1 "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1"
+	apicommon "github.com/keptn/lifecycle-toolkit/lifecycle-operator/apis/lifecycle/v1beta1/common"
+	"github.com/keptn/lifecycle-toolkit/lifecycle-operator/controllers/common/testcommon"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const testNamespace = "default"
+const testTaskDefinition = "my-approval"
+
+func TestTaskMutatingWebhook_Handle(t *testing.T) {
+	approvalDefinition := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: testTaskDefinition, Namespace: testNamespace},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Approval: &klcv1beta1.ApprovalSpec{
+				Users:  []string{"jane"},
+				Groups: []string{"release-managers"},
+			},
+		},
+	}
+	containerDefinition := &klcv1beta1.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-container", Namespace: testNamespace},
+		Spec: klcv1beta1.KeptnTaskDefinitionSpec{
+			Container: &klcv1beta1.ContainerSpec{},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		operation      admissionv1.Operation
+		taskDefinition string
+		oldAnnotations map[string]string
+		annotations    map[string]string
+		userInfo       authenticationv1.UserInfo
+		wantAllowed    bool
+		wantPatched    bool
+	}{
+		{
+			name:           "no approval decision",
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{"foo": "bar"},
+			userInfo:       authenticationv1.UserInfo{Username: "john"},
+			wantAllowed:    true,
+		},
+		{
+			name:           "approved by allowed user",
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalApproved},
+			userInfo:       authenticationv1.UserInfo{Username: "jane"},
+			wantAllowed:    true,
+			wantPatched:    true,
+		},
+		{
+			name:           "rejected by member of allowed group",
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalRejected},
+			userInfo:       authenticationv1.UserInfo{Username: "john", Groups: []string{"release-managers"}},
+			wantAllowed:    true,
+			wantPatched:    true,
+		},
+		{
+			name:           "approved by user who is not allowed",
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalApproved},
+			userInfo:       authenticationv1.UserInfo{Username: "john", Groups: []string{"developers"}},
+		},
+		{
+			name:           "invalid decision",
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: "maybe"},
+			userInfo:       authenticationv1.UserInfo{Username: "jane"},
+		},
+		{
+			name:           "decision already made",
+			taskDefinition: testTaskDefinition,
+			oldAnnotations: map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalRejected},
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalApproved},
+			userInfo:       authenticationv1.UserInfo{Username: "jane"},
+		},
+		{
+			name:           "approval user modified",
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalUserAnnotation: "jane"},
+			userInfo:       authenticationv1.UserInfo{Username: "john"},
+		},
+		{
+			name:           "task definition without approval",
+			taskDefinition: "my-container",
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalApproved},
+			userInfo:       authenticationv1.UserInfo{Username: "jane"},
+		},
+		{
+			name:           "created with approval decision by allowed user",
+			operation:      admissionv1.Create,
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalApproved},
+			userInfo:       authenticationv1.UserInfo{Username: "jane"},
+			wantAllowed:    true,
+			wantPatched:    true,
+		},
+		{
+			name:           "created with approval decision by user who is not allowed",
+			operation:      admissionv1.Create,
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalAnnotation: apicommon.ApprovalApproved},
+			userInfo:       authenticationv1.UserInfo{Username: "john"},
+		},
+		{
+			name:           "created with approval user",
+			operation:      admissionv1.Create,
+			taskDefinition: testTaskDefinition,
+			annotations:    map[string]string{apicommon.ApprovalUserAnnotation: "jane"},
+			userInfo:       authenticationv1.UserInfo{Username: "john"},
+		},
+		{
+			name:           "created without approval decision",
+			operation:      admissionv1.Create,
+			taskDefinition: testTaskDefinition,
+			userInfo:       authenticationv1.UserInfo{Username: "john"},
+			wantAllowed:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wh := NewTaskMutator(
+				testcommon.NewTestClient(approvalDefinition, containerDefinition),
+				admission.NewDecoder(runtime.NewScheme()),
+				testr.New(t),
+			)
+
+			task := makeTask(tt.taskDefinition, tt.annotations)
+			operation := admissionv1.Update
+			oldObject := runtime.RawExtension{Raw: marshal(t, makeTask(tt.taskDefinition, tt.oldAnnotations))}
+			if tt.operation == admissionv1.Create {
+				operation = admissionv1.Create
+				oldObject = runtime.RawExtension{}
+			}
+
+			resp := wh.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UID:       "12345",
+					Kind:      metav1.GroupVersionKind{Group: "lifecycle.keptn.sh", Version: "v1beta1", Kind: "KeptnTask"},
+					Operation: operation,
+					Object:    runtime.RawExtension{Raw: marshal(t, task)},
+					OldObject: oldObject,
+					Namespace: testNamespace,
+					UserInfo:  tt.userInfo,
+				},
+			})
+
+			require.Equal(t, tt.wantAllowed, resp.Allowed)
+			if !tt.wantPatched {
+				require.Empty(t, resp.Patches)
+				return
+			}
+			require.Len(t, resp.Patches, 2)
+			patched := map[string]interface{}{}
+			for _, patch := range resp.Patches {
+				patched[patch.Path] = patch.Value
+			}
+			require.Equal(t, tt.userInfo.Username, patched["/metadata/annotations/keptn.sh~1approval-user"])
+			require.NotEmpty(t, patched["/metadata/annotations/keptn.sh~1approval-time"])
+		})
+	}
+}
+
+func makeTask(taskDefinition string, annotations map[string]string) *klcv1beta1.KeptnTask {
+	return &klcv1beta1.KeptnTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-task",
+			Namespace:   testNamespace,
+			Annotations: annotations,
+		},
+		Spec: klcv1beta1.KeptnTaskSpec{
+			TaskDefinition: taskDefinition,
+		},
+	}
+}
+
+func marshal(t *testing.T, obj interface{}) []byte {
+	raw, err := json.Marshal(obj)
+	require.Nil(t, err)
+	return raw
+}
@@ -2,4 +2,4 @@
 
 set -eu
 
-deno run --allow-net --allow-write --allow-read --allow-env=DATA,SECURE_DATA,KEPTN_CONTEXT "$SCRIPT"
+deno run --allow-net --allow-write --allow-read --allow-env=DATA,SECURE_DATA,KEPTN_CONTEXT,TRACEPARENT,TRACESTATE "$SCRIPT"
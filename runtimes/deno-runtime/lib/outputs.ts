@@ -0,0 +1,24 @@
+// Helpers to hand over values from a KeptnTask to the tasks executed after it.
+// Keptn reads the outputs of a task from the termination message of its container
+// and passes the outputs of all previous tasks via the outputs field of KEPTN_CONTEXT.
+
+const terminationMessagePath = "/dev/termination-log";
+
+/**
+ * Writes the given outputs to the termination message of the task container.
+ * Kubernetes limits the termination message to 4096 bytes, so only small values such as IDs or URLs should be written.
+ */
+export function writeOutputs(outputs: Record<string, string>): void {
+  Deno.writeTextFileSync(terminationMessagePath, JSON.stringify(outputs));
+}
+
+/**
+ * Returns the outputs of the previous tasks, grouped by the name of the KeptnTaskDefinition that produced them.
+ */
+export function taskOutputs(): Record<string, Record<string, string>> {
+  const context = Deno.env.get("KEPTN_CONTEXT");
+  if (!context) {
+    return {};
+  }
+  return JSON.parse(context).outputs ?? {};
+}
@@ -0,0 +1,26 @@
+// Helpers to continue the trace of a Keptn deployment in a KeptnTask.
+// Keptn passes the W3C trace context of the current phase via the TRACEPARENT and TRACESTATE environment variables.
+
+/**
+ * Returns the W3C trace context headers of the current phase,
+ * which can be added to outgoing HTTP requests or passed to an OpenTelemetry propagator.
+ */
+export function traceHeaders(): Record<string, string> {
+  const headers: Record<string, string> = {};
+  const traceparent = Deno.env.get("TRACEPARENT");
+  const tracestate = Deno.env.get("TRACESTATE");
+  if (traceparent) {
+    headers["traceparent"] = traceparent;
+  }
+  if (tracestate) {
+    headers["tracestate"] = tracestate;
+  }
+  return headers;
+}
+
+/**
+ * Returns the trace ID of the deployment trace, or undefined if no trace context has been passed.
+ */
+export function traceId(): string | undefined {
+  return Deno.env.get("TRACEPARENT")?.split("-")[1];
+}
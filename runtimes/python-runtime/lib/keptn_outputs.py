@@ -0,0 +1,27 @@
+"""Helpers to hand over values from a KeptnTask to the tasks executed after it.
+
+Keptn reads the outputs of a task from the termination message of its container
+and passes the outputs of all previous tasks via the outputs field of KEPTN_CONTEXT.
+"""
+import json
+import os
+
+TERMINATION_MESSAGE_PATH = "/dev/termination-log"
+
+
+def write_outputs(outputs):
+    """Write the given dict of outputs to the termination message of the task container.
+
+    Kubernetes limits the termination message to 4096 bytes,
+    so only small values such as IDs or URLs should be written.
+    """
+    with open(TERMINATION_MESSAGE_PATH, "w") as f:
+        json.dump(outputs, f)
+
+
+def task_outputs():
+    """Return the outputs of the previous tasks, grouped by the name of the KeptnTaskDefinition that produced them."""
+    context = os.environ.get("KEPTN_CONTEXT")
+    if not context:
+        return {}
+    return json.loads(context).get("outputs") or {}
@@ -0,0 +1,31 @@
+"""Helpers to continue the trace of a Keptn deployment in a KeptnTask.
+
+Keptn passes the W3C trace context of the current phase
+via the TRACEPARENT and TRACESTATE environment variables.
+"""
+import os
+
+
+def trace_headers():
+    """Return the W3C trace context headers of the current phase.
+
+    The headers can be added to outgoing HTTP requests
+    or passed to an OpenTelemetry propagator.
+    """
+    headers = {}
+    traceparent = os.environ.get("TRACEPARENT")
+    tracestate = os.environ.get("TRACESTATE")
+    if traceparent:
+        headers["traceparent"] = traceparent
+    if tracestate:
+        headers["tracestate"] = tracestate
+    return headers
+
+
+def trace_id():
+    """Return the trace ID of the deployment trace, or None if no trace context has been passed."""
+    traceparent = os.environ.get("TRACEPARENT", "")
+    parts = traceparent.split("-")
+    if len(parts) < 2:
+        return None
+    return parts[1]
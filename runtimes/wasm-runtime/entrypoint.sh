@@ -0,0 +1,28 @@
+#!/bin/sh
+
+MODULE=$SCRIPT
+# the module cannot access the termination message of the container directly,
+# hence it writes its outputs to /keptn/outputs, which is copied to the termination message afterwards
+OUTPUTS_DIR=$(mktemp -d)
+
+case "$SCRIPT" in
+    http://*|https://*|ftp://*|file://*)
+        MODULE=/tmp/module.wasm
+        curl -sfL -o $MODULE "$SCRIPT" || exit 1
+        ;;
+esac
+
+# shellcheck disable=SC2086
+wasmtime run \
+    --env DATA="$DATA" \
+    --env SECURE_DATA="$SECURE_DATA" \
+    --env KEPTN_CONTEXT="$KEPTN_CONTEXT" \
+    --dir "$OUTPUTS_DIR::/keptn" \
+    $MODULE $CMD_ARGS
+EXIT_CODE=$?
+
+if [ -s "$OUTPUTS_DIR/outputs" ]; then
+    cp "$OUTPUTS_DIR/outputs" /dev/termination-log
+fi
+
+exit $EXIT_CODE